@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	repositories "github.com/ShenokZlob/collector-service/internal/rep/mongo"
+	"github.com/ShenokZlob/collector-service/usecase/catalog"
+	"github.com/ShenokZlob/collector-service/usecase/collection"
+	"github.com/ShenokZlob/collector-service/usecase/trade"
+	"github.com/ShenokZlob/collector-service/usecase/valuation"
+	"go.uber.org/zap"
+)
+
+// runCommand runs a subcommand of the service binary:
+//
+//	collector-service import-catalog [-force] [-batch 1000] default-cards.json
+//	collector-service import-prices [-date 2006-01-02] [-batch 1000] default-cards.json
+//	collector-service refresh-trades
+//	collector-service purge-trash
+func runCommand(log *zap.Logger, rep *repositories.Repository, command string, args []string) error {
+	switch command {
+	case "import-catalog":
+		return runImportCatalog(log, rep, args)
+	case "import-prices":
+		return runImportPrices(log, rep, args)
+	case "refresh-trades":
+		return runRefreshTrades(log, rep)
+	case "purge-trash":
+		return runPurgeTrash(log, rep)
+	default:
+		return fmt.Errorf("unknown command %q", command)
+	}
+}
+
+// runImportCatalog imports a Scryfall bulk data file into the card catalog.
+// Interrupting it with Ctrl-C keeps the progress, running it again resumes.
+func runImportCatalog(log *zap.Logger, rep *repositories.Repository, args []string) error {
+	flags := flag.NewFlagSet("import-catalog", flag.ContinueOnError)
+	force := flags.Bool("force", false, "import the file again even if it's already imported")
+	batch := flags.Int("batch", catalog.DefaultImportBatchSize, "cards stored at once")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("usage: import-catalog [-force] [-batch N] <bulk data file>")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	importer := catalog.NewImporter(log, rep, *batch)
+	return importer.Import(ctx, flags.Arg(0), *force, func(state domain.CatalogImport) {
+		percent := 100.0
+		if state.Size > 0 && !state.Done {
+			percent = float64(state.BytesRead) * 100 / float64(state.Size)
+		}
+		log.Info("Catalog import progress",
+			zap.Int("cards", state.Processed),
+			zap.String("progress", fmt.Sprintf("%.1f%%", percent)),
+			zap.Bool("done", state.Done))
+	})
+}
+
+// runImportPrices stores the prices of a Scryfall bulk data file as the snapshots
+// of a day and records the values of all collections on that day. It's meant to
+// run daily with a fresh file; running it again for the day replaces the day's data.
+func runImportPrices(log *zap.Logger, rep *repositories.Repository, args []string) error {
+	flags := flag.NewFlagSet("import-prices", flag.ContinueOnError)
+	day := flags.String("date", "", "day of the prices as YYYY-MM-DD, today by default")
+	batch := flags.Int("batch", valuation.DefaultImportBatchSize, "price snapshots stored at once")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("usage: import-prices [-date YYYY-MM-DD] [-batch N] <bulk data file>")
+	}
+
+	date := time.Now()
+	if *day != "" {
+		var err error
+		if date, err = time.Parse(time.DateOnly, *day); err != nil {
+			return fmt.Errorf("invalid date %q", *day)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	importer := valuation.NewPriceImporter(log, rep, *batch)
+	_, err := importer.Import(ctx, flags.Arg(0), date, func(stored int) {
+		log.Info("Price import progress", zap.Int("prices", stored))
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = valuation.NewValuationService(log, rep).RecordValues(ctx, date)
+	return err
+}
+
+// runRefreshTrades recomputes trade matches once, the server does it every
+// TRADE_REFRESH_INTERVAL. Running it after importing prices revalues the matches.
+func runRefreshTrades(log *zap.Logger, rep *repositories.Repository) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	_, err := trade.NewMatchService(log, rep).Refresh(ctx)
+	return err
+}
+
+// runPurgeTrash removes collections and cards deleted longer than TRASH_RETENTION
+// ago for good, the server does it every hour.
+func runPurgeTrash(log *zap.Logger, rep *repositories.Repository) error {
+	retention, err := trashRetention()
+	if err != nil {
+		return err
+	}
+
+	_, respErr := collection.NewTrashService(log, rep).Purge(retention)
+	if respErr != nil {
+		return respErr
+	}
+	return nil
+}
+
+// trashRetention reads how long deleted items are kept from TRASH_RETENTION,
+// 30 days by default
+func trashRetention() (time.Duration, error) {
+	raw := os.Getenv("TRASH_RETENTION")
+	if raw == "" {
+		return domain.DefaultTrashRetention, nil
+	}
+	retention, err := time.ParseDuration(raw)
+	if err != nil || retention <= 0 {
+		return 0, fmt.Errorf("invalid TRASH_RETENTION %q", raw)
+	}
+	return retention, nil
+}
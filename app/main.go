@@ -17,6 +17,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	_ "github.com/ShenokZlob/collector-service/docs"
 
@@ -24,8 +25,12 @@ import (
 	"github.com/ShenokZlob/collector-service/internal/controllers/middleware"
 	repositories "github.com/ShenokZlob/collector-service/internal/rep/mongo"
 	"github.com/ShenokZlob/collector-service/pkg/logger"
+	"github.com/ShenokZlob/collector-service/pkg/scryfall"
 	"github.com/ShenokZlob/collector-service/usecase/auth"
+	"github.com/ShenokZlob/collector-service/usecase/catalog"
 	"github.com/ShenokZlob/collector-service/usecase/collection"
+	"github.com/ShenokZlob/collector-service/usecase/trade"
+	"github.com/ShenokZlob/collector-service/usecase/valuation"
 	"github.com/gin-gonic/gin"
 	_ "github.com/joho/godotenv/autoload"
 	swaggerFiles "github.com/swaggo/files"
@@ -44,7 +49,13 @@ func main() {
 
 	log.Info("Starting collector service")
 
-	if os.Getenv("JWT_SECRET") == "" {
+	// Subcommands run a maintenance task instead of the server
+	var command string
+	if len(os.Args) > 1 {
+		command = os.Args[1]
+	}
+
+	if command == "" && os.Getenv("JWT_SECRET") == "" {
 		panic("Don't have JWT_SECRET")
 	}
 
@@ -65,17 +76,71 @@ func main() {
 
 	rep := repositories.NewRepository(db)
 
+	renamed, err := rep.RenameDuplicateCollections()
+	if err != nil {
+		panic(err)
+	}
+	log.Info("Duplicate collection names renamed", zap.Int("collections", renamed))
+
+	if err := rep.EnsureIndexes(); err != nil {
+		panic(err)
+	}
+
+	if command != "" {
+		if err := runCommand(log, rep, command, os.Args[2:]); err != nil {
+			log.Error("Command failed", zap.String("command", command), zap.Error(err))
+			os.Exit(1)
+		}
+		return
+	}
+
+	migrated, err := rep.MigrateEmbeddedCards()
+	if err != nil {
+		panic(err)
+	}
+	log.Info("Embedded card entries migrated", zap.Int("collections", migrated))
+
 	servAuth := auth.NewAuthUsecase(log, rep)
 	servCollections := collection.NewCollectionsService(log, rep)
-	servCards := collection.NewCardsService(log, rep)
+	// Empty SCRYFALL_BASE_URL is the public Scryfall API
+	scryfallClient := scryfall.NewClient(scryfall.Config{
+		BaseURL: os.Getenv("SCRYFALL_BASE_URL"),
+		Cache:   rep.ScryfallCache(),
+	})
+
+	servCatalog := catalog.NewCatalogService(log, rep)
+	servCards := collection.NewCardsService(log, rep, catalog.NewLookup(log, rep, scryfallClient))
+	servImport := collection.NewImportService(log, servCards, rep)
+	servExport := collection.NewExportService(log, rep, rep)
+	servValuation := valuation.NewValuationService(log, rep)
+	servDeck := collection.NewDeckService(log, rep, rep)
+	servSearch := collection.NewSearchService(log, rep)
+	servStats := collection.NewStatsService(log, rep)
+	servTrash := collection.NewTrashService(log, rep)
+	servHistory := collection.NewHistoryService(log, rep)
+	servSnapshot := collection.NewSnapshotService(log, rep)
+	servGroup := trade.NewGroupService(log, rep)
+	servTradeMatch := trade.NewMatchService(log, rep)
 
 	ctrlAuth := controllers.NewAuthController(log, servAuth)
 	ctrlCollections := controllers.NewCollectionsController(log, servCollections)
 	ctrlCards := controllers.NewCardsController(log, servCards)
+	ctrlImport := controllers.NewImportController(log, servImport)
+	ctrlExport := controllers.NewExportController(log, servExport)
+	ctrlCatalog := controllers.NewCatalogController(log, servCatalog)
+	ctrlValuation := controllers.NewValuationController(log, servValuation)
+	ctrlDeck := controllers.NewDeckController(log, servDeck)
+	ctrlSearch := controllers.NewSearchController(log, servSearch)
+	ctrlStats := controllers.NewStatsController(log, servStats)
+	ctrlTrash := controllers.NewTrashController(log, servTrash)
+	ctrlHistory := controllers.NewHistoryController(log, servHistory)
+	ctrlSnapshot := controllers.NewSnapshotController(log, servSnapshot)
+	ctrlTrade := controllers.NewTradeController(log, servGroup, servTradeMatch)
 
 	// Setup router
 	router := gin.Default()
 	router.Use(gin.Recovery())
+	router.Use(middleware.RequestID())
 
 	// Public routes
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -104,12 +169,59 @@ func main() {
 		authorized.POST("/collections", ctrlCollections.Create)
 		authorized.PATCH("/collections/:id", ctrlCollections.Rename)
 		authorized.DELETE("/collections/:id", ctrlCollections.Delete)
-		// authorized.GET("/collections/name/:name", ctrlCollections.GetCollectionByName)
+		authorized.POST("/collections/:id/clone", ctrlCollections.Clone)
+		authorized.POST("/collections/:id/merge", ctrlCollections.Merge)
+		authorized.PUT("/collections/:id/kind", ctrlCollections.SetKind)
+		authorized.GET("/collections/name/:name", ctrlCollections.GetByName)
+		authorized.GET("/collections/value", ctrlValuation.ValueUser)
+		authorized.GET("/collections/value/history", ctrlValuation.UserHistory)
+		authorized.GET("/collections/stats", ctrlStats.UserStats)
 
 		authorized.GET("/collections/:id/cards", ctrlCards.ListCardsInCollection)
 		authorized.POST("/collections/:id/cards", ctrlCards.AddCardToCollection)
-		authorized.PATCH("/collections/:id/cards/:id", ctrlCards.SetCardCountInCollection)
-		authorized.DELETE("/collections/:id/cards/:id", ctrlCards.DeleteCardFromCollection)
+		authorized.PATCH("/collections/:id/cards/:entry_id", ctrlCards.SetCardCountInCollection)
+		authorized.DELETE("/collections/:id/cards/:entry_id", ctrlCards.DeleteCardFromCollection)
+		authorized.POST("/collections/:id/cards/:entry_id/adjust", ctrlCards.AdjustCardCount)
+		authorized.POST("/collections/:id/cards/:entry_id/move", ctrlCards.MoveCardBetweenZones)
+		authorized.POST("/collections/:id/cards/:entry_id/transfer", ctrlCards.TransferCard)
+		authorized.POST("/collections/:id/transfer", ctrlCards.TransferCards)
+		authorized.POST("/collections/:id/import", ctrlImport.ImportDecklist)
+		authorized.POST("/collections/:id/import/csv", ctrlImport.ImportCSV)
+		authorized.GET("/collections/:id/export", ctrlExport.ExportDecklist)
+		authorized.GET("/collections/:id/export/csv", ctrlExport.ExportCSV)
+		authorized.GET("/collections/:id/value", ctrlValuation.ValueCollection)
+		authorized.GET("/collections/:id/value/history", ctrlValuation.CollectionHistory)
+		authorized.GET("/collections/:id/stats", ctrlStats.CollectionStats)
+		authorized.GET("/collections/:id/validate", ctrlDeck.ValidateDeck)
+		authorized.GET("/collections/:id/missing", ctrlDeck.FindMissing)
+		authorized.GET("/collections/:id/history", ctrlHistory.History)
+		authorized.POST("/collections/:id/history/undo", ctrlHistory.UndoLast)
+		authorized.POST("/collections/:id/history/:change_id/undo", ctrlHistory.Undo)
+		authorized.GET("/collections/:id/snapshots", ctrlSnapshot.List)
+		authorized.POST("/collections/:id/snapshots", ctrlSnapshot.Create)
+		authorized.GET("/collections/:id/snapshots/:snapshot_id", ctrlSnapshot.Get)
+		authorized.DELETE("/collections/:id/snapshots/:snapshot_id", ctrlSnapshot.Delete)
+		authorized.GET("/collections/:id/snapshots/:snapshot_id/diff", ctrlSnapshot.Diff)
+		authorized.POST("/collections/:id/snapshots/:snapshot_id/restore", ctrlSnapshot.Restore)
+		authorized.POST("/collections/:id/:method", controllers.CustomMethods(map[string]gin.HandlerFunc{
+			"cards:batch": ctrlCards.ApplyCardOperations,
+		}))
+
+		authorized.GET("/trash", ctrlTrash.List)
+		authorized.POST("/trash/collections/:id/restore", ctrlTrash.RestoreCollection)
+		authorized.POST("/trash/collections/:id/cards/:entry_id/restore", ctrlTrash.RestoreCard)
+
+		authorized.GET("/cards/search", ctrlSearch.SearchCards)
+
+		authorized.GET("/catalog/cards", ctrlCatalog.SearchByName)
+		authorized.GET("/catalog/cards/:set/:number", ctrlCatalog.GetPrinting)
+		authorized.GET("/catalog/oracle/:oracle_id", ctrlCatalog.GetByOracleID)
+
+		authorized.GET("/groups", ctrlTrade.ListGroups)
+		authorized.POST("/groups", ctrlTrade.CreateGroup)
+		authorized.POST("/groups/join", ctrlTrade.JoinGroup)
+		authorized.POST("/groups/:id/leave", ctrlTrade.LeaveGroup)
+		authorized.GET("/trades/matches", ctrlTrade.ListMatches)
 	}
 
 	server := &http.Server{
@@ -129,6 +241,22 @@ func main() {
 	}()
 	log.Info("Server started", zap.String("host", host))
 
+	// Trade matches are recomputed in the background so listing them stays fast
+	refreshInterval := trade.DefaultRefreshInterval
+	if raw := os.Getenv("TRADE_REFRESH_INTERVAL"); raw != "" {
+		if refreshInterval, err = time.ParseDuration(raw); err != nil || refreshInterval <= 0 {
+			panic("Invalid TRADE_REFRESH_INTERVAL")
+		}
+	}
+	go servTradeMatch.RunRefresh(ctx, refreshInterval)
+
+	// Deleted collections and cards are purged for good after TRASH_RETENTION
+	trashRetention, err := trashRetention()
+	if err != nil {
+		panic(err)
+	}
+	go servTrash.RunPurge(ctx, collection.DefaultTrashPurgeInterval, trashRetention)
+
 	// Stop server
 	<-ctx.Done()
 
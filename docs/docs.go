@@ -15,72 +15,41 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/collections": {
+        "/cards/search": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Получить список коллекций текущего пользователя",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Collections"
-                ],
-                "summary": "Get user's collections",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.Collection"
-                            }
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Создать новую коллекцию с указанным именем",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Найти карты во всех коллекциях пользователя: по Scryfall ID выпуска, по словам названия или, если ничего не нашлось, по похожим названиям. Запрос с ключевыми словами (например, c:r t:creature cmc\u003c=3 set:mh3 is:foil) ищет карты, подходящие под все условия",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Collections"
+                    "Search"
                 ],
-                "summary": "Create new collection",
+                "summary": "Search cards across user's collections",
                 "parameters": [
                     {
-                        "description": "Название новой коллекции",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateCollectionRequest"
-                        }
+                        "type": "string",
+                        "description": "Название карты, его часть, Scryfall ID или запрос с ключевыми словами",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Максимум записей (по умолчанию 50, максимум 200)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.Collection"
+                            "$ref": "#/definitions/dto.CardSearchResult"
                         }
                     },
                     "400": {
@@ -98,26 +67,50 @@ const docTemplate = `{
                 }
             }
         },
-        "/collections/{id}": {
+        "/catalog/cards": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Получить коллекцию пользователя",
+                "description": "Найти печати карт, имя которых начинается с префикса (без учёта регистра)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Collections"
+                    "Catalog"
+                ],
+                "summary": "Search catalog cards by name prefix",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Начало имени карты",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Максимум результатов, по умолчанию 20, не больше 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get one user's collection by ID",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.Collection"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.CatalogCard"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "401": {
@@ -127,33 +120,45 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/catalog/cards/{set}/{number}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Удалить коллекцию по ID",
+                "description": "Получить печать карты по коду сета и номеру в сете",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Collections"
+                    "Catalog"
                 ],
-                "summary": "Delete collection",
+                "summary": "Get a catalog printing by set and collector number",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Collection ID",
-                        "name": "id",
+                        "description": "Код сета",
+                        "name": "set",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Номер карты в сете",
+                        "name": "number",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CatalogCard"
+                        }
                     },
                     "401": {
                         "description": "Unauthorized",
@@ -168,53 +173,40 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/catalog/oracle/{oracle_id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Переименовать коллекцию по ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Получить все печати карты по её oracle ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Collections"
+                    "Catalog"
                 ],
-                "summary": "Rename collection",
+                "summary": "Get all printings of a card by oracle ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Collection ID",
-                        "name": "id",
+                        "description": "Oracle ID карты",
+                        "name": "oracle_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Новое имя коллекции",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.RenameCollectionRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Collection"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.CatalogCard"
+                            }
                         }
                     },
                     "401": {
@@ -232,28 +224,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/collections/{id}/cards": {
+        "/collections": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Получить список карт из коллекции юзера",
+                "description": "Получить список коллекций текущего пользователя",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Cards"
+                    "Collections"
                 ],
-                "summary": "Get user's cards in collection",
+                "summary": "Get user's collections",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/dto.Card"
+                                "$ref": "#/definitions/dto.Collection"
                             }
                         }
                     },
@@ -271,71 +263,135 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Добавить карту в коллекцию юзера",
+                "description": "Создать новую коллекцию с указанным именем",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Cards"
+                    "Collections"
+                ],
+                "summary": "Create new collection",
+                "parameters": [
+                    {
+                        "description": "Название новой коллекции",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateCollectionRequest"
+                        }
+                    }
                 ],
-                "summary": "Add a card to user's collection",
                 "responses": {
                     "201": {
-                        "description": "No Content"
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Collection"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/collections/{id}/cards/{card_id}": {
-            "delete": {
+        "/collections/name/{name}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Удалить карту из коллекции юзера",
+                "description": "Найти коллекцию пользователя по имени без учёта регистра",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Cards"
+                    "Collections"
+                ],
+                "summary": "Get user's collection by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Delete the card from user's collection",
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Collection"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/collections/stats": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Установить количество карт в коллекции юзера",
+                "description": "Получить статистику всех коллекций текущего пользователя вместе",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Cards"
+                    "Stats"
                 ],
-                "summary": "Set card count in user's collection",
+                "summary": "Get statistics of all user's collections",
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CollectionStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     },
                     "401": {
                         "description": "Unauthorized",
@@ -346,101 +402,231 @@ const docTemplate = `{
                 }
             }
         },
-        "/login": {
-            "post": {
-                "description": "Вход пользователя по email и паролю",
-                "consumes": [
-                    "application/json"
+        "/collections/value": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Получить стоимость всех коллекций текущего пользователя по последним ценам",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Valuation"
                 ],
-                "summary": "Login user by email",
+                "summary": "Get the value of all user's collections",
                 "parameters": [
                     {
-                        "description": "Данные для входа",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.LoginRequest"
-                        }
+                        "type": "string",
+                        "description": "usd, eur или tix; по умолчанию usd",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Число самых дорогих карт, по умолчанию 10, не больше 100",
+                        "name": "top",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата для сравнения цен (YYYY-MM-DD или RFC3339)",
+                        "name": "since",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.LoginResponse"
+                            "$ref": "#/definitions/dto.Valuation"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/domain.ResponseErr"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/domain.ResponseErr"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/logout": {
-            "post": {
-                "description": "Выход пользователя и инвалидация refresh токена",
-                "consumes": [
-                    "application/json"
+        "/collections/value/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Получить суммарную стоимость коллекций текущего пользователя по дням, по умолчанию за последние 90 дней",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Valuation"
                 ],
-                "summary": "Logout user",
+                "summary": "Get the daily value history of all user's collections",
                 "parameters": [
                     {
-                        "description": "Refresh token для выхода",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.LogoutRequest"
-                        }
+                        "type": "string",
+                        "description": "Первый день (YYYY-MM-DD или RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Последний день (YYYY-MM-DD или RFC3339), по умолчанию сегодня",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.LogoutResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ValuePoint"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/domain.ResponseErr"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/domain.ResponseErr"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/refresh": {
-            "post": {
-                "description": "Обновление access и refresh токенов",
+        "/collections/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Получить коллекцию пользователя. ETag ответа — версия коллекции, её можно передать в If-Match изменяющих запросов. Если версия совпадает с If-None-Match, вернёт 304 без тела",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Get one user's collection by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag известной версии коллекции",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Collection"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Переместить коллекцию вместе с картами в корзину. Её можно восстановить, пока корзина не очищена по сроку хранения",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Delete collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag версии коллекции, которую ожидает запрос",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Переименовать коллекцию по ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -448,293 +634,4229 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Collections"
                 ],
-                "summary": "Refresh JWT tokens",
+                "summary": "Rename collection",
                 "parameters": [
                     {
-                        "description": "Refresh token",
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новое имя коллекции",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.RefreshTokenRequest"
+                            "$ref": "#/definitions/dto.RenameCollectionRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag версии коллекции, которую ожидает запрос",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Collection"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/cards": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Получить страницу карт из коллекции юзера с фильтрами и сортировкой",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cards"
+                ],
+                "summary": "Get user's cards in collection",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы (по умолчанию 50, максимум 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение от начала списка",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Поле сортировки: name, count или added_at",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Порядок сортировки: asc или desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Подстрока имени карты",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Запрос с ключевыми словами, например c:r t:creature cmc\u003c=3 set:mh3 is:foil",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Только карты, добавленные не раньше даты (RFC3339 или YYYY-MM-DD)",
+                        "name": "added_since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Минимальное количество копий",
+                        "name": "min_count",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.RefreshTokenResponse"
+                            "$ref": "#/definitions/dto.CardsPage"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/domain.ResponseErr"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/domain.ResponseErr"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
                     }
+                ],
+                "description": "Добавить карту в коллекцию юзера",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cards"
+                ],
+                "summary": "Add a card to user's collection",
+                "parameters": [
+                    {
+                        "description": "Карта и её вариант",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AddCardRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag версии коллекции, которую ожидает запрос",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Card"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/cards/{entry_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Переместить запись карты в корзину. Её можно восстановить, пока корзина не очищена по сроку хранения",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cards"
+                ],
+                "summary": "Delete the card from user's collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Card entry ID",
+                        "name": "entry_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag версии коллекции, которую ожидает запрос",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Установить количество карт в коллекции юзера",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cards"
+                ],
+                "summary": "Set card count in user's collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Card entry ID",
+                        "name": "entry_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag версии коллекции, которую ожидает запрос",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/cards/{entry_id}/adjust": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Атомарно добавить (delta \u003e 0) или убрать (delta \u003c 0) копии записи карты. Запись уходит в корзину, когда копий не остаётся",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cards"
+                ],
+                "summary": "Add or remove copies of the card",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Card entry ID",
+                        "name": "entry_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Изменение количества копий",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AdjustCardCountRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag версии коллекции, которую ожидает запрос",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Запись после изменения, count = 0 если запись удалена",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Card"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/cards/{entry_id}/move": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Переместить копии записи карты в другую зону колоды (main, side, maybe, commander)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cards"
+                ],
+                "summary": "Move the card between deck zones",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Card entry ID",
+                        "name": "entry_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Куда и сколько копий",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MoveCardRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag версии коллекции, которую ожидает запрос",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/cards/{entry_id}/transfer": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Перенести копии записи карты в другую коллекцию пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cards"
+                ],
+                "summary": "Transfer the card to another collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Source collection ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Card entry ID",
+                        "name": "entry_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Коллекция назначения и количество копий",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransferCardRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag версии коллекции, которую ожидает запрос",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/cards:batch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Применить к коллекции пакет операций add, set_count и delete одной транзакцией. Карты операций add проверяются в Scryfall вместе, одним запросом",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cards"
+                ],
+                "summary": "Apply a batch of card operations",
+                "parameters": [
+                    {
+                        "description": "Операции и режим пакета",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CardBatchRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag версии коллекции, которую ожидает запрос",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CardBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/clone": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Скопировать коллекцию вместе с картами под новым именем",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Clone collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Имя копии",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CloneCollectionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Collection"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Выгрузить карты коллекции по зонам в формате для импорта в MTG Arena, MTGO, простым текстом или в markdown",
+                "produces": [
+                    "text/plain",
+                    "text/markdown"
+                ],
+                "tags": [
+                    "Export"
+                ],
+                "summary": "Export the collection as a text decklist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID коллекции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "arena",
+                            "mtgo",
+                            "text",
+                            "markdown"
+                        ],
+                        "type": "string",
+                        "description": "Формат списка, по умолчанию text",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/export/csv": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Выгрузить карты коллекции в CSV для Moxfield, Deckbox, ManaBox или в собственном формате сервиса. Файл отдаётся потоком",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "Export"
+                ],
+                "summary": "Export the collection as CSV",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID коллекции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "native",
+                            "moxfield",
+                            "deckbox",
+                            "manabox"
+                        ],
+                        "type": "string",
+                        "description": "Формат CSV, по умолчанию native",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Получить историю изменений карт коллекции, последние изменения первыми. Для каждого изменения указаны автор, время, ID запроса и количество копий до и после",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "History"
+                ],
+                "summary": "Get collection history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID коллекции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы (по умолчанию 50, максимум 200)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ChangesPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/history/undo": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Отменить последние ещё не отменённые изменения коллекции одной транзакцией: отменяются все или ни одно. Если карты изменились так, что отменить нельзя, вернёт 409",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "History"
+                ],
+                "summary": "Undo the last changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID коллекции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Сколько изменений отменить (максимум 50)",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UndoChangesRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag версии коллекции, которую ожидает запрос",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Изменение undo",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CollectionChange"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/history/{change_id}/undo": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Отменить изменение из истории коллекции. Если карты изменения менялись позже и эти изменения не отменены, вернёт 409. Отмена записывается в историю как изменение undo",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "History"
+                ],
+                "summary": "Undo a change",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID коллекции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID изменения",
+                        "name": "change_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag версии коллекции, которую ожидает запрос",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Изменение undo",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CollectionChange"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Импортировать текстовый список карт (MTGO, Arena или простой список) в коллекцию. С dry_run только показывает, какие карты будут добавлены",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Import"
+                ],
+                "summary": "Import a text decklist into the collection",
+                "parameters": [
+                    {
+                        "description": "Текст списка и режим предпросмотра",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImportDecklistRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag версии коллекции, которую ожидает запрос",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/import/csv": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Импортировать CSV-экспорт Moxfield, Deckbox, ManaBox или собственный формат сервиса. Формат определяется по заголовку, если не указан. Ошибки отдельных строк не останавливают импорт",
+                "consumes": [
+                    "text/csv"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Import"
+                ],
+                "summary": "Import a CSV file into the collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID коллекции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "native",
+                            "moxfield",
+                            "deckbox",
+                            "manabox"
+                        ],
+                        "type": "string",
+                        "description": "Формат CSV",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только показать результат, ничего не добавляя",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "CSV-файл",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag версии коллекции, которую ожидает запрос",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/kind": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Изменить вид коллекции: binder, deck, wishlist или trade",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Set collection kind",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новый вид коллекции",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetCollectionKindRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag версии коллекции, которую ожидает запрос",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Collection"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/merge": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Слить карты другой коллекции пользователя в эту коллекцию",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Merge collections",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Target collection ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Коллекция-источник и стратегия для дубликатов",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MergeCollectionsRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag версии целевой коллекции, которую ожидает запрос",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Collection"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/missing": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Сравнить коллекцию (колоду или список желаемого) с коллекциями-источниками: сколько копий каждой записи уже есть, в каких коллекциях они лежат и сколько не хватает. Подходит любой выпуск карты. По умолчанию источники — все binder-коллекции пользователя",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Decks"
+                ],
+                "summary": "Find the cards a collection is missing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID коллекции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID коллекций-источников через запятую",
+                        "name": "sources",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MissingReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/snapshots": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Получить снимки коллекции без карт, последние первыми",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Snapshots"
+                ],
+                "summary": "List snapshots",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID коллекции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.Snapshot"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Сохранить текущие карты коллекции как неизменяемый снимок с меткой. Если снимок с такой меткой уже есть, вернёт 409",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Snapshots"
+                ],
+                "summary": "Create a snapshot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID коллекции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Метка снимка",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateSnapshotRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Snapshot"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/snapshots/{snapshot_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Получить снимок коллекции вместе с его картами",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Snapshots"
+                ],
+                "summary": "Get a snapshot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID коллекции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID снимка",
+                        "name": "snapshot_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Snapshot"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удалить снимок коллекции. Карты коллекции не меняются",
+                "tags": [
+                    "Snapshots"
+                ],
+                "summary": "Delete a snapshot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID коллекции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID снимка",
+                        "name": "snapshot_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/snapshots/{snapshot_id}/diff": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Сравнить снимок с другим снимком коллекции или, если to не указан, с текущим состоянием коллекции. Возвращает добавленные, удалённые и изменившиеся по количеству копий варианты карт",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Snapshots"
+                ],
+                "summary": "Diff a snapshot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID коллекции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID снимка, с которого считаются изменения",
+                        "name": "snapshot_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID снимка, с которым сравнивать",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SnapshotDiff"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/snapshots/{snapshot_id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Вернуть карты коллекции к состоянию снимка одной транзакцией. Записи вариантов, которых нет в снимке, уходят в корзину. Восстановление записывается в историю как изменение snapshot_restore и может быть отменено",
+                "tags": [
+                    "Snapshots"
+                ],
+                "summary": "Restore a snapshot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID коллекции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID снимка",
+                        "name": "snapshot_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag версии коллекции, которую ожидает запрос",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Получить статистику коллекции: количество карт, разбивки по цвету, редкости, сету, типу и мана-стоимости, кривую маны для колод, долю фольги и добавления по месяцам",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stats"
+                ],
+                "summary": "Get collection statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID коллекции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CollectionStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/transfer": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Перенести несколько записей карт в другую коллекцию пользователя одной транзакцией",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cards"
+                ],
+                "summary": "Transfer many cards to another collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Source collection ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Коллекция назначения и карты",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransferCardsRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag версии коллекции, которую ожидает запрос",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/validate": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Проверить коллекцию как колоду формата: запрещённые и нелегальные карты, лимит копий, размер колоды и сайдборда, для commander — цветовую идентичность",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Decks"
+                ],
+                "summary": "Validate a collection as a deck of a format",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID коллекции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "standard, pioneer, modern, legacy, vintage, pauper или commander",
+                        "name": "format",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DeckValidation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/value": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Получить стоимость коллекции по последним ценам, самые дорогие карты и изменение стоимости с даты since",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Valuation"
+                ],
+                "summary": "Get the value of a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID коллекции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "usd, eur или tix; по умолчанию usd",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Число самых дорогих карт, по умолчанию 10, не больше 100",
+                        "name": "top",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата для сравнения цен (YYYY-MM-DD или RFC3339)",
+                        "name": "since",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Valuation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/value/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Получить стоимость коллекции по дням во всех валютах, по умолчанию за последние 90 дней",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Valuation"
+                ],
+                "summary": "Get the daily value history of a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID коллекции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Первый день (YYYY-MM-DD или RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Последний день (YYYY-MM-DD или RFC3339), по умолчанию сегодня",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ValuePoint"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Получить группы игроков, в которых состоит пользователь",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trades"
+                ],
+                "summary": "List user's groups",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.Group"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создать группу игроков; создатель становится её участником и получает код приглашения",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trades"
+                ],
+                "summary": "Create a group",
+                "parameters": [
+                    {
+                        "description": "Название группы",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Group"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/join": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Вступить в группу игроков по коду приглашения",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trades"
+                ],
+                "summary": "Join a group",
+                "parameters": [
+                    {
+                        "description": "Код приглашения",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.JoinGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Group"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{id}/leave": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Выйти из группы игроков; группа удаляется, когда из неё выходит последний участник",
+                "tags": [
+                    "Trades"
+                ],
+                "summary": "Leave a group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID группы",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "Вход пользователя по email и паролю",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Login user by email",
+                "parameters": [
+                    {
+                        "description": "Данные для входа",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ResponseErr"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ResponseErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/logout": {
+            "post": {
+                "description": "Выход пользователя и инвалидация refresh токена",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Logout user",
+                "parameters": [
+                    {
+                        "description": "Refresh token для выхода",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LogoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LogoutResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ResponseErr"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ResponseErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/refresh": {
+            "post": {
+                "description": "Обновление access и refresh токенов",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Refresh JWT tokens",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RefreshTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ResponseErr"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ResponseErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/register": {
+            "post": {
+                "description": "Регистрация пользователя через Telegram бота",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Register telegram user",
+                "parameters": [
+                    {
+                        "description": "Данные для регистрации",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RegisterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ResponseErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/trades/matches": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Получить предложения обмена с участниками общих групп: карты из их списков обмена, которые есть в списках желаемого пользователя, и наоборот. Самые равноценные обмены первыми. Предложения пересчитываются в фоне",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trades"
+                ],
+                "summary": "List trade matches",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы (по умолчанию 20, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TradeMatchesPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Получить удалённые коллекции и карты текущего пользователя, которые ещё можно восстановить",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "List trash",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Trash"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash/collections/{id}/cards/{entry_id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Восстановить удалённую запись карты. Если в коллекции уже есть запись того же варианта карты, копии добавляются к ней",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Restore card",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID коллекции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Card entry ID",
+                        "name": "entry_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag версии коллекции, которую ожидает запрос",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Восстановленная запись",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Card"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash/collections/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Восстановить удалённую коллекцию вместе с картами. Если у пользователя уже есть коллекция с таким именем, вернёт 409",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Restore collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID коллекции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Collection"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.ResponseErr": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.AddCardRequest": {
+            "description": "Запрос для добавления карты по Scryfall ID. Имя, изображение, сет, редкость и тип карты заполняются из Scryfall",
+            "type": "object",
+            "required": [
+                "count",
+                "scryfall_id"
+            ],
+            "properties": {
+                "card_url": {
+                    "description": "берётся из Scryfall, используется только если Scryfall недоступен",
+                    "type": "string",
+                    "example": "https://example.com/black-lotus.jpg"
+                },
+                "condition": {
+                    "description": "NM, LP, MP, HP или DMG; по умолчанию NM",
+                    "type": "string",
+                    "example": "NM"
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "finish": {
+                    "description": "nonfoil, foil или etched; по умолчанию nonfoil",
+                    "type": "string",
+                    "example": "foil"
+                },
+                "language": {
+                    "description": "код языка Scryfall; по умолчанию en",
+                    "type": "string",
+                    "example": "en"
+                },
+                "name": {
+                    "description": "берётся из Scryfall, используется только если Scryfall недоступен",
+                    "type": "string",
+                    "example": "Black Lotus"
+                },
+                "scryfall_id": {
+                    "type": "string",
+                    "example": "12345678-1234-1234-1234-123456789012"
+                },
+                "zone": {
+                    "description": "main, side, maybe или commander; по умолчанию main",
+                    "type": "string",
+                    "example": "main"
+                }
+            }
+        },
+        "dto.AdjustCardCountRequest": {
+            "description": "Запрос для добавления (delta \u003e 0) или удаления (delta \u003c 0) копий записи карты",
+            "type": "object",
+            "required": [
+                "delta"
+            ],
+            "properties": {
+                "delta": {
+                    "type": "integer",
+                    "example": -1
+                }
+            }
+        },
+        "dto.Card": {
+            "description": "Модель записи карты: ID записи, Scryfall ID, имя, URL изображения, количество, вариант, сет, редкость и тип",
+            "type": "object",
+            "properties": {
+                "card_url": {
+                    "type": "string",
+                    "example": "https://example.com/black-lotus.jpg"
+                },
+                "condition": {
+                    "type": "string",
+                    "example": "NM"
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "finish": {
+                    "type": "string",
+                    "example": "foil"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "64a9b66b2db8b91234a6e8e4"
+                },
+                "language": {
+                    "type": "string",
+                    "example": "en"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Black Lotus"
+                },
+                "rarity": {
+                    "type": "string",
+                    "example": "rare"
+                },
+                "scryfall_id": {
+                    "type": "string",
+                    "example": "12345678-1234-1234-1234-123456789012"
+                },
+                "set_code": {
+                    "type": "string",
+                    "example": "lea"
+                },
+                "type_line": {
+                    "type": "string",
+                    "example": "Artifact"
+                },
+                "zone": {
+                    "type": "string",
+                    "example": "main"
+                }
+            }
+        },
+        "dto.CardBatchRequest": {
+            "description": "Операции add, set_count и delete, применяемые одной транзакцией. В режиме atomic (по умолчанию) пакет применяется целиком или не применяется, в режиме best_effort ошибочные операции пропускаются",
+            "type": "object",
+            "required": [
+                "operations"
+            ],
+            "properties": {
+                "mode": {
+                    "description": "atomic или best_effort",
+                    "type": "string",
+                    "example": "atomic"
+                },
+                "operations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CardOperationRequest"
+                    }
+                }
+            }
+        },
+        "dto.CardBatchResponse": {
+            "description": "Результат для каждой операции в порядке запроса. applied = false, если atomic пакет отклонён",
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CardOperationResult"
+                    }
+                }
+            }
+        },
+        "dto.CardCountChange": {
+            "description": "card — вариант карты в более новой версии",
+            "type": "object",
+            "properties": {
+                "after": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "before": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "card": {
+                    "$ref": "#/definitions/dto.Card"
+                }
+            }
+        },
+        "dto.CardOperationRequest": {
+            "description": "Для add задаются карта и её вариант, для set_count и delete — ID записи, для set_count ещё и новое количество",
+            "type": "object",
+            "required": [
+                "op"
+            ],
+            "properties": {
+                "card_url": {
+                    "type": "string",
+                    "example": "https://example.com/black-lotus.jpg"
+                },
+                "condition": {
+                    "type": "string",
+                    "example": "NM"
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "entry_id": {
+                    "type": "string",
+                    "example": "64a9b66b2db8b91234a6e8e4"
+                },
+                "finish": {
+                    "type": "string",
+                    "example": "nonfoil"
+                },
+                "language": {
+                    "type": "string",
+                    "example": "en"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Black Lotus"
+                },
+                "op": {
+                    "description": "add, set_count или delete",
+                    "type": "string",
+                    "example": "add"
+                },
+                "scryfall_id": {
+                    "type": "string",
+                    "example": "12345678-1234-1234-1234-123456789012"
+                },
+                "zone": {
+                    "type": "string",
+                    "example": "main"
+                }
+            }
+        },
+        "dto.CardOperationResult": {
+            "description": "HTTP статус операции, запись карты после операции (count = 0 для удалённой) или ошибка",
+            "type": "object",
+            "properties": {
+                "card": {
+                    "$ref": "#/definitions/dto.Card"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "dto.CardSearchHit": {
+            "description": "Запись карты (количество копий и вариант: зона, отделка, состояние, язык) и коллекция, в которой она лежит",
+            "type": "object",
+            "properties": {
+                "card": {
+                    "$ref": "#/definitions/dto.Card"
+                },
+                "collection_id": {
+                    "type": "string",
+                    "example": "64a9b66b2db8b91234a6e8e3"
+                },
+                "collection_name": {
+                    "type": "string",
+                    "example": "Binder"
+                }
+            }
+        },
+        "dto.CardSearchResult": {
+            "description": "Найденные записи карт с коллекциями, в которых они лежат. match: scryfall_id (поиск по ID выпуска), text (по словам названия), fuzzy (по похожим названиям, с опечатками и началами слов) или query (по запросу с ключевыми словами)",
+            "type": "object",
+            "properties": {
+                "hits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CardSearchHit"
+                    }
+                },
+                "match": {
+                    "type": "string",
+                    "example": "text"
+                },
+                "query": {
+                    "type": "string",
+                    "example": "sheoldred"
+                }
+            }
+        },
+        "dto.CardValue": {
+            "description": "Цена одной копии и стоимость всех копий записи карты",
+            "type": "object",
+            "properties": {
+                "card": {
+                    "$ref": "#/definitions/dto.Card"
+                },
+                "collection_id": {
+                    "type": "string",
+                    "example": "64a9b66b2db8b91234a6e8e4"
+                },
+                "unit_price": {
+                    "type": "number",
+                    "example": 2.15
+                },
+                "value": {
+                    "type": "number",
+                    "example": 8.6
+                }
+            }
+        },
+        "dto.CardsPage": {
+            "description": "Страница записей карт коллекции с метаданными пагинации",
+            "type": "object",
+            "properties": {
+                "cards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.Card"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/dto.Pagination"
+                }
+            }
+        },
+        "dto.CatalogCard": {
+            "description": "Печать карты из офлайн-каталога, собранного из bulk-данных Scryfall",
+            "type": "object",
+            "properties": {
+                "cmc": {
+                    "type": "number",
+                    "example": 1
+                },
+                "collector_number": {
+                    "type": "string",
+                    "example": "146"
+                },
+                "color_identity": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "colors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "image_uri": {
+                    "type": "string",
+                    "example": "https://cards.scryfall.io/normal/front/e/3/bolt.jpg"
+                },
+                "lang": {
+                    "type": "string",
+                    "example": "en"
+                },
+                "legalities": {
+                    "description": "формат → legal, not_legal, restricted или banned",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "mana_cost": {
+                    "type": "string",
+                    "example": "{R}"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Lightning Bolt"
+                },
+                "oracle_id": {
+                    "type": "string",
+                    "example": "4457ed35-7c10-48c8-9776-456485fdf070"
+                },
+                "rarity": {
+                    "type": "string",
+                    "example": "common"
+                },
+                "scryfall_id": {
+                    "type": "string",
+                    "example": "e3285e6b-3e79-4d7c-bf96-d920f973b80d"
+                },
+                "set_code": {
+                    "type": "string",
+                    "example": "m10"
+                },
+                "set_name": {
+                    "type": "string",
+                    "example": "Magic 2010"
+                },
+                "type_line": {
+                    "type": "string",
+                    "example": "Instant"
+                }
+            }
+        },
+        "dto.ChangeEntry": {
+            "description": "before равно 0 для добавленной записи, after равно 0 для удалённой. count карты равен after",
+            "type": "object",
+            "properties": {
+                "after": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "before": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "card": {
+                    "$ref": "#/definitions/dto.Card"
+                },
+                "collection_id": {
+                    "type": "string",
+                    "example": "64a9b66b2db8b91234a6e8e3"
+                }
+            }
+        },
+        "dto.ChangesPage": {
+            "description": "Изменения карт коллекции, последние первыми",
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CollectionChange"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/dto.Pagination"
+                }
+            }
+        },
+        "dto.CloneCollectionRequest": {
+            "description": "Запрос для копирования коллекции вместе с картами под новым именем",
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Burn before changes"
+                }
+            }
+        },
+        "dto.Collection": {
+            "description": "Модель коллекции с ID, именем и видом",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "64a9b66b2db8b91234a6e8e3"
+                },
+                "kind": {
+                    "description": "в списке коллекций пользователя не заполняется",
+                    "type": "string",
+                    "example": "binder"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "My cool collection"
+                },
+                "version": {
+                    "description": "версия для If-Match, ETag коллекции; в списке коллекций пользователя не заполняется",
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
+        "dto.CollectionChange": {
+            "description": "Одно изменение карт: kind — add, import, set_count, delete, adjust, move, transfer, batch, merge, restore, snapshot_restore или undo. Перенос между коллекциями есть в истории обеих. undone_by — ID отменившего изменения, reverts — изменения, которые отменяет undo",
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string",
+                    "example": "2026-10-19T12:00:00Z"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ChangeEntry"
+                    }
+                },
+                "id": {
+                    "type": "string",
+                    "example": "6710a0b2c3d4e5f601234567"
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "adjust"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "2f1c7a9e-4b8d-4f3a-9c1e-7d2b5a6f8e90"
+                },
+                "reverts": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "undone_by": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "64a9b66b2db8b91234a6e8e0"
+                }
+            }
+        },
+        "dto.CollectionStats": {
+            "description": "Количество копий и уникальных карт, доля фольги и разбивки по цвету, редкости, сету, типу и мана-стоимости. Цвет, тип и мана-стоимость считаются только для карт из каталога; mana_curve есть только у колод",
+            "type": "object",
+            "properties": {
+                "added_per_month": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.MonthCount"
+                    }
+                },
+                "by_color": {
+                    "description": "W, U, B, R, G и C для бесцветных",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.StatsBucket"
+                    }
+                },
+                "by_mana_value": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.StatsBucket"
+                    }
+                },
+                "by_rarity": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.StatsBucket"
+                    }
+                },
+                "by_set": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.StatsBucket"
+                    }
+                },
+                "by_type": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.StatsBucket"
+                    }
+                },
+                "cards": {
+                    "type": "integer",
+                    "example": 250
+                },
+                "foil": {
+                    "description": "копии foil и etched",
+                    "type": "integer",
+                    "example": 25
+                },
+                "foil_ratio": {
+                    "type": "number",
+                    "example": 0.1
+                },
+                "mana_curve": {
+                    "description": "только для колод: карты кроме земель в main и commander",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CurveBar"
+                    }
+                },
+                "multicolor": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "printings": {
+                    "description": "разные выпуски",
+                    "type": "integer",
+                    "example": 195
+                },
+                "uncataloged": {
+                    "description": "копии выпусков, которых нет в каталоге",
+                    "type": "integer",
+                    "example": 3
+                },
+                "unique": {
+                    "description": "разные карты, все выпуски карты считаются одной",
+                    "type": "integer",
+                    "example": 180
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateCollectionRequest": {
+            "description": "Запрос для создания коллекции с указанным именем и видом",
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "kind": {
+                    "description": "binder, deck, wishlist или trade; по умолчанию binder",
+                    "type": "string",
+                    "example": "deck"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "My cool collection"
+                }
+            }
+        },
+        "dto.CreateGroupRequest": {
+            "description": "Название новой группы игроков. Создатель становится её первым участником",
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Friday Night Magic"
+                }
+            }
+        },
+        "dto.CreateSnapshotRequest": {
+            "description": "Метка снимка, уникальная в пределах коллекции, до 100 символов",
+            "type": "object",
+            "required": [
+                "label"
+            ],
+            "properties": {
+                "label": {
+                    "type": "string",
+                    "example": "Before FNM"
+                }
+            }
+        },
+        "dto.CurveBar": {
+            "description": "Копии с мана-стоимостью mana_value; последний столбец, 7, включает всё дороже",
+            "type": "object",
+            "properties": {
+                "cards": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "mana_value": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.DeckValidation": {
+            "description": "Легальность колоды в формате: размер колоды и сайдборда и список нарушений. Карты из maybe не проверяются",
+            "type": "object",
+            "properties": {
+                "deck_size": {
+                    "description": "основная колода вместе с командирами",
+                    "type": "integer",
+                    "example": 58
+                },
+                "format": {
+                    "type": "string",
+                    "example": "modern"
+                },
+                "legal": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "sideboard": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DeckViolation"
+                    }
+                }
+            }
+        },
+        "dto.DeckViolation": {
+            "description": "Вид нарушения, карта (пусто для правил всей колоды), количество и допустимый предел",
+            "type": "object",
+            "properties": {
+                "card": {
+                    "type": "string",
+                    "example": "Lightning Bolt"
+                },
+                "colors": {
+                    "description": "цвета вне цветовой идентичности командира",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "kind": {
+                    "description": "banned, not_legal, restricted, too_many_copies, deck_too_small, deck_too_large,\nsideboard_too_large, missing_commander, too_many_commanders, color_identity или unknown_card",
+                    "type": "string",
+                    "example": "too_many_copies"
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Deck has 5 copies of Lightning Bolt, modern allows 4"
+                }
+            }
+        },
+        "dto.ErrorResponse": {
+            "description": "Структура ответа при ошибке",
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "unauthorized"
+                },
+                "position": {
+                    "description": "Позиция ошибки в поисковом запросе, с 1, в символах. Только для синтаксических ошибок запроса",
+                    "type": "integer",
+                    "example": 5
+                },
+                "status": {
+                    "description": "Optional, can be used to indicate HTTP status code",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.Group": {
+            "description": "Группа игроков, которые обмениваются картами. Участники группы видят предложения обмена друг с другом",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "64a9b66b2db8b91234a6e8f0"
+                },
+                "invite_code": {
+                    "type": "string",
+                    "example": "K3ZQ7M2A"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Friday Night Magic"
+                },
+                "owner_id": {
+                    "type": "string",
+                    "example": "64a9b66b2db8b91234a6e8e0"
+                }
+            }
+        },
+        "dto.ImportDecklistRequest": {
+            "description": "Список карт в формате MTGO, Arena (с заголовками Deck/Sideboard) или простой список \"4x Lightning Bolt\"",
+            "type": "object",
+            "required": [
+                "text"
+            ],
+            "properties": {
+                "dry_run": {
+                    "description": "только показать результат, ничего не добавляя",
+                    "type": "boolean",
+                    "example": true
+                },
+                "text": {
+                    "description": "не длиннее 100000 символов",
+                    "type": "string",
+                    "maxLength": 100000,
+                    "example": "4 Lightning Bolt (M10) 146"
+                }
+            }
+        },
+        "dto.ImportResponse": {
+            "description": "Распознанные карты (с ID записей, если импорт не dry_run) и строки, которые не удалось импортировать",
+            "type": "object",
+            "properties": {
+                "cards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ImportedCard"
+                    }
+                },
+                "dry_run": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "unresolved": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.UnresolvedLine"
+                    }
+                }
+            }
+        },
+        "dto.ImportedCard": {
+            "description": "Номер строки и карта, в которую она превратилась",
+            "type": "object",
+            "properties": {
+                "card": {
+                    "$ref": "#/definitions/dto.Card"
+                },
+                "line": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "dto.JoinGroupRequest": {
+            "description": "Код приглашения группы",
+            "type": "object",
+            "required": [
+                "invite_code"
+            ],
+            "properties": {
+                "invite_code": {
+                    "type": "string",
+                    "example": "K3ZQ7M2A"
+                }
+            }
+        },
+        "dto.LoginRequest": {
+            "description": "Вход пользователя по email и паролю",
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6,
+                    "example": "strongpassword"
+                }
+            }
+        },
+        "dto.LoginResponse": {
+            "description": "Ответ с JWT-токенами (access и refresh) после логина",
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJSUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "expires_at": {
+                    "type": "integer",
+                    "example": 900
+                }
+            }
+        },
+        "dto.LogoutRequest": {
+            "description": "Выход пользователя: инвалидация предоставленного refresh-токена",
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "dGhpc19pc19hX3JlZnJlc2hfdG9rZW4="
+                }
+            }
+        },
+        "dto.LogoutResponse": {
+            "description": "Подтверждение успешного logout",
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Logout successful"
+                }
+            }
+        },
+        "dto.MergeCollectionsRequest": {
+            "description": "Слить карты коллекции-источника в текущую. strategy: sum, max или keep_target",
+            "type": "object",
+            "required": [
+                "source_collection_id"
+            ],
+            "properties": {
+                "delete_source": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "source_collection_id": {
+                    "type": "string",
+                    "example": "64a9b66b2db8b91234a6e8e5"
+                },
+                "strategy": {
+                    "description": "по умолчанию sum",
+                    "type": "string",
+                    "example": "sum"
+                }
+            }
+        },
+        "dto.MissingCardsEntry": {
+            "description": "Запись карты коллекции, число нужных копий, коллекции-источники с найденными копиями и число недостающих",
+            "type": "object",
+            "properties": {
+                "card": {
+                    "$ref": "#/definitions/dto.Card"
+                },
+                "missing": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "needed": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "owned": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.OwnedCopies"
+                    }
+                }
+            }
+        },
+        "dto.MissingReport": {
+            "description": "Сколько копий карт коллекции (колоды или списка желаемого) есть в коллекциях-источниках и сколько не хватает. Подходит любой выпуск карты, карты из maybe не учитываются",
+            "type": "object",
+            "properties": {
+                "collection_id": {
+                    "type": "string",
+                    "example": "64a9b66b2db8b91234a6e8e3"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.MissingCardsEntry"
+                    }
+                },
+                "missing": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "needed": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "owned": {
+                    "type": "integer",
+                    "example": 52
+                },
+                "sources": {
+                    "description": "по умолчанию все binder-коллекции пользователя",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.Collection"
+                    }
+                }
+            }
+        },
+        "dto.MonthCount": {
+            "description": "Месяц в формате YYYY-MM и число добавленных за него копий",
+            "type": "object",
+            "properties": {
+                "cards": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "month": {
+                    "type": "string",
+                    "example": "2026-10"
+                }
+            }
+        },
+        "dto.MoveCardRequest": {
+            "description": "Запрос для перемещения карт между main, side, maybe и commander",
+            "type": "object",
+            "required": [
+                "count",
+                "to_zone"
+            ],
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "to_zone": {
+                    "type": "string",
+                    "example": "side"
+                }
+            }
+        },
+        "dto.OwnedCopies": {
+            "description": "Сколько копий карты из коллекции-источника засчитано записи",
+            "type": "object",
+            "properties": {
+                "collection_id": {
+                    "type": "string",
+                    "example": "64a9b66b2db8b91234a6e8e4"
+                },
+                "collection_name": {
+                    "type": "string",
+                    "example": "Binder"
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "dto.Pagination": {
+            "description": "Общее число записей, подходящих под фильтры, смещение и размер страницы",
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "dto.RefreshTokenRequest": {
+            "description": "Обновление access-токена по refresh-токену",
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "dGhpc19pc19hX3JlZnJlc2hfdG9rZW4="
+                }
+            }
+        },
+        "dto.RefreshTokenResponse": {
+            "description": "Новый access- и refresh-токены после успешного refresh",
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJSUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "expires_at": {
+                    "type": "integer",
+                    "example": 900
+                }
+            }
+        },
+        "dto.RegisterRequest": {
+            "description": "Регистрация пользователя по email и паролю",
+            "type": "object",
+            "required": [
+                "email",
+                "first_name",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "first_name": {
+                    "type": "string",
+                    "example": "Ivan"
+                },
+                "last_name": {
+                    "type": "string",
+                    "example": "Ivanov"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6,
+                    "example": "strongpassword"
+                }
+            }
+        },
+        "dto.RegisterResponse": {
+            "description": "Ответ с JWT-токенами (access и refresh)",
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJSUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "expires_at": {
+                    "type": "integer",
+                    "example": 900
                 }
             }
         },
-        "/register": {
-            "post": {
-                "description": "Регистрация пользователя через Telegram бота",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Auth"
-                ],
-                "summary": "Register telegram user",
-                "parameters": [
-                    {
-                        "description": "Данные для регистрации",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.RegisterRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/dto.RegisterResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ResponseErr"
-                        }
-                    }
+        "dto.RenameCollectionRequest": {
+            "description": "Запрос для изменения названия коллекции",
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Renamed collection"
                 }
             }
-        }
-    },
-    "definitions": {
-        "domain.ResponseErr": {
+        },
+        "dto.SetCollectionKindRequest": {
+            "description": "Вид коллекции: binder (карты пользователя), deck (колода), wishlist (список желаемого) или trade (карты на обмен)",
             "type": "object",
+            "required": [
+                "kind"
+            ],
             "properties": {
-                "message": {
-                    "type": "string"
-                },
-                "status": {
-                    "type": "integer"
+                "kind": {
+                    "type": "string",
+                    "example": "wishlist"
                 }
             }
         },
-        "dto.Card": {
-            "description": "Модель карты с Scryfall ID, именем, URL изображения и количеством",
+        "dto.Snapshot": {
+            "description": "Неизменяемая копия записей карт коллекции на момент created_at. entries — число записей, copies — число копий. cards возвращается только для одного снимка",
             "type": "object",
             "properties": {
-                "card_url": {
+                "cards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.Card"
+                    }
+                },
+                "collection_id": {
                     "type": "string",
-                    "example": "https://example.com/black-lotus.jpg"
+                    "example": "64a9b66b2db8b91234a6e8e3"
                 },
-                "count": {
+                "copies": {
                     "type": "integer",
-                    "example": 1
+                    "example": 7
                 },
-                "name": {
+                "created_at": {
                     "type": "string",
-                    "example": "Black Lotus"
+                    "example": "2026-10-19T12:00:00Z"
                 },
-                "scryfall_id": {
+                "entries": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "id": {
                     "type": "string",
-                    "example": "12345678-1234-1234-1234-123456789012"
+                    "example": "6710a0b2c3d4e5f601234570"
+                },
+                "label": {
+                    "type": "string",
+                    "example": "Before FNM"
                 }
             }
         },
-        "dto.Collection": {
-            "description": "Модель коллекции с ID и именем",
+        "dto.SnapshotDiff": {
+            "description": "Карты, добавленные, удалённые и с изменённым количеством копий по сравнению со снимком from_snapshot_id. Если to_snapshot_id пуст, сравнение с текущим состоянием коллекции. Варианты карт сравниваются по Scryfall ID, зоне, отделке, состоянию и языку",
             "type": "object",
             "properties": {
-                "id": {
+                "added": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.Card"
+                    }
+                },
+                "changed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CardCountChange"
+                    }
+                },
+                "from_snapshot_id": {
                     "type": "string",
-                    "example": "64a9b66b2db8b91234a6e8e3"
+                    "example": "6710a0b2c3d4e5f601234570"
                 },
-                "name": {
+                "removed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.Card"
+                    }
+                },
+                "to_snapshot_id": {
                     "type": "string",
-                    "example": "My cool collection"
+                    "example": "6710a0b2c3d4e5f601234571"
                 }
             }
         },
-        "dto.CreateCollectionRequest": {
-            "description": "Запрос для создания коллекции с указанным именем",
+        "dto.StatsBucket": {
+            "description": "Копии и уникальные карты с одним значением: цветом, редкостью, кодом сета, типом или мана-стоимостью",
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "name": {
+                "cards": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "key": {
                     "type": "string",
-                    "example": "My cool collection"
+                    "example": "R"
+                },
+                "unique": {
+                    "type": "integer",
+                    "example": 40
                 }
             }
         },
-        "dto.ErrorResponse": {
-            "description": "Структура ответа при ошибке",
+        "dto.TradeItem": {
+            "description": "Выпуск карты, число копий и их стоимость в USD",
             "type": "object",
             "properties": {
-                "message": {
+                "count": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "finish": {
                     "type": "string",
-                    "example": "unauthorized"
+                    "example": "foil"
                 },
-                "status": {
-                    "description": "Optional, can be used to indicate HTTP status code",
-                    "type": "integer"
+                "name": {
+                    "type": "string",
+                    "example": "Lightning Bolt"
+                },
+                "scryfall_id": {
+                    "type": "string",
+                    "example": "e3285e6b-3e79-4d7c-bf96-d920f973b122"
+                },
+                "unit_price": {
+                    "type": "number",
+                    "example": 5
+                },
+                "value": {
+                    "type": "number",
+                    "example": 10
                 }
             }
         },
-        "dto.LoginRequest": {
-            "description": "Вход пользователя по email и паролю",
+        "dto.TradeMatch": {
+            "description": "Карты из списка обмена партнёра, которые есть в списке желаемого пользователя (gets), и наоборот (gives), со стоимостью в USD. balance = gets_value - gives_value, score от 0 (обмен в одну сторону) до 1 (равноценный обмен)",
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
+                "balance": {
+                    "type": "number",
+                    "example": 3
+                },
+                "gets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TradeItem"
+                    }
+                },
+                "gets_value": {
+                    "type": "number",
+                    "example": 10
+                },
+                "gives": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TradeItem"
+                    }
+                },
+                "gives_value": {
+                    "type": "number",
+                    "example": 7
+                },
+                "partner_id": {
                     "type": "string",
-                    "example": "user@example.com"
+                    "example": "64a9b66b2db8b91234a6e8e1"
                 },
-                "password": {
+                "partner_name": {
                     "type": "string",
-                    "minLength": 6,
-                    "example": "strongpassword"
+                    "example": "bob"
+                },
+                "score": {
+                    "type": "number",
+                    "example": 0.7
+                },
+                "updated_at": {
+                    "description": "время последнего пересчёта",
+                    "type": "string"
                 }
             }
         },
-        "dto.LoginResponse": {
-            "description": "Ответ с JWT-токенами (access и refresh) после логина",
+        "dto.TradeMatchesPage": {
+            "description": "Предложения обмена с участниками общих групп, самые равноценные первыми",
             "type": "object",
             "properties": {
-                "access_token": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJSUzI1NiIsInR5cCI6IkpXVCJ9..."
+                "matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TradeMatch"
+                    }
                 },
-                "expires_at": {
-                    "type": "integer",
-                    "example": 900
+                "pagination": {
+                    "$ref": "#/definitions/dto.Pagination"
                 }
             }
         },
-        "dto.LogoutRequest": {
-            "description": "Выход пользователя: инвалидация предоставленного refresh-токена",
+        "dto.TransferCardItem": {
             "type": "object",
             "required": [
-                "refresh_token"
+                "count",
+                "entry_id"
             ],
             "properties": {
-                "refresh_token": {
+                "count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "entry_id": {
                     "type": "string",
-                    "example": "dGhpc19pc19hX3JlZnJlc2hfdG9rZW4="
+                    "example": "64a9b66b2db8b91234a6e8e4"
                 }
             }
         },
-        "dto.LogoutResponse": {
-            "description": "Подтверждение успешного logout",
+        "dto.TransferCardRequest": {
+            "description": "Запрос для переноса копий записи карты в другую коллекцию пользователя",
             "type": "object",
+            "required": [
+                "count",
+                "to_collection_id"
+            ],
             "properties": {
-                "message": {
+                "count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "to_collection_id": {
                     "type": "string",
-                    "example": "Logout successful"
+                    "example": "64a9b66b2db8b91234a6e8e5"
                 }
             }
         },
-        "dto.RefreshTokenRequest": {
-            "description": "Обновление access-токена по refresh-токену",
+        "dto.TransferCardsRequest": {
+            "description": "Запрос для переноса нескольких записей карт в другую коллекцию пользователя одной транзакцией",
             "type": "object",
             "required": [
-                "refresh_token"
+                "items",
+                "to_collection_id"
             ],
             "properties": {
-                "refresh_token": {
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.TransferCardItem"
+                    }
+                },
+                "to_collection_id": {
                     "type": "string",
-                    "example": "dGhpc19pc19hX3JlZnJlc2hfdG9rZW4="
+                    "example": "64a9b66b2db8b91234a6e8e5"
                 }
             }
         },
-        "dto.RefreshTokenResponse": {
-            "description": "Новый access- и refresh-токены после успешного refresh",
+        "dto.Trash": {
+            "description": "Удалённые коллекции и карты, удалённые из коллекций пользователя. Их можно восстановить, пока корзина не очищена по сроку хранения. Карты удалённой коллекции восстанавливаются вместе с ней и отдельно не показываются",
             "type": "object",
             "properties": {
-                "access_token": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJSUzI1NiIsInR5cCI6IkpXVCJ9..."
+                "cards": {
+                    "description": "сначала удалённые последними",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TrashedCard"
+                    }
                 },
-                "expires_at": {
-                    "type": "integer",
-                    "example": 900
+                "collections": {
+                    "description": "сначала удалённые последними",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TrashedCollection"
+                    }
                 }
             }
         },
-        "dto.RegisterRequest": {
-            "description": "Регистрация пользователя по email и паролю",
+        "dto.TrashedCard": {
+            "description": "Запись карты в корзине, коллекция, из которой она удалена, и время удаления",
             "type": "object",
-            "required": [
-                "email",
-                "first_name",
-                "password"
-            ],
             "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "user@example.com"
+                "card": {
+                    "$ref": "#/definitions/dto.Card"
                 },
-                "first_name": {
+                "collection_id": {
                     "type": "string",
-                    "example": "Ivan"
+                    "example": "64a9b66b2db8b91234a6e8e5"
                 },
-                "last_name": {
+                "collection_name": {
                     "type": "string",
-                    "example": "Ivanov"
+                    "example": "Burn"
                 },
-                "password": {
+                "deleted_at": {
                     "type": "string",
-                    "minLength": 6,
-                    "example": "strongpassword"
+                    "example": "2026-10-19T12:00:00Z"
                 }
             }
         },
-        "dto.RegisterResponse": {
-            "description": "Ответ с JWT-токенами (access и refresh)",
+        "dto.TrashedCollection": {
+            "description": "Коллекция в корзине и время её удаления",
             "type": "object",
             "properties": {
-                "access_token": {
+                "deleted_at": {
                     "type": "string",
-                    "example": "eyJhbGciOiJSUzI1NiIsInR5cCI6IkpXVCJ9..."
+                    "example": "2026-10-19T12:00:00Z"
                 },
-                "expires_at": {
-                    "type": "integer",
-                    "example": 900
+                "id": {
+                    "type": "string",
+                    "example": "64a9b66b2db8b91234a6e8e3"
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "binder"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Old binder"
                 }
             }
         },
-        "dto.RenameCollectionRequest": {
-            "description": "Запрос для изменения названия коллекции",
+        "dto.UndoChangesRequest": {
+            "description": "Сколько последних ещё не отменённых изменений отменить",
             "type": "object",
             "required": [
-                "name"
+                "last"
             ],
             "properties": {
-                "name": {
+                "last": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "dto.UnresolvedLine": {
+            "description": "Номер строки, её текст и причина",
+            "type": "object",
+            "properties": {
+                "line": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "reason": {
                     "type": "string",
-                    "example": "Renamed collection"
+                    "example": "Card not found in catalog"
+                },
+                "text": {
+                    "type": "string",
+                    "example": "4 Lightning Blot"
+                }
+            }
+        },
+        "dto.Valuation": {
+            "description": "Стоимость карт по последним ценам с учётом отделки (foil, etched), самые дорогие карты и изменение цены с даты since",
+            "type": "object",
+            "properties": {
+                "cards": {
+                    "description": "число копий, включая копии без цены",
+                    "type": "integer",
+                    "example": 250
+                },
+                "change": {
+                    "type": "number",
+                    "example": 54.46
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "usd"
+                },
+                "price_date": {
+                    "description": "дата самых свежих использованных цен",
+                    "type": "string"
+                },
+                "since": {
+                    "type": "string"
+                },
+                "since_total": {
+                    "description": "стоимость тех же карт по ценам на дату since",
+                    "type": "number",
+                    "example": 1180.1
+                },
+                "top": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CardValue"
+                    }
+                },
+                "total": {
+                    "type": "number",
+                    "example": 1234.56
+                },
+                "unpriced": {
+                    "description": "копии без цены, в total не входят",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "dto.ValuePoint": {
+            "description": "Стоимость коллекции или всех коллекций пользователя за день во всех валютах",
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "eur": {
+                    "type": "number",
+                    "example": 1100.2
+                },
+                "tix": {
+                    "type": "number",
+                    "example": 310.5
+                },
+                "usd": {
+                    "type": "number",
+                    "example": 1234.56
                 }
             }
         }
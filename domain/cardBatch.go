@@ -0,0 +1,62 @@
+package domain
+
+// MaxCardOperations limits the number of operations in one card batch.
+const MaxCardOperations = 500
+
+type CardOperationType string
+
+const (
+	CardOperationAdd      CardOperationType = "add"
+	CardOperationSetCount CardOperationType = "set_count"
+	CardOperationDelete   CardOperationType = "delete"
+)
+
+func (t CardOperationType) IsValid() bool {
+	switch t {
+	case CardOperationAdd, CardOperationSetCount, CardOperationDelete:
+		return true
+	}
+	return false
+}
+
+// BatchMode tells what happens to a batch with failed operations.
+type BatchMode string
+
+const (
+	// BatchAtomic applies all operations or none of them.
+	BatchAtomic BatchMode = "atomic"
+	// BatchBestEffort applies operations which succeed and skips failed ones.
+	BatchBestEffort BatchMode = "best_effort"
+)
+
+func (m BatchMode) IsValid() bool {
+	return m == BatchAtomic || m == BatchBestEffort
+}
+
+// CardOperation is an operation of a card batch. Add operations describe
+// the card variant and copies to add, set count and delete operations
+// address the entry by Card.ID and set count uses Card.Count.
+type CardOperation struct {
+	Type CardOperationType
+	Card Card
+}
+
+// CardBatch is a list of card operations applied to a collection in one transaction.
+type CardBatch struct {
+	Mode       BatchMode
+	Operations []CardOperation
+}
+
+// CardOperationResult is the entry after an operation or the error of the operation.
+// Deleted entries have zero count.
+type CardOperationResult struct {
+	Entry *Card
+	Err   *ResponseErr
+}
+
+// CardBatchResult has a result for every operation of the batch in the same order.
+// Applied is false when an atomic batch is rejected because of failed operations.
+type CardBatchResult struct {
+	Applied bool
+	Results []CardOperationResult
+}
@@ -0,0 +1,22 @@
+package domain
+
+// CardImport is the result of importing a decklist or a CSV file into a collection.
+// Cards of a dry run are resolved but not stored, so they have no entry ID.
+type CardImport struct {
+	DryRun     bool
+	Cards      []ImportedCard
+	Unresolved []UnresolvedLine
+}
+
+// ImportedCard is a decklist line or a CSV row resolved to a card.
+type ImportedCard struct {
+	Line int
+	Card Card
+}
+
+// UnresolvedLine is a decklist line or a CSV row which is not imported.
+type UnresolvedLine struct {
+	Line   int
+	Text   string
+	Reason string
+}
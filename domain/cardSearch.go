@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/ShenokZlob/collector-service/pkg/cardquery"
+)
+
+const (
+	DefaultCardSearchLimit = 50
+	MaxCardSearchLimit     = 200
+)
+
+// CardSearchMatch says how a card search found its hits.
+type CardSearchMatch string
+
+const (
+	MatchScryfallID CardSearchMatch = "scryfall_id" // the query is a Scryfall ID
+	MatchText       CardSearchMatch = "text"        // words of the query are in card names
+	MatchFuzzy      CardSearchMatch = "fuzzy"       // names close to the query, for typos and word prefixes
+	MatchQuery      CardSearchMatch = "query"       // entries matching a query with keywords, like c:r t:goblin
+)
+
+// CardSearchQuery is a card search: the text of the query and the text parsed
+// with the card query language.
+type CardSearchQuery struct {
+	Text   string
+	Filter cardquery.Expr
+	Limit  int
+}
+
+// CardSearchHit is a card entry found by a search with the collection it's in.
+type CardSearchHit struct {
+	CollectionID   string
+	CollectionName string
+	Card           Card
+}
+
+// CardSearchResult is the card entries of a user's collections matching a search.
+type CardSearchResult struct {
+	Query string
+	Match CardSearchMatch
+	Hits  []CardSearchHit
+}
+
+// FuzzyMatchNames returns at most limit names close to the query, the closest first.
+// A name is close when one of its words starts with a word of the query or is a few
+// typos away from it; every word of the query has to match a word of the name.
+func FuzzyMatchNames(query string, names []string, limit int) []string {
+	queryWords := nameWords(query)
+	if len(queryWords) == 0 {
+		return []string{}
+	}
+
+	type scored struct {
+		name  string
+		score int
+	}
+	var matches []scored
+	for _, name := range names {
+		words := nameWords(name)
+		total := 0
+		for _, queryWord := range queryWords {
+			best := -1
+			for _, word := range words {
+				if distance := wordDistance(queryWord, word); distance >= 0 && (best < 0 || distance < best) {
+					best = distance
+				}
+			}
+			if best < 0 {
+				total = -1
+				break
+			}
+			total += best
+		}
+		if total >= 0 {
+			matches = append(matches, scored{name: name, score: total})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score < matches[j].score
+		}
+		return matches[i].name < matches[j].name
+	})
+
+	out := make([]string, 0, min(limit, len(matches)))
+	for _, match := range matches {
+		if len(out) == limit {
+			break
+		}
+		out = append(out, match.name)
+	}
+	return out
+}
+
+// nameWords splits a card name into lower case words without punctuation
+func nameWords(name string) []string {
+	return strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// wordDistance is 0 when word starts with the query word, the number of typos between
+// them when there are few enough for the query word's length, and -1 otherwise.
+func wordDistance(queryWord, word string) int {
+	if strings.HasPrefix(word, queryWord) {
+		return 0
+	}
+
+	query := []rune(queryWord)
+	maxTypos := len(query) / 4
+	if maxTypos == 0 {
+		return -1
+	}
+
+	// Compare with the beginning of the word, so typos in a prefix count too
+	target := []rune(word)
+	if len(target) > len(query)+maxTypos {
+		target = target[:len(query)+maxTypos]
+	}
+	distance := prefixEditDistance(query, target)
+	if distance > maxTypos {
+		return -1
+	}
+	return distance
+}
+
+// prefixEditDistance is the smallest Levenshtein distance between a and a prefix of b
+func prefixEditDistance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+
+	best := prev[0]
+	for _, d := range prev {
+		best = min(best, d)
+	}
+	return best
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuzzyMatchNames(t *testing.T) {
+	names := []string{
+		"Sheoldred, the Apocalypse",
+		"Sheoldred, Whispering One",
+		"Lightning Bolt",
+		"Lightning Helix",
+		"Shock",
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"word prefix", "sheol", []string{"Sheoldred, Whispering One", "Sheoldred, the Apocalypse"}},
+		{"typo", "sheoldrd apocalypse", []string{"Sheoldred, the Apocalypse"}},
+		{"transposed letters", "lihgtning bolt", []string{"Lightning Bolt"}},
+		{"exact words rank first", "lightning hel", []string{"Lightning Helix"}},
+		{"short words need a prefix", "shx", []string{}},
+		{"punctuation is ignored", "sheoldred,", []string{"Sheoldred, Whispering One", "Sheoldred, the Apocalypse"}},
+		{"no words", "  ", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, FuzzyMatchNames(tt.query, names, 10))
+		})
+	}
+}
+
+func TestFuzzyMatchNamesRanksByTypos(t *testing.T) {
+	names := []string{"Lightning Bolt", "Lightning Blast", "Lighting Bolt"}
+
+	assert.Equal(t, []string{"Lighting Bolt", "Lightning Bolt"}, FuzzyMatchNames("lighting bolt", names, 2))
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/ShenokZlob/collector-service/pkg/cardquery"
+)
+
+const (
+	DefaultCardsLimit = 50
+	MaxCardsLimit     = 500
+)
+
+// CardSortField is a card entry field the cards listing can be sorted by.
+type CardSortField string
+
+const (
+	SortByName    CardSortField = "name"
+	SortByCount   CardSortField = "count"
+	SortByAddedAt CardSortField = "added_at"
+)
+
+func (f CardSortField) IsValid() bool {
+	switch f {
+	case SortByName, SortByCount, SortByAddedAt:
+		return true
+	}
+	return false
+}
+
+// CardsQuery filters, sorts and paginates card entries of a collection.
+// Zero values of filters mean no filtering.
+type CardsQuery struct {
+	Name       string // substring of the card name, case insensitive
+	AddedSince time.Time
+	MinCount   int
+	Filter     cardquery.Expr // parsed query language filter, nil matches every card
+
+	SortBy CardSortField
+	Desc   bool
+
+	Offset int
+	Limit  int
+}
+
+// CardsPage is a page of card entries with the number of entries matching the query.
+type CardsPage struct {
+	Cards  []Card
+	Total  int
+	Offset int
+	Limit  int
+}
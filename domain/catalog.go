@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+// CatalogCard is a card printing from the card catalog.
+type CatalogCard struct {
+	ScryfallID      string
+	OracleID        string
+	Name            string
+	Lang            string
+	SetCode         string
+	SetName         string
+	CollectorNumber string
+	Rarity          string
+	TypeLine        string
+	ManaCost        string
+	CMC             float64
+	Colors          []string
+	ColorIdentity   []string
+	ImageURI        string
+	Legalities      map[string]string // format name to legal, not_legal, restricted or banned
+}
+
+const (
+	DefaultCatalogLimit = 20
+	MaxCatalogLimit     = 100
+)
+
+// CatalogImport is the progress of importing a bulk data file into the catalog.
+// A file is identified by its path, size and modification time, so an import of
+// a changed file starts over.
+type CatalogImport struct {
+	Source    string
+	Size      int64
+	ModTime   time.Time
+	Processed int // cards stored, an interrupted import resumes after them
+	BytesRead int64
+	Done      bool
+	StartedAt time.Time
+	UpdatedAt time.Time
+}
+
+// SameFile reports whether the import is of the file with the size and modification time.
+func (ci *CatalogImport) SameFile(size int64, modTime time.Time) bool {
+	return ci.Size == size && ci.ModTime.Equal(modTime)
+}
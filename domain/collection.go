@@ -1,22 +1,236 @@
 package domain
 
 import (
+	"strings"
 	"time"
 )
 
 type Collection struct {
-	ID        string    `json:"id"`
-	UserID    string    `json:"user_id"`
-	Name      string    `json:"name"`
-	Cards     []Card    `json:"cards,omitempty"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        string         `json:"id"`
+	UserID    string         `json:"user_id"`
+	Name      string         `json:"name"`
+	Kind      CollectionKind `json:"kind"`
+	Cards     []Card         `json:"cards,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt time.Time      `json:"deleted_at,omitzero"` // zero unless the collection is in the trash
+	Version   int64          `json:"version"`             // incremented by every change of the collection or its cards
 }
 
+// CollectionKind says what a collection is used for. Binders hold the cards a user
+// owns, decks and wishlists hold cards the user wants, trade lists hold cards
+// the user is ready to give away.
+type CollectionKind string
+
+const (
+	KindBinder   CollectionKind = "binder"
+	KindDeck     CollectionKind = "deck"
+	KindWishlist CollectionKind = "wishlist"
+	KindTrade    CollectionKind = "trade"
+)
+
+func (k CollectionKind) IsValid() bool {
+	switch k {
+	case KindBinder, KindDeck, KindWishlist, KindTrade:
+		return true
+	}
+	return false
+}
+
+// Card is an entry of a collection. An entry is identified by its ID and,
+// equivalently, by the (ScryfallID, Zone, Finish, Condition, Language) tuple.
 type Card struct {
+	ID         string    `json:"id"`
 	ScryfallID string    `json:"scryfall_id"`
 	Name       string    `json:"name"`
 	CardUrl    string    `json:"card_url"`
 	Count      int       `json:"count"`
+	Zone       Zone      `json:"zone"`
+	Finish     Finish    `json:"finish"`
+	Condition  Condition `json:"condition"`
+	Language   string    `json:"language"`
+	SetCode    string    `json:"set_code"`
+	Rarity     string    `json:"rarity"`
+	TypeLine   string    `json:"type_line"`
 	AddedAt    time.Time `json:"added_at"`
 }
+
+// SetVariantDefaults fills empty variant fields: a mainboard, non-foil,
+// near mint english card.
+func (c *Card) SetVariantDefaults() {
+	if c.Zone == "" {
+		c.Zone = ZoneMain
+	}
+	if c.Finish == "" {
+		c.Finish = FinishNonfoil
+	}
+	if c.Condition == "" {
+		c.Condition = ConditionNearMint
+	}
+	if c.Language == "" {
+		c.Language = DefaultLanguage
+	}
+}
+
+// SameVariant reports whether two entries have the same identity tuple.
+func (c Card) SameVariant(other Card) bool {
+	return c.VariantKey() == other.VariantKey()
+}
+
+// VariantKey joins the identity tuple of the entry, entries of the same variant have the same key.
+func (c Card) VariantKey() string {
+	return strings.Join([]string{c.ScryfallID, string(c.Zone), string(c.Finish), string(c.Condition), c.Language}, "|")
+}
+
+// Zone is a part of a deck the card entry belongs to.
+type Zone string
+
+const (
+	ZoneMain      Zone = "main"
+	ZoneSide      Zone = "side"
+	ZoneMaybe     Zone = "maybe"
+	ZoneCommander Zone = "commander"
+)
+
+func (z Zone) IsValid() bool {
+	switch z {
+	case ZoneMain, ZoneSide, ZoneMaybe, ZoneCommander:
+		return true
+	}
+	return false
+}
+
+type Finish string
+
+const (
+	FinishNonfoil Finish = "nonfoil"
+	FinishFoil    Finish = "foil"
+	FinishEtched  Finish = "etched"
+)
+
+func (f Finish) IsValid() bool {
+	switch f {
+	case FinishNonfoil, FinishFoil, FinishEtched:
+		return true
+	}
+	return false
+}
+
+type Condition string
+
+const (
+	ConditionNearMint         Condition = "NM"
+	ConditionLightlyPlayed    Condition = "LP"
+	ConditionModeratelyPlayed Condition = "MP"
+	ConditionHeavilyPlayed    Condition = "HP"
+	ConditionDamaged          Condition = "DMG"
+)
+
+func (c Condition) IsValid() bool {
+	switch c {
+	case ConditionNearMint, ConditionLightlyPlayed, ConditionModeratelyPlayed, ConditionHeavilyPlayed, ConditionDamaged:
+		return true
+	}
+	return false
+}
+
+const DefaultLanguage = "en"
+
+// languages are the language codes used by Scryfall
+var languages = map[string]bool{
+	"en": true, "es": true, "fr": true, "de": true, "it": true, "pt": true,
+	"ja": true, "ko": true, "ru": true, "zhs": true, "zht": true, "he": true,
+	"la": true, "grc": true, "ar": true, "sa": true, "ph": true,
+}
+
+func IsValidLanguage(lang string) bool {
+	return languages[lang]
+}
+
+// CardMove describes moving copies of a card entry to another zone
+// inside the same collection.
+type CardMove struct {
+	EntryID string
+	To      Zone
+	Count   int
+}
+
+// CardAdjustment describes adding (positive Delta) or removing (negative Delta)
+// copies of a card entry. The entry goes to the trash when no copies are left.
+type CardAdjustment struct {
+	EntryID string
+	Delta   int
+}
+
+// CardTransfer describes moving copies of card entries from one collection
+// of the user to another one.
+type CardTransfer struct {
+	UserID           string
+	FromCollectionID string
+	ToCollectionID   string
+	Items            []CardTransferItem
+}
+
+type CardTransferItem struct {
+	EntryID string
+	Count   int
+}
+
+// MergeStrategy says how to combine counts of a card variant present in both
+// collections being merged.
+type MergeStrategy string
+
+const (
+	MergeSum        MergeStrategy = "sum"
+	MergeMax        MergeStrategy = "max"
+	MergeKeepTarget MergeStrategy = "keep_target"
+)
+
+func (s MergeStrategy) IsValid() bool {
+	switch s {
+	case MergeSum, MergeMax, MergeKeepTarget:
+		return true
+	}
+	return false
+}
+
+// Merge returns target cards with source cards merged into them. Source variants
+// missing in target are appended without an entry ID.
+func (s MergeStrategy) Merge(target, source []Card) []Card {
+	merged := make([]Card, len(target), len(target)+len(source))
+	copy(merged, target)
+
+	byVariant := make(map[string]int, len(merged))
+	for i := range merged {
+		byVariant[merged[i].VariantKey()] = i
+	}
+
+	for _, card := range source {
+		key := card.VariantKey()
+		i, found := byVariant[key]
+		if !found {
+			card.ID = ""
+			byVariant[key] = len(merged)
+			merged = append(merged, card)
+			continue
+		}
+
+		switch s {
+		case MergeSum:
+			merged[i].Count += card.Count
+		case MergeMax:
+			merged[i].Count = max(merged[i].Count, card.Count)
+		}
+	}
+
+	return merged
+}
+
+// CollectionMerge describes merging one user's collection into another.
+type CollectionMerge struct {
+	UserID       string
+	SourceID     string
+	TargetID     string
+	Strategy     MergeStrategy
+	DeleteSource bool
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeStrategyMerge(t *testing.T) {
+	bolt := Card{ID: "t1", ScryfallID: "bolt", Count: 2, Zone: ZoneMain, Finish: FinishNonfoil, Condition: ConditionNearMint, Language: "en"}
+	foilBolt := Card{ID: "s1", ScryfallID: "bolt", Count: 1, Zone: ZoneMain, Finish: FinishFoil, Condition: ConditionNearMint, Language: "en"}
+	sourceBolt := bolt
+	sourceBolt.ID = "s2"
+	sourceBolt.Count = 3
+
+	tests := []struct {
+		strategy  MergeStrategy
+		boltCount int
+	}{
+		{MergeSum, 5},
+		{MergeMax, 3},
+		{MergeKeepTarget, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			merged := tt.strategy.Merge([]Card{bolt}, []Card{sourceBolt, foilBolt})
+
+			assert.Len(t, merged, 2)
+			assert.Equal(t, "t1", merged[0].ID)
+			assert.Equal(t, tt.boltCount, merged[0].Count)
+			// A new variant is appended without the source entry ID
+			assert.Equal(t, FinishFoil, merged[1].Finish)
+			assert.Equal(t, 1, merged[1].Count)
+			assert.Empty(t, merged[1].ID)
+		})
+	}
+}
+
+func TestMergeStrategyMergeKeepsTargetUntouched(t *testing.T) {
+	target := []Card{{ID: "t1", ScryfallID: "bolt", Count: 2}}
+	source := []Card{{ID: "s1", ScryfallID: "bolt", Count: 3}}
+
+	MergeSum.Merge(target, source)
+
+	assert.Equal(t, 2, target[0].Count)
+}
@@ -0,0 +1,325 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DeckFormat is a constructed format a deck collection can be validated against.
+// Its values are the format names Scryfall uses for legalities.
+type DeckFormat string
+
+const (
+	FormatStandard  DeckFormat = "standard"
+	FormatPioneer   DeckFormat = "pioneer"
+	FormatModern    DeckFormat = "modern"
+	FormatLegacy    DeckFormat = "legacy"
+	FormatVintage   DeckFormat = "vintage"
+	FormatPauper    DeckFormat = "pauper"
+	FormatCommander DeckFormat = "commander"
+)
+
+func (f DeckFormat) IsValid() bool {
+	_, ok := formatRules[f]
+	return ok
+}
+
+// formatRule is the deck construction rules of a format
+type formatRule struct {
+	minDeck   int
+	maxDeck   int // 0 is no maximum
+	maxSide   int
+	copies    int
+	commander bool
+}
+
+var formatRules = map[DeckFormat]formatRule{
+	FormatStandard:  {minDeck: 60, maxSide: 15, copies: 4},
+	FormatPioneer:   {minDeck: 60, maxSide: 15, copies: 4},
+	FormatModern:    {minDeck: 60, maxSide: 15, copies: 4},
+	FormatLegacy:    {minDeck: 60, maxSide: 15, copies: 4},
+	FormatVintage:   {minDeck: 60, maxSide: 15, copies: 4},
+	FormatPauper:    {minDeck: 60, maxSide: 15, copies: 4},
+	FormatCommander: {minDeck: 100, maxDeck: 100, maxSide: 0, copies: 1, commander: true},
+}
+
+// anyNumberCards are cards whose rules text lifts the copy limit; the value is
+// the limit they set instead, 0 is any number. Basic lands are always exempt.
+var anyNumberCards = map[string]int{
+	"Cid, Timeless Artificer": 0,
+	"Dragon's Approach":       0,
+	"Hare Apparent":           0,
+	"Persistent Petitioners":  0,
+	"Rat Colony":              0,
+	"Relentless Rats":         0,
+	"Shadowborn Apostle":      0,
+	"Slime Against Humanity":  0,
+	"Tempest Hawk":            0,
+	"Templar Knight":          0,
+	"Nazgûl":                  9,
+	"Seven Dwarves":           7,
+}
+
+// Legality values of Scryfall
+const (
+	LegalityLegal      = "legal"
+	LegalityNotLegal   = "not_legal"
+	LegalityRestricted = "restricted"
+	LegalityBanned     = "banned"
+)
+
+// ViolationKind says what rule a deck breaks.
+type ViolationKind string
+
+const (
+	ViolationBanned            ViolationKind = "banned"
+	ViolationNotLegal          ViolationKind = "not_legal"
+	ViolationRestricted        ViolationKind = "restricted"
+	ViolationTooManyCopies     ViolationKind = "too_many_copies"
+	ViolationDeckTooSmall      ViolationKind = "deck_too_small"
+	ViolationDeckTooLarge      ViolationKind = "deck_too_large"
+	ViolationSideboardTooLarge ViolationKind = "sideboard_too_large"
+	ViolationMissingCommander  ViolationKind = "missing_commander"
+	ViolationTooManyCommanders ViolationKind = "too_many_commanders"
+	ViolationColorIdentity     ViolationKind = "color_identity"
+	ViolationUnknownCard       ViolationKind = "unknown_card"
+)
+
+// DeckViolation is a broken deck rule. Card is empty for rules about the whole deck.
+type DeckViolation struct {
+	Kind    ViolationKind
+	Card    string
+	Count   int      // copies of the card, or cards in the deck or sideboard
+	Limit   int      // allowed number for count rules
+	Colors  []string // colors outside the commander's color identity
+	Message string
+}
+
+// DeckValidation is the result of validating a collection as a deck of a format.
+// Maybeboard cards are not part of the deck and aren't validated.
+type DeckValidation struct {
+	Format     DeckFormat
+	Legal      bool
+	DeckSize   int // main deck cards, commanders included
+	Sideboard  int
+	Violations []DeckViolation
+}
+
+// deckCard is all copies of a card in the deck and sideboard, by name
+type deckCard struct {
+	name      string
+	count     int
+	commander int
+	printing  *CatalogCard // nil when none of the printings is in the catalog
+	typeLine  string
+}
+
+// Validate checks the cards against the format's rules. Legality, color identity
+// and type lines come from catalog printings keyed by Scryfall ID; cards missing
+// from the catalog are reported as unknown instead of legality violations.
+func (f DeckFormat) Validate(cards []Card, printings map[string]CatalogCard) DeckValidation {
+	rule := formatRules[f]
+	result := DeckValidation{Format: f, Violations: []DeckViolation{}}
+
+	byName := make(map[string]*deckCard)
+	for _, card := range cards {
+		switch card.Zone {
+		case ZoneMaybe:
+			continue
+		case ZoneSide:
+			result.Sideboard += card.Count
+		default:
+			result.DeckSize += card.Count
+		}
+
+		dc, ok := byName[card.Name]
+		if !ok {
+			dc = &deckCard{name: card.Name, typeLine: card.TypeLine}
+			byName[card.Name] = dc
+		}
+		dc.count += card.Count
+		if card.Zone == ZoneCommander {
+			dc.commander += card.Count
+		}
+		if printing, ok := printings[card.ScryfallID]; ok && dc.printing == nil {
+			dc.printing = &printing
+			dc.typeLine = printing.TypeLine
+		}
+	}
+
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	add := func(v DeckViolation) {
+		result.Violations = append(result.Violations, v)
+	}
+
+	// Deck size
+	if result.DeckSize < rule.minDeck {
+		add(DeckViolation{
+			Kind:    ViolationDeckTooSmall,
+			Count:   result.DeckSize,
+			Limit:   rule.minDeck,
+			Message: fmt.Sprintf("Deck has %d cards, %s needs at least %d", result.DeckSize, f, rule.minDeck),
+		})
+	}
+	if rule.maxDeck > 0 && result.DeckSize > rule.maxDeck {
+		add(DeckViolation{
+			Kind:    ViolationDeckTooLarge,
+			Count:   result.DeckSize,
+			Limit:   rule.maxDeck,
+			Message: fmt.Sprintf("Deck has %d cards, %s allows at most %d", result.DeckSize, f, rule.maxDeck),
+		})
+	}
+	if result.Sideboard > rule.maxSide {
+		add(DeckViolation{
+			Kind:    ViolationSideboardTooLarge,
+			Count:   result.Sideboard,
+			Limit:   rule.maxSide,
+			Message: fmt.Sprintf("Sideboard has %d cards, %s allows at most %d", result.Sideboard, f, rule.maxSide),
+		})
+	}
+
+	// Commanders and their color identity
+	var identity map[string]bool
+	if rule.commander {
+		identity = f.checkCommanders(names, byName, add)
+	}
+
+	for _, name := range names {
+		dc := byName[name]
+		if dc.printing == nil {
+			add(DeckViolation{
+				Kind:    ViolationUnknownCard,
+				Card:    name,
+				Count:   dc.count,
+				Message: fmt.Sprintf("%s is not in the card catalog, its legality is unknown", name),
+			})
+		} else {
+			switch dc.printing.Legalities[string(f)] {
+			case LegalityLegal:
+			case LegalityBanned:
+				add(DeckViolation{
+					Kind:    ViolationBanned,
+					Card:    name,
+					Count:   dc.count,
+					Message: fmt.Sprintf("%s is banned in %s", name, f),
+				})
+				continue
+			case LegalityRestricted:
+				if dc.count > 1 {
+					add(DeckViolation{
+						Kind:    ViolationRestricted,
+						Card:    name,
+						Count:   dc.count,
+						Limit:   1,
+						Message: fmt.Sprintf("%s is restricted in %s, a deck can have 1 copy", name, f),
+					})
+				}
+				continue
+			default:
+				add(DeckViolation{
+					Kind:    ViolationNotLegal,
+					Card:    name,
+					Count:   dc.count,
+					Message: fmt.Sprintf("%s is not legal in %s", name, f),
+				})
+				continue
+			}
+		}
+
+		if limit, ok := copyLimit(rule, dc); ok && dc.count > limit {
+			add(DeckViolation{
+				Kind:    ViolationTooManyCopies,
+				Card:    name,
+				Count:   dc.count,
+				Limit:   limit,
+				Message: fmt.Sprintf("Deck has %d copies of %s, %s allows %d", dc.count, name, f, limit),
+			})
+		}
+
+		if identity != nil && dc.commander == 0 && dc.printing != nil {
+			if outside := colorsOutside(dc.printing.ColorIdentity, identity); len(outside) > 0 {
+				add(DeckViolation{
+					Kind:    ViolationColorIdentity,
+					Card:    name,
+					Count:   dc.count,
+					Colors:  outside,
+					Message: fmt.Sprintf("%s has colors %s outside the commander's color identity", name, strings.Join(outside, "")),
+				})
+			}
+		}
+	}
+
+	result.Legal = len(result.Violations) == 0
+	return result
+}
+
+// checkCommanders reports a missing commander or too many of them and returns
+// the color identity of the commanders, nil when it's unknown.
+func (f DeckFormat) checkCommanders(names []string, byName map[string]*deckCard, add func(DeckViolation)) map[string]bool {
+	commanders := 0
+	identity := make(map[string]bool)
+	known := true
+	for _, name := range names {
+		dc := byName[name]
+		if dc.commander == 0 {
+			continue
+		}
+		commanders += dc.commander
+		if dc.printing == nil {
+			known = false
+			continue
+		}
+		for _, color := range dc.printing.ColorIdentity {
+			identity[color] = true
+		}
+	}
+
+	switch {
+	case commanders == 0:
+		add(DeckViolation{
+			Kind:    ViolationMissingCommander,
+			Message: "Deck has no commander, put it in the commander zone",
+		})
+		return nil
+	case commanders > 2:
+		// Two commanders are partners or a commander with a background
+		add(DeckViolation{
+			Kind:    ViolationTooManyCommanders,
+			Count:   commanders,
+			Limit:   2,
+			Message: fmt.Sprintf("Deck has %d commanders, at most 2 are allowed", commanders),
+		})
+	}
+
+	if !known {
+		return nil
+	}
+	return identity
+}
+
+// copyLimit returns the number of copies of the card the format allows, false when it's unlimited.
+func copyLimit(rule formatRule, dc *deckCard) (int, bool) {
+	if strings.HasPrefix(dc.typeLine, "Basic ") {
+		return 0, false
+	}
+	if limit, ok := anyNumberCards[dc.name]; ok {
+		return limit, limit > 0
+	}
+	return rule.copies, true
+}
+
+func colorsOutside(colors []string, identity map[string]bool) []string {
+	var outside []string
+	for _, color := range colors {
+		if !identity[color] {
+			outside = append(outside, color)
+		}
+	}
+	return outside
+}
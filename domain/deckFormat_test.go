@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCatalog has printings keyed by card name, used as their Scryfall IDs
+var testCatalog = map[string]CatalogCard{
+	"Lightning Bolt":   {Name: "Lightning Bolt", TypeLine: "Instant", ColorIdentity: []string{"R"}, Legalities: map[string]string{"modern": "legal", "vintage": "legal", "commander": "legal", "standard": "not_legal"}},
+	"Mountain":         {Name: "Mountain", TypeLine: "Basic Land — Mountain", ColorIdentity: []string{"R"}, Legalities: map[string]string{"modern": "legal", "commander": "legal"}},
+	"Island":           {Name: "Island", TypeLine: "Basic Land — Island", ColorIdentity: []string{"U"}, Legalities: map[string]string{"commander": "legal"}},
+	"Mental Misstep":   {Name: "Mental Misstep", TypeLine: "Instant", ColorIdentity: []string{"U"}, Legalities: map[string]string{"modern": "banned", "vintage": "restricted"}},
+	"Sol Ring":         {Name: "Sol Ring", TypeLine: "Artifact", Legalities: map[string]string{"vintage": "restricted", "commander": "legal", "modern": "not_legal"}},
+	"Relentless Rats":  {Name: "Relentless Rats", TypeLine: "Creature — Rat", ColorIdentity: []string{"B"}, Legalities: map[string]string{"commander": "legal", "modern": "legal"}},
+	"Krenko, Mob Boss": {Name: "Krenko, Mob Boss", TypeLine: "Legendary Creature — Goblin Warrior", ColorIdentity: []string{"R"}, Legalities: map[string]string{"commander": "legal"}},
+}
+
+func deckEntry(name string, count int, zone Zone) Card {
+	return Card{ScryfallID: name, Name: name, Count: count, Zone: zone}
+}
+
+func violationKinds(validation DeckValidation) []ViolationKind {
+	kinds := make([]ViolationKind, len(validation.Violations))
+	for i, v := range validation.Violations {
+		kinds[i] = v.Kind
+	}
+	return kinds
+}
+
+func TestValidateLegalModernDeck(t *testing.T) {
+	cards := []Card{
+		deckEntry("Lightning Bolt", 4, ZoneMain),
+		deckEntry("Mountain", 56, ZoneMain),
+		deckEntry("Relentless Rats", 15, ZoneSide),
+		deckEntry("Mental Misstep", 4, ZoneMaybe),
+	}
+
+	validation := FormatModern.Validate(cards, testCatalog)
+
+	assert.True(t, validation.Legal)
+	assert.Empty(t, validation.Violations)
+	assert.Equal(t, 60, validation.DeckSize)
+	assert.Equal(t, 15, validation.Sideboard)
+}
+
+func TestValidateModernViolations(t *testing.T) {
+	cards := []Card{
+		deckEntry("Lightning Bolt", 3, ZoneMain),
+		{ScryfallID: "other-bolt", Name: "Lightning Bolt", Count: 2, Zone: ZoneSide},
+		deckEntry("Mental Misstep", 1, ZoneMain),
+		deckEntry("Sol Ring", 1, ZoneMain),
+		deckEntry("Unknown Card", 1, ZoneMain),
+		deckEntry("Mountain", 40, ZoneMain),
+		deckEntry("Relentless Rats", 14, ZoneSide),
+	}
+
+	validation := FormatModern.Validate(cards, testCatalog)
+
+	assert.False(t, validation.Legal)
+	assert.Equal(t, []ViolationKind{
+		ViolationDeckTooSmall,
+		ViolationSideboardTooLarge,
+		ViolationTooManyCopies,
+		ViolationBanned,
+		ViolationNotLegal,
+		ViolationUnknownCard,
+	}, violationKinds(validation))
+
+	small := validation.Violations[0]
+	assert.Equal(t, 46, small.Count)
+	assert.Equal(t, 60, small.Limit)
+
+	copies := validation.Violations[2]
+	assert.Equal(t, "Lightning Bolt", copies.Card)
+	assert.Equal(t, 5, copies.Count, "copies are counted across printings, deck and sideboard")
+	assert.Equal(t, 4, copies.Limit)
+	assert.Equal(t, "Deck has 5 copies of Lightning Bolt, modern allows 4", copies.Message)
+}
+
+func TestValidateVintageRestricted(t *testing.T) {
+	cards := []Card{
+		deckEntry("Sol Ring", 1, ZoneMain),
+		deckEntry("Mental Misstep", 2, ZoneMain),
+		deckEntry("Lightning Bolt", 57, ZoneMain),
+	}
+
+	validation := FormatVintage.Validate(cards, testCatalog)
+
+	require.Len(t, validation.Violations, 2)
+	assert.Equal(t, ViolationTooManyCopies, validation.Violations[0].Kind)
+	assert.Equal(t, "Lightning Bolt", validation.Violations[0].Card)
+	assert.Equal(t, ViolationRestricted, validation.Violations[1].Kind)
+	assert.Equal(t, "Mental Misstep", validation.Violations[1].Card)
+	assert.Equal(t, 1, validation.Violations[1].Limit)
+}
+
+func TestValidateCommander(t *testing.T) {
+	cards := []Card{
+		deckEntry("Krenko, Mob Boss", 1, ZoneCommander),
+		deckEntry("Sol Ring", 1, ZoneMain),
+		deckEntry("Lightning Bolt", 2, ZoneMain),
+		deckEntry("Relentless Rats", 20, ZoneMain),
+		deckEntry("Island", 1, ZoneMain),
+		deckEntry("Mountain", 75, ZoneMain),
+		deckEntry("Mental Misstep", 1, ZoneSide),
+	}
+
+	validation := FormatCommander.Validate(cards, testCatalog)
+
+	assert.Equal(t, 100, validation.DeckSize)
+	assert.Equal(t, []ViolationKind{
+		ViolationSideboardTooLarge,
+		ViolationColorIdentity,
+		ViolationTooManyCopies,
+		ViolationNotLegal,
+		ViolationColorIdentity,
+	}, violationKinds(validation))
+
+	island := validation.Violations[1]
+	assert.Equal(t, "Island", island.Card)
+	assert.Equal(t, []string{"U"}, island.Colors)
+	assert.Equal(t, "Lightning Bolt", validation.Violations[2].Card)
+	assert.Equal(t, 1, validation.Violations[2].Limit)
+	assert.Equal(t, "Relentless Rats", validation.Violations[4].Card, "any number of copies is allowed, the color still isn't")
+}
+
+func TestValidateCommanderDeckSize(t *testing.T) {
+	cards := []Card{
+		deckEntry("Krenko, Mob Boss", 1, ZoneCommander),
+		deckEntry("Mountain", 100, ZoneMain),
+	}
+
+	validation := FormatCommander.Validate(cards, testCatalog)
+
+	require.Len(t, validation.Violations, 1)
+	assert.Equal(t, ViolationDeckTooLarge, validation.Violations[0].Kind)
+	assert.Equal(t, 101, validation.Violations[0].Count)
+}
+
+func TestValidateCommanderWithoutCommander(t *testing.T) {
+	cards := []Card{
+		deckEntry("Island", 1, ZoneMain),
+		deckEntry("Mountain", 99, ZoneMain),
+	}
+
+	validation := FormatCommander.Validate(cards, testCatalog)
+
+	assert.Equal(t, []ViolationKind{ViolationMissingCommander}, violationKinds(validation),
+		"color identity isn't checked without a commander")
+}
+
+func TestDeckFormatIsValid(t *testing.T) {
+	assert.True(t, FormatPauper.IsValid())
+	assert.False(t, DeckFormat("brawl").IsValid())
+	assert.False(t, DeckFormat("").IsValid())
+}
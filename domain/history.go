@@ -0,0 +1,70 @@
+package domain
+
+import "time"
+
+const (
+	DefaultChangesLimit = 50
+	MaxChangesLimit     = 200
+	// MaxUndoChanges is how many of the last changes can be undone at once.
+	MaxUndoChanges = 50
+)
+
+// Actor is who changes a collection and in which request, it's recorded in
+// the collection history with the change.
+type Actor struct {
+	UserID    string
+	RequestID string
+	Import    bool // cards are added by an import
+	// IfMatch are the versions the request expects the collection to have, the
+	// change fails when the collection has none of them. Nil skips the check.
+	IfMatch []int64
+}
+
+type ChangeKind string
+
+const (
+	ChangeAdd      ChangeKind = "add"
+	ChangeImport   ChangeKind = "import"
+	ChangeSetCount ChangeKind = "set_count"
+	ChangeDelete   ChangeKind = "delete"
+	ChangeAdjust   ChangeKind = "adjust"
+	ChangeMove     ChangeKind = "move"
+	ChangeTransfer ChangeKind = "transfer"
+	ChangeBatch    ChangeKind = "batch"
+	ChangeMerge    ChangeKind = "merge"
+	ChangeRestore  ChangeKind = "restore"
+	ChangeSnapshot ChangeKind = "snapshot_restore"
+	ChangeUndo     ChangeKind = "undo"
+)
+
+// CollectionChange is a record of the collection history. Records are never
+// changed, a change is undone by a newer undo change which reverts it.
+// A transfer is one change in the history of both collections.
+type CollectionChange struct {
+	ID            string
+	CollectionIDs []string
+	Kind          ChangeKind
+	UserID        string
+	RequestID     string
+	At            time.Time
+	Entries       []ChangeEntry
+	Reverts       []string // changes reverted by an undo change
+	UndoneBy      string   // the undo change which reverted it, empty if it isn't undone
+}
+
+// ChangeEntry is how a change changed the count of a card entry. Before is zero
+// for added entries, After is zero for deleted ones.
+type ChangeEntry struct {
+	CollectionID string
+	Card         Card // the entry with the count after the change
+	Before       int
+	After        int
+}
+
+// ChangesPage is a page of the collection history, most recent changes first.
+type ChangesPage struct {
+	Changes []CollectionChange
+	Total   int
+	Offset  int
+	Limit   int
+}
@@ -0,0 +1,97 @@
+package domain
+
+import "sort"
+
+// CardHoldings is a card of a target collection by oracle identity: the target's
+// entries of any of its printings and the copies of any printing the source
+// collections hold, by collection ID.
+type CardHoldings struct {
+	OracleKey string // Scryfall oracle ID, or the lower case name for cards missing from the catalog
+	Entries   []Card
+	Owned     map[string]int
+}
+
+// OwnedCopies is the number of copies of a card a source collection holds.
+type OwnedCopies struct {
+	CollectionID   string
+	CollectionName string
+	Count          int
+}
+
+// MissingEntry says how many copies of a target entry are owned, where they
+// are and how many are still missing.
+type MissingEntry struct {
+	Card    Card
+	Needed  int
+	Owned   []OwnedCopies
+	Missing int
+}
+
+// MissingReport is the cards a target collection needs compared to the copies
+// the source collections hold. Maybeboard cards are not needed.
+type MissingReport struct {
+	CollectionID string
+	Sources      []UserCollectionRef
+	Needed       int
+	Owned        int
+	Missing      int
+	Entries      []MissingEntry // in card name order
+}
+
+// zoneNeedOrder is the order entries of the same card get owned copies in
+var zoneNeedOrder = map[Zone]int{ZoneCommander: 0, ZoneMain: 1, ZoneSide: 2}
+
+// FindMissing allocates the copies the sources hold to the entries of the target
+// that are the same card. Copies are taken from the sources in their order, so a
+// copy is never counted for two entries. Entries of a card get copies commander
+// zone first, then mainboard and sideboard.
+func FindMissing(holdings []CardHoldings, sources []UserCollectionRef) MissingReport {
+	report := MissingReport{Sources: sources, Entries: []MissingEntry{}}
+
+	for _, holding := range holdings {
+		left := make([]int, len(sources))
+		for i, source := range sources {
+			left[i] = holding.Owned[source.ID]
+		}
+
+		entries := append([]Card(nil), holding.Entries...)
+		sort.SliceStable(entries, func(i, j int) bool {
+			return zoneNeedOrder[entries[i].Zone] < zoneNeedOrder[entries[j].Zone]
+		})
+
+		for _, card := range entries {
+			if card.Zone == ZoneMaybe {
+				continue
+			}
+			entry := MissingEntry{Card: card, Needed: card.Count, Owned: []OwnedCopies{}}
+			need := card.Count
+			for i, source := range sources {
+				if need == 0 {
+					break
+				}
+				take := min(need, left[i])
+				if take == 0 {
+					continue
+				}
+				left[i] -= take
+				need -= take
+				entry.Owned = append(entry.Owned, OwnedCopies{
+					CollectionID:   source.ID,
+					CollectionName: source.Name,
+					Count:          take,
+				})
+			}
+			entry.Missing = need
+
+			report.Needed += entry.Needed
+			report.Owned += entry.Needed - entry.Missing
+			report.Missing += entry.Missing
+			report.Entries = append(report.Entries, entry)
+		}
+	}
+
+	sort.SliceStable(report.Entries, func(i, j int) bool {
+		return report.Entries[i].Card.Name < report.Entries[j].Card.Name
+	})
+	return report
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindMissing(t *testing.T) {
+	sources := []UserCollectionRef{{ID: "binder-a", Name: "Binder A"}, {ID: "binder-b", Name: "Binder B"}}
+	holdings := []CardHoldings{
+		{
+			OracleKey: "bolt",
+			Entries: []Card{
+				{Name: "Lightning Bolt", ScryfallID: "bolt-2xm", Count: 2, Zone: ZoneSide},
+				{Name: "Lightning Bolt", ScryfallID: "bolt-m10", Count: 3, Zone: ZoneMain},
+			},
+			Owned: map[string]int{"binder-a": 2, "binder-b": 2},
+		},
+		{
+			OracleKey: "arena",
+			Entries:   []Card{{Name: "Arena of Glory", Count: 1, Zone: ZoneMain}, {Name: "Arena of Glory", Count: 4, Zone: ZoneMaybe}},
+			Owned:     map[string]int{"binder-b": 3, "not-a-source": 1},
+		},
+		{
+			OracleKey: "rats",
+			Entries:   []Card{{Name: "Relentless Rats", Count: 20, Zone: ZoneMain}},
+		},
+	}
+
+	report := FindMissing(holdings, sources)
+
+	assert.Equal(t, 26, report.Needed)
+	assert.Equal(t, 5, report.Owned)
+	assert.Equal(t, 21, report.Missing)
+	require.Len(t, report.Entries, 4)
+
+	arena := report.Entries[0]
+	assert.Equal(t, "Arena of Glory", arena.Card.Name)
+	assert.Equal(t, 0, arena.Missing)
+	assert.Equal(t, []OwnedCopies{{CollectionID: "binder-b", CollectionName: "Binder B", Count: 1}}, arena.Owned)
+
+	main := report.Entries[1]
+	assert.Equal(t, ZoneMain, main.Card.Zone, "mainboard gets owned copies before the sideboard")
+	assert.Equal(t, 0, main.Missing)
+	assert.Equal(t, []OwnedCopies{
+		{CollectionID: "binder-a", CollectionName: "Binder A", Count: 2},
+		{CollectionID: "binder-b", CollectionName: "Binder B", Count: 1},
+	}, main.Owned)
+
+	side := report.Entries[2]
+	assert.Equal(t, ZoneSide, side.Card.Zone)
+	assert.Equal(t, 2, side.Needed)
+	assert.Equal(t, 1, side.Missing, "copies owned by the mainboard entry aren't counted again")
+	assert.Equal(t, []OwnedCopies{{CollectionID: "binder-b", CollectionName: "Binder B", Count: 1}}, side.Owned)
+
+	rats := report.Entries[3]
+	assert.Equal(t, 20, rats.Missing)
+	assert.Empty(t, rats.Owned)
+}
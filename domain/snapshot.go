@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// MaxSnapshotLabelLength is the longest snapshot label in characters.
+const MaxSnapshotLabelLength = 100
+
+// Snapshot is a labeled copy of the collection's card entries at a moment, like
+// a decklist frozen before a tournament. Snapshots are never changed.
+type Snapshot struct {
+	ID           string
+	CollectionID string
+	Label        string
+	CreatedAt    time.Time
+	Entries      int
+	Copies       int
+	Cards        []Card // not loaded when snapshots are listed
+}
+
+// CardCountChange is a card variant with different counts in two versions of a collection.
+type CardCountChange struct {
+	Card   Card // the card of the newer version
+	Before int
+	After  int
+}
+
+// SnapshotDiff is how the cards of a collection changed from a snapshot to another
+// snapshot or to the live collection. Cards are in name order.
+type SnapshotDiff struct {
+	FromSnapshotID string
+	ToSnapshotID   string // empty for the live collection
+	Added          []Card
+	Removed        []Card
+	Changed        []CardCountChange
+}
+
+// DiffCards compares two versions of a collection's cards by variant. Cards of
+// variants only in from are removed, only in to added.
+func DiffCards(from, to []Card) SnapshotDiff {
+	diff := SnapshotDiff{
+		Added:   []Card{},
+		Removed: []Card{},
+		Changed: []CardCountChange{},
+	}
+
+	before := make(map[string]Card, len(from))
+	for _, card := range from {
+		before[card.VariantKey()] = card
+	}
+	for _, card := range to {
+		key := card.VariantKey()
+		old, ok := before[key]
+		delete(before, key)
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, card)
+		case old.Count != card.Count:
+			diff.Changed = append(diff.Changed, CardCountChange{Card: card, Before: old.Count, After: card.Count})
+		}
+	}
+	for _, card := range from {
+		if _, ok := before[card.VariantKey()]; ok {
+			diff.Removed = append(diff.Removed, card)
+		}
+	}
+
+	sortCards(diff.Added)
+	sortCards(diff.Removed)
+	sort.SliceStable(diff.Changed, func(i, j int) bool {
+		return cardLess(diff.Changed[i].Card, diff.Changed[j].Card)
+	})
+	return diff
+}
+
+func sortCards(cards []Card) {
+	sort.SliceStable(cards, func(i, j int) bool {
+		return cardLess(cards[i], cards[j])
+	})
+}
+
+// cardLess orders cards by name, variants of a card by their identity tuple
+func cardLess(a, b Card) bool {
+	if a.Name != b.Name {
+		return a.Name < b.Name
+	}
+	return a.VariantKey() < b.VariantKey()
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffCards(t *testing.T) {
+	bolt := Card{ScryfallID: "bolt", Name: "Lightning Bolt", Count: 4, Zone: ZoneMain, Finish: FinishNonfoil}
+	guide := Card{ScryfallID: "guide", Name: "Goblin Guide", Count: 4, Zone: ZoneMain, Finish: FinishNonfoil}
+	pyro := Card{ScryfallID: "pyro", Name: "Pyroblast", Count: 2, Zone: ZoneSide, Finish: FinishNonfoil}
+	from := []Card{bolt, guide, pyro}
+
+	fewerGuides := guide
+	fewerGuides.Count = 2
+	sidePyro := pyro
+	sidePyro.Zone = ZoneMain
+	eidolon := Card{ScryfallID: "eidolon", Name: "Eidolon of the Great Revel", Count: 4, Zone: ZoneMain, Finish: FinishNonfoil}
+	to := []Card{eidolon, bolt, fewerGuides, sidePyro}
+
+	diff := DiffCards(from, to)
+
+	assert.Equal(t, []Card{eidolon, sidePyro}, diff.Added, "a card moved to another zone is another variant")
+	assert.Equal(t, []Card{pyro}, diff.Removed)
+	assert.Equal(t, []CardCountChange{{Card: fewerGuides, Before: 4, After: 2}}, diff.Changed)
+}
+
+func TestDiffCardsSameVersion(t *testing.T) {
+	cards := []Card{{ScryfallID: "bolt", Name: "Lightning Bolt", Count: 4}}
+
+	diff := DiffCards(cards, cards)
+
+	assert.Empty(t, diff.Added)
+	assert.Empty(t, diff.Removed)
+	assert.Empty(t, diff.Changed)
+}
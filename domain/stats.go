@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// MaxCurveManaValue is the last bar of the mana curve, it counts cards of this mana value and more.
+const MaxCurveManaValue = 7
+
+// CardTypes are the card types the type breakdown counts, cards with none of them are OtherCardType.
+var CardTypes = []string{"Creature", "Planeswalker", "Battle", "Instant", "Sorcery", "Artifact", "Enchantment", "Land"}
+
+const OtherCardType = "Other"
+
+// ColorlessKey is the color breakdown key of colorless cards.
+const ColorlessKey = "C"
+
+// statsColorOrder and statsRarityOrder are the orders of the color and rarity breakdowns
+var (
+	statsColorOrder  = []string{"W", "U", "B", "R", "G", ColorlessKey}
+	statsRarityOrder = []string{"common", "uncommon", "rare", "mythic", "special", "bonus"}
+)
+
+// CollectionStats describes the cards of a collection or of all collections of a user.
+// Counts are copies. Color, type and mana value breakdowns only count cards found in
+// the card catalog, set and rarity breakdowns skip entries without a set or rarity.
+type CollectionStats struct {
+	Cards       int
+	Unique      int // distinct cards by oracle identity, all printings of a card count once
+	Printings   int // distinct printings
+	Foil        int // foil and etched copies
+	FoilRatio   float64
+	Multicolor  int // copies of cards with two colors or more
+	Uncataloged int // copies of printings missing from the catalog
+
+	ByColor       []StatsBucket // W, U, B, R, G and C; a multicolored card counts for each of its colors
+	ByRarity      []StatsBucket
+	BySet         []StatsBucket // most cards first
+	ByType        []StatsBucket // an artifact creature counts for both types; most cards first
+	ByManaValue   []StatsBucket // keys are whole mana values
+	ManaCurve     []CurveBar    // decks only, nonland mainboard and commander cards
+	AddedPerMonth []MonthCount  // oldest first
+
+	UpdatedAt time.Time // of the collection, or the latest update of the user's collections
+}
+
+// StatsBucket is the cards of a breakdown with the same key.
+type StatsBucket struct {
+	Key    string
+	Cards  int
+	Unique int
+}
+
+// CurveBar is the copies with a mana value, MaxCurveManaValue counts that much and more.
+type CurveBar struct {
+	ManaValue int
+	Cards     int
+}
+
+// MonthCount is the copies added in a month, formatted as 2006-01.
+type MonthCount struct {
+	Month string
+	Cards int
+}
+
+// SortStats puts the breakdowns in their order: colors in WUBRG order, rarities
+// from common up, mana values and months ascending and the rest most cards first.
+func SortStats(stats *CollectionStats) {
+	sortBucketsByOrder(stats.ByColor, statsColorOrder)
+	sortBucketsByOrder(stats.ByRarity, statsRarityOrder)
+	sortBucketsByCards(stats.BySet)
+	sortBucketsByCards(stats.ByType)
+	sort.SliceStable(stats.ByManaValue, func(i, j int) bool {
+		a, _ := strconv.ParseFloat(stats.ByManaValue[i].Key, 64)
+		b, _ := strconv.ParseFloat(stats.ByManaValue[j].Key, 64)
+		return a < b
+	})
+	sort.SliceStable(stats.ManaCurve, func(i, j int) bool {
+		return stats.ManaCurve[i].ManaValue < stats.ManaCurve[j].ManaValue
+	})
+	sort.SliceStable(stats.AddedPerMonth, func(i, j int) bool {
+		return stats.AddedPerMonth[i].Month < stats.AddedPerMonth[j].Month
+	})
+}
+
+// sortBucketsByOrder puts keys of the order first, in its order, and others after them by cards
+func sortBucketsByOrder(buckets []StatsBucket, order []string) {
+	rank := func(key string) int {
+		if i := slices.Index(order, key); i >= 0 {
+			return i
+		}
+		return len(order)
+	}
+	sort.SliceStable(buckets, func(i, j int) bool {
+		a, b := rank(buckets[i].Key), rank(buckets[j].Key)
+		if a != b {
+			return a < b
+		}
+		return bucketLess(buckets[i], buckets[j])
+	})
+}
+
+func sortBucketsByCards(buckets []StatsBucket) {
+	sort.SliceStable(buckets, func(i, j int) bool { return bucketLess(buckets[i], buckets[j]) })
+}
+
+func bucketLess(a, b StatsBucket) bool {
+	if a.Cards != b.Cards {
+		return a.Cards > b.Cards
+	}
+	return a.Key < b.Key
+}
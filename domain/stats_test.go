@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortStats(t *testing.T) {
+	stats := &CollectionStats{
+		ByColor:       []StatsBucket{{Key: "C", Cards: 9}, {Key: "G", Cards: 1}, {Key: "W", Cards: 2}, {Key: "R", Cards: 5}},
+		ByRarity:      []StatsBucket{{Key: "mythic", Cards: 1}, {Key: "weird", Cards: 7}, {Key: "common", Cards: 30}, {Key: "rare", Cards: 4}},
+		BySet:         []StatsBucket{{Key: "zen", Cards: 4}, {Key: "m10", Cards: 4}, {Key: "mh3", Cards: 12}},
+		ByManaValue:   []StatsBucket{{Key: "10", Cards: 1}, {Key: "2", Cards: 8}, {Key: "0", Cards: 20}},
+		ManaCurve:     []CurveBar{{ManaValue: 7, Cards: 1}, {ManaValue: 1, Cards: 12}},
+		AddedPerMonth: []MonthCount{{Month: "2024-11", Cards: 3}, {Month: "2023-02", Cards: 1}},
+	}
+
+	SortStats(stats)
+
+	keys := func(buckets []StatsBucket) []string {
+		out := []string{}
+		for _, b := range buckets {
+			out = append(out, b.Key)
+		}
+		return out
+	}
+	assert.Equal(t, []string{"W", "R", "G", "C"}, keys(stats.ByColor))
+	assert.Equal(t, []string{"common", "rare", "mythic", "weird"}, keys(stats.ByRarity), "unknown rarities go last")
+	assert.Equal(t, []string{"mh3", "m10", "zen"}, keys(stats.BySet), "ties are in key order")
+	assert.Equal(t, []string{"0", "2", "10"}, keys(stats.ByManaValue), "mana values sort as numbers")
+	assert.Equal(t, 1, stats.ManaCurve[0].ManaValue)
+	assert.Equal(t, "2023-02", stats.AddedPerMonth[0].Month)
+}
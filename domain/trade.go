@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Group is a playgroup. Members are trade partners of each other; a group of
+// two is a pair of friends.
+type Group struct {
+	ID         string
+	Name       string
+	OwnerID    string
+	InviteCode string // joins the group
+	Members    []string
+	CreatedAt  time.Time
+}
+
+const (
+	DefaultTradeMatchesLimit = 20
+	MaxTradeMatchesLimit     = 100
+)
+
+// TradeCurrency is the currency trade matches are valued in.
+const TradeCurrency = CurrencyUSD
+
+// TradeCard is the copies of a card a user wants, on wishlists, or offers,
+// on trade lists, summed by oracle identity so any printing is the same card.
+type TradeCard struct {
+	UserID     string
+	Kind       CollectionKind // KindWishlist or KindTrade
+	OracleKey  string
+	Name       string
+	ScryfallID string // a printing of the card, the one valued for offered cards
+	Finish     Finish
+	Count      int
+}
+
+// TradeItem is copies of a card that change hands in a trade.
+type TradeItem struct {
+	Name       string
+	ScryfallID string
+	Finish     Finish
+	Count      int
+	UnitPrice  float64
+	Value      float64
+}
+
+// TradeMatch is a possible trade between a user and a partner from a shared group.
+type TradeMatch struct {
+	UserID      string
+	PartnerID   string
+	PartnerName string
+	Gets        []TradeItem // partner's trade list cards on the user's wishlists
+	Gives       []TradeItem // user's trade list cards on the partner's wishlists
+	GetsValue   float64
+	GivesValue  float64
+	Balance     float64 // GetsValue minus GivesValue
+	Score       float64 // how even the trade is, from 0 for one-sided trades to 1
+	UpdatedAt   time.Time
+}
+
+// TradeMatchesPage is a page of the user's trade matches, most even trades first.
+type TradeMatchesPage struct {
+	Matches []TradeMatch
+	Total   int
+	Offset  int
+	Limit   int
+}
+
+// MatchTrades finds the cards on the wishlists of wanting that offering has on
+// its trade lists. The count of an item is the smaller of wanted and offered copies.
+// Items are in name order.
+func MatchTrades(wanting, offering []TradeCard) []TradeItem {
+	wanted := make(map[string]int)
+	for _, card := range wanting {
+		if card.Kind == KindWishlist {
+			wanted[card.OracleKey] += card.Count
+		}
+	}
+
+	items := []TradeItem{}
+	for _, card := range offering {
+		if card.Kind != KindTrade || wanted[card.OracleKey] == 0 {
+			continue
+		}
+		count := min(card.Count, wanted[card.OracleKey])
+		wanted[card.OracleKey] -= count
+		items = append(items, TradeItem{
+			Name:       card.Name,
+			ScryfallID: card.ScryfallID,
+			Finish:     card.Finish,
+			Count:      count,
+		})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+	return items
+}
+
+// NewTradeMatch values the items at the prices keyed by Scryfall ID and ranks the
+// trade. Items without a price are worth nothing.
+func NewTradeMatch(userID, partnerID string, gets, gives []TradeItem, prices map[string]CardPrice) TradeMatch {
+	match := TradeMatch{UserID: userID, PartnerID: partnerID, Gets: gets, Gives: gives}
+	match.GetsValue = priceTradeItems(match.Gets, prices)
+	match.GivesValue = priceTradeItems(match.Gives, prices)
+	match.Balance = RoundPrice(match.GetsValue - match.GivesValue)
+
+	high := math.Max(match.GetsValue, match.GivesValue)
+	switch {
+	case len(gets) == 0 || len(gives) == 0:
+		match.Score = 0
+	case high == 0:
+		// Neither side is priced, it's even as far as we know
+		match.Score = 1
+	default:
+		match.Score = math.Round(math.Min(match.GetsValue, match.GivesValue)/high*1000) / 1000
+	}
+	return match
+}
+
+func priceTradeItems(items []TradeItem, prices map[string]CardPrice) float64 {
+	var total float64
+	for i := range items {
+		items[i].UnitPrice = prices[items[i].ScryfallID].Price(TradeCurrency, items[i].Finish)
+		items[i].Value = RoundPrice(items[i].UnitPrice * float64(items[i].Count))
+		total += items[i].Value
+	}
+	return RoundPrice(total)
+}
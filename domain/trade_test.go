@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchTrades(t *testing.T) {
+	alice := []TradeCard{
+		{UserID: "alice", Kind: KindWishlist, OracleKey: "bolt", Name: "Lightning Bolt", Count: 4},
+		{UserID: "alice", Kind: KindWishlist, OracleKey: "guide", Name: "Goblin Guide", Count: 1},
+		{UserID: "alice", Kind: KindTrade, OracleKey: "rats", Name: "Relentless Rats", Count: 10},
+	}
+	bob := []TradeCard{
+		{UserID: "bob", Kind: KindTrade, OracleKey: "bolt", Name: "Lightning Bolt", ScryfallID: "bolt-2xm", Finish: FinishFoil, Count: 2},
+		{UserID: "bob", Kind: KindTrade, OracleKey: "guide", Name: "Goblin Guide", ScryfallID: "guide-zen", Count: 3},
+		{UserID: "bob", Kind: KindTrade, OracleKey: "shock", Name: "Shock", ScryfallID: "shock", Count: 3},
+		{UserID: "bob", Kind: KindWishlist, OracleKey: "bolt", Name: "Lightning Bolt", Count: 4},
+	}
+
+	gets := MatchTrades(alice, bob)
+	assert.Equal(t, []TradeItem{
+		{Name: "Goblin Guide", ScryfallID: "guide-zen", Count: 1},
+		{Name: "Lightning Bolt", ScryfallID: "bolt-2xm", Finish: FinishFoil, Count: 2},
+	}, gets)
+
+	assert.Empty(t, MatchTrades(bob, alice), "alice doesn't trade bolts away, she only wants them")
+}
+
+func TestNewTradeMatch(t *testing.T) {
+	prices := map[string]CardPrice{
+		"bolt":  {USD: 2, USDFoil: 5},
+		"guide": {USD: 3.5},
+	}
+	gets := []TradeItem{{Name: "Lightning Bolt", ScryfallID: "bolt", Finish: FinishFoil, Count: 2}}
+	gives := []TradeItem{
+		{Name: "Goblin Guide", ScryfallID: "guide", Finish: FinishNonfoil, Count: 2},
+		{Name: "Unpriced", ScryfallID: "unknown", Finish: FinishNonfoil, Count: 1},
+	}
+
+	match := NewTradeMatch("alice", "bob", gets, gives, prices)
+
+	assert.Equal(t, 10.0, match.GetsValue)
+	assert.Equal(t, 7.0, match.GivesValue)
+	assert.Equal(t, 3.0, match.Balance)
+	assert.Equal(t, 0.7, match.Score)
+	require.Len(t, match.Gets, 1)
+	assert.Equal(t, 5.0, match.Gets[0].UnitPrice)
+	assert.Equal(t, 0.0, match.Gives[1].Value)
+
+	oneSided := NewTradeMatch("alice", "bob", gets, []TradeItem{}, prices)
+	assert.Equal(t, 0.0, oneSided.Score)
+	assert.Equal(t, 10.0, oneSided.Balance)
+}
@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// DefaultTrashRetention is how long deleted collections and card entries stay in
+// the trash before they are purged for good.
+const DefaultTrashRetention = 30 * 24 * time.Hour
+
+// Trash is what the user deleted and can still restore. Card entries of deleted
+// collections are restored with their collection and aren't listed on their own.
+type Trash struct {
+	Collections []Collection // most recently deleted first
+	Cards       []TrashedCard
+}
+
+// TrashedCard is a card entry deleted from one of the user's collections.
+type TrashedCard struct {
+	CollectionID   string
+	CollectionName string
+	Card           Card
+	DeletedAt      time.Time
+}
+
+// TrashPurge counts what a purge removed for good.
+type TrashPurge struct {
+	Collections int
+	Cards       int
+}
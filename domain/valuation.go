@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// Currency is a currency Scryfall has prices in. Tix are MTGO event tickets.
+type Currency string
+
+const (
+	CurrencyUSD Currency = "usd"
+	CurrencyEUR Currency = "eur"
+	CurrencyTix Currency = "tix"
+)
+
+func (c Currency) IsValid() bool {
+	switch c {
+	case CurrencyUSD, CurrencyEUR, CurrencyTix:
+		return true
+	}
+	return false
+}
+
+// CardPrice is a snapshot of the prices of a printing on a day.
+// A zero price means Scryfall had no price.
+type CardPrice struct {
+	ScryfallID string
+	Date       time.Time // midnight UTC, see PriceDate
+	USD        float64
+	USDFoil    float64
+	USDEtched  float64
+	EUR        float64
+	EURFoil    float64
+	Tix        float64
+}
+
+// Price is the price of a copy with the finish. A foil or etched copy without
+// a price of its own is valued at the nonfoil price; Tix don't depend on finish.
+func (p CardPrice) Price(currency Currency, finish Finish) float64 {
+	var nonfoil, finishPrice float64
+	switch currency {
+	case CurrencyUSD:
+		nonfoil = p.USD
+		switch finish {
+		case FinishFoil:
+			finishPrice = p.USDFoil
+		case FinishEtched:
+			finishPrice = p.USDEtched
+		}
+	case CurrencyEUR:
+		nonfoil = p.EUR
+		if finish == FinishFoil {
+			finishPrice = p.EURFoil
+		}
+	case CurrencyTix:
+		return p.Tix
+	}
+
+	if finish == FinishNonfoil || finishPrice == 0 {
+		return nonfoil
+	}
+	return finishPrice
+}
+
+// PriceDate is the day of t prices are stored under.
+func PriceDate(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+const (
+	DefaultValuationTop = 10
+	MaxValuationTop     = 100
+)
+
+// ValuationQuery says how to value cards. A zero Since skips the comparison.
+type ValuationQuery struct {
+	Currency Currency
+	Top      int
+	Since    time.Time
+}
+
+// Valuation is the value of a collection or all collections of a user at the latest prices.
+type Valuation struct {
+	Currency  Currency
+	PriceDate time.Time // the newest price snapshot used, zero without prices
+	Total     float64
+	Cards     int // copies, priced or not
+	Unpriced  int // copies without a price, they add nothing to Total
+	Top       []CardValue
+
+	// The same cards at prices on Since, so Change is the price change of what
+	// is owned now, not of what was owned then.
+	Since      time.Time
+	SinceTotal float64
+	Change     float64
+}
+
+// CardValue is the value of all copies of a card entry.
+type CardValue struct {
+	CollectionID string
+	Card         Card
+	UnitPrice    float64
+	Value        float64
+}
+
+// ValuePoint is the value of a collection or a user's collections on a day in every currency.
+type ValuePoint struct {
+	Date time.Time
+	USD  float64
+	EUR  float64
+	Tix  float64
+}
+
+// RoundPrice rounds a sum of prices to cents.
+func RoundPrice(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCardPricePrice(t *testing.T) {
+	price := CardPrice{USD: 2, USDFoil: 5, EUR: 1.5, Tix: 0.1}
+
+	tests := []struct {
+		name     string
+		currency Currency
+		finish   Finish
+		want     float64
+	}{
+		{"usd nonfoil", CurrencyUSD, FinishNonfoil, 2},
+		{"usd foil", CurrencyUSD, FinishFoil, 5},
+		{"usd etched without price falls back to nonfoil", CurrencyUSD, FinishEtched, 2},
+		{"eur foil without price falls back to nonfoil", CurrencyEUR, FinishFoil, 1.5},
+		{"tix ignore finish", CurrencyTix, FinishFoil, 0.1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, price.Price(tt.currency, tt.finish))
+		})
+	}
+}
+
+func TestPriceDate(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	date := PriceDate(time.Date(2026, 10, 19, 1, 30, 0, 0, moscow))
+
+	assert.Equal(t, time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC), date)
+}
@@ -1,9 +1,14 @@
 package controllers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/ShenokZlob/collector-service/pkg/cardquery"
 	dto "github.com/ShenokZlob/collector-service/pkg/contracts"
 	"go.uber.org/zap"
 
@@ -19,10 +24,14 @@ type CardsController struct {
 }
 
 type CardsServicer interface {
-	ListCardsInCollection(collectionId string) ([]domain.Card, *domain.ResponseErr)
-	AddCardToCollection(collectionId string, card *domain.Card) *domain.ResponseErr
-	SetCardCountInCollection(collectionId string, card *domain.Card) *domain.ResponseErr
-	DeleteCardFromCollection(collectionId string, card *domain.Card) *domain.ResponseErr
+	ListCardsInCollection(collectionId string, query *domain.CardsQuery) (*domain.CardsPage, *domain.ResponseErr)
+	AddCardToCollection(actor domain.Actor, collectionId string, card *domain.Card) (*domain.Card, *domain.ResponseErr)
+	SetCardCountInCollection(actor domain.Actor, collectionId string, card *domain.Card) *domain.ResponseErr
+	DeleteCardFromCollection(actor domain.Actor, collectionId string, card *domain.Card) *domain.ResponseErr
+	AdjustCardCount(actor domain.Actor, collectionId string, adjust *domain.CardAdjustment) (*domain.Card, *domain.ResponseErr)
+	MoveCardBetweenZones(actor domain.Actor, collectionId string, move *domain.CardMove) *domain.ResponseErr
+	TransferCards(actor domain.Actor, transfer *domain.CardTransfer) *domain.ResponseErr
+	ApplyCardOperations(actor domain.Actor, collectionId string, batch *domain.CardBatch) (*domain.CardBatchResult, *domain.ResponseErr)
 }
 
 func NewCardsController(log *zap.Logger, cardsService CardsServicer) *CardsController {
@@ -33,58 +42,160 @@ func NewCardsController(log *zap.Logger, cardsService CardsServicer) *CardsContr
 }
 
 // @Summary     Get user's cards in collection
-// @Description Получить список карт из коллекции юзера
+// @Description Получить страницу карт из коллекции юзера с фильтрами и сортировкой
 // @Tags        Cards
 // @Security    BearerAuth
 // @Produce     json
-// @Success     200 {array} dto.Card
-// @Failure     401 {object} dto.ErrorResponse
+// @Param       limit       query int    false "Размер страницы (по умолчанию 50, максимум 500)"
+// @Param       offset      query int    false "Смещение от начала списка"
+// @Param       sort        query string false "Поле сортировки: name, count или added_at"
+// @Param       order       query string false "Порядок сортировки: asc или desc"
+// @Param       name        query string false "Подстрока имени карты"
+// @Param       q           query string false "Запрос с ключевыми словами, например c:r t:creature cmc<=3 set:mh3 is:foil"
+// @Param       added_since query string false "Только карты, добавленные не раньше даты (RFC3339 или YYYY-MM-DD)"
+// @Param       min_count   query int    false "Минимальное количество копий"
+// @Success     200 {object} dto.CardsPage
+// @Failure     400,401,404 {object} dto.ErrorResponse
 // @Router      /collections/{id}/cards [get]
 func (cc CardsController) ListCardsInCollection(ctx *gin.Context) {
 	collectionId := ctx.Param("id")
 
-	cardsList, respErr := cc.cardsService.ListCardsInCollection(collectionId)
+	query, err := parseCardsQuery(ctx)
+	if err != nil {
+		cc.log.Warn("ListCardsInCollection: invalid query", zap.Error(err))
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, queryErrorResponse(err))
+		return
+	}
+
+	page, respErr := cc.cardsService.ListCardsInCollection(collectionId, query)
 	if respErr != nil {
 		ctx.AbortWithStatusJSON(respErr.Status, respErr)
 		return
 	}
 
-	var out []dto.Card
-	for _, card := range cardsList {
-		out = append(out, dto.Card{
-			ScryfallID: card.ScryfallID,
-			Name:       card.Name,
-			CardUrl:    card.CardUrl,
-			Count:      card.Count,
-		})
+	out := dto.CardsPage{
+		Cards: make([]dto.Card, 0, len(page.Cards)),
+		Pagination: dto.Pagination{
+			Total:  page.Total,
+			Offset: page.Offset,
+			Limit:  page.Limit,
+		},
+	}
+	for _, card := range page.Cards {
+		out.Cards = append(out.Cards, cardToDTO(card))
 	}
 
 	ctx.JSON(200, out)
 }
 
+// parseCardsQuery reads filtering, sorting and pagination parameters of the cards listing.
+func parseCardsQuery(ctx *gin.Context) (*domain.CardsQuery, error) {
+	query := &domain.CardsQuery{
+		Name:   ctx.Query("name"),
+		SortBy: domain.CardSortField(ctx.Query("sort")),
+	}
+
+	switch ctx.Query("order") {
+	case "", "asc":
+	case "desc":
+		query.Desc = true
+	default:
+		return nil, fmt.Errorf("invalid order %q, want asc or desc", ctx.Query("order"))
+	}
+
+	ints := []struct {
+		name string
+		dst  *int
+	}{
+		{"limit", &query.Limit},
+		{"offset", &query.Offset},
+		{"min_count", &query.MinCount},
+	}
+	for _, p := range ints {
+		raw := ctx.Query(p.name)
+		if raw == "" {
+			continue
+		}
+		v, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %q is not a number", p.name, raw)
+		}
+		*p.dst = v
+	}
+
+	filter, err := cardquery.Parse(ctx.Query("q"))
+	if err != nil {
+		return nil, err
+	}
+	query.Filter = filter
+
+	if raw := ctx.Query("added_since"); raw != "" {
+		since, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			since, err = time.Parse(time.DateOnly, raw)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid added_since: %q is not a date", raw)
+		}
+		query.AddedSince = since
+	}
+
+	return query, nil
+}
+
+// queryErrorResponse is the response to an invalid query, syntax errors of the
+// card query language point at the position of the error.
+func queryErrorResponse(err error) dto.ErrorResponse {
+	resp := dto.ErrorResponse{Message: err.Error()}
+	var syntaxErr *cardquery.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		resp.Message = "invalid q: " + err.Error()
+		resp.Position = syntaxErr.Pos
+	}
+	return resp
+}
+
 // @Summary     Add a card to user's collection
 // @Description Добавить карту в коллекцию юзера
 // @Tags        Cards
 // @Security    BearerAuth
+// @Accept      json
 // @Produce     json
-// @Success     201 "No Content"
-// @Failure     401 {object} dto.ErrorResponse
+// @Param       input body dto.AddCardRequest true "Карта и её вариант"
+// @Param       If-Match header string false "ETag версии коллекции, которую ожидает запрос"
+// @Success     201 {object} dto.Card
+// @Failure     400,401,404,412 {object} dto.ErrorResponse
 // @Router      /collections/{id}/cards [post]
 func (cc CardsController) AddCardToCollection(ctx *gin.Context) {
+	actor, respErr := getActorFromCtx(ctx)
+	if respErr != nil {
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
 	collectionId := ctx.Param("id")
-	var card domain.Card
-	if err := ctx.ShouldBindJSON(&card); err != nil {
-		ctx.AbortWithError(http.StatusBadRequest, err)
+	var req dto.AddCardRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, dto.ErrorResponse{Message: err.Error()})
 		return
 	}
 
-	respErr := cc.cardsService.AddCardToCollection(collectionId, &card)
+	card := &domain.Card{
+		ScryfallID: req.ScryfallID,
+		Name:       req.Name,
+		CardUrl:    req.CardUrl,
+		Count:      req.Count,
+		Zone:       domain.Zone(req.Zone),
+		Finish:     domain.Finish(req.Finish),
+		Condition:  domain.Condition(req.Condition),
+		Language:   req.Language,
+	}
+	entry, respErr := cc.cardsService.AddCardToCollection(actor, collectionId, card)
 	if respErr != nil {
 		ctx.AbortWithStatusJSON(respErr.Status, respErr)
 		return
 	}
 
-	ctx.Status(http.StatusCreated)
+	ctx.JSON(http.StatusCreated, cardToDTO(*entry))
 }
 
 // @Summary     Set card count in user's collection
@@ -92,12 +203,19 @@ func (cc CardsController) AddCardToCollection(ctx *gin.Context) {
 // @Tags        Cards
 // @Security    BearerAuth
 // @Produce     json
+// @Param       entry_id path string true "Card entry ID"
+// @Param       If-Match header string false "ETag версии коллекции, которую ожидает запрос"
 // @Success     204 "No Content"
-// @Failure     401 {object} dto.ErrorResponse
-// @Router      /collections/{id}/cards/{card_id} [patch]
+// @Failure     401,412 {object} dto.ErrorResponse
+// @Router      /collections/{id}/cards/{entry_id} [patch]
 func (cc CardsController) SetCardCountInCollection(ctx *gin.Context) {
+	actor, respErr := getActorFromCtx(ctx)
+	if respErr != nil {
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
 	collectionId := ctx.Param("id")
-	scryfallId := ctx.Param("card_id")
+	entryId := ctx.Param("entry_id")
 
 	var card domain.Card
 	if err := ctx.ShouldBindJSON(&card); err != nil {
@@ -105,8 +223,8 @@ func (cc CardsController) SetCardCountInCollection(ctx *gin.Context) {
 		return
 	}
 
-	card.ScryfallID = scryfallId
-	respErr := cc.cardsService.SetCardCountInCollection(collectionId, &card)
+	card.ID = entryId
+	respErr = cc.cardsService.SetCardCountInCollection(actor, collectionId, &card)
 	if respErr != nil {
 		ctx.AbortWithStatusJSON(respErr.Status, respErr)
 		return
@@ -116,22 +234,290 @@ func (cc CardsController) SetCardCountInCollection(ctx *gin.Context) {
 }
 
 // @Summary     Delete the card from user's collection
-// @Description Удалить карту из коллекции юзера
+// @Description Переместить запись карты в корзину. Её можно восстановить, пока корзина не очищена по сроку хранения
 // @Tags        Cards
 // @Security    BearerAuth
 // @Produce     json
+// @Param       entry_id path string true "Card entry ID"
+// @Param       If-Match header string false "ETag версии коллекции, которую ожидает запрос"
 // @Success     204 "No Content"
-// @Failure     401 {object} dto.ErrorResponse
-// @Router      /collections/{id}/cards/{card_id} [delete]
+// @Failure     401,412 {object} dto.ErrorResponse
+// @Router      /collections/{id}/cards/{entry_id} [delete]
 func (cc CardsController) DeleteCardFromCollection(ctx *gin.Context) {
+	actor, respErr := getActorFromCtx(ctx)
+	if respErr != nil {
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
 	collectionId := ctx.Param("id")
-	scryfallId := ctx.Param("card_id")
+	entryId := ctx.Param("entry_id")
+
+	respErr = cc.cardsService.DeleteCardFromCollection(actor, collectionId, &domain.Card{ID: entryId})
+	if respErr != nil {
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Summary     Add or remove copies of the card
+// @Description Атомарно добавить (delta > 0) или убрать (delta < 0) копии записи карты. Запись уходит в корзину, когда копий не остаётся
+// @Tags        Cards
+// @Security    BearerAuth
+// @Accept      json
+// @Produce     json
+// @Param       entry_id path string true "Card entry ID"
+// @Param       input body dto.AdjustCardCountRequest true "Изменение количества копий"
+// @Param       If-Match header string false "ETag версии коллекции, которую ожидает запрос"
+// @Success     200 {object} dto.Card "Запись после изменения, count = 0 если запись удалена"
+// @Failure     400,401,404,409,412 {object} dto.ErrorResponse
+// @Router      /collections/{id}/cards/{entry_id}/adjust [post]
+func (cc CardsController) AdjustCardCount(ctx *gin.Context) {
+	actor, respErr := getActorFromCtx(ctx)
+	if respErr != nil {
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+	collectionId := ctx.Param("id")
+	entryId := ctx.Param("entry_id")
+
+	var req dto.AdjustCardCountRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, dto.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	adjust := &domain.CardAdjustment{
+		EntryID: entryId,
+		Delta:   req.Delta,
+	}
+	entry, respErr := cc.cardsService.AdjustCardCount(actor, collectionId, adjust)
+	if respErr != nil {
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, cardToDTO(*entry))
+}
+
+// @Summary     Move the card between deck zones
+// @Description Переместить копии записи карты в другую зону колоды (main, side, maybe, commander)
+// @Tags        Cards
+// @Security    BearerAuth
+// @Accept      json
+// @Produce     json
+// @Param       entry_id path string true "Card entry ID"
+// @Param       input body dto.MoveCardRequest true "Куда и сколько копий"
+// @Param       If-Match header string false "ETag версии коллекции, которую ожидает запрос"
+// @Success     204 "No Content"
+// @Failure     400,401,404,409,412 {object} dto.ErrorResponse
+// @Router      /collections/{id}/cards/{entry_id}/move [post]
+func (cc CardsController) MoveCardBetweenZones(ctx *gin.Context) {
+	actor, respErr := getActorFromCtx(ctx)
+	if respErr != nil {
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+	collectionId := ctx.Param("id")
+	entryId := ctx.Param("entry_id")
+
+	var req dto.MoveCardRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, dto.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	move := &domain.CardMove{
+		EntryID: entryId,
+		To:      domain.Zone(req.ToZone),
+		Count:   req.Count,
+	}
+	respErr = cc.cardsService.MoveCardBetweenZones(actor, collectionId, move)
+	if respErr != nil {
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Summary     Transfer the card to another collection
+// @Description Перенести копии записи карты в другую коллекцию пользователя
+// @Tags        Cards
+// @Security    BearerAuth
+// @Accept      json
+// @Produce     json
+// @Param       id       path   string                  true  "Source collection ID"
+// @Param       entry_id path   string                  true  "Card entry ID"
+// @Param       input    body   dto.TransferCardRequest true  "Коллекция назначения и количество копий"
+// @Param       If-Match header string                  false "ETag версии коллекции, которую ожидает запрос"
+// @Success     204 "No Content"
+// @Failure     400,401,404,409,412 {object} dto.ErrorResponse
+// @Router      /collections/{id}/cards/{entry_id}/transfer [post]
+func (cc CardsController) TransferCard(ctx *gin.Context) {
+	actor, respErr := getActorFromCtx(ctx)
+	if respErr != nil {
+		cc.log.Error("TransferCard: failed to get userID", zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
 
-	respErr := cc.cardsService.DeleteCardFromCollection(collectionId, &domain.Card{ScryfallID: scryfallId})
+	var req dto.TransferCardRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, dto.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	transfer := &domain.CardTransfer{
+		UserID:           actor.UserID,
+		FromCollectionID: ctx.Param("id"),
+		ToCollectionID:   req.ToCollectionID,
+		Items:            []domain.CardTransferItem{{EntryID: ctx.Param("entry_id"), Count: req.Count}},
+	}
+	respErr = cc.cardsService.TransferCards(actor, transfer)
 	if respErr != nil {
+		cc.log.Error("TransferCard: failed to transfer card", zap.String("userID", actor.UserID), zap.Error(respErr))
 		ctx.AbortWithStatusJSON(respErr.Status, respErr)
 		return
 	}
 
 	ctx.Status(http.StatusNoContent)
 }
+
+// @Summary     Transfer many cards to another collection
+// @Description Перенести несколько записей карт в другую коллекцию пользователя одной транзакцией
+// @Tags        Cards
+// @Security    BearerAuth
+// @Accept      json
+// @Produce     json
+// @Param       id       path   string                   true  "Source collection ID"
+// @Param       input    body   dto.TransferCardsRequest true  "Коллекция назначения и карты"
+// @Param       If-Match header string                   false "ETag версии коллекции, которую ожидает запрос"
+// @Success     204 "No Content"
+// @Failure     400,401,404,409,412 {object} dto.ErrorResponse
+// @Router      /collections/{id}/transfer [post]
+func (cc CardsController) TransferCards(ctx *gin.Context) {
+	actor, respErr := getActorFromCtx(ctx)
+	if respErr != nil {
+		cc.log.Error("TransferCards: failed to get userID", zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	var req dto.TransferCardsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, dto.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	transfer := &domain.CardTransfer{
+		UserID:           actor.UserID,
+		FromCollectionID: ctx.Param("id"),
+		ToCollectionID:   req.ToCollectionID,
+	}
+	for _, item := range req.Items {
+		transfer.Items = append(transfer.Items, domain.CardTransferItem{EntryID: item.EntryID, Count: item.Count})
+	}
+	respErr = cc.cardsService.TransferCards(actor, transfer)
+	if respErr != nil {
+		cc.log.Error("TransferCards: failed to transfer cards", zap.String("userID", actor.UserID), zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Summary     Apply a batch of card operations
+// @Description Применить к коллекции пакет операций add, set_count и delete одной транзакцией. Карты операций add проверяются в Scryfall вместе, одним запросом
+// @Tags        Cards
+// @Security    BearerAuth
+// @Accept      json
+// @Produce     json
+// @Param       input body dto.CardBatchRequest true "Операции и режим пакета"
+// @Param       If-Match header string false "ETag версии коллекции, которую ожидает запрос"
+// @Success     200 {object} dto.CardBatchResponse
+// @Failure     400,401,404,412 {object} dto.ErrorResponse
+// @Router      /collections/{id}/cards:batch [post]
+func (cc CardsController) ApplyCardOperations(ctx *gin.Context) {
+	actor, respErr := getActorFromCtx(ctx)
+	if respErr != nil {
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+	collectionId := ctx.Param("id")
+
+	var req dto.CardBatchRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, dto.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	cc.log.Info("ApplyCardOperations: started", zap.String("collectionID", collectionId), zap.Int("operations", len(req.Operations)))
+
+	batch := &domain.CardBatch{
+		Mode:       domain.BatchMode(req.Mode),
+		Operations: make([]domain.CardOperation, len(req.Operations)),
+	}
+	for i, op := range req.Operations {
+		batch.Operations[i] = domain.CardOperation{
+			Type: domain.CardOperationType(op.Op),
+			Card: domain.Card{
+				ID:         op.EntryID,
+				ScryfallID: op.ScryfallID,
+				Name:       op.Name,
+				CardUrl:    op.CardUrl,
+				Count:      op.Count,
+				Zone:       domain.Zone(op.Zone),
+				Finish:     domain.Finish(op.Finish),
+				Condition:  domain.Condition(op.Condition),
+				Language:   op.Language,
+			},
+		}
+	}
+
+	result, respErr := cc.cardsService.ApplyCardOperations(actor, collectionId, batch)
+	if respErr != nil {
+		cc.log.Error("ApplyCardOperations: failed to apply operations", zap.String("collectionID", collectionId), zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	out := dto.CardBatchResponse{
+		Applied: result.Applied,
+		Results: make([]dto.CardOperationResult, len(result.Results)),
+	}
+	for i, res := range result.Results {
+		switch {
+		case res.Err != nil:
+			out.Results[i] = dto.CardOperationResult{Status: res.Err.Status, Error: res.Err.Message}
+		case res.Entry != nil:
+			card := cardToDTO(*res.Entry)
+			out.Results[i] = dto.CardOperationResult{Status: http.StatusOK, Card: &card}
+		default:
+			// Valid operation of a rejected atomic batch
+			out.Results[i] = dto.CardOperationResult{Status: http.StatusFailedDependency, Error: "Batch is not applied"}
+		}
+	}
+
+	cc.log.Info("ApplyCardOperations: success", zap.String("collectionID", collectionId), zap.Bool("applied", result.Applied))
+	ctx.JSON(http.StatusOK, out)
+}
+
+func cardToDTO(card domain.Card) dto.Card {
+	return dto.Card{
+		ID:         card.ID,
+		ScryfallID: card.ScryfallID,
+		Name:       card.Name,
+		CardUrl:    card.CardUrl,
+		Count:      card.Count,
+		Zone:       string(card.Zone),
+		Finish:     string(card.Finish),
+		Condition:  string(card.Condition),
+		Language:   card.Language,
+		SetCode:    card.SetCode,
+		Rarity:     card.Rarity,
+		TypeLine:   card.TypeLine,
+	}
+}
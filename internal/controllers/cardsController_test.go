@@ -0,0 +1,386 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	mocks "github.com/ShenokZlob/collector-service/internal/controllers/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMoveCardBetweenZones(t *testing.T) {
+	// Arrange
+	mockCardsService := new(mocks.MockCardsServicer)
+	ctrl := CardsController{
+		log:          zap.NewNop(),
+		cardsService: mockCardsService,
+	}
+
+	reqBody := `{"to_zone":"side","count":2}`
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", testActor.UserID)
+	c.Request, _ = http.NewRequest("POST", "/collections/64a9b66b2db8b91234a6e8e3/cards/64a9b66b2db8b91234a6e8e4/move", strings.NewReader(reqBody))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{
+		{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"},
+		{Key: "entry_id", Value: "64a9b66b2db8b91234a6e8e4"},
+	}
+
+	expectedMove := &domain.CardMove{
+		EntryID: "64a9b66b2db8b91234a6e8e4",
+		To:      domain.ZoneSide,
+		Count:   2,
+	}
+	mockCardsService.
+		On("MoveCardBetweenZones", testActor, "64a9b66b2db8b91234a6e8e3", expectedMove).
+		Return(nil)
+
+	// Act
+	ctrl.MoveCardBetweenZones(c)
+
+	// Assert
+	require.Equal(t, http.StatusNoContent, c.Writer.Status())
+	mockCardsService.AssertExpectations(t)
+}
+
+func TestMoveCardBetweenZonesNotEnoughCopies(t *testing.T) {
+	// Arrange
+	mockCardsService := new(mocks.MockCardsServicer)
+	ctrl := CardsController{
+		log:          zap.NewNop(),
+		cardsService: mockCardsService,
+	}
+
+	reqBody := `{"to_zone":"side","count":5}`
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", testActor.UserID)
+	c.Request, _ = http.NewRequest("POST", "/collections/64a9b66b2db8b91234a6e8e3/cards/64a9b66b2db8b91234a6e8e4/move", strings.NewReader(reqBody))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{
+		{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"},
+		{Key: "entry_id", Value: "64a9b66b2db8b91234a6e8e4"},
+	}
+
+	mockCardsService.
+		On("MoveCardBetweenZones", mock.Anything, mock.Anything, mock.AnythingOfType("*domain.CardMove")).
+		Return(&domain.ResponseErr{Status: http.StatusConflict, Message: "Not enough copies to move"})
+
+	// Act
+	ctrl.MoveCardBetweenZones(c)
+
+	// Assert
+	require.Equal(t, http.StatusConflict, w.Code)
+	mockCardsService.AssertExpectations(t)
+}
+
+func TestAddCardToCollectionReturnsEntry(t *testing.T) {
+	// Arrange
+	mockCardsService := new(mocks.MockCardsServicer)
+	ctrl := CardsController{
+		log:          zap.NewNop(),
+		cardsService: mockCardsService,
+	}
+
+	reqBody := `{"scryfall_id":"bolt","name":"Lightning Bolt","card_url":"https://example.com/bolt.jpg","count":2,"finish":"foil","language":"ja"}`
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", testActor.UserID)
+	c.Request, _ = http.NewRequest("POST", "/collections/64a9b66b2db8b91234a6e8e3/cards", strings.NewReader(reqBody))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"}}
+
+	entry := &domain.Card{
+		ID:         "64a9b66b2db8b91234a6e8e4",
+		ScryfallID: "bolt",
+		Name:       "Lightning Bolt",
+		Count:      2,
+		Zone:       domain.ZoneMain,
+		Finish:     domain.FinishFoil,
+		Condition:  domain.ConditionNearMint,
+		Language:   "ja",
+	}
+	mockCardsService.
+		On("AddCardToCollection", testActor, "64a9b66b2db8b91234a6e8e3", mock.MatchedBy(func(card *domain.Card) bool {
+			return card.Finish == domain.FinishFoil && card.Language == "ja"
+		})).
+		Return(entry, nil)
+
+	// Act
+	ctrl.AddCardToCollection(c)
+
+	// Assert
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.Contains(t, w.Body.String(), `"id":"64a9b66b2db8b91234a6e8e4"`)
+	require.Contains(t, w.Body.String(), `"finish":"foil"`)
+	mockCardsService.AssertExpectations(t)
+}
+
+func TestTransferCards(t *testing.T) {
+	// Arrange
+	mockCardsService := new(mocks.MockCardsServicer)
+	ctrl := CardsController{
+		log:          zap.NewNop(),
+		cardsService: mockCardsService,
+	}
+
+	reqBody := `{"to_collection_id":"64a9b66b2db8b91234a6e8e5","items":[{"entry_id":"64a9b66b2db8b91234a6e8e4","count":3},{"entry_id":"64a9b66b2db8b91234a6e8e6","count":1}]}`
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/collections/64a9b66b2db8b91234a6e8e3/transfer", strings.NewReader(reqBody))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"}}
+	c.Set("userID", "64a9b66b2db8b91234a6e8e0")
+
+	expectedTransfer := &domain.CardTransfer{
+		UserID:           "64a9b66b2db8b91234a6e8e0",
+		FromCollectionID: "64a9b66b2db8b91234a6e8e3",
+		ToCollectionID:   "64a9b66b2db8b91234a6e8e5",
+		Items: []domain.CardTransferItem{
+			{EntryID: "64a9b66b2db8b91234a6e8e4", Count: 3},
+			{EntryID: "64a9b66b2db8b91234a6e8e6", Count: 1},
+		},
+	}
+	mockCardsService.On("TransferCards", testActor, expectedTransfer).Return(nil)
+
+	// Act
+	ctrl.TransferCards(c)
+
+	// Assert
+	require.Equal(t, http.StatusNoContent, c.Writer.Status())
+	mockCardsService.AssertExpectations(t)
+}
+
+func TestListCardsInCollectionWithQuery(t *testing.T) {
+	// Arrange
+	mockCardsService := new(mocks.MockCardsServicer)
+	ctrl := CardsController{
+		log:          zap.NewNop(),
+		cardsService: mockCardsService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/collections/64a9b66b2db8b91234a6e8e3/cards?sort=count&order=desc&limit=10&offset=20&name=bolt&added_since=2024-05-01&min_count=2", nil)
+	c.Params = gin.Params{{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"}}
+
+	expectedQuery := &domain.CardsQuery{
+		Name:       "bolt",
+		AddedSince: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		MinCount:   2,
+		SortBy:     domain.SortByCount,
+		Desc:       true,
+		Offset:     20,
+		Limit:      10,
+	}
+	mockCardsService.
+		On("ListCardsInCollection", "64a9b66b2db8b91234a6e8e3", expectedQuery).
+		Return(&domain.CardsPage{
+			Cards:  []domain.Card{{ID: "64a9b66b2db8b91234a6e8e4", Name: "Lightning Bolt", Count: 4}},
+			Total:  21,
+			Offset: 20,
+			Limit:  10,
+		}, nil)
+
+	// Act
+	ctrl.ListCardsInCollection(c)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{
+		"cards": [{"id": "64a9b66b2db8b91234a6e8e4", "scryfall_id": "", "name": "Lightning Bolt", "card_url": "", "count": 4}],
+		"pagination": {"total": 21, "offset": 20, "limit": 10}
+	}`, w.Body.String())
+	mockCardsService.AssertExpectations(t)
+}
+
+func TestListCardsInCollectionInvalidQuery(t *testing.T) {
+	// Arrange
+	mockCardsService := new(mocks.MockCardsServicer)
+	ctrl := CardsController{
+		log:          zap.NewNop(),
+		cardsService: mockCardsService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/collections/64a9b66b2db8b91234a6e8e3/cards?limit=ten", nil)
+	c.Params = gin.Params{{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"}}
+
+	// Act
+	ctrl.ListCardsInCollection(c)
+
+	// Assert
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	mockCardsService.AssertNotCalled(t, "ListCardsInCollection", mock.Anything, mock.Anything)
+}
+
+func TestListCardsInCollectionWithQueryLanguage(t *testing.T) {
+	// Arrange
+	mockCardsService := new(mocks.MockCardsServicer)
+	ctrl := CardsController{
+		log:          zap.NewNop(),
+		cardsService: mockCardsService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/collections/64a9b66b2db8b91234a6e8e3/cards?q="+url.QueryEscape("c:r t:creature cmc<=3 is:foil"), nil)
+	c.Params = gin.Params{{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"}}
+
+	mockCardsService.
+		On("ListCardsInCollection", "64a9b66b2db8b91234a6e8e3", mock.MatchedBy(func(query *domain.CardsQuery) bool {
+			return query.Filter != nil && query.Filter.String() == "(color:R type:creature cmc<=3 finish=foil)"
+		})).
+		Return(&domain.CardsPage{Cards: []domain.Card{}}, nil)
+
+	// Act
+	ctrl.ListCardsInCollection(c)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	mockCardsService.AssertExpectations(t)
+}
+
+func TestListCardsInCollectionQuerySyntaxError(t *testing.T) {
+	// Arrange
+	mockCardsService := new(mocks.MockCardsServicer)
+	ctrl := CardsController{
+		log:          zap.NewNop(),
+		cardsService: mockCardsService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/collections/64a9b66b2db8b91234a6e8e3/cards?q="+url.QueryEscape("t:goblin (c:r"), nil)
+	c.Params = gin.Params{{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"}}
+
+	// Act
+	ctrl.ListCardsInCollection(c)
+
+	// Assert
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "missing closing parenthesis")
+	assert.Contains(t, w.Body.String(), `"position":10`)
+	mockCardsService.AssertNotCalled(t, "ListCardsInCollection", mock.Anything, mock.Anything)
+}
+
+func TestAdjustCardCountRemovesLastCopy(t *testing.T) {
+	// Arrange
+	mockCardsService := new(mocks.MockCardsServicer)
+	ctrl := CardsController{
+		log:          zap.NewNop(),
+		cardsService: mockCardsService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", testActor.UserID)
+	c.Request, _ = http.NewRequest("POST", "/collections/64a9b66b2db8b91234a6e8e3/cards/64a9b66b2db8b91234a6e8e4/adjust", strings.NewReader(`{"delta":-1}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{
+		{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"},
+		{Key: "entry_id", Value: "64a9b66b2db8b91234a6e8e4"},
+	}
+
+	expectedAdjust := &domain.CardAdjustment{EntryID: "64a9b66b2db8b91234a6e8e4", Delta: -1}
+	mockCardsService.
+		On("AdjustCardCount", testActor, "64a9b66b2db8b91234a6e8e3", expectedAdjust).
+		Return(&domain.Card{ID: "64a9b66b2db8b91234a6e8e4", Name: "Lightning Bolt", Count: 0}, nil)
+
+	// Act
+	ctrl.AdjustCardCount(c)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"count":0`)
+	mockCardsService.AssertExpectations(t)
+}
+
+func TestApplyCardOperationsThroughCustomMethod(t *testing.T) {
+	// Arrange
+	mockCardsService := new(mocks.MockCardsServicer)
+	ctrl := CardsController{
+		log:          zap.NewNop(),
+		cardsService: mockCardsService,
+	}
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) { c.Set("userID", testActor.UserID) })
+	router.POST("/collections/:id/cards", ctrl.AddCardToCollection)
+	router.POST("/collections/:id/:method", CustomMethods(map[string]gin.HandlerFunc{
+		"cards:batch": ctrl.ApplyCardOperations,
+	}))
+
+	reqBody := `{"mode":"best_effort","operations":[
+		{"op":"add","scryfall_id":"12345678-1234-1234-1234-123456789012","name":"Black Lotus","card_url":"https://example.com/black-lotus.jpg","count":1},
+		{"op":"delete","entry_id":"64a9b66b2db8b91234a6e8e4"}
+	]}`
+
+	expectedBatch := &domain.CardBatch{
+		Mode: domain.BatchBestEffort,
+		Operations: []domain.CardOperation{
+			{Type: domain.CardOperationAdd, Card: domain.Card{
+				ScryfallID: "12345678-1234-1234-1234-123456789012",
+				Name:       "Black Lotus",
+				CardUrl:    "https://example.com/black-lotus.jpg",
+				Count:      1,
+			}},
+			{Type: domain.CardOperationDelete, Card: domain.Card{ID: "64a9b66b2db8b91234a6e8e4"}},
+		},
+	}
+	mockCardsService.
+		On("ApplyCardOperations", testActor, "64a9b66b2db8b91234a6e8e3", expectedBatch).
+		Return(&domain.CardBatchResult{
+			Applied: true,
+			Results: []domain.CardOperationResult{
+				{Entry: &domain.Card{ID: "64a9b66b2db8b91234a6e8e5", Name: "Black Lotus", Count: 1}},
+				{Err: &domain.ResponseErr{Status: http.StatusNotFound, Message: "Card not found"}},
+			},
+		}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/collections/64a9b66b2db8b91234a6e8e3/cards:batch", strings.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{
+		"applied": true,
+		"results": [
+			{"status": 200, "card": {"id": "64a9b66b2db8b91234a6e8e5", "scryfall_id": "", "name": "Black Lotus", "card_url": "", "count": 1}},
+			{"status": 404, "error": "Card not found"}
+		]
+	}`, w.Body.String())
+	mockCardsService.AssertExpectations(t)
+}
+
+func TestCustomMethodsUnknownMethod(t *testing.T) {
+	router := gin.New()
+	router.POST("/collections/:id/:method", CustomMethods(map[string]gin.HandlerFunc{
+		"cards:batch": func(ctx *gin.Context) { ctx.Status(http.StatusOK) },
+	}))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/collections/64a9b66b2db8b91234a6e8e3/cards:unknown", nil)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,132 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	dto "github.com/ShenokZlob/collector-service/pkg/contracts"
+	"go.uber.org/zap"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CatalogController отвечает за поиск по каталогу карт
+// @Tags Catalog
+// @BasePath /
+type CatalogController struct {
+	log            *zap.Logger
+	catalogService CatalogServicer
+}
+
+type CatalogServicer interface {
+	SearchByName(prefix string, limit int) ([]domain.CatalogCard, *domain.ResponseErr)
+	GetPrinting(setCode, collectorNumber string) (*domain.CatalogCard, *domain.ResponseErr)
+	GetByOracleID(oracleId string) ([]domain.CatalogCard, *domain.ResponseErr)
+}
+
+func NewCatalogController(log *zap.Logger, catalogService CatalogServicer) *CatalogController {
+	return &CatalogController{
+		log:            log.With(zap.String("controller", "catalog")),
+		catalogService: catalogService,
+	}
+}
+
+// @Summary     Search catalog cards by name prefix
+// @Description Найти печати карт, имя которых начинается с префикса (без учёта регистра)
+// @Tags        Catalog
+// @Security    BearerAuth
+// @Produce     json
+// @Param       name  query string true  "Начало имени карты"
+// @Param       limit query int    false "Максимум результатов, по умолчанию 20, не больше 100"
+// @Success     200 {array} dto.CatalogCard
+// @Failure     400,401 {object} dto.ErrorResponse
+// @Router      /catalog/cards [get]
+func (cc CatalogController) SearchByName(ctx *gin.Context) {
+	prefix := ctx.Query("name")
+
+	limit := 0
+	if raw := ctx.Query("limit"); raw != "" {
+		var err error
+		if limit, err = strconv.Atoi(raw); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, dto.ErrorResponse{Message: fmt.Sprintf("invalid limit %q", raw)})
+			return
+		}
+	}
+
+	cards, respErr := cc.catalogService.SearchByName(prefix, limit)
+	if respErr != nil {
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, catalogCardsToDTO(cards))
+}
+
+// @Summary     Get a catalog printing by set and collector number
+// @Description Получить печать карты по коду сета и номеру в сете
+// @Tags        Catalog
+// @Security    BearerAuth
+// @Produce     json
+// @Param       set    path string true "Код сета"
+// @Param       number path string true "Номер карты в сете"
+// @Success     200 {object} dto.CatalogCard
+// @Failure     401,404 {object} dto.ErrorResponse
+// @Router      /catalog/cards/{set}/{number} [get]
+func (cc CatalogController) GetPrinting(ctx *gin.Context) {
+	card, respErr := cc.catalogService.GetPrinting(ctx.Param("set"), ctx.Param("number"))
+	if respErr != nil {
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, catalogCardToDTO(*card))
+}
+
+// @Summary     Get all printings of a card by oracle ID
+// @Description Получить все печати карты по её oracle ID
+// @Tags        Catalog
+// @Security    BearerAuth
+// @Produce     json
+// @Param       oracle_id path string true "Oracle ID карты"
+// @Success     200 {array} dto.CatalogCard
+// @Failure     401,404 {object} dto.ErrorResponse
+// @Router      /catalog/oracle/{oracle_id} [get]
+func (cc CatalogController) GetByOracleID(ctx *gin.Context) {
+	cards, respErr := cc.catalogService.GetByOracleID(ctx.Param("oracle_id"))
+	if respErr != nil {
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, catalogCardsToDTO(cards))
+}
+
+func catalogCardsToDTO(cards []domain.CatalogCard) []dto.CatalogCard {
+	out := make([]dto.CatalogCard, len(cards))
+	for i, card := range cards {
+		out[i] = catalogCardToDTO(card)
+	}
+	return out
+}
+
+func catalogCardToDTO(card domain.CatalogCard) dto.CatalogCard {
+	return dto.CatalogCard{
+		ScryfallID:      card.ScryfallID,
+		OracleID:        card.OracleID,
+		Name:            card.Name,
+		Lang:            card.Lang,
+		SetCode:         card.SetCode,
+		SetName:         card.SetName,
+		CollectorNumber: card.CollectorNumber,
+		Rarity:          card.Rarity,
+		TypeLine:        card.TypeLine,
+		ManaCost:        card.ManaCost,
+		CMC:             card.CMC,
+		Colors:          card.Colors,
+		ColorIdentity:   card.ColorIdentity,
+		ImageURI:        card.ImageURI,
+		Legalities:      card.Legalities,
+	}
+}
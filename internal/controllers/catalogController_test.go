@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	mocks "github.com/ShenokZlob/collector-service/internal/controllers/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCatalogSearchByName(t *testing.T) {
+	// Arrange
+	mockCatalogService := new(mocks.MockCatalogServicer)
+	ctrl := CatalogController{
+		log:            zap.NewNop(),
+		catalogService: mockCatalogService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/catalog/cards?name=light&limit=5", nil)
+
+	mockCatalogService.
+		On("SearchByName", "light", 5).
+		Return([]domain.CatalogCard{{
+			ScryfallID:      "e3285e6b-3e79-4d7c-bf96-d920f973b80d",
+			Name:            "Lightning Bolt",
+			SetCode:         "m10",
+			CollectorNumber: "146",
+			CMC:             1,
+			ColorIdentity:   []string{"R"},
+		}}, nil)
+
+	// Act
+	ctrl.SearchByName(c)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `[{
+		"scryfall_id": "e3285e6b-3e79-4d7c-bf96-d920f973b80d",
+		"name": "Lightning Bolt",
+		"set_code": "m10",
+		"collector_number": "146",
+		"cmc": 1,
+		"color_identity": ["R"]
+	}]`, w.Body.String())
+	mockCatalogService.AssertExpectations(t)
+}
+
+func TestCatalogSearchByNameInvalidLimit(t *testing.T) {
+	mockCatalogService := new(mocks.MockCatalogServicer)
+	ctrl := CatalogController{
+		log:            zap.NewNop(),
+		catalogService: mockCatalogService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/catalog/cards?name=light&limit=many", nil)
+
+	ctrl.SearchByName(c)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	mockCatalogService.AssertNotCalled(t, "SearchByName")
+}
+
+func TestCatalogGetPrintingNotFound(t *testing.T) {
+	mockCatalogService := new(mocks.MockCatalogServicer)
+	ctrl := CatalogController{
+		log:            zap.NewNop(),
+		catalogService: mockCatalogService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/catalog/cards/m10/999", nil)
+	c.Params = gin.Params{{Key: "set", Value: "m10"}, {Key: "number", Value: "999"}}
+
+	mockCatalogService.
+		On("GetPrinting", "m10", "999").
+		Return(nil, &domain.ResponseErr{Status: http.StatusNotFound, Message: "Card not found"})
+
+	ctrl.GetPrinting(c)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -2,7 +2,11 @@
 package controllers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/ShenokZlob/collector-service/domain"
 	dto "github.com/ShenokZlob/collector-service/pkg/contracts"
@@ -22,9 +26,13 @@ type CollectionsController struct {
 type CollectionsServicer interface {
 	GetAll(userId string) ([]domain.UserCollectionRef, *domain.ResponseErr)
 	Get(collectionID string) (*domain.Collection, *domain.ResponseErr)
+	GetByName(userID, name string) (*domain.Collection, *domain.ResponseErr)
 	Create(collection *domain.Collection) (*domain.Collection, *domain.ResponseErr)
-	Rename(collection *domain.Collection) (*domain.Collection, *domain.ResponseErr)
-	Delete(userID, collectionID string) *domain.ResponseErr
+	Rename(actor domain.Actor, collection *domain.Collection) (*domain.Collection, *domain.ResponseErr)
+	Delete(actor domain.Actor, collectionID string) *domain.ResponseErr
+	Clone(userID, collectionID, name string) (*domain.Collection, *domain.ResponseErr)
+	Merge(actor domain.Actor, merge *domain.CollectionMerge) (*domain.Collection, *domain.ResponseErr)
+	SetKind(actor domain.Actor, collectionID string, kind domain.CollectionKind) (*domain.Collection, *domain.ResponseErr)
 }
 
 // NewCollectionsController создает контроллер коллекций
@@ -70,11 +78,14 @@ func (cc CollectionsController) GetAll(ctx *gin.Context) {
 }
 
 // @Summary     Get one user's collection by ID
-// @Description Получить коллекцию пользователя
+// @Description Получить коллекцию пользователя. ETag ответа — версия коллекции, её можно передать в If-Match изменяющих запросов. Если версия совпадает с If-None-Match, вернёт 304 без тела
 // @Tags        Collections
 // @Security    BearerAuth
 // @Produce     json
+// @Param       id            path   string true  "Collection ID"
+// @Param       If-None-Match header string false "ETag известной версии коллекции"
 // @Success     200 {object} dto.Collection
+// @Success     304 "Not Modified"
 // @Failure     401 {object} dto.ErrorResponse
 // @Router      /collections/{id} [get]
 func (cc CollectionsController) Get(ctx *gin.Context) {
@@ -96,10 +107,50 @@ func (cc CollectionsController) Get(ctx *gin.Context) {
 		return
 	}
 
+	etag := collectionETag(collection.Version)
+	ctx.Header("ETag", etag)
+	if etagListHas(ctx.GetHeader("If-None-Match"), etag) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+
 	cc.log.Info("GetCollection: success", zap.String("userID", userID), zap.String("collectionID", collectionID))
 	ctx.JSON(http.StatusOK, collection)
 }
 
+// @Summary     Get user's collection by name
+// @Description Найти коллекцию пользователя по имени без учёта регистра
+// @Tags        Collections
+// @Security    BearerAuth
+// @Produce     json
+// @Param       name path string true "Collection name"
+// @Success     200 {object} dto.Collection
+// @Failure     400,401,404 {object} dto.ErrorResponse
+// @Router      /collections/name/{name} [get]
+func (cc CollectionsController) GetByName(ctx *gin.Context) {
+	userID, respErr := getUserFromCtx(ctx)
+	if respErr != nil {
+		cc.log.Error("GetCollectionByName: failed to get userID", zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	cc.log.Info("GetCollectionByName: started", zap.String("userID", userID))
+
+	name := ctx.Param("name")
+	collection, respErr := cc.collectionsService.GetByName(userID, name)
+	if respErr != nil {
+		cc.log.Error("GetCollectionByName: failed to get collection", zap.String("userID", userID),
+			zap.String("collectionName", name), zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	out := collectionToDTO(collection)
+	cc.log.Info("GetCollectionByName: success", zap.String("userID", userID), zap.String("collectionID", collection.ID))
+	ctx.JSON(http.StatusOK, out)
+}
+
 // @Summary     Create new collection
 // @Description Создать новую коллекцию с указанным именем
 // @Tags        Collections
@@ -108,7 +159,7 @@ func (cc CollectionsController) Get(ctx *gin.Context) {
 // @Produce     json
 // @Param       input body dto.CreateCollectionRequest true "Название новой коллекции"
 // @Success     201 {object} dto.Collection
-// @Failure     400,401 {object} dto.ErrorResponse
+// @Failure     400,401,409 {object} dto.ErrorResponse
 // @Router      /collections [post]
 func (cc CollectionsController) Create(ctx *gin.Context) {
 	userID, respErr := getUserFromCtx(ctx)
@@ -127,7 +178,7 @@ func (cc CollectionsController) Create(ctx *gin.Context) {
 		return
 	}
 
-	collection := &domain.Collection{UserID: userID, Name: req.Name}
+	collection := &domain.Collection{UserID: userID, Name: req.Name, Kind: domain.CollectionKind(req.Kind)}
 	created, respErr := cc.collectionsService.Create(collection)
 	if respErr != nil {
 		cc.log.Error("CreateCollection: failed to create collection", zap.String("userID", userID), zap.Error(respErr))
@@ -135,7 +186,7 @@ func (cc CollectionsController) Create(ctx *gin.Context) {
 		return
 	}
 
-	out := dto.Collection{ID: created.ID, Name: created.Name}
+	out := collectionToDTO(created)
 	cc.log.Info("CreateCollection: success", zap.String("userID", userID))
 	ctx.JSON(http.StatusCreated, out)
 }
@@ -148,16 +199,18 @@ func (cc CollectionsController) Create(ctx *gin.Context) {
 // @Produce     json
 // @Param       id   path string                         true "Collection ID"
 // @Param       input body dto.RenameCollectionRequest true "Новое имя коллекции"
+// @Param       If-Match header string false "ETag версии коллекции, которую ожидает запрос"
 // @Success     204 {object} dto.Collection
-// @Failure     400,401,404 {object} dto.ErrorResponse
+// @Failure     400,401,404,409,412 {object} dto.ErrorResponse
 // @Router      /collections/{id} [patch]
 func (cc CollectionsController) Rename(ctx *gin.Context) {
-	userID, respErr := getUserFromCtx(ctx)
+	actor, respErr := getActorFromCtx(ctx)
 	if respErr != nil {
 		cc.log.Error("RenameCollection: failed to get userID", zap.Error(respErr))
 		ctx.AbortWithStatusJSON(respErr.Status, respErr)
 		return
 	}
+	userID := actor.UserID
 
 	cc.log.Info("RenameCollection: started", zap.String("userID", userID))
 
@@ -170,39 +223,42 @@ func (cc CollectionsController) Rename(ctx *gin.Context) {
 	}
 
 	collection := &domain.Collection{ID: collectionID, UserID: userID, Name: req.Name}
-	updatedCollection, respErr := cc.collectionsService.Rename(collection)
+	updatedCollection, respErr := cc.collectionsService.Rename(actor, collection)
 	if respErr != nil {
 		cc.log.Error("RenameCollection: failed to rename collection", zap.String("userID", userID), zap.Error(respErr))
 		ctx.AbortWithStatusJSON(respErr.Status, respErr)
 		return
 	}
 
-	out := dto.Collection{ID: updatedCollection.ID, Name: updatedCollection.Name}
+	out := collectionToDTO(updatedCollection)
 	cc.log.Info("RenameCollection: success", zap.String("userID", userID))
+	ctx.Header("ETag", collectionETag(updatedCollection.Version))
 	ctx.JSON(http.StatusNoContent, out)
 }
 
 // @Summary     Delete collection
-// @Description Удалить коллекцию по ID
+// @Description Переместить коллекцию вместе с картами в корзину. Её можно восстановить, пока корзина не очищена по сроку хранения
 // @Tags        Collections
 // @Security    BearerAuth
 // @Produce     json
-// @Param       id path string true "Collection ID"
+// @Param       id       path   string true  "Collection ID"
+// @Param       If-Match header string false "ETag версии коллекции, которую ожидает запрос"
 // @Success     204 "No Content"
-// @Failure     401,404 {object} dto.ErrorResponse
+// @Failure     400,401,404,412 {object} dto.ErrorResponse
 // @Router      /collections/{id} [delete]
 func (cc CollectionsController) Delete(ctx *gin.Context) {
-	userID, respErr := getUserFromCtx(ctx)
+	actor, respErr := getActorFromCtx(ctx)
 	if respErr != nil {
 		cc.log.Error("Deletecollection: failed to get userID", zap.Error(respErr))
 		ctx.AbortWithStatusJSON(respErr.Status, respErr)
 		return
 	}
+	userID := actor.UserID
 
 	cc.log.Info("DeleteCollection: started", zap.String("userID", userID))
 
 	collectionID := ctx.Param("id")
-	respErr = cc.collectionsService.Delete(userID, collectionID)
+	respErr = cc.collectionsService.Delete(actor, collectionID)
 	if respErr != nil {
 		cc.log.Error("DeleteCollection: failed to delete collection", zap.String("userID", userID), zap.String("collectionID", collectionID), zap.Error(respErr))
 		ctx.AbortWithStatusJSON(respErr.Status, respErr)
@@ -213,6 +269,143 @@ func (cc CollectionsController) Delete(ctx *gin.Context) {
 	ctx.Status(http.StatusNoContent)
 }
 
+// @Summary     Clone collection
+// @Description Скопировать коллекцию вместе с картами под новым именем
+// @Tags        Collections
+// @Security    BearerAuth
+// @Accept      json
+// @Produce     json
+// @Param       id    path string                     true "Collection ID"
+// @Param       input body dto.CloneCollectionRequest true "Имя копии"
+// @Success     201 {object} dto.Collection
+// @Failure     400,401,404,409 {object} dto.ErrorResponse
+// @Router      /collections/{id}/clone [post]
+func (cc CollectionsController) Clone(ctx *gin.Context) {
+	userID, respErr := getUserFromCtx(ctx)
+	if respErr != nil {
+		cc.log.Error("CloneCollection: failed to get userID", zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	cc.log.Info("CloneCollection: started", zap.String("userID", userID))
+
+	collectionID := ctx.Param("id")
+	var req dto.CloneCollectionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		cc.log.Error("CloneCollection: failed to get request body", zap.String("userID", userID), zap.Error(err))
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, dto.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	clone, respErr := cc.collectionsService.Clone(userID, collectionID, req.Name)
+	if respErr != nil {
+		cc.log.Error("CloneCollection: failed to clone collection", zap.String("userID", userID),
+			zap.String("collectionID", collectionID), zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	out := collectionToDTO(clone)
+	cc.log.Info("CloneCollection: success", zap.String("userID", userID), zap.String("collectionID", clone.ID))
+	ctx.JSON(http.StatusCreated, out)
+}
+
+// @Summary     Merge collections
+// @Description Слить карты другой коллекции пользователя в эту коллекцию
+// @Tags        Collections
+// @Security    BearerAuth
+// @Accept      json
+// @Produce     json
+// @Param       id       path   string                      true  "Target collection ID"
+// @Param       input    body   dto.MergeCollectionsRequest true  "Коллекция-источник и стратегия для дубликатов"
+// @Param       If-Match header string                      false "ETag версии целевой коллекции, которую ожидает запрос"
+// @Success     200 {object} dto.Collection
+// @Failure     400,401,404,412 {object} dto.ErrorResponse
+// @Router      /collections/{id}/merge [post]
+func (cc CollectionsController) Merge(ctx *gin.Context) {
+	actor, respErr := getActorFromCtx(ctx)
+	if respErr != nil {
+		cc.log.Error("MergeCollections: failed to get userID", zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+	userID := actor.UserID
+
+	cc.log.Info("MergeCollections: started", zap.String("userID", userID))
+
+	var req dto.MergeCollectionsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		cc.log.Error("MergeCollections: failed to get request body", zap.String("userID", userID), zap.Error(err))
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, dto.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	merge := &domain.CollectionMerge{
+		UserID:       userID,
+		SourceID:     req.SourceCollectionID,
+		TargetID:     ctx.Param("id"),
+		Strategy:     domain.MergeStrategy(req.Strategy),
+		DeleteSource: req.DeleteSource,
+	}
+	merged, respErr := cc.collectionsService.Merge(actor, merge)
+	if respErr != nil {
+		cc.log.Error("MergeCollections: failed to merge collections", zap.String("userID", userID), zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	out := collectionToDTO(merged)
+	cc.log.Info("MergeCollections: success", zap.String("userID", userID), zap.String("collectionID", merged.ID))
+	ctx.Header("ETag", collectionETag(merged.Version))
+	ctx.JSON(http.StatusOK, out)
+}
+
+// @Summary     Set collection kind
+// @Description Изменить вид коллекции: binder, deck, wishlist или trade
+// @Tags        Collections
+// @Security    BearerAuth
+// @Accept      json
+// @Produce     json
+// @Param       id    path string                         true "Collection ID"
+// @Param       input body dto.SetCollectionKindRequest true "Новый вид коллекции"
+// @Param       If-Match header string false "ETag версии коллекции, которую ожидает запрос"
+// @Success     200 {object} dto.Collection
+// @Failure     400,401,404,412 {object} dto.ErrorResponse
+// @Router      /collections/{id}/kind [put]
+func (cc CollectionsController) SetKind(ctx *gin.Context) {
+	actor, respErr := getActorFromCtx(ctx)
+	if respErr != nil {
+		cc.log.Error("SetCollectionKind: failed to get userID", zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+	userID := actor.UserID
+
+	collectionID := ctx.Param("id")
+	var req dto.SetCollectionKindRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		cc.log.Error("SetCollectionKind: failed to get request body", zap.String("userID", userID), zap.Error(err))
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, dto.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	updated, respErr := cc.collectionsService.SetKind(actor, collectionID, domain.CollectionKind(req.Kind))
+	if respErr != nil {
+		cc.log.Error("SetCollectionKind: failed to set kind", zap.String("userID", userID), zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	cc.log.Info("SetCollectionKind: success", zap.String("userID", userID), zap.String("kind", req.Kind))
+	ctx.Header("ETag", collectionETag(updated.Version))
+	ctx.JSON(http.StatusOK, collectionToDTO(updated))
+}
+
+func collectionToDTO(collection *domain.Collection) dto.Collection {
+	return dto.Collection{ID: collection.ID, Name: collection.Name, Kind: string(collection.Kind), Version: collection.Version}
+}
+
 func getUserFromCtx(ctx *gin.Context) (string, *domain.ResponseErr) {
 	val, ok := ctx.Get("userID")
 	if !ok {
@@ -224,3 +417,109 @@ func getUserFromCtx(ctx *gin.Context) (string, *domain.ResponseErr) {
 	}
 	return userID, nil
 }
+
+// getActorFromCtx returns the user and the request changing a collection, the collection history
+// records them. If-Match lists the collection versions the request expects, "*" expects any version.
+func getActorFromCtx(ctx *gin.Context) (domain.Actor, *domain.ResponseErr) {
+	userID, respErr := getUserFromCtx(ctx)
+	if respErr != nil {
+		return domain.Actor{}, respErr
+	}
+	actor := domain.Actor{UserID: userID, RequestID: ctx.GetString("requestID")}
+
+	ifMatch := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	if ifMatch == "" || ifMatch == "*" {
+		return actor, nil
+	}
+	versions, err := parseIfMatch(ifMatch)
+	if err != nil {
+		return domain.Actor{}, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: fmt.Sprintf("Invalid If-Match header: %v", err),
+		}
+	}
+	if len(versions) == 0 {
+		// Weak and foreign tags never match a collection version
+		return domain.Actor{}, &domain.ResponseErr{
+			Status:  http.StatusPreconditionFailed,
+			Message: "If-Match doesn't match the collection version",
+		}
+	}
+	actor.IfMatch = versions
+	return actor, nil
+}
+
+// parseIfMatch returns the collection versions of the If-Match entity tag list. If-Match
+// compares tags strongly, so weak tags and tags of other resources are skipped.
+func parseIfMatch(list string) ([]int64, error) {
+	versions := []int64{}
+	tags := 0
+	for rest := list; ; {
+		rest = strings.TrimLeft(rest, " \t")
+		if rest == "" {
+			break
+		}
+		if rest[0] == ',' {
+			rest = rest[1:]
+			continue
+		}
+
+		weak := strings.HasPrefix(rest, "W/")
+		if weak {
+			rest = rest[2:]
+		}
+		if rest == "" || rest[0] != '"' {
+			return nil, errors.New("entity tag must be quoted")
+		}
+		end := strings.IndexByte(rest[1:], '"') + 1
+		if end == 0 {
+			return nil, errors.New("entity tag isn't closed")
+		}
+		for _, b := range []byte(rest[1:end]) {
+			if b < 0x21 || b == 0x7f {
+				return nil, fmt.Errorf("entity tag has invalid character %q", b)
+			}
+		}
+		tag := rest[:end+1]
+		rest = strings.TrimLeft(rest[end+1:], " \t")
+		if rest != "" && rest[0] != ',' {
+			return nil, errors.New("entity tags must be separated by commas")
+		}
+
+		tags++
+		if version, ok := parseCollectionETag(tag); ok && !weak {
+			versions = append(versions, version)
+		}
+	}
+	if tags == 0 {
+		return nil, errors.New("no entity tags")
+	}
+	return versions, nil
+}
+
+// collectionETag is the strong ETag of the collection version
+func collectionETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+func parseCollectionETag(etag string) (int64, bool) {
+	if len(etag) < 2 || etag[0] != '"' || etag[len(etag)-1] != '"' {
+		return 0, false
+	}
+	version, err := strconv.ParseInt(etag[1:len(etag)-1], 10, 64)
+	if err != nil || version < 0 {
+		return 0, false
+	}
+	return version, true
+}
+
+// etagListHas reports whether the If-None-Match list has the ETag, tags are compared weakly
+func etagListHas(list, etag string) bool {
+	for _, tag := range strings.Split(list, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
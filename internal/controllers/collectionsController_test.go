@@ -0,0 +1,222 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	mocks "github.com/ShenokZlob/collector-service/internal/controllers/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestGetCollectionByName(t *testing.T) {
+	// Arrange
+	mockCollectionsService := new(mocks.MockCollectionsServicer)
+	ctrl := CollectionsController{
+		log:                zap.NewNop(),
+		collectionsService: mockCollectionsService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/collections/name/burn", nil)
+	c.Params = gin.Params{{Key: "name", Value: "burn"}}
+	c.Set("userID", "64a9b66b2db8b91234a6e8e0")
+
+	mockCollectionsService.
+		On("GetByName", "64a9b66b2db8b91234a6e8e0", "burn").
+		Return(&domain.Collection{ID: "64a9b66b2db8b91234a6e8e3", Name: "Burn"}, nil)
+
+	// Act
+	ctrl.GetByName(c)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":"64a9b66b2db8b91234a6e8e3","name":"Burn"}`, w.Body.String())
+	mockCollectionsService.AssertExpectations(t)
+}
+
+func TestGetCollectionByNameUnauthorized(t *testing.T) {
+	// Arrange
+	mockCollectionsService := new(mocks.MockCollectionsServicer)
+	ctrl := CollectionsController{
+		log:                zap.NewNop(),
+		collectionsService: mockCollectionsService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/collections/name/burn", nil)
+	c.Params = gin.Params{{Key: "name", Value: "burn"}}
+
+	// Act
+	ctrl.GetByName(c)
+
+	// Assert
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	mockCollectionsService.AssertNotCalled(t, "GetByName")
+}
+
+func TestSetCollectionKind(t *testing.T) {
+	// Arrange
+	mockCollectionsService := new(mocks.MockCollectionsServicer)
+	ctrl := CollectionsController{
+		log:                zap.NewNop(),
+		collectionsService: mockCollectionsService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("PUT", "/collections/64a9b66b2db8b91234a6e8e3/kind", strings.NewReader(`{"kind":"wishlist"}`))
+	c.Params = gin.Params{{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"}}
+	c.Set("userID", testActor.UserID)
+
+	mockCollectionsService.
+		On("SetKind", testActor, "64a9b66b2db8b91234a6e8e3", domain.KindWishlist).
+		Return(&domain.Collection{ID: "64a9b66b2db8b91234a6e8e3", Name: "Wants", Kind: domain.KindWishlist, Version: 4}, nil)
+
+	// Act
+	ctrl.SetKind(c)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"4"`, w.Header().Get("ETag"))
+	assert.JSONEq(t, `{"id":"64a9b66b2db8b91234a6e8e3","name":"Wants","kind":"wishlist","version":4}`, w.Body.String())
+	mockCollectionsService.AssertExpectations(t)
+}
+
+func TestGetCollectionNotModified(t *testing.T) {
+	// Arrange
+	mockCollectionsService := new(mocks.MockCollectionsServicer)
+	ctrl := CollectionsController{
+		log:                zap.NewNop(),
+		collectionsService: mockCollectionsService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/collections/64a9b66b2db8b91234a6e8e3", nil)
+	c.Request.Header.Set("If-None-Match", `"2", W/"3"`)
+	c.Params = gin.Params{{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"}}
+	c.Set("userID", testActor.UserID)
+
+	mockCollectionsService.
+		On("Get", "64a9b66b2db8b91234a6e8e3").
+		Return(&domain.Collection{ID: "64a9b66b2db8b91234a6e8e3", Name: "Wants", Version: 3}, nil)
+
+	// Act
+	ctrl.Get(c)
+
+	// Assert
+	assert.Equal(t, http.StatusNotModified, c.Writer.Status())
+	assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+	assert.Empty(t, w.Body.String())
+	mockCollectionsService.AssertExpectations(t)
+}
+
+func TestRenameCollectionChangedByAnotherRequest(t *testing.T) {
+	// Arrange
+	mockCollectionsService := new(mocks.MockCollectionsServicer)
+	ctrl := CollectionsController{
+		log:                zap.NewNop(),
+		collectionsService: mockCollectionsService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("PATCH", "/collections/64a9b66b2db8b91234a6e8e3", strings.NewReader(`{"name":"Burn"}`))
+	c.Request.Header.Set("If-Match", `"5"`)
+	c.Params = gin.Params{{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"}}
+	c.Set("userID", testActor.UserID)
+
+	actor := domain.Actor{UserID: testActor.UserID, IfMatch: []int64{5}}
+	collection := &domain.Collection{ID: "64a9b66b2db8b91234a6e8e3", UserID: testActor.UserID, Name: "Burn"}
+	mockCollectionsService.
+		On("Rename", actor, collection).
+		Return(nil, &domain.ResponseErr{Status: http.StatusPreconditionFailed, Message: "Collection was changed by another request"})
+
+	// Act
+	ctrl.Rename(c)
+
+	// Assert
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	mockCollectionsService.AssertExpectations(t)
+}
+
+func TestDeleteCollectionWithWeakIfMatch(t *testing.T) {
+	mockCollectionsService := new(mocks.MockCollectionsServicer)
+	ctrl := CollectionsController{
+		log:                zap.NewNop(),
+		collectionsService: mockCollectionsService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("DELETE", "/collections/64a9b66b2db8b91234a6e8e3", nil)
+	c.Request.Header.Set("If-Match", `W/"5"`)
+	c.Params = gin.Params{{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"}}
+	c.Set("userID", testActor.UserID)
+
+	ctrl.Delete(c)
+
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	mockCollectionsService.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestDeleteCollectionWithMalformedIfMatch(t *testing.T) {
+	mockCollectionsService := new(mocks.MockCollectionsServicer)
+	ctrl := CollectionsController{
+		log:                zap.NewNop(),
+		collectionsService: mockCollectionsService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("DELETE", "/collections/64a9b66b2db8b91234a6e8e3", nil)
+	c.Request.Header.Set("If-Match", `5`)
+	c.Params = gin.Params{{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"}}
+	c.Set("userID", testActor.UserID)
+
+	ctrl.Delete(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockCollectionsService.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestParseIfMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		list     string
+		versions []int64
+		wantErr  bool
+	}{
+		{name: "one tag", list: `"5"`, versions: []int64{5}},
+		{name: "list of tags", list: `"5", "7",,"9"`, versions: []int64{5, 7, 9}},
+		{name: "weak tags never match", list: `W/"5", "7"`, versions: []int64{7}},
+		{name: "foreign tags never match", list: `"abc", "x,y"`, versions: []int64{}},
+		{name: "unquoted tag", list: `5`, wantErr: true},
+		{name: "unclosed tag", list: `"5`, wantErr: true},
+		{name: "missing comma", list: `"5" "7"`, wantErr: true},
+		{name: "space in tag", list: `"5 7"`, wantErr: true},
+		{name: "star in list", list: `"5", *`, wantErr: true},
+		{name: "only commas", list: `, ,`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			versions, err := parseIfMatch(tt.list)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.versions, versions)
+		})
+	}
+}
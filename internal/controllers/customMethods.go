@@ -0,0 +1,22 @@
+package controllers
+
+import (
+	"net/http"
+
+	dto "github.com/ShenokZlob/collector-service/pkg/contracts"
+	"github.com/gin-gonic/gin"
+)
+
+// CustomMethods dispatches custom methods of a resource like /collections/:id/cards:batch.
+// Gin reads a colon in a route as a parameter, so they are registered as /collections/:id/:method
+// and handlers are looked up by the last path segment.
+func CustomMethods(handlers map[string]gin.HandlerFunc) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		handler, ok := handlers[ctx.Param("method")]
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusNotFound, dto.ErrorResponse{Message: "Not found"})
+			return
+		}
+		handler(ctx)
+	}
+}
@@ -0,0 +1,138 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	dto "github.com/ShenokZlob/collector-service/pkg/contracts"
+	"go.uber.org/zap"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeckController отвечает за проверку колод
+// @Tags Decks
+// @BasePath /
+type DeckController struct {
+	log         *zap.Logger
+	deckService DeckServicer
+}
+
+type DeckServicer interface {
+	ValidateDeck(collectionId string, format domain.DeckFormat) (*domain.DeckValidation, *domain.ResponseErr)
+	FindMissing(userID, targetID string, sourceIDs []string) (*domain.MissingReport, *domain.ResponseErr)
+}
+
+func NewDeckController(log *zap.Logger, deckService DeckServicer) *DeckController {
+	return &DeckController{
+		log:         log.With(zap.String("controller", "deck")),
+		deckService: deckService,
+	}
+}
+
+// @Summary     Validate a collection as a deck of a format
+// @Description Проверить коллекцию как колоду формата: запрещённые и нелегальные карты, лимит копий, размер колоды и сайдборда, для commander — цветовую идентичность
+// @Tags        Decks
+// @Security    BearerAuth
+// @Produce     json
+// @Param       id     path  string true "ID коллекции"
+// @Param       format query string true "standard, pioneer, modern, legacy, vintage, pauper или commander"
+// @Success     200 {object} dto.DeckValidation
+// @Failure     400,401,404 {object} dto.ErrorResponse
+// @Router      /collections/{id}/validate [get]
+func (dc DeckController) ValidateDeck(ctx *gin.Context) {
+	collectionID := ctx.Param("id")
+	format := domain.DeckFormat(ctx.Query("format"))
+
+	validation, respErr := dc.deckService.ValidateDeck(collectionID, format)
+	if respErr != nil {
+		dc.log.Error("ValidateDeck: failed to validate deck", zap.String("collectionID", collectionID), zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	out := dto.DeckValidation{
+		Format:     string(validation.Format),
+		Legal:      validation.Legal,
+		DeckSize:   validation.DeckSize,
+		Sideboard:  validation.Sideboard,
+		Violations: make([]dto.DeckViolation, len(validation.Violations)),
+	}
+	for i, v := range validation.Violations {
+		out.Violations[i] = dto.DeckViolation{
+			Kind:    string(v.Kind),
+			Card:    v.Card,
+			Count:   v.Count,
+			Limit:   v.Limit,
+			Colors:  v.Colors,
+			Message: v.Message,
+		}
+	}
+
+	ctx.JSON(http.StatusOK, out)
+}
+
+// @Summary     Find the cards a collection is missing
+// @Description Сравнить коллекцию (колоду или список желаемого) с коллекциями-источниками: сколько копий каждой записи уже есть, в каких коллекциях они лежат и сколько не хватает. Подходит любой выпуск карты. По умолчанию источники — все binder-коллекции пользователя
+// @Tags        Decks
+// @Security    BearerAuth
+// @Produce     json
+// @Param       id      path  string true  "ID коллекции"
+// @Param       sources query string false "ID коллекций-источников через запятую"
+// @Success     200 {object} dto.MissingReport
+// @Failure     400,401,404 {object} dto.ErrorResponse
+// @Router      /collections/{id}/missing [get]
+func (dc DeckController) FindMissing(ctx *gin.Context) {
+	userID, respErr := getUserFromCtx(ctx)
+	if respErr != nil {
+		dc.log.Error("FindMissing: failed to get userID", zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	collectionID := ctx.Param("id")
+	var sourceIDs []string
+	for _, id := range strings.Split(ctx.Query("sources"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			sourceIDs = append(sourceIDs, id)
+		}
+	}
+
+	report, respErr := dc.deckService.FindMissing(userID, collectionID, sourceIDs)
+	if respErr != nil {
+		dc.log.Error("FindMissing: failed to find missing cards", zap.String("collectionID", collectionID), zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	out := dto.MissingReport{
+		CollectionID: report.CollectionID,
+		Sources:      make([]dto.Collection, len(report.Sources)),
+		Needed:       report.Needed,
+		Owned:        report.Owned,
+		Missing:      report.Missing,
+		Entries:      make([]dto.MissingCardsEntry, len(report.Entries)),
+	}
+	for i, source := range report.Sources {
+		out.Sources[i] = dto.Collection{ID: source.ID, Name: source.Name}
+	}
+	for i, entry := range report.Entries {
+		owned := make([]dto.OwnedCopies, len(entry.Owned))
+		for j, copies := range entry.Owned {
+			owned[j] = dto.OwnedCopies{
+				CollectionID:   copies.CollectionID,
+				CollectionName: copies.CollectionName,
+				Count:          copies.Count,
+			}
+		}
+		out.Entries[i] = dto.MissingCardsEntry{
+			Card:    cardToDTO(entry.Card),
+			Needed:  entry.Needed,
+			Owned:   owned,
+			Missing: entry.Missing,
+		}
+	}
+
+	ctx.JSON(http.StatusOK, out)
+}
@@ -0,0 +1,129 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	mocks "github.com/ShenokZlob/collector-service/internal/controllers/mocks"
+	dto "github.com/ShenokZlob/collector-service/pkg/contracts"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestValidateDeck(t *testing.T) {
+	// Arrange
+	mockDeckService := new(mocks.MockDeckServicer)
+	ctrl := DeckController{
+		log:         zap.NewNop(),
+		deckService: mockDeckService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/collections/64a9b66b2db8b91234a6e8e3/validate?format=commander", nil)
+	c.Params = gin.Params{{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"}}
+
+	mockDeckService.
+		On("ValidateDeck", "64a9b66b2db8b91234a6e8e3", domain.FormatCommander).
+		Return(&domain.DeckValidation{
+			Format:   domain.FormatCommander,
+			DeckSize: 100,
+			Violations: []domain.DeckViolation{{
+				Kind:    domain.ViolationColorIdentity,
+				Card:    "Counterspell",
+				Count:   1,
+				Colors:  []string{"U"},
+				Message: "Counterspell has colors U outside the commander's color identity",
+			}},
+		}, nil)
+
+	// Act
+	ctrl.ValidateDeck(c)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{
+		"format": "commander",
+		"legal": false,
+		"deck_size": 100,
+		"sideboard": 0,
+		"violations": [{
+			"kind": "color_identity",
+			"card": "Counterspell",
+			"count": 1,
+			"colors": ["U"],
+			"message": "Counterspell has colors U outside the commander's color identity"
+		}]
+	}`, w.Body.String())
+	mockDeckService.AssertExpectations(t)
+}
+
+func TestValidateDeckInvalidFormat(t *testing.T) {
+	mockDeckService := new(mocks.MockDeckServicer)
+	ctrl := DeckController{
+		log:         zap.NewNop(),
+		deckService: mockDeckService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/collections/64a9b66b2db8b91234a6e8e3/validate?format=brawl", nil)
+	c.Params = gin.Params{{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"}}
+
+	mockDeckService.
+		On("ValidateDeck", "64a9b66b2db8b91234a6e8e3", domain.DeckFormat("brawl")).
+		Return(nil, &domain.ResponseErr{Status: http.StatusBadRequest, Message: "Invalid format"})
+
+	ctrl.ValidateDeck(c)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestFindMissing(t *testing.T) {
+	// Arrange
+	mockDeckService := new(mocks.MockDeckServicer)
+	ctrl := DeckController{
+		log:         zap.NewNop(),
+		deckService: mockDeckService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/collections/64a9b66b2db8b91234a6e8e3/missing?sources=64a9b66b2db8b91234a6e8e4,", nil)
+	c.Params = gin.Params{{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"}}
+	c.Set("userID", "64a9b66b2db8b91234a6e8e0")
+
+	mockDeckService.
+		On("FindMissing", "64a9b66b2db8b91234a6e8e0", "64a9b66b2db8b91234a6e8e3", []string{"64a9b66b2db8b91234a6e8e4"}).
+		Return(&domain.MissingReport{
+			CollectionID: "64a9b66b2db8b91234a6e8e3",
+			Sources:      []domain.UserCollectionRef{{ID: "64a9b66b2db8b91234a6e8e4", Name: "Binder"}},
+			Needed:       4,
+			Owned:        3,
+			Missing:      1,
+			Entries: []domain.MissingEntry{{
+				Card:    domain.Card{ID: "64a9b66b2db8b91234a6e8e5", Name: "Lightning Bolt", Count: 4, Zone: domain.ZoneMain},
+				Needed:  4,
+				Owned:   []domain.OwnedCopies{{CollectionID: "64a9b66b2db8b91234a6e8e4", CollectionName: "Binder", Count: 3}},
+				Missing: 1,
+			}},
+		}, nil)
+
+	// Act
+	ctrl.FindMissing(c)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	var out dto.MissingReport
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &out))
+	require.Len(t, out.Entries, 1)
+	require.Equal(t, "Lightning Bolt", out.Entries[0].Card.Name)
+	require.Equal(t, []dto.OwnedCopies{{CollectionID: "64a9b66b2db8b91234a6e8e4", CollectionName: "Binder", Count: 3}}, out.Entries[0].Owned)
+	require.Equal(t, []dto.Collection{{ID: "64a9b66b2db8b91234a6e8e4", Name: "Binder"}}, out.Sources)
+	require.Equal(t, 1, out.Missing)
+	mockDeckService.AssertExpectations(t)
+}
@@ -0,0 +1,149 @@
+package controllers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/ShenokZlob/collector-service/pkg/decklist"
+	"go.uber.org/zap"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExportController отвечает за экспорт коллекций в файлы
+// @Tags Export
+// @BasePath /
+type ExportController struct {
+	log           *zap.Logger
+	exportService ExportServicer
+}
+
+type ExportServicer interface {
+	ExportCSV(collectionId, format string, w io.Writer) *domain.ResponseErr
+	ExportDecklist(collectionId, format string, w io.Writer) *domain.ResponseErr
+}
+
+func NewExportController(log *zap.Logger, exportService ExportServicer) *ExportController {
+	return &ExportController{
+		log:           log.With(zap.String("controller", "export")),
+		exportService: exportService,
+	}
+}
+
+// @Summary     Export the collection as CSV
+// @Description Выгрузить карты коллекции в CSV для Moxfield, Deckbox, ManaBox или в собственном формате сервиса. Файл отдаётся потоком
+// @Tags        Export
+// @Security    BearerAuth
+// @Produce     text/csv
+// @Param       id     path  string true  "ID коллекции"
+// @Param       format query string false "Формат CSV, по умолчанию native" Enums(native, moxfield, deckbox, manabox)
+// @Success     200 {file} file
+// @Failure     400,401,404 {object} dto.ErrorResponse
+// @Router      /collections/{id}/export/csv [get]
+func (ec ExportController) ExportCSV(ctx *gin.Context) {
+	collectionId := ctx.Param("id")
+	format := ctx.DefaultQuery("format", "native")
+
+	ec.log.Info("ExportCSV: started", zap.String("collectionID", collectionId), zap.String("format", format))
+
+	w := &attachmentWriter{
+		ctx:         ctx,
+		contentType: "text/csv; charset=utf-8",
+		filename:    fmt.Sprintf("collection-%s-%s.csv", collectionId, format),
+	}
+	if respErr := ec.exportService.ExportCSV(collectionId, format, w); respErr != nil {
+		ec.log.Error("ExportCSV: failed to export", zap.String("collectionID", collectionId), zap.Error(respErr))
+		// The status is already sent when the stream breaks in the middle
+		if !w.started {
+			ctx.AbortWithStatusJSON(respErr.Status, respErr)
+			return
+		}
+		if err := w.abort(); err != nil {
+			ec.log.Error("ExportCSV: failed to abort the response", zap.String("collectionID", collectionId), zap.Error(err))
+		}
+		return
+	}
+
+	ec.log.Info("ExportCSV: success", zap.String("collectionID", collectionId))
+}
+
+// @Summary     Export the collection as a text decklist
+// @Description Выгрузить карты коллекции по зонам в формате для импорта в MTG Arena, MTGO, простым текстом или в markdown
+// @Tags        Export
+// @Security    BearerAuth
+// @Produce     plain
+// @Produce     text/markdown
+// @Param       id     path  string true  "ID коллекции"
+// @Param       format query string false "Формат списка, по умолчанию text" Enums(arena, mtgo, text, markdown)
+// @Success     200 {string} string
+// @Failure     400,401,404 {object} dto.ErrorResponse
+// @Router      /collections/{id}/export [get]
+func (ec ExportController) ExportDecklist(ctx *gin.Context) {
+	collectionId := ctx.Param("id")
+	format := decklist.Format(ctx.DefaultQuery("format", string(decklist.FormatText)))
+
+	ec.log.Info("ExportDecklist: started", zap.String("collectionID", collectionId), zap.String("format", string(format)))
+
+	w := &attachmentWriter{
+		ctx:         ctx,
+		contentType: format.ContentType(),
+		filename:    fmt.Sprintf("collection-%s-%s%s", collectionId, format, format.Extension()),
+	}
+	if respErr := ec.exportService.ExportDecklist(collectionId, string(format), w); respErr != nil {
+		ec.log.Error("ExportDecklist: failed to export", zap.String("collectionID", collectionId), zap.Error(respErr))
+		if !w.started {
+			ctx.AbortWithStatusJSON(respErr.Status, respErr)
+			return
+		}
+		if err := w.abort(); err != nil {
+			ec.log.Error("ExportDecklist: failed to abort the response", zap.String("collectionID", collectionId), zap.Error(err))
+		}
+		return
+	}
+
+	// An empty collection writes nothing, the file is sent anyway
+	if !w.started {
+		w.Write(nil)
+	}
+
+	ec.log.Info("ExportDecklist: success", zap.String("collectionID", collectionId))
+}
+
+// attachmentWriter sends the file headers with the first written bytes, so an
+// export which fails before writing anything still responds with a JSON error.
+// An export which fails later is aborted, the client mustn't take a part of
+// the file for the whole one.
+type attachmentWriter struct {
+	ctx         *gin.Context
+	contentType string
+	filename    string
+	started     bool
+}
+
+func (w *attachmentWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.started = true
+		w.ctx.Header("Content-Type", w.contentType)
+		w.ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", w.filename))
+		w.ctx.Status(http.StatusOK)
+	}
+	return w.ctx.Writer.Write(p)
+}
+
+// abort closes the connection without ending the body of the started response,
+// so the client sees a broken transfer instead of a complete file.
+func (w *attachmentWriter) abort() error {
+	var rw http.ResponseWriter = w.ctx.Writer
+	// The gin writer has Hijack even when the writer under it can't hijack
+	if u, ok := rw.(interface{ Unwrap() http.ResponseWriter }); ok {
+		rw = u.Unwrap()
+	}
+
+	conn, _, err := http.NewResponseController(rw).Hijack()
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	mocks "github.com/ShenokZlob/collector-service/internal/controllers/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestExportCSV(t *testing.T) {
+	// Arrange
+	mockExportService := new(mocks.MockExportServicer)
+	ctrl := ExportController{
+		log:           zap.NewNop(),
+		exportService: mockExportService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/collections/64a9b66b2db8b91234a6e8e3/export/csv?format=moxfield", nil)
+	c.Params = gin.Params{{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"}}
+
+	mockExportService.
+		On("ExportCSV", "64a9b66b2db8b91234a6e8e3", "moxfield", mock.Anything).
+		Run(func(args mock.Arguments) {
+			_, _ = io.WriteString(args.Get(2).(io.Writer), "Count,Name\n4,Lightning Bolt\n")
+		}).
+		Return(nil)
+
+	// Act
+	ctrl.ExportCSV(c)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	require.Equal(t, `attachment; filename="collection-64a9b66b2db8b91234a6e8e3-moxfield.csv"`, w.Header().Get("Content-Disposition"))
+	require.Equal(t, "Count,Name\n4,Lightning Bolt\n", w.Body.String())
+	mockExportService.AssertExpectations(t)
+}
+
+func TestExportCSVNotFound(t *testing.T) {
+	mockExportService := new(mocks.MockExportServicer)
+	ctrl := ExportController{
+		log:           zap.NewNop(),
+		exportService: mockExportService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/collections/64a9b66b2db8b91234a6e8e3/export/csv", nil)
+	c.Params = gin.Params{{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"}}
+
+	mockExportService.
+		On("ExportCSV", "64a9b66b2db8b91234a6e8e3", "native", mock.Anything).
+		Return(&domain.ResponseErr{Status: http.StatusNotFound, Message: "Collection not found"})
+
+	ctrl.ExportCSV(c)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.JSONEq(t, `{"status": 404, "message": "Collection not found"}`, w.Body.String())
+	require.Empty(t, w.Header().Get("Content-Disposition"))
+}
+
+func TestExportDecklist(t *testing.T) {
+	// Arrange
+	mockExportService := new(mocks.MockExportServicer)
+	ctrl := ExportController{
+		log:           zap.NewNop(),
+		exportService: mockExportService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/collections/64a9b66b2db8b91234a6e8e3/export?format=markdown", nil)
+	c.Params = gin.Params{{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"}}
+
+	mockExportService.
+		On("ExportDecklist", "64a9b66b2db8b91234a6e8e3", "markdown", mock.Anything).
+		Run(func(args mock.Arguments) {
+			_, _ = io.WriteString(args.Get(2).(io.Writer), "# Burn\n\n## Deck (4)\n\n- 4 Lightning Bolt\n")
+		}).
+		Return(nil)
+
+	// Act
+	ctrl.ExportDecklist(c)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "text/markdown; charset=utf-8", w.Header().Get("Content-Type"))
+	require.Equal(t, `attachment; filename="collection-64a9b66b2db8b91234a6e8e3-markdown.md"`, w.Header().Get("Content-Disposition"))
+	require.Equal(t, "# Burn\n\n## Deck (4)\n\n- 4 Lightning Bolt\n", w.Body.String())
+	mockExportService.AssertExpectations(t)
+}
+
+func TestExportDecklistEmptyCollection(t *testing.T) {
+	mockExportService := new(mocks.MockExportServicer)
+	ctrl := ExportController{
+		log:           zap.NewNop(),
+		exportService: mockExportService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/collections/64a9b66b2db8b91234a6e8e3/export", nil)
+	c.Params = gin.Params{{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"}}
+
+	mockExportService.On("ExportDecklist", "64a9b66b2db8b91234a6e8e3", "text", mock.Anything).Return(nil)
+
+	ctrl.ExportDecklist(c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+	require.Empty(t, w.Body.String())
+}
+
+func TestExportCSVBrokenStreamIsAborted(t *testing.T) {
+	// Arrange
+	mockExportService := new(mocks.MockExportServicer)
+	ctrl := ExportController{
+		log:           zap.NewNop(),
+		exportService: mockExportService,
+	}
+
+	router := gin.New()
+	router.GET("/collections/:id/export/csv", ctrl.ExportCSV)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	mockExportService.
+		On("ExportCSV", "64a9b66b2db8b91234a6e8e3", "native", mock.Anything).
+		Run(func(args mock.Arguments) {
+			_, _ = io.WriteString(args.Get(2).(io.Writer), "Count,Name\n4,Lightning Bolt\n")
+		}).
+		Return(&domain.ResponseErr{Status: http.StatusInternalServerError, Message: "Find cards error"})
+
+	// Act
+	resp, err := http.Get(server.URL + "/collections/64a9b66b2db8b91234a6e8e3/export/csv")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	_, err = io.ReadAll(resp.Body)
+
+	// Assert
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	mockExportService.AssertExpectations(t)
+}
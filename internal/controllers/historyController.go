@@ -0,0 +1,183 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	dto "github.com/ShenokZlob/collector-service/pkg/contracts"
+	"go.uber.org/zap"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HistoryController отвечает за историю изменений карт коллекции и их отмену
+// @Tags History
+// @BasePath /
+type HistoryController struct {
+	log            *zap.Logger
+	historyService HistoryServicer
+}
+
+type HistoryServicer interface {
+	History(userId, collectionId string, offset, limit int) (*domain.ChangesPage, *domain.ResponseErr)
+	Undo(actor domain.Actor, collectionId, changeId string) (*domain.CollectionChange, *domain.ResponseErr)
+	UndoLast(actor domain.Actor, collectionId string, count int) (*domain.CollectionChange, *domain.ResponseErr)
+}
+
+func NewHistoryController(log *zap.Logger, historyService HistoryServicer) *HistoryController {
+	return &HistoryController{
+		log:            log.With(zap.String("controller", "history")),
+		historyService: historyService,
+	}
+}
+
+// @Summary     Get collection history
+// @Description Получить историю изменений карт коллекции, последние изменения первыми. Для каждого изменения указаны автор, время, ID запроса и количество копий до и после
+// @Tags        History
+// @Security    BearerAuth
+// @Produce     json
+// @Param       id     path  string true  "ID коллекции"
+// @Param       offset query int    false "Смещение"
+// @Param       limit  query int    false "Размер страницы (по умолчанию 50, максимум 200)"
+// @Success     200 {object} dto.ChangesPage
+// @Failure     400,401,404 {object} dto.ErrorResponse
+// @Router      /collections/{id}/history [get]
+func (hc HistoryController) History(ctx *gin.Context) {
+	userID, respErr := getUserFromCtx(ctx)
+	if respErr != nil {
+		hc.log.Error("History: failed to get userID", zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	collectionId := ctx.Param("id")
+
+	var offset, limit int
+	for _, p := range []struct {
+		name string
+		dst  *int
+	}{{"offset", &offset}, {"limit", &limit}} {
+		raw := ctx.Query(p.name)
+		if raw == "" {
+			continue
+		}
+		v, err := strconv.Atoi(raw)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, dto.ErrorResponse{Message: fmt.Sprintf("invalid %s: %q is not a number", p.name, raw)})
+			return
+		}
+		*p.dst = v
+	}
+
+	page, respErr := hc.historyService.History(userID, collectionId, offset, limit)
+	if respErr != nil {
+		hc.log.Error("History: failed to list changes", zap.String("collectionID", collectionId), zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	out := dto.ChangesPage{
+		Changes: make([]dto.CollectionChange, len(page.Changes)),
+		Pagination: dto.Pagination{
+			Total:  page.Total,
+			Offset: page.Offset,
+			Limit:  page.Limit,
+		},
+	}
+	for i, change := range page.Changes {
+		out.Changes[i] = changeToDTO(change)
+	}
+	ctx.JSON(http.StatusOK, out)
+}
+
+// @Summary     Undo a change
+// @Description Отменить изменение из истории коллекции. Если карты изменения менялись позже и эти изменения не отменены, вернёт 409. Отмена записывается в историю как изменение undo
+// @Tags        History
+// @Security    BearerAuth
+// @Produce     json
+// @Param       id        path   string true  "ID коллекции"
+// @Param       change_id path   string true  "ID изменения"
+// @Param       If-Match  header string false "ETag версии коллекции, которую ожидает запрос"
+// @Success     200 {object} dto.CollectionChange "Изменение undo"
+// @Failure     400,401,404,409,412 {object} dto.ErrorResponse
+// @Router      /collections/{id}/history/{change_id}/undo [post]
+func (hc HistoryController) Undo(ctx *gin.Context) {
+	actor, respErr := getActorFromCtx(ctx)
+	if respErr != nil {
+		hc.log.Error("Undo: failed to get userID", zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	collectionId := ctx.Param("id")
+	changeId := ctx.Param("change_id")
+	undo, respErr := hc.historyService.Undo(actor, collectionId, changeId)
+	if respErr != nil {
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	hc.log.Info("Undo: success", zap.String("collectionID", collectionId), zap.String("changeID", changeId))
+	ctx.JSON(http.StatusOK, changeToDTO(*undo))
+}
+
+// @Summary     Undo the last changes
+// @Description Отменить последние ещё не отменённые изменения коллекции одной транзакцией: отменяются все или ни одно. Если карты изменились так, что отменить нельзя, вернёт 409
+// @Tags        History
+// @Security    BearerAuth
+// @Accept      json
+// @Produce     json
+// @Param       id       path   string                 true  "ID коллекции"
+// @Param       input    body   dto.UndoChangesRequest true  "Сколько изменений отменить (максимум 50)"
+// @Param       If-Match header string                 false "ETag версии коллекции, которую ожидает запрос"
+// @Success     200 {object} dto.CollectionChange "Изменение undo"
+// @Failure     400,401,404,409,412 {object} dto.ErrorResponse
+// @Router      /collections/{id}/history/undo [post]
+func (hc HistoryController) UndoLast(ctx *gin.Context) {
+	actor, respErr := getActorFromCtx(ctx)
+	if respErr != nil {
+		hc.log.Error("UndoLast: failed to get userID", zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	var req dto.UndoChangesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, dto.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	collectionId := ctx.Param("id")
+	undo, respErr := hc.historyService.UndoLast(actor, collectionId, req.Last)
+	if respErr != nil {
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	hc.log.Info("UndoLast: success", zap.String("collectionID", collectionId), zap.Int("changes", len(undo.Reverts)))
+	ctx.JSON(http.StatusOK, changeToDTO(*undo))
+}
+
+func changeToDTO(change domain.CollectionChange) dto.CollectionChange {
+	out := dto.CollectionChange{
+		ID:        change.ID,
+		Kind:      string(change.Kind),
+		UserID:    change.UserID,
+		RequestID: change.RequestID,
+		At:        change.At,
+		Entries:   make([]dto.ChangeEntry, len(change.Entries)),
+		Reverts:   change.Reverts,
+		UndoneBy:  change.UndoneBy,
+	}
+	for i, e := range change.Entries {
+		out.Entries[i] = dto.ChangeEntry{
+			CollectionID: e.CollectionID,
+			Card:         cardToDTO(e.Card),
+			Before:       e.Before,
+			After:        e.After,
+		}
+	}
+	return out
+}
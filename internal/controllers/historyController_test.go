@@ -0,0 +1,152 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	mocks "github.com/ShenokZlob/collector-service/internal/controllers/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// testActor is the user of requests which change cards in controller tests
+var testActor = domain.Actor{UserID: "64a9b66b2db8b91234a6e8e0"}
+
+func TestCollectionHistory(t *testing.T) {
+	// Arrange
+	mockHistoryService := new(mocks.MockHistoryServicer)
+	ctrl := HistoryController{
+		log:            zap.NewNop(),
+		historyService: mockHistoryService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/collections/64a9b66b2db8b91234a6e8e3/history?offset=10&limit=5", nil)
+	c.Params = gin.Params{{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"}}
+	c.Set("userID", testActor.UserID)
+
+	at := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	mockHistoryService.
+		On("History", testActor.UserID, "64a9b66b2db8b91234a6e8e3", 10, 5).
+		Return(&domain.ChangesPage{
+			Changes: []domain.CollectionChange{{
+				ID:        "6710a0b2c3d4e5f601234567",
+				Kind:      domain.ChangeAdjust,
+				UserID:    "64a9b66b2db8b91234a6e8e0",
+				RequestID: "req-1",
+				At:        at,
+				Entries: []domain.ChangeEntry{{
+					CollectionID: "64a9b66b2db8b91234a6e8e3",
+					Card:         domain.Card{ID: "64a9b66b2db8b91234a6e8e4", Name: "Lightning Bolt", Count: 3},
+					Before:       4,
+					After:        3,
+				}},
+				UndoneBy: "6710a0b2c3d4e5f601234568",
+			}},
+			Total:  11,
+			Offset: 10,
+			Limit:  5,
+		}, nil)
+
+	// Act
+	ctrl.History(c)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"kind":"adjust","user_id":"64a9b66b2db8b91234a6e8e0","request_id":"req-1","at":"2026-10-19T12:00:00Z"`)
+	assert.Contains(t, w.Body.String(), `"before":4,"after":3`)
+	assert.Contains(t, w.Body.String(), `"undone_by":"6710a0b2c3d4e5f601234568"`)
+	assert.Contains(t, w.Body.String(), `"pagination":{"total":11,"offset":10,"limit":5}`)
+	mockHistoryService.AssertExpectations(t)
+}
+
+func TestUndoChangeConflict(t *testing.T) {
+	// Arrange
+	mockHistoryService := new(mocks.MockHistoryServicer)
+	ctrl := HistoryController{
+		log:            zap.NewNop(),
+		historyService: mockHistoryService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/collections/64a9b66b2db8b91234a6e8e3/history/6710a0b2c3d4e5f601234567/undo", nil)
+	c.Params = gin.Params{
+		{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"},
+		{Key: "change_id", Value: "6710a0b2c3d4e5f601234567"},
+	}
+	c.Set("userID", testActor.UserID)
+
+	mockHistoryService.
+		On("Undo", testActor, "64a9b66b2db8b91234a6e8e3", "6710a0b2c3d4e5f601234567").
+		Return(nil, &domain.ResponseErr{Status: http.StatusConflict, Message: "Card Lightning Bolt was changed after change 6710a0b2c3d4e5f601234567"})
+
+	// Act
+	ctrl.Undo(c)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockHistoryService.AssertExpectations(t)
+}
+
+func TestUndoLastChanges(t *testing.T) {
+	// Arrange
+	mockHistoryService := new(mocks.MockHistoryServicer)
+	ctrl := HistoryController{
+		log:            zap.NewNop(),
+		historyService: mockHistoryService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/collections/64a9b66b2db8b91234a6e8e3/history/undo", strings.NewReader(`{"last":2}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"}}
+	c.Set("userID", testActor.UserID)
+	c.Set("requestID", "req-2")
+
+	actor := domain.Actor{UserID: testActor.UserID, RequestID: "req-2"}
+	mockHistoryService.
+		On("UndoLast", actor, "64a9b66b2db8b91234a6e8e3", 2).
+		Return(&domain.CollectionChange{
+			ID:      "6710a0b2c3d4e5f601234569",
+			Kind:    domain.ChangeUndo,
+			Reverts: []string{"6710a0b2c3d4e5f601234568", "6710a0b2c3d4e5f601234567"},
+		}, nil)
+
+	// Act
+	ctrl.UndoLast(c)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"reverts":["6710a0b2c3d4e5f601234568","6710a0b2c3d4e5f601234567"]`)
+	mockHistoryService.AssertExpectations(t)
+}
+
+func TestUndoLastChangesWithoutCount(t *testing.T) {
+	mockHistoryService := new(mocks.MockHistoryServicer)
+	ctrl := HistoryController{
+		log:            zap.NewNop(),
+		historyService: mockHistoryService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/collections/64a9b66b2db8b91234a6e8e3/history/undo", strings.NewReader(`{}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"}}
+	c.Set("userID", testActor.UserID)
+
+	ctrl.UndoLast(c)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	mockHistoryService.AssertNotCalled(t, "UndoLast", mock.Anything, mock.Anything, mock.Anything)
+}
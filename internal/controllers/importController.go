@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	dto "github.com/ShenokZlob/collector-service/pkg/contracts"
+	"go.uber.org/zap"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ImportController отвечает за импорт карт в коллекции
+// @Tags Import
+// @BasePath /
+type ImportController struct {
+	log           *zap.Logger
+	importService ImportServicer
+}
+
+type ImportServicer interface {
+	ImportDecklist(actor domain.Actor, collectionId, text string, dryRun bool) (*domain.CardImport, *domain.ResponseErr)
+	ImportCSV(actor domain.Actor, collectionId string, r io.Reader, format string, dryRun bool) (*domain.CardImport, *domain.ResponseErr)
+}
+
+func NewImportController(log *zap.Logger, importService ImportServicer) *ImportController {
+	return &ImportController{
+		log:           log.With(zap.String("controller", "import")),
+		importService: importService,
+	}
+}
+
+// @Summary     Import a text decklist into the collection
+// @Description Импортировать текстовый список карт (MTGO, Arena или простой список) в коллекцию. С dry_run только показывает, какие карты будут добавлены
+// @Tags        Import
+// @Security    BearerAuth
+// @Accept      json
+// @Produce     json
+// @Param       input body dto.ImportDecklistRequest true "Текст списка и режим предпросмотра"
+// @Param       If-Match header string false "ETag версии коллекции, которую ожидает запрос"
+// @Success     200 {object} dto.ImportResponse
+// @Failure     400,401,404,412 {object} dto.ErrorResponse
+// @Router      /collections/{id}/import [post]
+func (ic ImportController) ImportDecklist(ctx *gin.Context) {
+	actor, respErr := getActorFromCtx(ctx)
+	if respErr != nil {
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+	collectionId := ctx.Param("id")
+
+	var req dto.ImportDecklistRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, dto.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	ic.log.Info("ImportDecklist: started", zap.String("collectionID", collectionId), zap.Bool("dryRun", req.DryRun))
+
+	result, respErr := ic.importService.ImportDecklist(actor, collectionId, req.Text, req.DryRun)
+	if respErr != nil {
+		ic.log.Error("ImportDecklist: failed to import", zap.String("collectionID", collectionId), zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	out := importToDTO(result)
+	ic.log.Info("ImportDecklist: success", zap.String("collectionID", collectionId),
+		zap.Int("cards", len(out.Cards)), zap.Int("unresolved", len(out.Unresolved)))
+	ctx.JSON(http.StatusOK, out)
+}
+
+// @Summary     Import a CSV file into the collection
+// @Description Импортировать CSV-экспорт Moxfield, Deckbox, ManaBox или собственный формат сервиса. Формат определяется по заголовку, если не указан. Ошибки отдельных строк не останавливают импорт
+// @Tags        Import
+// @Security    BearerAuth
+// @Accept      text/csv
+// @Produce     json
+// @Param       id       path   string true  "ID коллекции"
+// @Param       format   query  string false "Формат CSV" Enums(native, moxfield, deckbox, manabox)
+// @Param       dry_run  query  bool   false "Только показать результат, ничего не добавляя"
+// @Param       input    body   string true  "CSV-файл"
+// @Param       If-Match header string false "ETag версии коллекции, которую ожидает запрос"
+// @Success     200 {object} dto.ImportResponse
+// @Failure     400,401,404,412 {object} dto.ErrorResponse
+// @Router      /collections/{id}/import/csv [post]
+func (ic ImportController) ImportCSV(ctx *gin.Context) {
+	actor, respErr := getActorFromCtx(ctx)
+	if respErr != nil {
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+	collectionId := ctx.Param("id")
+	format := ctx.Query("format")
+
+	dryRun := false
+	if raw := ctx.Query("dry_run"); raw != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(raw); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, dto.ErrorResponse{Message: fmt.Sprintf("invalid dry_run %q", raw)})
+			return
+		}
+	}
+
+	ic.log.Info("ImportCSV: started", zap.String("collectionID", collectionId), zap.String("format", format), zap.Bool("dryRun", dryRun))
+
+	result, respErr := ic.importService.ImportCSV(actor, collectionId, ctx.Request.Body, format, dryRun)
+	if respErr != nil {
+		ic.log.Error("ImportCSV: failed to import", zap.String("collectionID", collectionId), zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	out := importToDTO(result)
+
+	ic.log.Info("ImportCSV: success", zap.String("collectionID", collectionId),
+		zap.Int("cards", len(out.Cards)), zap.Int("unresolved", len(out.Unresolved)))
+	ctx.JSON(http.StatusOK, out)
+}
+
+func importToDTO(result *domain.CardImport) dto.ImportResponse {
+	out := dto.ImportResponse{
+		DryRun:     result.DryRun,
+		Cards:      make([]dto.ImportedCard, len(result.Cards)),
+		Unresolved: make([]dto.UnresolvedLine, len(result.Unresolved)),
+	}
+	for i, c := range result.Cards {
+		out.Cards[i] = dto.ImportedCard{Line: c.Line, Card: cardToDTO(c.Card)}
+	}
+	for i, u := range result.Unresolved {
+		out.Unresolved[i] = dto.UnresolvedLine{Line: u.Line, Text: u.Text, Reason: u.Reason}
+	}
+	return out
+}
@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	mocks "github.com/ShenokZlob/collector-service/internal/controllers/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestImportDecklist(t *testing.T) {
+	// Arrange
+	mockImportService := new(mocks.MockImportServicer)
+	ctrl := ImportController{
+		log:           zap.NewNop(),
+		importService: mockImportService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", testActor.UserID)
+	c.Request, _ = http.NewRequest("POST", "/collections/64a9b66b2db8b91234a6e8e3/import", strings.NewReader(`{"text":"4 Lightning Bolt\n4 Lightning Blot","dry_run":true}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"}}
+
+	mockImportService.
+		On("ImportDecklist", testActor, "64a9b66b2db8b91234a6e8e3", "4 Lightning Bolt\n4 Lightning Blot", true).
+		Return(&domain.CardImport{
+			DryRun: true,
+			Cards: []domain.ImportedCard{
+				{Line: 1, Card: domain.Card{ScryfallID: "e3285e6b-3e79-4d7c-bf96-d920f973b80d", Name: "Lightning Bolt", Count: 4, Zone: domain.ZoneMain}},
+			},
+			Unresolved: []domain.UnresolvedLine{
+				{Line: 2, Text: "4 Lightning Blot", Reason: "Card not found in catalog"},
+			},
+		}, nil)
+
+	// Act
+	ctrl.ImportDecklist(c)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{
+		"dry_run": true,
+		"cards": [{"line": 1, "card": {"scryfall_id": "e3285e6b-3e79-4d7c-bf96-d920f973b80d", "name": "Lightning Bolt", "card_url": "", "count": 4, "zone": "main"}}],
+		"unresolved": [{"line": 2, "text": "4 Lightning Blot", "reason": "Card not found in catalog"}]
+	}`, w.Body.String())
+	mockImportService.AssertExpectations(t)
+}
+
+func TestImportCSV(t *testing.T) {
+	// Arrange
+	mockImportService := new(mocks.MockImportServicer)
+	ctrl := ImportController{
+		log:           zap.NewNop(),
+		importService: mockImportService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", testActor.UserID)
+	c.Request, _ = http.NewRequest("POST", "/collections/64a9b66b2db8b91234a6e8e3/import/csv?format=moxfield&dry_run=true", strings.NewReader("Count,Name\n4,Lightning Bolt\n"))
+	c.Request.Header.Set("Content-Type", "text/csv")
+	c.Params = gin.Params{{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"}}
+
+	mockImportService.
+		On("ImportCSV", testActor, "64a9b66b2db8b91234a6e8e3", mock.Anything, "moxfield", true).
+		Return(&domain.CardImport{
+			DryRun:     true,
+			Cards:      []domain.ImportedCard{},
+			Unresolved: []domain.UnresolvedLine{{Line: 2, Text: "4 Lightning Bolt", Reason: "Card not found in catalog"}},
+		}, nil)
+
+	// Act
+	ctrl.ImportCSV(c)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{
+		"dry_run": true,
+		"cards": [],
+		"unresolved": [{"line": 2, "text": "4 Lightning Bolt", "reason": "Card not found in catalog"}]
+	}`, w.Body.String())
+	mockImportService.AssertExpectations(t)
+}
+
+func TestImportCSVInvalidDryRun(t *testing.T) {
+	mockImportService := new(mocks.MockImportServicer)
+	ctrl := ImportController{
+		log:           zap.NewNop(),
+		importService: mockImportService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/collections/64a9b66b2db8b91234a6e8e3/import/csv?dry_run=maybe", strings.NewReader(""))
+	c.Set("userID", testActor.UserID)
+	c.Params = gin.Params{{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"}}
+
+	ctrl.ImportCSV(c)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	mockImportService.AssertNotCalled(t, "ImportCSV", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	uuid "github.com/satori/go.uuid"
 	"go.uber.org/zap"
 )
 
@@ -55,3 +56,21 @@ func AuthMiddleware(log *zap.Logger) gin.HandlerFunc {
 		ctx.Next()
 	}
 }
+
+// RequestIDHeader carries the ID of a request, a client can send its own
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID takes the request ID from the header or generates one. The ID is set
+// as "requestID" in the context and sent back in the response header.
+func RequestID() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := ctx.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > 128 {
+			requestID = uuid.NewV4().String()
+		}
+
+		ctx.Set("requestID", requestID)
+		ctx.Header(RequestIDHeader, requestID)
+		ctx.Next()
+	}
+}
@@ -5,6 +5,9 @@
 package mocks
 
 import (
+	"io"
+	"time"
+
 	"github.com/ShenokZlob/collector-service/domain"
 	"github.com/ShenokZlob/collector-service/pkg/contracts"
 	mock "github.com/stretchr/testify/mock"
@@ -360,22 +363,33 @@ func (_m *MockCardsServicer) EXPECT() *MockCardsServicer_Expecter {
 }
 
 // AddCardToCollection provides a mock function for the type MockCardsServicer
-func (_mock *MockCardsServicer) AddCardToCollection(collectionId string, card *domain.Card) *domain.ResponseErr {
-	ret := _mock.Called(collectionId, card)
+func (_mock *MockCardsServicer) AddCardToCollection(actor domain.Actor, collectionId string, card *domain.Card) (*domain.Card, *domain.ResponseErr) {
+	ret := _mock.Called(actor, collectionId, card)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AddCardToCollection")
 	}
 
-	var r0 *domain.ResponseErr
-	if returnFunc, ok := ret.Get(0).(func(string, *domain.Card) *domain.ResponseErr); ok {
-		r0 = returnFunc(collectionId, card)
+	var r0 *domain.Card
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(domain.Actor, string, *domain.Card) (*domain.Card, *domain.ResponseErr)); ok {
+		return returnFunc(actor, collectionId, card)
+	}
+	if returnFunc, ok := ret.Get(0).(func(domain.Actor, string, *domain.Card) *domain.Card); ok {
+		r0 = returnFunc(actor, collectionId, card)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.ResponseErr)
+			r0 = ret.Get(0).(*domain.Card)
 		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(domain.Actor, string, *domain.Card) *domain.ResponseErr); ok {
+		r1 = returnFunc(actor, collectionId, card)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
 }
 
 // MockCardsServicer_AddCardToCollection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddCardToCollection'
@@ -384,40 +398,161 @@ type MockCardsServicer_AddCardToCollection_Call struct {
 }
 
 // AddCardToCollection is a helper method to define mock.On call
+//   - actor
 //   - collectionId
 //   - card
-func (_e *MockCardsServicer_Expecter) AddCardToCollection(collectionId interface{}, card interface{}) *MockCardsServicer_AddCardToCollection_Call {
-	return &MockCardsServicer_AddCardToCollection_Call{Call: _e.mock.On("AddCardToCollection", collectionId, card)}
+func (_e *MockCardsServicer_Expecter) AddCardToCollection(actor interface{}, collectionId interface{}, card interface{}) *MockCardsServicer_AddCardToCollection_Call {
+	return &MockCardsServicer_AddCardToCollection_Call{Call: _e.mock.On("AddCardToCollection", actor, collectionId, card)}
 }
 
-func (_c *MockCardsServicer_AddCardToCollection_Call) Run(run func(collectionId string, card *domain.Card)) *MockCardsServicer_AddCardToCollection_Call {
+func (_c *MockCardsServicer_AddCardToCollection_Call) Run(run func(actor domain.Actor, collectionId string, card *domain.Card)) *MockCardsServicer_AddCardToCollection_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(*domain.Card))
+		run(args[0].(domain.Actor), args[1].(string), args[2].(*domain.Card))
 	})
 	return _c
 }
 
-func (_c *MockCardsServicer_AddCardToCollection_Call) Return(responseErr *domain.ResponseErr) *MockCardsServicer_AddCardToCollection_Call {
-	_c.Call.Return(responseErr)
+func (_c *MockCardsServicer_AddCardToCollection_Call) Return(card1 *domain.Card, responseErr *domain.ResponseErr) *MockCardsServicer_AddCardToCollection_Call {
+	_c.Call.Return(card1, responseErr)
+	return _c
+}
+
+func (_c *MockCardsServicer_AddCardToCollection_Call) RunAndReturn(run func(actor domain.Actor, collectionId string, card *domain.Card) (*domain.Card, *domain.ResponseErr)) *MockCardsServicer_AddCardToCollection_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AdjustCardCount provides a mock function for the type MockCardsServicer
+func (_mock *MockCardsServicer) AdjustCardCount(actor domain.Actor, collectionId string, adjust *domain.CardAdjustment) (*domain.Card, *domain.ResponseErr) {
+	ret := _mock.Called(actor, collectionId, adjust)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdjustCardCount")
+	}
+
+	var r0 *domain.Card
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(domain.Actor, string, *domain.CardAdjustment) (*domain.Card, *domain.ResponseErr)); ok {
+		return returnFunc(actor, collectionId, adjust)
+	}
+	if returnFunc, ok := ret.Get(0).(func(domain.Actor, string, *domain.CardAdjustment) *domain.Card); ok {
+		r0 = returnFunc(actor, collectionId, adjust)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Card)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(domain.Actor, string, *domain.CardAdjustment) *domain.ResponseErr); ok {
+		r1 = returnFunc(actor, collectionId, adjust)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockCardsServicer_AdjustCardCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AdjustCardCount'
+type MockCardsServicer_AdjustCardCount_Call struct {
+	*mock.Call
+}
+
+// AdjustCardCount is a helper method to define mock.On call
+//   - actor
+//   - collectionId
+//   - adjust
+func (_e *MockCardsServicer_Expecter) AdjustCardCount(actor interface{}, collectionId interface{}, adjust interface{}) *MockCardsServicer_AdjustCardCount_Call {
+	return &MockCardsServicer_AdjustCardCount_Call{Call: _e.mock.On("AdjustCardCount", actor, collectionId, adjust)}
+}
+
+func (_c *MockCardsServicer_AdjustCardCount_Call) Run(run func(actor domain.Actor, collectionId string, adjust *domain.CardAdjustment)) *MockCardsServicer_AdjustCardCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.Actor), args[1].(string), args[2].(*domain.CardAdjustment))
+	})
+	return _c
+}
+
+func (_c *MockCardsServicer_AdjustCardCount_Call) Return(card *domain.Card, responseErr *domain.ResponseErr) *MockCardsServicer_AdjustCardCount_Call {
+	_c.Call.Return(card, responseErr)
+	return _c
+}
+
+func (_c *MockCardsServicer_AdjustCardCount_Call) RunAndReturn(run func(actor domain.Actor, collectionId string, adjust *domain.CardAdjustment) (*domain.Card, *domain.ResponseErr)) *MockCardsServicer_AdjustCardCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ApplyCardOperations provides a mock function for the type MockCardsServicer
+func (_mock *MockCardsServicer) ApplyCardOperations(actor domain.Actor, collectionId string, batch *domain.CardBatch) (*domain.CardBatchResult, *domain.ResponseErr) {
+	ret := _mock.Called(actor, collectionId, batch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApplyCardOperations")
+	}
+
+	var r0 *domain.CardBatchResult
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(domain.Actor, string, *domain.CardBatch) (*domain.CardBatchResult, *domain.ResponseErr)); ok {
+		return returnFunc(actor, collectionId, batch)
+	}
+	if returnFunc, ok := ret.Get(0).(func(domain.Actor, string, *domain.CardBatch) *domain.CardBatchResult); ok {
+		r0 = returnFunc(actor, collectionId, batch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CardBatchResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(domain.Actor, string, *domain.CardBatch) *domain.ResponseErr); ok {
+		r1 = returnFunc(actor, collectionId, batch)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockCardsServicer_ApplyCardOperations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApplyCardOperations'
+type MockCardsServicer_ApplyCardOperations_Call struct {
+	*mock.Call
+}
+
+// ApplyCardOperations is a helper method to define mock.On call
+//   - actor
+//   - collectionId
+//   - batch
+func (_e *MockCardsServicer_Expecter) ApplyCardOperations(actor interface{}, collectionId interface{}, batch interface{}) *MockCardsServicer_ApplyCardOperations_Call {
+	return &MockCardsServicer_ApplyCardOperations_Call{Call: _e.mock.On("ApplyCardOperations", actor, collectionId, batch)}
+}
+
+func (_c *MockCardsServicer_ApplyCardOperations_Call) Run(run func(actor domain.Actor, collectionId string, batch *domain.CardBatch)) *MockCardsServicer_ApplyCardOperations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.Actor), args[1].(string), args[2].(*domain.CardBatch))
+	})
+	return _c
+}
+
+func (_c *MockCardsServicer_ApplyCardOperations_Call) Return(cardBatchResult *domain.CardBatchResult, responseErr *domain.ResponseErr) *MockCardsServicer_ApplyCardOperations_Call {
+	_c.Call.Return(cardBatchResult, responseErr)
 	return _c
 }
 
-func (_c *MockCardsServicer_AddCardToCollection_Call) RunAndReturn(run func(collectionId string, card *domain.Card) *domain.ResponseErr) *MockCardsServicer_AddCardToCollection_Call {
+func (_c *MockCardsServicer_ApplyCardOperations_Call) RunAndReturn(run func(actor domain.Actor, collectionId string, batch *domain.CardBatch) (*domain.CardBatchResult, *domain.ResponseErr)) *MockCardsServicer_ApplyCardOperations_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // DeleteCardFromCollection provides a mock function for the type MockCardsServicer
-func (_mock *MockCardsServicer) DeleteCardFromCollection(collectionId string, card *domain.Card) *domain.ResponseErr {
-	ret := _mock.Called(collectionId, card)
+func (_mock *MockCardsServicer) DeleteCardFromCollection(actor domain.Actor, collectionId string, card *domain.Card) *domain.ResponseErr {
+	ret := _mock.Called(actor, collectionId, card)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteCardFromCollection")
 	}
 
 	var r0 *domain.ResponseErr
-	if returnFunc, ok := ret.Get(0).(func(string, *domain.Card) *domain.ResponseErr); ok {
-		r0 = returnFunc(collectionId, card)
+	if returnFunc, ok := ret.Get(0).(func(domain.Actor, string, *domain.Card) *domain.ResponseErr); ok {
+		r0 = returnFunc(actor, collectionId, card)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.ResponseErr)
@@ -432,15 +567,16 @@ type MockCardsServicer_DeleteCardFromCollection_Call struct {
 }
 
 // DeleteCardFromCollection is a helper method to define mock.On call
+//   - actor
 //   - collectionId
 //   - card
-func (_e *MockCardsServicer_Expecter) DeleteCardFromCollection(collectionId interface{}, card interface{}) *MockCardsServicer_DeleteCardFromCollection_Call {
-	return &MockCardsServicer_DeleteCardFromCollection_Call{Call: _e.mock.On("DeleteCardFromCollection", collectionId, card)}
+func (_e *MockCardsServicer_Expecter) DeleteCardFromCollection(actor interface{}, collectionId interface{}, card interface{}) *MockCardsServicer_DeleteCardFromCollection_Call {
+	return &MockCardsServicer_DeleteCardFromCollection_Call{Call: _e.mock.On("DeleteCardFromCollection", actor, collectionId, card)}
 }
 
-func (_c *MockCardsServicer_DeleteCardFromCollection_Call) Run(run func(collectionId string, card *domain.Card)) *MockCardsServicer_DeleteCardFromCollection_Call {
+func (_c *MockCardsServicer_DeleteCardFromCollection_Call) Run(run func(actor domain.Actor, collectionId string, card *domain.Card)) *MockCardsServicer_DeleteCardFromCollection_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(*domain.Card))
+		run(args[0].(domain.Actor), args[1].(string), args[2].(*domain.Card))
 	})
 	return _c
 }
@@ -450,33 +586,33 @@ func (_c *MockCardsServicer_DeleteCardFromCollection_Call) Return(responseErr *d
 	return _c
 }
 
-func (_c *MockCardsServicer_DeleteCardFromCollection_Call) RunAndReturn(run func(collectionId string, card *domain.Card) *domain.ResponseErr) *MockCardsServicer_DeleteCardFromCollection_Call {
+func (_c *MockCardsServicer_DeleteCardFromCollection_Call) RunAndReturn(run func(actor domain.Actor, collectionId string, card *domain.Card) *domain.ResponseErr) *MockCardsServicer_DeleteCardFromCollection_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // ListCardsInCollection provides a mock function for the type MockCardsServicer
-func (_mock *MockCardsServicer) ListCardsInCollection(collectionId string) ([]domain.Card, *domain.ResponseErr) {
-	ret := _mock.Called(collectionId)
+func (_mock *MockCardsServicer) ListCardsInCollection(collectionId string, query *domain.CardsQuery) (*domain.CardsPage, *domain.ResponseErr) {
+	ret := _mock.Called(collectionId, query)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListCardsInCollection")
 	}
 
-	var r0 []domain.Card
+	var r0 *domain.CardsPage
 	var r1 *domain.ResponseErr
-	if returnFunc, ok := ret.Get(0).(func(string) ([]domain.Card, *domain.ResponseErr)); ok {
-		return returnFunc(collectionId)
+	if returnFunc, ok := ret.Get(0).(func(string, *domain.CardsQuery) (*domain.CardsPage, *domain.ResponseErr)); ok {
+		return returnFunc(collectionId, query)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) []domain.Card); ok {
-		r0 = returnFunc(collectionId)
+	if returnFunc, ok := ret.Get(0).(func(string, *domain.CardsQuery) *domain.CardsPage); ok {
+		r0 = returnFunc(collectionId, query)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.Card)
+			r0 = ret.Get(0).(*domain.CardsPage)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) *domain.ResponseErr); ok {
-		r1 = returnFunc(collectionId)
+	if returnFunc, ok := ret.Get(1).(func(string, *domain.CardsQuery) *domain.ResponseErr); ok {
+		r1 = returnFunc(collectionId, query)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*domain.ResponseErr)
@@ -492,38 +628,39 @@ type MockCardsServicer_ListCardsInCollection_Call struct {
 
 // ListCardsInCollection is a helper method to define mock.On call
 //   - collectionId
-func (_e *MockCardsServicer_Expecter) ListCardsInCollection(collectionId interface{}) *MockCardsServicer_ListCardsInCollection_Call {
-	return &MockCardsServicer_ListCardsInCollection_Call{Call: _e.mock.On("ListCardsInCollection", collectionId)}
+//   - query
+func (_e *MockCardsServicer_Expecter) ListCardsInCollection(collectionId interface{}, query interface{}) *MockCardsServicer_ListCardsInCollection_Call {
+	return &MockCardsServicer_ListCardsInCollection_Call{Call: _e.mock.On("ListCardsInCollection", collectionId, query)}
 }
 
-func (_c *MockCardsServicer_ListCardsInCollection_Call) Run(run func(collectionId string)) *MockCardsServicer_ListCardsInCollection_Call {
+func (_c *MockCardsServicer_ListCardsInCollection_Call) Run(run func(collectionId string, query *domain.CardsQuery)) *MockCardsServicer_ListCardsInCollection_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(*domain.CardsQuery))
 	})
 	return _c
 }
 
-func (_c *MockCardsServicer_ListCardsInCollection_Call) Return(cards []domain.Card, responseErr *domain.ResponseErr) *MockCardsServicer_ListCardsInCollection_Call {
-	_c.Call.Return(cards, responseErr)
+func (_c *MockCardsServicer_ListCardsInCollection_Call) Return(cardsPage *domain.CardsPage, responseErr *domain.ResponseErr) *MockCardsServicer_ListCardsInCollection_Call {
+	_c.Call.Return(cardsPage, responseErr)
 	return _c
 }
 
-func (_c *MockCardsServicer_ListCardsInCollection_Call) RunAndReturn(run func(collectionId string) ([]domain.Card, *domain.ResponseErr)) *MockCardsServicer_ListCardsInCollection_Call {
+func (_c *MockCardsServicer_ListCardsInCollection_Call) RunAndReturn(run func(collectionId string, query *domain.CardsQuery) (*domain.CardsPage, *domain.ResponseErr)) *MockCardsServicer_ListCardsInCollection_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetCardCountInCollection provides a mock function for the type MockCardsServicer
-func (_mock *MockCardsServicer) SetCardCountInCollection(collectionId string, card *domain.Card) *domain.ResponseErr {
-	ret := _mock.Called(collectionId, card)
+// MoveCardBetweenZones provides a mock function for the type MockCardsServicer
+func (_mock *MockCardsServicer) MoveCardBetweenZones(actor domain.Actor, collectionId string, move *domain.CardMove) *domain.ResponseErr {
+	ret := _mock.Called(actor, collectionId, move)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SetCardCountInCollection")
+		panic("no return value specified for MoveCardBetweenZones")
 	}
 
 	var r0 *domain.ResponseErr
-	if returnFunc, ok := ret.Get(0).(func(string, *domain.Card) *domain.ResponseErr); ok {
-		r0 = returnFunc(collectionId, card)
+	if returnFunc, ok := ret.Get(0).(func(domain.Actor, string, *domain.CardMove) *domain.ResponseErr); ok {
+		r0 = returnFunc(actor, collectionId, move)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.ResponseErr)
@@ -532,131 +669,96 @@ func (_mock *MockCardsServicer) SetCardCountInCollection(collectionId string, ca
 	return r0
 }
 
-// MockCardsServicer_SetCardCountInCollection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetCardCountInCollection'
-type MockCardsServicer_SetCardCountInCollection_Call struct {
+// MockCardsServicer_MoveCardBetweenZones_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveCardBetweenZones'
+type MockCardsServicer_MoveCardBetweenZones_Call struct {
 	*mock.Call
 }
 
-// SetCardCountInCollection is a helper method to define mock.On call
+// MoveCardBetweenZones is a helper method to define mock.On call
+//   - actor
 //   - collectionId
-//   - card
-func (_e *MockCardsServicer_Expecter) SetCardCountInCollection(collectionId interface{}, card interface{}) *MockCardsServicer_SetCardCountInCollection_Call {
-	return &MockCardsServicer_SetCardCountInCollection_Call{Call: _e.mock.On("SetCardCountInCollection", collectionId, card)}
+//   - move
+func (_e *MockCardsServicer_Expecter) MoveCardBetweenZones(actor interface{}, collectionId interface{}, move interface{}) *MockCardsServicer_MoveCardBetweenZones_Call {
+	return &MockCardsServicer_MoveCardBetweenZones_Call{Call: _e.mock.On("MoveCardBetweenZones", actor, collectionId, move)}
 }
 
-func (_c *MockCardsServicer_SetCardCountInCollection_Call) Run(run func(collectionId string, card *domain.Card)) *MockCardsServicer_SetCardCountInCollection_Call {
+func (_c *MockCardsServicer_MoveCardBetweenZones_Call) Run(run func(actor domain.Actor, collectionId string, move *domain.CardMove)) *MockCardsServicer_MoveCardBetweenZones_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(*domain.Card))
+		run(args[0].(domain.Actor), args[1].(string), args[2].(*domain.CardMove))
 	})
 	return _c
 }
 
-func (_c *MockCardsServicer_SetCardCountInCollection_Call) Return(responseErr *domain.ResponseErr) *MockCardsServicer_SetCardCountInCollection_Call {
+func (_c *MockCardsServicer_MoveCardBetweenZones_Call) Return(responseErr *domain.ResponseErr) *MockCardsServicer_MoveCardBetweenZones_Call {
 	_c.Call.Return(responseErr)
 	return _c
 }
 
-func (_c *MockCardsServicer_SetCardCountInCollection_Call) RunAndReturn(run func(collectionId string, card *domain.Card) *domain.ResponseErr) *MockCardsServicer_SetCardCountInCollection_Call {
+func (_c *MockCardsServicer_MoveCardBetweenZones_Call) RunAndReturn(run func(actor domain.Actor, collectionId string, move *domain.CardMove) *domain.ResponseErr) *MockCardsServicer_MoveCardBetweenZones_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockCollectionsServicer creates a new instance of MockCollectionsServicer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockCollectionsServicer(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockCollectionsServicer {
-	mock := &MockCollectionsServicer{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockCollectionsServicer is an autogenerated mock type for the CollectionsServicer type
-type MockCollectionsServicer struct {
-	mock.Mock
-}
-
-type MockCollectionsServicer_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockCollectionsServicer) EXPECT() *MockCollectionsServicer_Expecter {
-	return &MockCollectionsServicer_Expecter{mock: &_m.Mock}
-}
-
-// Create provides a mock function for the type MockCollectionsServicer
-func (_mock *MockCollectionsServicer) Create(collection *domain.Collection) (*domain.Collection, *domain.ResponseErr) {
-	ret := _mock.Called(collection)
+// SetCardCountInCollection provides a mock function for the type MockCardsServicer
+func (_mock *MockCardsServicer) SetCardCountInCollection(actor domain.Actor, collectionId string, card *domain.Card) *domain.ResponseErr {
+	ret := _mock.Called(actor, collectionId, card)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Create")
+		panic("no return value specified for SetCardCountInCollection")
 	}
 
-	var r0 *domain.Collection
-	var r1 *domain.ResponseErr
-	if returnFunc, ok := ret.Get(0).(func(*domain.Collection) (*domain.Collection, *domain.ResponseErr)); ok {
-		return returnFunc(collection)
-	}
-	if returnFunc, ok := ret.Get(0).(func(*domain.Collection) *domain.Collection); ok {
-		r0 = returnFunc(collection)
+	var r0 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(domain.Actor, string, *domain.Card) *domain.ResponseErr); ok {
+		r0 = returnFunc(actor, collectionId, card)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.Collection)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(*domain.Collection) *domain.ResponseErr); ok {
-		r1 = returnFunc(collection)
-	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*domain.ResponseErr)
+			r0 = ret.Get(0).(*domain.ResponseErr)
 		}
 	}
-	return r0, r1
+	return r0
 }
 
-// MockCollectionsServicer_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
-type MockCollectionsServicer_Create_Call struct {
+// MockCardsServicer_SetCardCountInCollection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetCardCountInCollection'
+type MockCardsServicer_SetCardCountInCollection_Call struct {
 	*mock.Call
 }
 
-// Create is a helper method to define mock.On call
-//   - collection
-func (_e *MockCollectionsServicer_Expecter) Create(collection interface{}) *MockCollectionsServicer_Create_Call {
-	return &MockCollectionsServicer_Create_Call{Call: _e.mock.On("Create", collection)}
+// SetCardCountInCollection is a helper method to define mock.On call
+//   - actor
+//   - collectionId
+//   - card
+func (_e *MockCardsServicer_Expecter) SetCardCountInCollection(actor interface{}, collectionId interface{}, card interface{}) *MockCardsServicer_SetCardCountInCollection_Call {
+	return &MockCardsServicer_SetCardCountInCollection_Call{Call: _e.mock.On("SetCardCountInCollection", actor, collectionId, card)}
 }
 
-func (_c *MockCollectionsServicer_Create_Call) Run(run func(collection *domain.Collection)) *MockCollectionsServicer_Create_Call {
+func (_c *MockCardsServicer_SetCardCountInCollection_Call) Run(run func(actor domain.Actor, collectionId string, card *domain.Card)) *MockCardsServicer_SetCardCountInCollection_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*domain.Collection))
+		run(args[0].(domain.Actor), args[1].(string), args[2].(*domain.Card))
 	})
 	return _c
 }
 
-func (_c *MockCollectionsServicer_Create_Call) Return(collection1 *domain.Collection, responseErr *domain.ResponseErr) *MockCollectionsServicer_Create_Call {
-	_c.Call.Return(collection1, responseErr)
+func (_c *MockCardsServicer_SetCardCountInCollection_Call) Return(responseErr *domain.ResponseErr) *MockCardsServicer_SetCardCountInCollection_Call {
+	_c.Call.Return(responseErr)
 	return _c
 }
 
-func (_c *MockCollectionsServicer_Create_Call) RunAndReturn(run func(collection *domain.Collection) (*domain.Collection, *domain.ResponseErr)) *MockCollectionsServicer_Create_Call {
+func (_c *MockCardsServicer_SetCardCountInCollection_Call) RunAndReturn(run func(actor domain.Actor, collectionId string, card *domain.Card) *domain.ResponseErr) *MockCardsServicer_SetCardCountInCollection_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Delete provides a mock function for the type MockCollectionsServicer
-func (_mock *MockCollectionsServicer) Delete(userID string, collectionID string) *domain.ResponseErr {
-	ret := _mock.Called(userID, collectionID)
+// TransferCards provides a mock function for the type MockCardsServicer
+func (_mock *MockCardsServicer) TransferCards(actor domain.Actor, transfer *domain.CardTransfer) *domain.ResponseErr {
+	ret := _mock.Called(actor, transfer)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Delete")
+		panic("no return value specified for TransferCards")
 	}
 
 	var r0 *domain.ResponseErr
-	if returnFunc, ok := ret.Get(0).(func(string, string) *domain.ResponseErr); ok {
-		r0 = returnFunc(userID, collectionID)
+	if returnFunc, ok := ret.Get(0).(func(domain.Actor, *domain.CardTransfer) *domain.ResponseErr); ok {
+		r0 = returnFunc(actor, transfer)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.ResponseErr)
@@ -665,57 +767,84 @@ func (_mock *MockCollectionsServicer) Delete(userID string, collectionID string)
 	return r0
 }
 
-// MockCollectionsServicer_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
-type MockCollectionsServicer_Delete_Call struct {
+// MockCardsServicer_TransferCards_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransferCards'
+type MockCardsServicer_TransferCards_Call struct {
 	*mock.Call
 }
 
-// Delete is a helper method to define mock.On call
-//   - userID
-//   - collectionID
-func (_e *MockCollectionsServicer_Expecter) Delete(userID interface{}, collectionID interface{}) *MockCollectionsServicer_Delete_Call {
-	return &MockCollectionsServicer_Delete_Call{Call: _e.mock.On("Delete", userID, collectionID)}
+// TransferCards is a helper method to define mock.On call
+//   - actor
+//   - transfer
+func (_e *MockCardsServicer_Expecter) TransferCards(actor interface{}, transfer interface{}) *MockCardsServicer_TransferCards_Call {
+	return &MockCardsServicer_TransferCards_Call{Call: _e.mock.On("TransferCards", actor, transfer)}
 }
 
-func (_c *MockCollectionsServicer_Delete_Call) Run(run func(userID string, collectionID string)) *MockCollectionsServicer_Delete_Call {
+func (_c *MockCardsServicer_TransferCards_Call) Run(run func(actor domain.Actor, transfer *domain.CardTransfer)) *MockCardsServicer_TransferCards_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(domain.Actor), args[1].(*domain.CardTransfer))
 	})
 	return _c
 }
 
-func (_c *MockCollectionsServicer_Delete_Call) Return(responseErr *domain.ResponseErr) *MockCollectionsServicer_Delete_Call {
+func (_c *MockCardsServicer_TransferCards_Call) Return(responseErr *domain.ResponseErr) *MockCardsServicer_TransferCards_Call {
 	_c.Call.Return(responseErr)
 	return _c
 }
 
-func (_c *MockCollectionsServicer_Delete_Call) RunAndReturn(run func(userID string, collectionID string) *domain.ResponseErr) *MockCollectionsServicer_Delete_Call {
+func (_c *MockCardsServicer_TransferCards_Call) RunAndReturn(run func(actor domain.Actor, transfer *domain.CardTransfer) *domain.ResponseErr) *MockCardsServicer_TransferCards_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Get provides a mock function for the type MockCollectionsServicer
-func (_mock *MockCollectionsServicer) Get(collectionID string) (*domain.Collection, *domain.ResponseErr) {
-	ret := _mock.Called(collectionID)
+// NewMockCatalogServicer creates a new instance of MockCatalogServicer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCatalogServicer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCatalogServicer {
+	mock := &MockCatalogServicer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockCatalogServicer is an autogenerated mock type for the CatalogServicer type
+type MockCatalogServicer struct {
+	mock.Mock
+}
+
+type MockCatalogServicer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCatalogServicer) EXPECT() *MockCatalogServicer_Expecter {
+	return &MockCatalogServicer_Expecter{mock: &_m.Mock}
+}
+
+// GetByOracleID provides a mock function for the type MockCatalogServicer
+func (_mock *MockCatalogServicer) GetByOracleID(oracleId string) ([]domain.CatalogCard, *domain.ResponseErr) {
+	ret := _mock.Called(oracleId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Get")
+		panic("no return value specified for GetByOracleID")
 	}
 
-	var r0 *domain.Collection
+	var r0 []domain.CatalogCard
 	var r1 *domain.ResponseErr
-	if returnFunc, ok := ret.Get(0).(func(string) (*domain.Collection, *domain.ResponseErr)); ok {
-		return returnFunc(collectionID)
+	if returnFunc, ok := ret.Get(0).(func(string) ([]domain.CatalogCard, *domain.ResponseErr)); ok {
+		return returnFunc(oracleId)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) *domain.Collection); ok {
-		r0 = returnFunc(collectionID)
+	if returnFunc, ok := ret.Get(0).(func(string) []domain.CatalogCard); ok {
+		r0 = returnFunc(oracleId)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.Collection)
+			r0 = ret.Get(0).([]domain.CatalogCard)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(string) *domain.ResponseErr); ok {
-		r1 = returnFunc(collectionID)
+		r1 = returnFunc(oracleId)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*domain.ResponseErr)
@@ -724,56 +853,56 @@ func (_mock *MockCollectionsServicer) Get(collectionID string) (*domain.Collecti
 	return r0, r1
 }
 
-// MockCollectionsServicer_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
-type MockCollectionsServicer_Get_Call struct {
+// MockCatalogServicer_GetByOracleID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByOracleID'
+type MockCatalogServicer_GetByOracleID_Call struct {
 	*mock.Call
 }
 
-// Get is a helper method to define mock.On call
-//   - collectionID
-func (_e *MockCollectionsServicer_Expecter) Get(collectionID interface{}) *MockCollectionsServicer_Get_Call {
-	return &MockCollectionsServicer_Get_Call{Call: _e.mock.On("Get", collectionID)}
+// GetByOracleID is a helper method to define mock.On call
+//   - oracleId
+func (_e *MockCatalogServicer_Expecter) GetByOracleID(oracleId interface{}) *MockCatalogServicer_GetByOracleID_Call {
+	return &MockCatalogServicer_GetByOracleID_Call{Call: _e.mock.On("GetByOracleID", oracleId)}
 }
 
-func (_c *MockCollectionsServicer_Get_Call) Run(run func(collectionID string)) *MockCollectionsServicer_Get_Call {
+func (_c *MockCatalogServicer_GetByOracleID_Call) Run(run func(oracleId string)) *MockCatalogServicer_GetByOracleID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *MockCollectionsServicer_Get_Call) Return(collection *domain.Collection, responseErr *domain.ResponseErr) *MockCollectionsServicer_Get_Call {
-	_c.Call.Return(collection, responseErr)
+func (_c *MockCatalogServicer_GetByOracleID_Call) Return(catalogCards []domain.CatalogCard, responseErr *domain.ResponseErr) *MockCatalogServicer_GetByOracleID_Call {
+	_c.Call.Return(catalogCards, responseErr)
 	return _c
 }
 
-func (_c *MockCollectionsServicer_Get_Call) RunAndReturn(run func(collectionID string) (*domain.Collection, *domain.ResponseErr)) *MockCollectionsServicer_Get_Call {
+func (_c *MockCatalogServicer_GetByOracleID_Call) RunAndReturn(run func(oracleId string) ([]domain.CatalogCard, *domain.ResponseErr)) *MockCatalogServicer_GetByOracleID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAll provides a mock function for the type MockCollectionsServicer
-func (_mock *MockCollectionsServicer) GetAll(userId string) ([]domain.UserCollectionRef, *domain.ResponseErr) {
-	ret := _mock.Called(userId)
+// GetPrinting provides a mock function for the type MockCatalogServicer
+func (_mock *MockCatalogServicer) GetPrinting(setCode string, collectorNumber string) (*domain.CatalogCard, *domain.ResponseErr) {
+	ret := _mock.Called(setCode, collectorNumber)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAll")
+		panic("no return value specified for GetPrinting")
 	}
 
-	var r0 []domain.UserCollectionRef
+	var r0 *domain.CatalogCard
 	var r1 *domain.ResponseErr
-	if returnFunc, ok := ret.Get(0).(func(string) ([]domain.UserCollectionRef, *domain.ResponseErr)); ok {
-		return returnFunc(userId)
+	if returnFunc, ok := ret.Get(0).(func(string, string) (*domain.CatalogCard, *domain.ResponseErr)); ok {
+		return returnFunc(setCode, collectorNumber)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) []domain.UserCollectionRef); ok {
-		r0 = returnFunc(userId)
+	if returnFunc, ok := ret.Get(0).(func(string, string) *domain.CatalogCard); ok {
+		r0 = returnFunc(setCode, collectorNumber)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.UserCollectionRef)
+			r0 = ret.Get(0).(*domain.CatalogCard)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) *domain.ResponseErr); ok {
-		r1 = returnFunc(userId)
+	if returnFunc, ok := ret.Get(1).(func(string, string) *domain.ResponseErr); ok {
+		r1 = returnFunc(setCode, collectorNumber)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*domain.ResponseErr)
@@ -782,56 +911,57 @@ func (_mock *MockCollectionsServicer) GetAll(userId string) ([]domain.UserCollec
 	return r0, r1
 }
 
-// MockCollectionsServicer_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
-type MockCollectionsServicer_GetAll_Call struct {
+// MockCatalogServicer_GetPrinting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPrinting'
+type MockCatalogServicer_GetPrinting_Call struct {
 	*mock.Call
 }
 
-// GetAll is a helper method to define mock.On call
-//   - userId
-func (_e *MockCollectionsServicer_Expecter) GetAll(userId interface{}) *MockCollectionsServicer_GetAll_Call {
-	return &MockCollectionsServicer_GetAll_Call{Call: _e.mock.On("GetAll", userId)}
+// GetPrinting is a helper method to define mock.On call
+//   - setCode
+//   - collectorNumber
+func (_e *MockCatalogServicer_Expecter) GetPrinting(setCode interface{}, collectorNumber interface{}) *MockCatalogServicer_GetPrinting_Call {
+	return &MockCatalogServicer_GetPrinting_Call{Call: _e.mock.On("GetPrinting", setCode, collectorNumber)}
 }
 
-func (_c *MockCollectionsServicer_GetAll_Call) Run(run func(userId string)) *MockCollectionsServicer_GetAll_Call {
+func (_c *MockCatalogServicer_GetPrinting_Call) Run(run func(setCode string, collectorNumber string)) *MockCatalogServicer_GetPrinting_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *MockCollectionsServicer_GetAll_Call) Return(userCollectionRefs []domain.UserCollectionRef, responseErr *domain.ResponseErr) *MockCollectionsServicer_GetAll_Call {
-	_c.Call.Return(userCollectionRefs, responseErr)
+func (_c *MockCatalogServicer_GetPrinting_Call) Return(catalogCard *domain.CatalogCard, responseErr *domain.ResponseErr) *MockCatalogServicer_GetPrinting_Call {
+	_c.Call.Return(catalogCard, responseErr)
 	return _c
 }
 
-func (_c *MockCollectionsServicer_GetAll_Call) RunAndReturn(run func(userId string) ([]domain.UserCollectionRef, *domain.ResponseErr)) *MockCollectionsServicer_GetAll_Call {
+func (_c *MockCatalogServicer_GetPrinting_Call) RunAndReturn(run func(setCode string, collectorNumber string) (*domain.CatalogCard, *domain.ResponseErr)) *MockCatalogServicer_GetPrinting_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Rename provides a mock function for the type MockCollectionsServicer
-func (_mock *MockCollectionsServicer) Rename(collection *domain.Collection) (*domain.Collection, *domain.ResponseErr) {
-	ret := _mock.Called(collection)
+// SearchByName provides a mock function for the type MockCatalogServicer
+func (_mock *MockCatalogServicer) SearchByName(prefix string, limit int) ([]domain.CatalogCard, *domain.ResponseErr) {
+	ret := _mock.Called(prefix, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Rename")
+		panic("no return value specified for SearchByName")
 	}
 
-	var r0 *domain.Collection
+	var r0 []domain.CatalogCard
 	var r1 *domain.ResponseErr
-	if returnFunc, ok := ret.Get(0).(func(*domain.Collection) (*domain.Collection, *domain.ResponseErr)); ok {
-		return returnFunc(collection)
+	if returnFunc, ok := ret.Get(0).(func(string, int) ([]domain.CatalogCard, *domain.ResponseErr)); ok {
+		return returnFunc(prefix, limit)
 	}
-	if returnFunc, ok := ret.Get(0).(func(*domain.Collection) *domain.Collection); ok {
-		r0 = returnFunc(collection)
+	if returnFunc, ok := ret.Get(0).(func(string, int) []domain.CatalogCard); ok {
+		r0 = returnFunc(prefix, limit)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.Collection)
+			r0 = ret.Get(0).([]domain.CatalogCard)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(*domain.Collection) *domain.ResponseErr); ok {
-		r1 = returnFunc(collection)
+	if returnFunc, ok := ret.Get(1).(func(string, int) *domain.ResponseErr); ok {
+		r1 = returnFunc(prefix, limit)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*domain.ResponseErr)
@@ -840,30 +970,2607 @@ func (_mock *MockCollectionsServicer) Rename(collection *domain.Collection) (*do
 	return r0, r1
 }
 
-// MockCollectionsServicer_Rename_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rename'
-type MockCollectionsServicer_Rename_Call struct {
+// MockCatalogServicer_SearchByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchByName'
+type MockCatalogServicer_SearchByName_Call struct {
 	*mock.Call
 }
 
-// Rename is a helper method to define mock.On call
+// SearchByName is a helper method to define mock.On call
+//   - prefix
+//   - limit
+func (_e *MockCatalogServicer_Expecter) SearchByName(prefix interface{}, limit interface{}) *MockCatalogServicer_SearchByName_Call {
+	return &MockCatalogServicer_SearchByName_Call{Call: _e.mock.On("SearchByName", prefix, limit)}
+}
+
+func (_c *MockCatalogServicer_SearchByName_Call) Run(run func(prefix string, limit int)) *MockCatalogServicer_SearchByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockCatalogServicer_SearchByName_Call) Return(catalogCards []domain.CatalogCard, responseErr *domain.ResponseErr) *MockCatalogServicer_SearchByName_Call {
+	_c.Call.Return(catalogCards, responseErr)
+	return _c
+}
+
+func (_c *MockCatalogServicer_SearchByName_Call) RunAndReturn(run func(prefix string, limit int) ([]domain.CatalogCard, *domain.ResponseErr)) *MockCatalogServicer_SearchByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCollectionsServicer creates a new instance of MockCollectionsServicer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCollectionsServicer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCollectionsServicer {
+	mock := &MockCollectionsServicer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockCollectionsServicer is an autogenerated mock type for the CollectionsServicer type
+type MockCollectionsServicer struct {
+	mock.Mock
+}
+
+type MockCollectionsServicer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCollectionsServicer) EXPECT() *MockCollectionsServicer_Expecter {
+	return &MockCollectionsServicer_Expecter{mock: &_m.Mock}
+}
+
+// Clone provides a mock function for the type MockCollectionsServicer
+func (_mock *MockCollectionsServicer) Clone(userID string, collectionID string, name string) (*domain.Collection, *domain.ResponseErr) {
+	ret := _mock.Called(userID, collectionID, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Clone")
+	}
+
+	var r0 *domain.Collection
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) (*domain.Collection, *domain.ResponseErr)); ok {
+		return returnFunc(userID, collectionID, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) *domain.Collection); ok {
+		r0 = returnFunc(userID, collectionID, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Collection)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string, string) *domain.ResponseErr); ok {
+		r1 = returnFunc(userID, collectionID, name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockCollectionsServicer_Clone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Clone'
+type MockCollectionsServicer_Clone_Call struct {
+	*mock.Call
+}
+
+// Clone is a helper method to define mock.On call
+//   - userID
+//   - collectionID
+//   - name
+func (_e *MockCollectionsServicer_Expecter) Clone(userID interface{}, collectionID interface{}, name interface{}) *MockCollectionsServicer_Clone_Call {
+	return &MockCollectionsServicer_Clone_Call{Call: _e.mock.On("Clone", userID, collectionID, name)}
+}
+
+func (_c *MockCollectionsServicer_Clone_Call) Run(run func(userID string, collectionID string, name string)) *MockCollectionsServicer_Clone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockCollectionsServicer_Clone_Call) Return(collection *domain.Collection, responseErr *domain.ResponseErr) *MockCollectionsServicer_Clone_Call {
+	_c.Call.Return(collection, responseErr)
+	return _c
+}
+
+func (_c *MockCollectionsServicer_Clone_Call) RunAndReturn(run func(userID string, collectionID string, name string) (*domain.Collection, *domain.ResponseErr)) *MockCollectionsServicer_Clone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockCollectionsServicer
+func (_mock *MockCollectionsServicer) Create(collection *domain.Collection) (*domain.Collection, *domain.ResponseErr) {
+	ret := _mock.Called(collection)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *domain.Collection
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(*domain.Collection) (*domain.Collection, *domain.ResponseErr)); ok {
+		return returnFunc(collection)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*domain.Collection) *domain.Collection); ok {
+		r0 = returnFunc(collection)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Collection)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*domain.Collection) *domain.ResponseErr); ok {
+		r1 = returnFunc(collection)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockCollectionsServicer_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockCollectionsServicer_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
 //   - collection
-func (_e *MockCollectionsServicer_Expecter) Rename(collection interface{}) *MockCollectionsServicer_Rename_Call {
-	return &MockCollectionsServicer_Rename_Call{Call: _e.mock.On("Rename", collection)}
+func (_e *MockCollectionsServicer_Expecter) Create(collection interface{}) *MockCollectionsServicer_Create_Call {
+	return &MockCollectionsServicer_Create_Call{Call: _e.mock.On("Create", collection)}
 }
 
-func (_c *MockCollectionsServicer_Rename_Call) Run(run func(collection *domain.Collection)) *MockCollectionsServicer_Rename_Call {
+func (_c *MockCollectionsServicer_Create_Call) Run(run func(collection *domain.Collection)) *MockCollectionsServicer_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(*domain.Collection))
 	})
 	return _c
 }
 
-func (_c *MockCollectionsServicer_Rename_Call) Return(collection1 *domain.Collection, responseErr *domain.ResponseErr) *MockCollectionsServicer_Rename_Call {
+func (_c *MockCollectionsServicer_Create_Call) Return(collection1 *domain.Collection, responseErr *domain.ResponseErr) *MockCollectionsServicer_Create_Call {
 	_c.Call.Return(collection1, responseErr)
 	return _c
 }
 
-func (_c *MockCollectionsServicer_Rename_Call) RunAndReturn(run func(collection *domain.Collection) (*domain.Collection, *domain.ResponseErr)) *MockCollectionsServicer_Rename_Call {
+func (_c *MockCollectionsServicer_Create_Call) RunAndReturn(run func(collection *domain.Collection) (*domain.Collection, *domain.ResponseErr)) *MockCollectionsServicer_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockCollectionsServicer
+func (_mock *MockCollectionsServicer) Delete(actor domain.Actor, collectionID string) *domain.ResponseErr {
+	ret := _mock.Called(actor, collectionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(domain.Actor, string) *domain.ResponseErr); ok {
+		r0 = returnFunc(actor, collectionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ResponseErr)
+		}
+	}
+	return r0
+}
+
+// MockCollectionsServicer_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockCollectionsServicer_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - actor
+//   - collectionID
+func (_e *MockCollectionsServicer_Expecter) Delete(actor interface{}, collectionID interface{}) *MockCollectionsServicer_Delete_Call {
+	return &MockCollectionsServicer_Delete_Call{Call: _e.mock.On("Delete", actor, collectionID)}
+}
+
+func (_c *MockCollectionsServicer_Delete_Call) Run(run func(actor domain.Actor, collectionID string)) *MockCollectionsServicer_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.Actor), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockCollectionsServicer_Delete_Call) Return(responseErr *domain.ResponseErr) *MockCollectionsServicer_Delete_Call {
+	_c.Call.Return(responseErr)
+	return _c
+}
+
+func (_c *MockCollectionsServicer_Delete_Call) RunAndReturn(run func(actor domain.Actor, collectionID string) *domain.ResponseErr) *MockCollectionsServicer_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockCollectionsServicer
+func (_mock *MockCollectionsServicer) Get(collectionID string) (*domain.Collection, *domain.ResponseErr) {
+	ret := _mock.Called(collectionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *domain.Collection
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(string) (*domain.Collection, *domain.ResponseErr)); ok {
+		return returnFunc(collectionID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) *domain.Collection); ok {
+		r0 = returnFunc(collectionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Collection)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) *domain.ResponseErr); ok {
+		r1 = returnFunc(collectionID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockCollectionsServicer_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockCollectionsServicer_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - collectionID
+func (_e *MockCollectionsServicer_Expecter) Get(collectionID interface{}) *MockCollectionsServicer_Get_Call {
+	return &MockCollectionsServicer_Get_Call{Call: _e.mock.On("Get", collectionID)}
+}
+
+func (_c *MockCollectionsServicer_Get_Call) Run(run func(collectionID string)) *MockCollectionsServicer_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockCollectionsServicer_Get_Call) Return(collection *domain.Collection, responseErr *domain.ResponseErr) *MockCollectionsServicer_Get_Call {
+	_c.Call.Return(collection, responseErr)
+	return _c
+}
+
+func (_c *MockCollectionsServicer_Get_Call) RunAndReturn(run func(collectionID string) (*domain.Collection, *domain.ResponseErr)) *MockCollectionsServicer_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function for the type MockCollectionsServicer
+func (_mock *MockCollectionsServicer) GetAll(userId string) ([]domain.UserCollectionRef, *domain.ResponseErr) {
+	ret := _mock.Called(userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []domain.UserCollectionRef
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(string) ([]domain.UserCollectionRef, *domain.ResponseErr)); ok {
+		return returnFunc(userId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) []domain.UserCollectionRef); ok {
+		r0 = returnFunc(userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.UserCollectionRef)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) *domain.ResponseErr); ok {
+		r1 = returnFunc(userId)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockCollectionsServicer_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type MockCollectionsServicer_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+//   - userId
+func (_e *MockCollectionsServicer_Expecter) GetAll(userId interface{}) *MockCollectionsServicer_GetAll_Call {
+	return &MockCollectionsServicer_GetAll_Call{Call: _e.mock.On("GetAll", userId)}
+}
+
+func (_c *MockCollectionsServicer_GetAll_Call) Run(run func(userId string)) *MockCollectionsServicer_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockCollectionsServicer_GetAll_Call) Return(userCollectionRefs []domain.UserCollectionRef, responseErr *domain.ResponseErr) *MockCollectionsServicer_GetAll_Call {
+	_c.Call.Return(userCollectionRefs, responseErr)
+	return _c
+}
+
+func (_c *MockCollectionsServicer_GetAll_Call) RunAndReturn(run func(userId string) ([]domain.UserCollectionRef, *domain.ResponseErr)) *MockCollectionsServicer_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByName provides a mock function for the type MockCollectionsServicer
+func (_mock *MockCollectionsServicer) GetByName(userID string, name string) (*domain.Collection, *domain.ResponseErr) {
+	ret := _mock.Called(userID, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByName")
+	}
+
+	var r0 *domain.Collection
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(string, string) (*domain.Collection, *domain.ResponseErr)); ok {
+		return returnFunc(userID, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string) *domain.Collection); ok {
+		r0 = returnFunc(userID, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Collection)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string) *domain.ResponseErr); ok {
+		r1 = returnFunc(userID, name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockCollectionsServicer_GetByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByName'
+type MockCollectionsServicer_GetByName_Call struct {
+	*mock.Call
+}
+
+// GetByName is a helper method to define mock.On call
+//   - userID
+//   - name
+func (_e *MockCollectionsServicer_Expecter) GetByName(userID interface{}, name interface{}) *MockCollectionsServicer_GetByName_Call {
+	return &MockCollectionsServicer_GetByName_Call{Call: _e.mock.On("GetByName", userID, name)}
+}
+
+func (_c *MockCollectionsServicer_GetByName_Call) Run(run func(userID string, name string)) *MockCollectionsServicer_GetByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockCollectionsServicer_GetByName_Call) Return(collection *domain.Collection, responseErr *domain.ResponseErr) *MockCollectionsServicer_GetByName_Call {
+	_c.Call.Return(collection, responseErr)
+	return _c
+}
+
+func (_c *MockCollectionsServicer_GetByName_Call) RunAndReturn(run func(userID string, name string) (*domain.Collection, *domain.ResponseErr)) *MockCollectionsServicer_GetByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Merge provides a mock function for the type MockCollectionsServicer
+func (_mock *MockCollectionsServicer) Merge(actor domain.Actor, merge *domain.CollectionMerge) (*domain.Collection, *domain.ResponseErr) {
+	ret := _mock.Called(actor, merge)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Merge")
+	}
+
+	var r0 *domain.Collection
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(domain.Actor, *domain.CollectionMerge) (*domain.Collection, *domain.ResponseErr)); ok {
+		return returnFunc(actor, merge)
+	}
+	if returnFunc, ok := ret.Get(0).(func(domain.Actor, *domain.CollectionMerge) *domain.Collection); ok {
+		r0 = returnFunc(actor, merge)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Collection)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(domain.Actor, *domain.CollectionMerge) *domain.ResponseErr); ok {
+		r1 = returnFunc(actor, merge)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockCollectionsServicer_Merge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Merge'
+type MockCollectionsServicer_Merge_Call struct {
+	*mock.Call
+}
+
+// Merge is a helper method to define mock.On call
+//   - actor
+//   - merge
+func (_e *MockCollectionsServicer_Expecter) Merge(actor interface{}, merge interface{}) *MockCollectionsServicer_Merge_Call {
+	return &MockCollectionsServicer_Merge_Call{Call: _e.mock.On("Merge", actor, merge)}
+}
+
+func (_c *MockCollectionsServicer_Merge_Call) Run(run func(actor domain.Actor, merge *domain.CollectionMerge)) *MockCollectionsServicer_Merge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.Actor), args[1].(*domain.CollectionMerge))
+	})
+	return _c
+}
+
+func (_c *MockCollectionsServicer_Merge_Call) Return(collection *domain.Collection, responseErr *domain.ResponseErr) *MockCollectionsServicer_Merge_Call {
+	_c.Call.Return(collection, responseErr)
+	return _c
+}
+
+func (_c *MockCollectionsServicer_Merge_Call) RunAndReturn(run func(actor domain.Actor, merge *domain.CollectionMerge) (*domain.Collection, *domain.ResponseErr)) *MockCollectionsServicer_Merge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Rename provides a mock function for the type MockCollectionsServicer
+func (_mock *MockCollectionsServicer) Rename(actor domain.Actor, collection *domain.Collection) (*domain.Collection, *domain.ResponseErr) {
+	ret := _mock.Called(actor, collection)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rename")
+	}
+
+	var r0 *domain.Collection
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(domain.Actor, *domain.Collection) (*domain.Collection, *domain.ResponseErr)); ok {
+		return returnFunc(actor, collection)
+	}
+	if returnFunc, ok := ret.Get(0).(func(domain.Actor, *domain.Collection) *domain.Collection); ok {
+		r0 = returnFunc(actor, collection)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Collection)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(domain.Actor, *domain.Collection) *domain.ResponseErr); ok {
+		r1 = returnFunc(actor, collection)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockCollectionsServicer_Rename_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rename'
+type MockCollectionsServicer_Rename_Call struct {
+	*mock.Call
+}
+
+// Rename is a helper method to define mock.On call
+//   - actor
+//   - collection
+func (_e *MockCollectionsServicer_Expecter) Rename(actor interface{}, collection interface{}) *MockCollectionsServicer_Rename_Call {
+	return &MockCollectionsServicer_Rename_Call{Call: _e.mock.On("Rename", actor, collection)}
+}
+
+func (_c *MockCollectionsServicer_Rename_Call) Run(run func(actor domain.Actor, collection *domain.Collection)) *MockCollectionsServicer_Rename_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.Actor), args[1].(*domain.Collection))
+	})
+	return _c
+}
+
+func (_c *MockCollectionsServicer_Rename_Call) Return(collection1 *domain.Collection, responseErr *domain.ResponseErr) *MockCollectionsServicer_Rename_Call {
+	_c.Call.Return(collection1, responseErr)
+	return _c
+}
+
+func (_c *MockCollectionsServicer_Rename_Call) RunAndReturn(run func(actor domain.Actor, collection *domain.Collection) (*domain.Collection, *domain.ResponseErr)) *MockCollectionsServicer_Rename_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetKind provides a mock function for the type MockCollectionsServicer
+func (_mock *MockCollectionsServicer) SetKind(actor domain.Actor, collectionID string, kind domain.CollectionKind) (*domain.Collection, *domain.ResponseErr) {
+	ret := _mock.Called(actor, collectionID, kind)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetKind")
+	}
+
+	var r0 *domain.Collection
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(domain.Actor, string, domain.CollectionKind) (*domain.Collection, *domain.ResponseErr)); ok {
+		return returnFunc(actor, collectionID, kind)
+	}
+	if returnFunc, ok := ret.Get(0).(func(domain.Actor, string, domain.CollectionKind) *domain.Collection); ok {
+		r0 = returnFunc(actor, collectionID, kind)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Collection)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(domain.Actor, string, domain.CollectionKind) *domain.ResponseErr); ok {
+		r1 = returnFunc(actor, collectionID, kind)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockCollectionsServicer_SetKind_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetKind'
+type MockCollectionsServicer_SetKind_Call struct {
+	*mock.Call
+}
+
+// SetKind is a helper method to define mock.On call
+//   - actor
+//   - collectionID
+//   - kind
+func (_e *MockCollectionsServicer_Expecter) SetKind(actor interface{}, collectionID interface{}, kind interface{}) *MockCollectionsServicer_SetKind_Call {
+	return &MockCollectionsServicer_SetKind_Call{Call: _e.mock.On("SetKind", actor, collectionID, kind)}
+}
+
+func (_c *MockCollectionsServicer_SetKind_Call) Run(run func(actor domain.Actor, collectionID string, kind domain.CollectionKind)) *MockCollectionsServicer_SetKind_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.Actor), args[1].(string), args[2].(domain.CollectionKind))
+	})
+	return _c
+}
+
+func (_c *MockCollectionsServicer_SetKind_Call) Return(collection *domain.Collection, responseErr *domain.ResponseErr) *MockCollectionsServicer_SetKind_Call {
+	_c.Call.Return(collection, responseErr)
+	return _c
+}
+
+func (_c *MockCollectionsServicer_SetKind_Call) RunAndReturn(run func(actor domain.Actor, collectionID string, kind domain.CollectionKind) (*domain.Collection, *domain.ResponseErr)) *MockCollectionsServicer_SetKind_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDeckServicer creates a new instance of MockDeckServicer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeckServicer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeckServicer {
+	mock := &MockDeckServicer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDeckServicer is an autogenerated mock type for the DeckServicer type
+type MockDeckServicer struct {
+	mock.Mock
+}
+
+type MockDeckServicer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeckServicer) EXPECT() *MockDeckServicer_Expecter {
+	return &MockDeckServicer_Expecter{mock: &_m.Mock}
+}
+
+// FindMissing provides a mock function for the type MockDeckServicer
+func (_mock *MockDeckServicer) FindMissing(userID string, targetID string, sourceIDs []string) (*domain.MissingReport, *domain.ResponseErr) {
+	ret := _mock.Called(userID, targetID, sourceIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindMissing")
+	}
+
+	var r0 *domain.MissingReport
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(string, string, []string) (*domain.MissingReport, *domain.ResponseErr)); ok {
+		return returnFunc(userID, targetID, sourceIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string, []string) *domain.MissingReport); ok {
+		r0 = returnFunc(userID, targetID, sourceIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.MissingReport)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string, []string) *domain.ResponseErr); ok {
+		r1 = returnFunc(userID, targetID, sourceIDs)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockDeckServicer_FindMissing_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindMissing'
+type MockDeckServicer_FindMissing_Call struct {
+	*mock.Call
+}
+
+// FindMissing is a helper method to define mock.On call
+//   - userID
+//   - targetID
+//   - sourceIDs
+func (_e *MockDeckServicer_Expecter) FindMissing(userID interface{}, targetID interface{}, sourceIDs interface{}) *MockDeckServicer_FindMissing_Call {
+	return &MockDeckServicer_FindMissing_Call{Call: _e.mock.On("FindMissing", userID, targetID, sourceIDs)}
+}
+
+func (_c *MockDeckServicer_FindMissing_Call) Run(run func(userID string, targetID string, sourceIDs []string)) *MockDeckServicer_FindMissing_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *MockDeckServicer_FindMissing_Call) Return(missingReport *domain.MissingReport, responseErr *domain.ResponseErr) *MockDeckServicer_FindMissing_Call {
+	_c.Call.Return(missingReport, responseErr)
+	return _c
+}
+
+func (_c *MockDeckServicer_FindMissing_Call) RunAndReturn(run func(userID string, targetID string, sourceIDs []string) (*domain.MissingReport, *domain.ResponseErr)) *MockDeckServicer_FindMissing_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ValidateDeck provides a mock function for the type MockDeckServicer
+func (_mock *MockDeckServicer) ValidateDeck(collectionId string, format domain.DeckFormat) (*domain.DeckValidation, *domain.ResponseErr) {
+	ret := _mock.Called(collectionId, format)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateDeck")
+	}
+
+	var r0 *domain.DeckValidation
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(string, domain.DeckFormat) (*domain.DeckValidation, *domain.ResponseErr)); ok {
+		return returnFunc(collectionId, format)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, domain.DeckFormat) *domain.DeckValidation); ok {
+		r0 = returnFunc(collectionId, format)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.DeckValidation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, domain.DeckFormat) *domain.ResponseErr); ok {
+		r1 = returnFunc(collectionId, format)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockDeckServicer_ValidateDeck_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateDeck'
+type MockDeckServicer_ValidateDeck_Call struct {
+	*mock.Call
+}
+
+// ValidateDeck is a helper method to define mock.On call
+//   - collectionId
+//   - format
+func (_e *MockDeckServicer_Expecter) ValidateDeck(collectionId interface{}, format interface{}) *MockDeckServicer_ValidateDeck_Call {
+	return &MockDeckServicer_ValidateDeck_Call{Call: _e.mock.On("ValidateDeck", collectionId, format)}
+}
+
+func (_c *MockDeckServicer_ValidateDeck_Call) Run(run func(collectionId string, format domain.DeckFormat)) *MockDeckServicer_ValidateDeck_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(domain.DeckFormat))
+	})
+	return _c
+}
+
+func (_c *MockDeckServicer_ValidateDeck_Call) Return(deckValidation *domain.DeckValidation, responseErr *domain.ResponseErr) *MockDeckServicer_ValidateDeck_Call {
+	_c.Call.Return(deckValidation, responseErr)
+	return _c
+}
+
+func (_c *MockDeckServicer_ValidateDeck_Call) RunAndReturn(run func(collectionId string, format domain.DeckFormat) (*domain.DeckValidation, *domain.ResponseErr)) *MockDeckServicer_ValidateDeck_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockExportServicer creates a new instance of MockExportServicer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockExportServicer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockExportServicer {
+	mock := &MockExportServicer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockExportServicer is an autogenerated mock type for the ExportServicer type
+type MockExportServicer struct {
+	mock.Mock
+}
+
+type MockExportServicer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockExportServicer) EXPECT() *MockExportServicer_Expecter {
+	return &MockExportServicer_Expecter{mock: &_m.Mock}
+}
+
+// ExportCSV provides a mock function for the type MockExportServicer
+func (_mock *MockExportServicer) ExportCSV(collectionId string, format string, w io.Writer) *domain.ResponseErr {
+	ret := _mock.Called(collectionId, format, w)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportCSV")
+	}
+
+	var r0 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(string, string, io.Writer) *domain.ResponseErr); ok {
+		r0 = returnFunc(collectionId, format, w)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ResponseErr)
+		}
+	}
+	return r0
+}
+
+// MockExportServicer_ExportCSV_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportCSV'
+type MockExportServicer_ExportCSV_Call struct {
+	*mock.Call
+}
+
+// ExportCSV is a helper method to define mock.On call
+//   - collectionId
+//   - format
+//   - w
+func (_e *MockExportServicer_Expecter) ExportCSV(collectionId interface{}, format interface{}, w interface{}) *MockExportServicer_ExportCSV_Call {
+	return &MockExportServicer_ExportCSV_Call{Call: _e.mock.On("ExportCSV", collectionId, format, w)}
+}
+
+func (_c *MockExportServicer_ExportCSV_Call) Run(run func(collectionId string, format string, w io.Writer)) *MockExportServicer_ExportCSV_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(io.Writer))
+	})
+	return _c
+}
+
+func (_c *MockExportServicer_ExportCSV_Call) Return(responseErr *domain.ResponseErr) *MockExportServicer_ExportCSV_Call {
+	_c.Call.Return(responseErr)
+	return _c
+}
+
+func (_c *MockExportServicer_ExportCSV_Call) RunAndReturn(run func(collectionId string, format string, w io.Writer) *domain.ResponseErr) *MockExportServicer_ExportCSV_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExportDecklist provides a mock function for the type MockExportServicer
+func (_mock *MockExportServicer) ExportDecklist(collectionId string, format string, w io.Writer) *domain.ResponseErr {
+	ret := _mock.Called(collectionId, format, w)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportDecklist")
+	}
+
+	var r0 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(string, string, io.Writer) *domain.ResponseErr); ok {
+		r0 = returnFunc(collectionId, format, w)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ResponseErr)
+		}
+	}
+	return r0
+}
+
+// MockExportServicer_ExportDecklist_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportDecklist'
+type MockExportServicer_ExportDecklist_Call struct {
+	*mock.Call
+}
+
+// ExportDecklist is a helper method to define mock.On call
+//   - collectionId
+//   - format
+//   - w
+func (_e *MockExportServicer_Expecter) ExportDecklist(collectionId interface{}, format interface{}, w interface{}) *MockExportServicer_ExportDecklist_Call {
+	return &MockExportServicer_ExportDecklist_Call{Call: _e.mock.On("ExportDecklist", collectionId, format, w)}
+}
+
+func (_c *MockExportServicer_ExportDecklist_Call) Run(run func(collectionId string, format string, w io.Writer)) *MockExportServicer_ExportDecklist_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(io.Writer))
+	})
+	return _c
+}
+
+func (_c *MockExportServicer_ExportDecklist_Call) Return(responseErr *domain.ResponseErr) *MockExportServicer_ExportDecklist_Call {
+	_c.Call.Return(responseErr)
+	return _c
+}
+
+func (_c *MockExportServicer_ExportDecklist_Call) RunAndReturn(run func(collectionId string, format string, w io.Writer) *domain.ResponseErr) *MockExportServicer_ExportDecklist_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockGroupServicer creates a new instance of MockGroupServicer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockGroupServicer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockGroupServicer {
+	mock := &MockGroupServicer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockGroupServicer is an autogenerated mock type for the GroupServicer type
+type MockGroupServicer struct {
+	mock.Mock
+}
+
+type MockGroupServicer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockGroupServicer) EXPECT() *MockGroupServicer_Expecter {
+	return &MockGroupServicer_Expecter{mock: &_m.Mock}
+}
+
+// CreateGroup provides a mock function for the type MockGroupServicer
+func (_mock *MockGroupServicer) CreateGroup(userID string, name string) (*domain.Group, *domain.ResponseErr) {
+	ret := _mock.Called(userID, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateGroup")
+	}
+
+	var r0 *domain.Group
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(string, string) (*domain.Group, *domain.ResponseErr)); ok {
+		return returnFunc(userID, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string) *domain.Group); ok {
+		r0 = returnFunc(userID, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Group)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string) *domain.ResponseErr); ok {
+		r1 = returnFunc(userID, name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockGroupServicer_CreateGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateGroup'
+type MockGroupServicer_CreateGroup_Call struct {
+	*mock.Call
+}
+
+// CreateGroup is a helper method to define mock.On call
+//   - userID
+//   - name
+func (_e *MockGroupServicer_Expecter) CreateGroup(userID interface{}, name interface{}) *MockGroupServicer_CreateGroup_Call {
+	return &MockGroupServicer_CreateGroup_Call{Call: _e.mock.On("CreateGroup", userID, name)}
+}
+
+func (_c *MockGroupServicer_CreateGroup_Call) Run(run func(userID string, name string)) *MockGroupServicer_CreateGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockGroupServicer_CreateGroup_Call) Return(group *domain.Group, responseErr *domain.ResponseErr) *MockGroupServicer_CreateGroup_Call {
+	_c.Call.Return(group, responseErr)
+	return _c
+}
+
+func (_c *MockGroupServicer_CreateGroup_Call) RunAndReturn(run func(userID string, name string) (*domain.Group, *domain.ResponseErr)) *MockGroupServicer_CreateGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// JoinGroup provides a mock function for the type MockGroupServicer
+func (_mock *MockGroupServicer) JoinGroup(userID string, inviteCode string) (*domain.Group, *domain.ResponseErr) {
+	ret := _mock.Called(userID, inviteCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JoinGroup")
+	}
+
+	var r0 *domain.Group
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(string, string) (*domain.Group, *domain.ResponseErr)); ok {
+		return returnFunc(userID, inviteCode)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string) *domain.Group); ok {
+		r0 = returnFunc(userID, inviteCode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Group)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string) *domain.ResponseErr); ok {
+		r1 = returnFunc(userID, inviteCode)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockGroupServicer_JoinGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'JoinGroup'
+type MockGroupServicer_JoinGroup_Call struct {
+	*mock.Call
+}
+
+// JoinGroup is a helper method to define mock.On call
+//   - userID
+//   - inviteCode
+func (_e *MockGroupServicer_Expecter) JoinGroup(userID interface{}, inviteCode interface{}) *MockGroupServicer_JoinGroup_Call {
+	return &MockGroupServicer_JoinGroup_Call{Call: _e.mock.On("JoinGroup", userID, inviteCode)}
+}
+
+func (_c *MockGroupServicer_JoinGroup_Call) Run(run func(userID string, inviteCode string)) *MockGroupServicer_JoinGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockGroupServicer_JoinGroup_Call) Return(group *domain.Group, responseErr *domain.ResponseErr) *MockGroupServicer_JoinGroup_Call {
+	_c.Call.Return(group, responseErr)
+	return _c
+}
+
+func (_c *MockGroupServicer_JoinGroup_Call) RunAndReturn(run func(userID string, inviteCode string) (*domain.Group, *domain.ResponseErr)) *MockGroupServicer_JoinGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LeaveGroup provides a mock function for the type MockGroupServicer
+func (_mock *MockGroupServicer) LeaveGroup(userID string, groupID string) *domain.ResponseErr {
+	ret := _mock.Called(userID, groupID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LeaveGroup")
+	}
+
+	var r0 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(string, string) *domain.ResponseErr); ok {
+		r0 = returnFunc(userID, groupID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ResponseErr)
+		}
+	}
+	return r0
+}
+
+// MockGroupServicer_LeaveGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LeaveGroup'
+type MockGroupServicer_LeaveGroup_Call struct {
+	*mock.Call
+}
+
+// LeaveGroup is a helper method to define mock.On call
+//   - userID
+//   - groupID
+func (_e *MockGroupServicer_Expecter) LeaveGroup(userID interface{}, groupID interface{}) *MockGroupServicer_LeaveGroup_Call {
+	return &MockGroupServicer_LeaveGroup_Call{Call: _e.mock.On("LeaveGroup", userID, groupID)}
+}
+
+func (_c *MockGroupServicer_LeaveGroup_Call) Run(run func(userID string, groupID string)) *MockGroupServicer_LeaveGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockGroupServicer_LeaveGroup_Call) Return(responseErr *domain.ResponseErr) *MockGroupServicer_LeaveGroup_Call {
+	_c.Call.Return(responseErr)
+	return _c
+}
+
+func (_c *MockGroupServicer_LeaveGroup_Call) RunAndReturn(run func(userID string, groupID string) *domain.ResponseErr) *MockGroupServicer_LeaveGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListGroups provides a mock function for the type MockGroupServicer
+func (_mock *MockGroupServicer) ListGroups(userID string) ([]domain.Group, *domain.ResponseErr) {
+	ret := _mock.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListGroups")
+	}
+
+	var r0 []domain.Group
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(string) ([]domain.Group, *domain.ResponseErr)); ok {
+		return returnFunc(userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) []domain.Group); ok {
+		r0 = returnFunc(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Group)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) *domain.ResponseErr); ok {
+		r1 = returnFunc(userID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockGroupServicer_ListGroups_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListGroups'
+type MockGroupServicer_ListGroups_Call struct {
+	*mock.Call
+}
+
+// ListGroups is a helper method to define mock.On call
+//   - userID
+func (_e *MockGroupServicer_Expecter) ListGroups(userID interface{}) *MockGroupServicer_ListGroups_Call {
+	return &MockGroupServicer_ListGroups_Call{Call: _e.mock.On("ListGroups", userID)}
+}
+
+func (_c *MockGroupServicer_ListGroups_Call) Run(run func(userID string)) *MockGroupServicer_ListGroups_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockGroupServicer_ListGroups_Call) Return(groups []domain.Group, responseErr *domain.ResponseErr) *MockGroupServicer_ListGroups_Call {
+	_c.Call.Return(groups, responseErr)
+	return _c
+}
+
+func (_c *MockGroupServicer_ListGroups_Call) RunAndReturn(run func(userID string) ([]domain.Group, *domain.ResponseErr)) *MockGroupServicer_ListGroups_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockHistoryServicer creates a new instance of MockHistoryServicer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockHistoryServicer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockHistoryServicer {
+	mock := &MockHistoryServicer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockHistoryServicer is an autogenerated mock type for the HistoryServicer type
+type MockHistoryServicer struct {
+	mock.Mock
+}
+
+type MockHistoryServicer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockHistoryServicer) EXPECT() *MockHistoryServicer_Expecter {
+	return &MockHistoryServicer_Expecter{mock: &_m.Mock}
+}
+
+// History provides a mock function for the type MockHistoryServicer
+func (_mock *MockHistoryServicer) History(userId string, collectionId string, offset int, limit int) (*domain.ChangesPage, *domain.ResponseErr) {
+	ret := _mock.Called(userId, collectionId, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for History")
+	}
+
+	var r0 *domain.ChangesPage
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(string, string, int, int) (*domain.ChangesPage, *domain.ResponseErr)); ok {
+		return returnFunc(userId, collectionId, offset, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string, int, int) *domain.ChangesPage); ok {
+		r0 = returnFunc(userId, collectionId, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ChangesPage)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string, int, int) *domain.ResponseErr); ok {
+		r1 = returnFunc(userId, collectionId, offset, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockHistoryServicer_History_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'History'
+type MockHistoryServicer_History_Call struct {
+	*mock.Call
+}
+
+// History is a helper method to define mock.On call
+//   - userId
+//   - collectionId
+//   - offset
+//   - limit
+func (_e *MockHistoryServicer_Expecter) History(userId interface{}, collectionId interface{}, offset interface{}, limit interface{}) *MockHistoryServicer_History_Call {
+	return &MockHistoryServicer_History_Call{Call: _e.mock.On("History", userId, collectionId, offset, limit)}
+}
+
+func (_c *MockHistoryServicer_History_Call) Run(run func(userId string, collectionId string, offset int, limit int)) *MockHistoryServicer_History_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockHistoryServicer_History_Call) Return(changesPage *domain.ChangesPage, responseErr *domain.ResponseErr) *MockHistoryServicer_History_Call {
+	_c.Call.Return(changesPage, responseErr)
+	return _c
+}
+
+func (_c *MockHistoryServicer_History_Call) RunAndReturn(run func(userId string, collectionId string, offset int, limit int) (*domain.ChangesPage, *domain.ResponseErr)) *MockHistoryServicer_History_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Undo provides a mock function for the type MockHistoryServicer
+func (_mock *MockHistoryServicer) Undo(actor domain.Actor, collectionId string, changeId string) (*domain.CollectionChange, *domain.ResponseErr) {
+	ret := _mock.Called(actor, collectionId, changeId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Undo")
+	}
+
+	var r0 *domain.CollectionChange
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(domain.Actor, string, string) (*domain.CollectionChange, *domain.ResponseErr)); ok {
+		return returnFunc(actor, collectionId, changeId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(domain.Actor, string, string) *domain.CollectionChange); ok {
+		r0 = returnFunc(actor, collectionId, changeId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CollectionChange)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(domain.Actor, string, string) *domain.ResponseErr); ok {
+		r1 = returnFunc(actor, collectionId, changeId)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockHistoryServicer_Undo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Undo'
+type MockHistoryServicer_Undo_Call struct {
+	*mock.Call
+}
+
+// Undo is a helper method to define mock.On call
+//   - actor
+//   - collectionId
+//   - changeId
+func (_e *MockHistoryServicer_Expecter) Undo(actor interface{}, collectionId interface{}, changeId interface{}) *MockHistoryServicer_Undo_Call {
+	return &MockHistoryServicer_Undo_Call{Call: _e.mock.On("Undo", actor, collectionId, changeId)}
+}
+
+func (_c *MockHistoryServicer_Undo_Call) Run(run func(actor domain.Actor, collectionId string, changeId string)) *MockHistoryServicer_Undo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.Actor), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockHistoryServicer_Undo_Call) Return(collectionChange *domain.CollectionChange, responseErr *domain.ResponseErr) *MockHistoryServicer_Undo_Call {
+	_c.Call.Return(collectionChange, responseErr)
+	return _c
+}
+
+func (_c *MockHistoryServicer_Undo_Call) RunAndReturn(run func(actor domain.Actor, collectionId string, changeId string) (*domain.CollectionChange, *domain.ResponseErr)) *MockHistoryServicer_Undo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UndoLast provides a mock function for the type MockHistoryServicer
+func (_mock *MockHistoryServicer) UndoLast(actor domain.Actor, collectionId string, count int) (*domain.CollectionChange, *domain.ResponseErr) {
+	ret := _mock.Called(actor, collectionId, count)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UndoLast")
+	}
+
+	var r0 *domain.CollectionChange
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(domain.Actor, string, int) (*domain.CollectionChange, *domain.ResponseErr)); ok {
+		return returnFunc(actor, collectionId, count)
+	}
+	if returnFunc, ok := ret.Get(0).(func(domain.Actor, string, int) *domain.CollectionChange); ok {
+		r0 = returnFunc(actor, collectionId, count)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CollectionChange)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(domain.Actor, string, int) *domain.ResponseErr); ok {
+		r1 = returnFunc(actor, collectionId, count)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockHistoryServicer_UndoLast_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UndoLast'
+type MockHistoryServicer_UndoLast_Call struct {
+	*mock.Call
+}
+
+// UndoLast is a helper method to define mock.On call
+//   - actor
+//   - collectionId
+//   - count
+func (_e *MockHistoryServicer_Expecter) UndoLast(actor interface{}, collectionId interface{}, count interface{}) *MockHistoryServicer_UndoLast_Call {
+	return &MockHistoryServicer_UndoLast_Call{Call: _e.mock.On("UndoLast", actor, collectionId, count)}
+}
+
+func (_c *MockHistoryServicer_UndoLast_Call) Run(run func(actor domain.Actor, collectionId string, count int)) *MockHistoryServicer_UndoLast_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.Actor), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockHistoryServicer_UndoLast_Call) Return(collectionChange *domain.CollectionChange, responseErr *domain.ResponseErr) *MockHistoryServicer_UndoLast_Call {
+	_c.Call.Return(collectionChange, responseErr)
+	return _c
+}
+
+func (_c *MockHistoryServicer_UndoLast_Call) RunAndReturn(run func(actor domain.Actor, collectionId string, count int) (*domain.CollectionChange, *domain.ResponseErr)) *MockHistoryServicer_UndoLast_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockImportServicer creates a new instance of MockImportServicer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockImportServicer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockImportServicer {
+	mock := &MockImportServicer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockImportServicer is an autogenerated mock type for the ImportServicer type
+type MockImportServicer struct {
+	mock.Mock
+}
+
+type MockImportServicer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockImportServicer) EXPECT() *MockImportServicer_Expecter {
+	return &MockImportServicer_Expecter{mock: &_m.Mock}
+}
+
+// ImportCSV provides a mock function for the type MockImportServicer
+func (_mock *MockImportServicer) ImportCSV(actor domain.Actor, collectionId string, r io.Reader, format string, dryRun bool) (*domain.CardImport, *domain.ResponseErr) {
+	ret := _mock.Called(actor, collectionId, r, format, dryRun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportCSV")
+	}
+
+	var r0 *domain.CardImport
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(domain.Actor, string, io.Reader, string, bool) (*domain.CardImport, *domain.ResponseErr)); ok {
+		return returnFunc(actor, collectionId, r, format, dryRun)
+	}
+	if returnFunc, ok := ret.Get(0).(func(domain.Actor, string, io.Reader, string, bool) *domain.CardImport); ok {
+		r0 = returnFunc(actor, collectionId, r, format, dryRun)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CardImport)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(domain.Actor, string, io.Reader, string, bool) *domain.ResponseErr); ok {
+		r1 = returnFunc(actor, collectionId, r, format, dryRun)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockImportServicer_ImportCSV_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportCSV'
+type MockImportServicer_ImportCSV_Call struct {
+	*mock.Call
+}
+
+// ImportCSV is a helper method to define mock.On call
+//   - actor
+//   - collectionId
+//   - r
+//   - format
+//   - dryRun
+func (_e *MockImportServicer_Expecter) ImportCSV(actor interface{}, collectionId interface{}, r interface{}, format interface{}, dryRun interface{}) *MockImportServicer_ImportCSV_Call {
+	return &MockImportServicer_ImportCSV_Call{Call: _e.mock.On("ImportCSV", actor, collectionId, r, format, dryRun)}
+}
+
+func (_c *MockImportServicer_ImportCSV_Call) Run(run func(actor domain.Actor, collectionId string, r io.Reader, format string, dryRun bool)) *MockImportServicer_ImportCSV_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.Actor), args[1].(string), args[2].(io.Reader), args[3].(string), args[4].(bool))
+	})
+	return _c
+}
+
+func (_c *MockImportServicer_ImportCSV_Call) Return(cardImport *domain.CardImport, responseErr *domain.ResponseErr) *MockImportServicer_ImportCSV_Call {
+	_c.Call.Return(cardImport, responseErr)
+	return _c
+}
+
+func (_c *MockImportServicer_ImportCSV_Call) RunAndReturn(run func(actor domain.Actor, collectionId string, r io.Reader, format string, dryRun bool) (*domain.CardImport, *domain.ResponseErr)) *MockImportServicer_ImportCSV_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportDecklist provides a mock function for the type MockImportServicer
+func (_mock *MockImportServicer) ImportDecklist(actor domain.Actor, collectionId string, text string, dryRun bool) (*domain.CardImport, *domain.ResponseErr) {
+	ret := _mock.Called(actor, collectionId, text, dryRun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportDecklist")
+	}
+
+	var r0 *domain.CardImport
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(domain.Actor, string, string, bool) (*domain.CardImport, *domain.ResponseErr)); ok {
+		return returnFunc(actor, collectionId, text, dryRun)
+	}
+	if returnFunc, ok := ret.Get(0).(func(domain.Actor, string, string, bool) *domain.CardImport); ok {
+		r0 = returnFunc(actor, collectionId, text, dryRun)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CardImport)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(domain.Actor, string, string, bool) *domain.ResponseErr); ok {
+		r1 = returnFunc(actor, collectionId, text, dryRun)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockImportServicer_ImportDecklist_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportDecklist'
+type MockImportServicer_ImportDecklist_Call struct {
+	*mock.Call
+}
+
+// ImportDecklist is a helper method to define mock.On call
+//   - actor
+//   - collectionId
+//   - text
+//   - dryRun
+func (_e *MockImportServicer_Expecter) ImportDecklist(actor interface{}, collectionId interface{}, text interface{}, dryRun interface{}) *MockImportServicer_ImportDecklist_Call {
+	return &MockImportServicer_ImportDecklist_Call{Call: _e.mock.On("ImportDecklist", actor, collectionId, text, dryRun)}
+}
+
+func (_c *MockImportServicer_ImportDecklist_Call) Run(run func(actor domain.Actor, collectionId string, text string, dryRun bool)) *MockImportServicer_ImportDecklist_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.Actor), args[1].(string), args[2].(string), args[3].(bool))
+	})
+	return _c
+}
+
+func (_c *MockImportServicer_ImportDecklist_Call) Return(cardImport *domain.CardImport, responseErr *domain.ResponseErr) *MockImportServicer_ImportDecklist_Call {
+	_c.Call.Return(cardImport, responseErr)
+	return _c
+}
+
+func (_c *MockImportServicer_ImportDecklist_Call) RunAndReturn(run func(actor domain.Actor, collectionId string, text string, dryRun bool) (*domain.CardImport, *domain.ResponseErr)) *MockImportServicer_ImportDecklist_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSearchServicer creates a new instance of MockSearchServicer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSearchServicer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSearchServicer {
+	mock := &MockSearchServicer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSearchServicer is an autogenerated mock type for the SearchServicer type
+type MockSearchServicer struct {
+	mock.Mock
+}
+
+type MockSearchServicer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSearchServicer) EXPECT() *MockSearchServicer_Expecter {
+	return &MockSearchServicer_Expecter{mock: &_m.Mock}
+}
+
+// SearchCards provides a mock function for the type MockSearchServicer
+func (_mock *MockSearchServicer) SearchCards(userID string, query *domain.CardSearchQuery) (*domain.CardSearchResult, *domain.ResponseErr) {
+	ret := _mock.Called(userID, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchCards")
+	}
+
+	var r0 *domain.CardSearchResult
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(string, *domain.CardSearchQuery) (*domain.CardSearchResult, *domain.ResponseErr)); ok {
+		return returnFunc(userID, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, *domain.CardSearchQuery) *domain.CardSearchResult); ok {
+		r0 = returnFunc(userID, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CardSearchResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, *domain.CardSearchQuery) *domain.ResponseErr); ok {
+		r1 = returnFunc(userID, query)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockSearchServicer_SearchCards_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchCards'
+type MockSearchServicer_SearchCards_Call struct {
+	*mock.Call
+}
+
+// SearchCards is a helper method to define mock.On call
+//   - userID
+//   - query
+func (_e *MockSearchServicer_Expecter) SearchCards(userID interface{}, query interface{}) *MockSearchServicer_SearchCards_Call {
+	return &MockSearchServicer_SearchCards_Call{Call: _e.mock.On("SearchCards", userID, query)}
+}
+
+func (_c *MockSearchServicer_SearchCards_Call) Run(run func(userID string, query *domain.CardSearchQuery)) *MockSearchServicer_SearchCards_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(*domain.CardSearchQuery))
+	})
+	return _c
+}
+
+func (_c *MockSearchServicer_SearchCards_Call) Return(cardSearchResult *domain.CardSearchResult, responseErr *domain.ResponseErr) *MockSearchServicer_SearchCards_Call {
+	_c.Call.Return(cardSearchResult, responseErr)
+	return _c
+}
+
+func (_c *MockSearchServicer_SearchCards_Call) RunAndReturn(run func(userID string, query *domain.CardSearchQuery) (*domain.CardSearchResult, *domain.ResponseErr)) *MockSearchServicer_SearchCards_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSnapshotServicer creates a new instance of MockSnapshotServicer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSnapshotServicer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSnapshotServicer {
+	mock := &MockSnapshotServicer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSnapshotServicer is an autogenerated mock type for the SnapshotServicer type
+type MockSnapshotServicer struct {
+	mock.Mock
+}
+
+type MockSnapshotServicer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSnapshotServicer) EXPECT() *MockSnapshotServicer_Expecter {
+	return &MockSnapshotServicer_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockSnapshotServicer
+func (_mock *MockSnapshotServicer) Create(userId string, collectionId string, label string) (*domain.Snapshot, *domain.ResponseErr) {
+	ret := _mock.Called(userId, collectionId, label)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *domain.Snapshot
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) (*domain.Snapshot, *domain.ResponseErr)); ok {
+		return returnFunc(userId, collectionId, label)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) *domain.Snapshot); ok {
+		r0 = returnFunc(userId, collectionId, label)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Snapshot)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string, string) *domain.ResponseErr); ok {
+		r1 = returnFunc(userId, collectionId, label)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockSnapshotServicer_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockSnapshotServicer_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - userId
+//   - collectionId
+//   - label
+func (_e *MockSnapshotServicer_Expecter) Create(userId interface{}, collectionId interface{}, label interface{}) *MockSnapshotServicer_Create_Call {
+	return &MockSnapshotServicer_Create_Call{Call: _e.mock.On("Create", userId, collectionId, label)}
+}
+
+func (_c *MockSnapshotServicer_Create_Call) Run(run func(userId string, collectionId string, label string)) *MockSnapshotServicer_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSnapshotServicer_Create_Call) Return(snapshot *domain.Snapshot, responseErr *domain.ResponseErr) *MockSnapshotServicer_Create_Call {
+	_c.Call.Return(snapshot, responseErr)
+	return _c
+}
+
+func (_c *MockSnapshotServicer_Create_Call) RunAndReturn(run func(userId string, collectionId string, label string) (*domain.Snapshot, *domain.ResponseErr)) *MockSnapshotServicer_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockSnapshotServicer
+func (_mock *MockSnapshotServicer) Delete(userId string, collectionId string, snapshotId string) *domain.ResponseErr {
+	ret := _mock.Called(userId, collectionId, snapshotId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) *domain.ResponseErr); ok {
+		r0 = returnFunc(userId, collectionId, snapshotId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ResponseErr)
+		}
+	}
+	return r0
+}
+
+// MockSnapshotServicer_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockSnapshotServicer_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - userId
+//   - collectionId
+//   - snapshotId
+func (_e *MockSnapshotServicer_Expecter) Delete(userId interface{}, collectionId interface{}, snapshotId interface{}) *MockSnapshotServicer_Delete_Call {
+	return &MockSnapshotServicer_Delete_Call{Call: _e.mock.On("Delete", userId, collectionId, snapshotId)}
+}
+
+func (_c *MockSnapshotServicer_Delete_Call) Run(run func(userId string, collectionId string, snapshotId string)) *MockSnapshotServicer_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSnapshotServicer_Delete_Call) Return(responseErr *domain.ResponseErr) *MockSnapshotServicer_Delete_Call {
+	_c.Call.Return(responseErr)
+	return _c
+}
+
+func (_c *MockSnapshotServicer_Delete_Call) RunAndReturn(run func(userId string, collectionId string, snapshotId string) *domain.ResponseErr) *MockSnapshotServicer_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Diff provides a mock function for the type MockSnapshotServicer
+func (_mock *MockSnapshotServicer) Diff(userId string, collectionId string, snapshotId string, toSnapshotId string) (*domain.SnapshotDiff, *domain.ResponseErr) {
+	ret := _mock.Called(userId, collectionId, snapshotId, toSnapshotId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Diff")
+	}
+
+	var r0 *domain.SnapshotDiff
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(string, string, string, string) (*domain.SnapshotDiff, *domain.ResponseErr)); ok {
+		return returnFunc(userId, collectionId, snapshotId, toSnapshotId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string, string, string) *domain.SnapshotDiff); ok {
+		r0 = returnFunc(userId, collectionId, snapshotId, toSnapshotId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.SnapshotDiff)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string, string, string) *domain.ResponseErr); ok {
+		r1 = returnFunc(userId, collectionId, snapshotId, toSnapshotId)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockSnapshotServicer_Diff_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Diff'
+type MockSnapshotServicer_Diff_Call struct {
+	*mock.Call
+}
+
+// Diff is a helper method to define mock.On call
+//   - userId
+//   - collectionId
+//   - snapshotId
+//   - toSnapshotId
+func (_e *MockSnapshotServicer_Expecter) Diff(userId interface{}, collectionId interface{}, snapshotId interface{}, toSnapshotId interface{}) *MockSnapshotServicer_Diff_Call {
+	return &MockSnapshotServicer_Diff_Call{Call: _e.mock.On("Diff", userId, collectionId, snapshotId, toSnapshotId)}
+}
+
+func (_c *MockSnapshotServicer_Diff_Call) Run(run func(userId string, collectionId string, snapshotId string, toSnapshotId string)) *MockSnapshotServicer_Diff_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockSnapshotServicer_Diff_Call) Return(snapshotDiff *domain.SnapshotDiff, responseErr *domain.ResponseErr) *MockSnapshotServicer_Diff_Call {
+	_c.Call.Return(snapshotDiff, responseErr)
+	return _c
+}
+
+func (_c *MockSnapshotServicer_Diff_Call) RunAndReturn(run func(userId string, collectionId string, snapshotId string, toSnapshotId string) (*domain.SnapshotDiff, *domain.ResponseErr)) *MockSnapshotServicer_Diff_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockSnapshotServicer
+func (_mock *MockSnapshotServicer) Get(userId string, collectionId string, snapshotId string) (*domain.Snapshot, *domain.ResponseErr) {
+	ret := _mock.Called(userId, collectionId, snapshotId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *domain.Snapshot
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) (*domain.Snapshot, *domain.ResponseErr)); ok {
+		return returnFunc(userId, collectionId, snapshotId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) *domain.Snapshot); ok {
+		r0 = returnFunc(userId, collectionId, snapshotId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Snapshot)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string, string) *domain.ResponseErr); ok {
+		r1 = returnFunc(userId, collectionId, snapshotId)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockSnapshotServicer_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockSnapshotServicer_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - userId
+//   - collectionId
+//   - snapshotId
+func (_e *MockSnapshotServicer_Expecter) Get(userId interface{}, collectionId interface{}, snapshotId interface{}) *MockSnapshotServicer_Get_Call {
+	return &MockSnapshotServicer_Get_Call{Call: _e.mock.On("Get", userId, collectionId, snapshotId)}
+}
+
+func (_c *MockSnapshotServicer_Get_Call) Run(run func(userId string, collectionId string, snapshotId string)) *MockSnapshotServicer_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSnapshotServicer_Get_Call) Return(snapshot *domain.Snapshot, responseErr *domain.ResponseErr) *MockSnapshotServicer_Get_Call {
+	_c.Call.Return(snapshot, responseErr)
+	return _c
+}
+
+func (_c *MockSnapshotServicer_Get_Call) RunAndReturn(run func(userId string, collectionId string, snapshotId string) (*domain.Snapshot, *domain.ResponseErr)) *MockSnapshotServicer_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockSnapshotServicer
+func (_mock *MockSnapshotServicer) List(userId string, collectionId string) ([]domain.Snapshot, *domain.ResponseErr) {
+	ret := _mock.Called(userId, collectionId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []domain.Snapshot
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(string, string) ([]domain.Snapshot, *domain.ResponseErr)); ok {
+		return returnFunc(userId, collectionId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string) []domain.Snapshot); ok {
+		r0 = returnFunc(userId, collectionId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Snapshot)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string) *domain.ResponseErr); ok {
+		r1 = returnFunc(userId, collectionId)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockSnapshotServicer_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockSnapshotServicer_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - userId
+//   - collectionId
+func (_e *MockSnapshotServicer_Expecter) List(userId interface{}, collectionId interface{}) *MockSnapshotServicer_List_Call {
+	return &MockSnapshotServicer_List_Call{Call: _e.mock.On("List", userId, collectionId)}
+}
+
+func (_c *MockSnapshotServicer_List_Call) Run(run func(userId string, collectionId string)) *MockSnapshotServicer_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSnapshotServicer_List_Call) Return(snapshots []domain.Snapshot, responseErr *domain.ResponseErr) *MockSnapshotServicer_List_Call {
+	_c.Call.Return(snapshots, responseErr)
+	return _c
+}
+
+func (_c *MockSnapshotServicer_List_Call) RunAndReturn(run func(userId string, collectionId string) ([]domain.Snapshot, *domain.ResponseErr)) *MockSnapshotServicer_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function for the type MockSnapshotServicer
+func (_mock *MockSnapshotServicer) Restore(actor domain.Actor, collectionId string, snapshotId string) *domain.ResponseErr {
+	ret := _mock.Called(actor, collectionId, snapshotId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(domain.Actor, string, string) *domain.ResponseErr); ok {
+		r0 = returnFunc(actor, collectionId, snapshotId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ResponseErr)
+		}
+	}
+	return r0
+}
+
+// MockSnapshotServicer_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type MockSnapshotServicer_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - actor
+//   - collectionId
+//   - snapshotId
+func (_e *MockSnapshotServicer_Expecter) Restore(actor interface{}, collectionId interface{}, snapshotId interface{}) *MockSnapshotServicer_Restore_Call {
+	return &MockSnapshotServicer_Restore_Call{Call: _e.mock.On("Restore", actor, collectionId, snapshotId)}
+}
+
+func (_c *MockSnapshotServicer_Restore_Call) Run(run func(actor domain.Actor, collectionId string, snapshotId string)) *MockSnapshotServicer_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.Actor), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSnapshotServicer_Restore_Call) Return(responseErr *domain.ResponseErr) *MockSnapshotServicer_Restore_Call {
+	_c.Call.Return(responseErr)
+	return _c
+}
+
+func (_c *MockSnapshotServicer_Restore_Call) RunAndReturn(run func(actor domain.Actor, collectionId string, snapshotId string) *domain.ResponseErr) *MockSnapshotServicer_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockStatsServicer creates a new instance of MockStatsServicer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStatsServicer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStatsServicer {
+	mock := &MockStatsServicer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockStatsServicer is an autogenerated mock type for the StatsServicer type
+type MockStatsServicer struct {
+	mock.Mock
+}
+
+type MockStatsServicer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStatsServicer) EXPECT() *MockStatsServicer_Expecter {
+	return &MockStatsServicer_Expecter{mock: &_m.Mock}
+}
+
+// CollectionStats provides a mock function for the type MockStatsServicer
+func (_mock *MockStatsServicer) CollectionStats(collectionId string) (*domain.CollectionStats, *domain.ResponseErr) {
+	ret := _mock.Called(collectionId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CollectionStats")
+	}
+
+	var r0 *domain.CollectionStats
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(string) (*domain.CollectionStats, *domain.ResponseErr)); ok {
+		return returnFunc(collectionId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) *domain.CollectionStats); ok {
+		r0 = returnFunc(collectionId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CollectionStats)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) *domain.ResponseErr); ok {
+		r1 = returnFunc(collectionId)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockStatsServicer_CollectionStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CollectionStats'
+type MockStatsServicer_CollectionStats_Call struct {
+	*mock.Call
+}
+
+// CollectionStats is a helper method to define mock.On call
+//   - collectionId
+func (_e *MockStatsServicer_Expecter) CollectionStats(collectionId interface{}) *MockStatsServicer_CollectionStats_Call {
+	return &MockStatsServicer_CollectionStats_Call{Call: _e.mock.On("CollectionStats", collectionId)}
+}
+
+func (_c *MockStatsServicer_CollectionStats_Call) Run(run func(collectionId string)) *MockStatsServicer_CollectionStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockStatsServicer_CollectionStats_Call) Return(collectionStats *domain.CollectionStats, responseErr *domain.ResponseErr) *MockStatsServicer_CollectionStats_Call {
+	_c.Call.Return(collectionStats, responseErr)
+	return _c
+}
+
+func (_c *MockStatsServicer_CollectionStats_Call) RunAndReturn(run func(collectionId string) (*domain.CollectionStats, *domain.ResponseErr)) *MockStatsServicer_CollectionStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UserStats provides a mock function for the type MockStatsServicer
+func (_mock *MockStatsServicer) UserStats(userID string) (*domain.CollectionStats, *domain.ResponseErr) {
+	ret := _mock.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserStats")
+	}
+
+	var r0 *domain.CollectionStats
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(string) (*domain.CollectionStats, *domain.ResponseErr)); ok {
+		return returnFunc(userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) *domain.CollectionStats); ok {
+		r0 = returnFunc(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CollectionStats)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) *domain.ResponseErr); ok {
+		r1 = returnFunc(userID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockStatsServicer_UserStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserStats'
+type MockStatsServicer_UserStats_Call struct {
+	*mock.Call
+}
+
+// UserStats is a helper method to define mock.On call
+//   - userID
+func (_e *MockStatsServicer_Expecter) UserStats(userID interface{}) *MockStatsServicer_UserStats_Call {
+	return &MockStatsServicer_UserStats_Call{Call: _e.mock.On("UserStats", userID)}
+}
+
+func (_c *MockStatsServicer_UserStats_Call) Run(run func(userID string)) *MockStatsServicer_UserStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockStatsServicer_UserStats_Call) Return(collectionStats *domain.CollectionStats, responseErr *domain.ResponseErr) *MockStatsServicer_UserStats_Call {
+	_c.Call.Return(collectionStats, responseErr)
+	return _c
+}
+
+func (_c *MockStatsServicer_UserStats_Call) RunAndReturn(run func(userID string) (*domain.CollectionStats, *domain.ResponseErr)) *MockStatsServicer_UserStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTradeMatchServicer creates a new instance of MockTradeMatchServicer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTradeMatchServicer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTradeMatchServicer {
+	mock := &MockTradeMatchServicer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTradeMatchServicer is an autogenerated mock type for the TradeMatchServicer type
+type MockTradeMatchServicer struct {
+	mock.Mock
+}
+
+type MockTradeMatchServicer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTradeMatchServicer) EXPECT() *MockTradeMatchServicer_Expecter {
+	return &MockTradeMatchServicer_Expecter{mock: &_m.Mock}
+}
+
+// ListMatches provides a mock function for the type MockTradeMatchServicer
+func (_mock *MockTradeMatchServicer) ListMatches(userID string, offset int, limit int) (*domain.TradeMatchesPage, *domain.ResponseErr) {
+	ret := _mock.Called(userID, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListMatches")
+	}
+
+	var r0 *domain.TradeMatchesPage
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(string, int, int) (*domain.TradeMatchesPage, *domain.ResponseErr)); ok {
+		return returnFunc(userID, offset, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, int, int) *domain.TradeMatchesPage); ok {
+		r0 = returnFunc(userID, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TradeMatchesPage)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, int, int) *domain.ResponseErr); ok {
+		r1 = returnFunc(userID, offset, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockTradeMatchServicer_ListMatches_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListMatches'
+type MockTradeMatchServicer_ListMatches_Call struct {
+	*mock.Call
+}
+
+// ListMatches is a helper method to define mock.On call
+//   - userID
+//   - offset
+//   - limit
+func (_e *MockTradeMatchServicer_Expecter) ListMatches(userID interface{}, offset interface{}, limit interface{}) *MockTradeMatchServicer_ListMatches_Call {
+	return &MockTradeMatchServicer_ListMatches_Call{Call: _e.mock.On("ListMatches", userID, offset, limit)}
+}
+
+func (_c *MockTradeMatchServicer_ListMatches_Call) Run(run func(userID string, offset int, limit int)) *MockTradeMatchServicer_ListMatches_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockTradeMatchServicer_ListMatches_Call) Return(tradeMatchesPage *domain.TradeMatchesPage, responseErr *domain.ResponseErr) *MockTradeMatchServicer_ListMatches_Call {
+	_c.Call.Return(tradeMatchesPage, responseErr)
+	return _c
+}
+
+func (_c *MockTradeMatchServicer_ListMatches_Call) RunAndReturn(run func(userID string, offset int, limit int) (*domain.TradeMatchesPage, *domain.ResponseErr)) *MockTradeMatchServicer_ListMatches_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTrashServicer creates a new instance of MockTrashServicer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTrashServicer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTrashServicer {
+	mock := &MockTrashServicer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTrashServicer is an autogenerated mock type for the TrashServicer type
+type MockTrashServicer struct {
+	mock.Mock
+}
+
+type MockTrashServicer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTrashServicer) EXPECT() *MockTrashServicer_Expecter {
+	return &MockTrashServicer_Expecter{mock: &_m.Mock}
+}
+
+// RestoreCard provides a mock function for the type MockTrashServicer
+func (_mock *MockTrashServicer) RestoreCard(actor domain.Actor, collectionId string, entryId string) (*domain.Card, *domain.ResponseErr) {
+	ret := _mock.Called(actor, collectionId, entryId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreCard")
+	}
+
+	var r0 *domain.Card
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(domain.Actor, string, string) (*domain.Card, *domain.ResponseErr)); ok {
+		return returnFunc(actor, collectionId, entryId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(domain.Actor, string, string) *domain.Card); ok {
+		r0 = returnFunc(actor, collectionId, entryId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Card)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(domain.Actor, string, string) *domain.ResponseErr); ok {
+		r1 = returnFunc(actor, collectionId, entryId)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockTrashServicer_RestoreCard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreCard'
+type MockTrashServicer_RestoreCard_Call struct {
+	*mock.Call
+}
+
+// RestoreCard is a helper method to define mock.On call
+//   - actor
+//   - collectionId
+//   - entryId
+func (_e *MockTrashServicer_Expecter) RestoreCard(actor interface{}, collectionId interface{}, entryId interface{}) *MockTrashServicer_RestoreCard_Call {
+	return &MockTrashServicer_RestoreCard_Call{Call: _e.mock.On("RestoreCard", actor, collectionId, entryId)}
+}
+
+func (_c *MockTrashServicer_RestoreCard_Call) Run(run func(actor domain.Actor, collectionId string, entryId string)) *MockTrashServicer_RestoreCard_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.Actor), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockTrashServicer_RestoreCard_Call) Return(card *domain.Card, responseErr *domain.ResponseErr) *MockTrashServicer_RestoreCard_Call {
+	_c.Call.Return(card, responseErr)
+	return _c
+}
+
+func (_c *MockTrashServicer_RestoreCard_Call) RunAndReturn(run func(actor domain.Actor, collectionId string, entryId string) (*domain.Card, *domain.ResponseErr)) *MockTrashServicer_RestoreCard_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreCollection provides a mock function for the type MockTrashServicer
+func (_mock *MockTrashServicer) RestoreCollection(userID string, collectionID string) (*domain.Collection, *domain.ResponseErr) {
+	ret := _mock.Called(userID, collectionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreCollection")
+	}
+
+	var r0 *domain.Collection
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(string, string) (*domain.Collection, *domain.ResponseErr)); ok {
+		return returnFunc(userID, collectionID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string) *domain.Collection); ok {
+		r0 = returnFunc(userID, collectionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Collection)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string) *domain.ResponseErr); ok {
+		r1 = returnFunc(userID, collectionID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockTrashServicer_RestoreCollection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreCollection'
+type MockTrashServicer_RestoreCollection_Call struct {
+	*mock.Call
+}
+
+// RestoreCollection is a helper method to define mock.On call
+//   - userID
+//   - collectionID
+func (_e *MockTrashServicer_Expecter) RestoreCollection(userID interface{}, collectionID interface{}) *MockTrashServicer_RestoreCollection_Call {
+	return &MockTrashServicer_RestoreCollection_Call{Call: _e.mock.On("RestoreCollection", userID, collectionID)}
+}
+
+func (_c *MockTrashServicer_RestoreCollection_Call) Run(run func(userID string, collectionID string)) *MockTrashServicer_RestoreCollection_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockTrashServicer_RestoreCollection_Call) Return(collection *domain.Collection, responseErr *domain.ResponseErr) *MockTrashServicer_RestoreCollection_Call {
+	_c.Call.Return(collection, responseErr)
+	return _c
+}
+
+func (_c *MockTrashServicer_RestoreCollection_Call) RunAndReturn(run func(userID string, collectionID string) (*domain.Collection, *domain.ResponseErr)) *MockTrashServicer_RestoreCollection_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Trash provides a mock function for the type MockTrashServicer
+func (_mock *MockTrashServicer) Trash(userID string) (*domain.Trash, *domain.ResponseErr) {
+	ret := _mock.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Trash")
+	}
+
+	var r0 *domain.Trash
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(string) (*domain.Trash, *domain.ResponseErr)); ok {
+		return returnFunc(userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) *domain.Trash); ok {
+		r0 = returnFunc(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Trash)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) *domain.ResponseErr); ok {
+		r1 = returnFunc(userID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockTrashServicer_Trash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Trash'
+type MockTrashServicer_Trash_Call struct {
+	*mock.Call
+}
+
+// Trash is a helper method to define mock.On call
+//   - userID
+func (_e *MockTrashServicer_Expecter) Trash(userID interface{}) *MockTrashServicer_Trash_Call {
+	return &MockTrashServicer_Trash_Call{Call: _e.mock.On("Trash", userID)}
+}
+
+func (_c *MockTrashServicer_Trash_Call) Run(run func(userID string)) *MockTrashServicer_Trash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockTrashServicer_Trash_Call) Return(trash *domain.Trash, responseErr *domain.ResponseErr) *MockTrashServicer_Trash_Call {
+	_c.Call.Return(trash, responseErr)
+	return _c
+}
+
+func (_c *MockTrashServicer_Trash_Call) RunAndReturn(run func(userID string) (*domain.Trash, *domain.ResponseErr)) *MockTrashServicer_Trash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockValuationServicer creates a new instance of MockValuationServicer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockValuationServicer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockValuationServicer {
+	mock := &MockValuationServicer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockValuationServicer is an autogenerated mock type for the ValuationServicer type
+type MockValuationServicer struct {
+	mock.Mock
+}
+
+type MockValuationServicer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockValuationServicer) EXPECT() *MockValuationServicer_Expecter {
+	return &MockValuationServicer_Expecter{mock: &_m.Mock}
+}
+
+// CollectionHistory provides a mock function for the type MockValuationServicer
+func (_mock *MockValuationServicer) CollectionHistory(collectionId string, from time.Time, to time.Time) ([]domain.ValuePoint, *domain.ResponseErr) {
+	ret := _mock.Called(collectionId, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CollectionHistory")
+	}
+
+	var r0 []domain.ValuePoint
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(string, time.Time, time.Time) ([]domain.ValuePoint, *domain.ResponseErr)); ok {
+		return returnFunc(collectionId, from, to)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, time.Time, time.Time) []domain.ValuePoint); ok {
+		r0 = returnFunc(collectionId, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ValuePoint)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, time.Time, time.Time) *domain.ResponseErr); ok {
+		r1 = returnFunc(collectionId, from, to)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockValuationServicer_CollectionHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CollectionHistory'
+type MockValuationServicer_CollectionHistory_Call struct {
+	*mock.Call
+}
+
+// CollectionHistory is a helper method to define mock.On call
+//   - collectionId
+//   - from
+//   - to
+func (_e *MockValuationServicer_Expecter) CollectionHistory(collectionId interface{}, from interface{}, to interface{}) *MockValuationServicer_CollectionHistory_Call {
+	return &MockValuationServicer_CollectionHistory_Call{Call: _e.mock.On("CollectionHistory", collectionId, from, to)}
+}
+
+func (_c *MockValuationServicer_CollectionHistory_Call) Run(run func(collectionId string, from time.Time, to time.Time)) *MockValuationServicer_CollectionHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Time), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockValuationServicer_CollectionHistory_Call) Return(valuePoints []domain.ValuePoint, responseErr *domain.ResponseErr) *MockValuationServicer_CollectionHistory_Call {
+	_c.Call.Return(valuePoints, responseErr)
+	return _c
+}
+
+func (_c *MockValuationServicer_CollectionHistory_Call) RunAndReturn(run func(collectionId string, from time.Time, to time.Time) ([]domain.ValuePoint, *domain.ResponseErr)) *MockValuationServicer_CollectionHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UserHistory provides a mock function for the type MockValuationServicer
+func (_mock *MockValuationServicer) UserHistory(userId string, from time.Time, to time.Time) ([]domain.ValuePoint, *domain.ResponseErr) {
+	ret := _mock.Called(userId, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserHistory")
+	}
+
+	var r0 []domain.ValuePoint
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(string, time.Time, time.Time) ([]domain.ValuePoint, *domain.ResponseErr)); ok {
+		return returnFunc(userId, from, to)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, time.Time, time.Time) []domain.ValuePoint); ok {
+		r0 = returnFunc(userId, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ValuePoint)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, time.Time, time.Time) *domain.ResponseErr); ok {
+		r1 = returnFunc(userId, from, to)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockValuationServicer_UserHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserHistory'
+type MockValuationServicer_UserHistory_Call struct {
+	*mock.Call
+}
+
+// UserHistory is a helper method to define mock.On call
+//   - userId
+//   - from
+//   - to
+func (_e *MockValuationServicer_Expecter) UserHistory(userId interface{}, from interface{}, to interface{}) *MockValuationServicer_UserHistory_Call {
+	return &MockValuationServicer_UserHistory_Call{Call: _e.mock.On("UserHistory", userId, from, to)}
+}
+
+func (_c *MockValuationServicer_UserHistory_Call) Run(run func(userId string, from time.Time, to time.Time)) *MockValuationServicer_UserHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Time), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockValuationServicer_UserHistory_Call) Return(valuePoints []domain.ValuePoint, responseErr *domain.ResponseErr) *MockValuationServicer_UserHistory_Call {
+	_c.Call.Return(valuePoints, responseErr)
+	return _c
+}
+
+func (_c *MockValuationServicer_UserHistory_Call) RunAndReturn(run func(userId string, from time.Time, to time.Time) ([]domain.ValuePoint, *domain.ResponseErr)) *MockValuationServicer_UserHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ValueCollection provides a mock function for the type MockValuationServicer
+func (_mock *MockValuationServicer) ValueCollection(collectionId string, query domain.ValuationQuery) (*domain.Valuation, *domain.ResponseErr) {
+	ret := _mock.Called(collectionId, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValueCollection")
+	}
+
+	var r0 *domain.Valuation
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(string, domain.ValuationQuery) (*domain.Valuation, *domain.ResponseErr)); ok {
+		return returnFunc(collectionId, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, domain.ValuationQuery) *domain.Valuation); ok {
+		r0 = returnFunc(collectionId, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Valuation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, domain.ValuationQuery) *domain.ResponseErr); ok {
+		r1 = returnFunc(collectionId, query)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockValuationServicer_ValueCollection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValueCollection'
+type MockValuationServicer_ValueCollection_Call struct {
+	*mock.Call
+}
+
+// ValueCollection is a helper method to define mock.On call
+//   - collectionId
+//   - query
+func (_e *MockValuationServicer_Expecter) ValueCollection(collectionId interface{}, query interface{}) *MockValuationServicer_ValueCollection_Call {
+	return &MockValuationServicer_ValueCollection_Call{Call: _e.mock.On("ValueCollection", collectionId, query)}
+}
+
+func (_c *MockValuationServicer_ValueCollection_Call) Run(run func(collectionId string, query domain.ValuationQuery)) *MockValuationServicer_ValueCollection_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(domain.ValuationQuery))
+	})
+	return _c
+}
+
+func (_c *MockValuationServicer_ValueCollection_Call) Return(valuation *domain.Valuation, responseErr *domain.ResponseErr) *MockValuationServicer_ValueCollection_Call {
+	_c.Call.Return(valuation, responseErr)
+	return _c
+}
+
+func (_c *MockValuationServicer_ValueCollection_Call) RunAndReturn(run func(collectionId string, query domain.ValuationQuery) (*domain.Valuation, *domain.ResponseErr)) *MockValuationServicer_ValueCollection_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ValueUser provides a mock function for the type MockValuationServicer
+func (_mock *MockValuationServicer) ValueUser(userId string, query domain.ValuationQuery) (*domain.Valuation, *domain.ResponseErr) {
+	ret := _mock.Called(userId, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValueUser")
+	}
+
+	var r0 *domain.Valuation
+	var r1 *domain.ResponseErr
+	if returnFunc, ok := ret.Get(0).(func(string, domain.ValuationQuery) (*domain.Valuation, *domain.ResponseErr)); ok {
+		return returnFunc(userId, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, domain.ValuationQuery) *domain.Valuation); ok {
+		r0 = returnFunc(userId, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Valuation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, domain.ValuationQuery) *domain.ResponseErr); ok {
+		r1 = returnFunc(userId, query)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ResponseErr)
+		}
+	}
+	return r0, r1
+}
+
+// MockValuationServicer_ValueUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValueUser'
+type MockValuationServicer_ValueUser_Call struct {
+	*mock.Call
+}
+
+// ValueUser is a helper method to define mock.On call
+//   - userId
+//   - query
+func (_e *MockValuationServicer_Expecter) ValueUser(userId interface{}, query interface{}) *MockValuationServicer_ValueUser_Call {
+	return &MockValuationServicer_ValueUser_Call{Call: _e.mock.On("ValueUser", userId, query)}
+}
+
+func (_c *MockValuationServicer_ValueUser_Call) Run(run func(userId string, query domain.ValuationQuery)) *MockValuationServicer_ValueUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(domain.ValuationQuery))
+	})
+	return _c
+}
+
+func (_c *MockValuationServicer_ValueUser_Call) Return(valuation *domain.Valuation, responseErr *domain.ResponseErr) *MockValuationServicer_ValueUser_Call {
+	_c.Call.Return(valuation, responseErr)
+	return _c
+}
+
+func (_c *MockValuationServicer_ValueUser_Call) RunAndReturn(run func(userId string, query domain.ValuationQuery) (*domain.Valuation, *domain.ResponseErr)) *MockValuationServicer_ValueUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
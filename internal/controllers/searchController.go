@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/ShenokZlob/collector-service/pkg/cardquery"
+	dto "github.com/ShenokZlob/collector-service/pkg/contracts"
+	"go.uber.org/zap"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SearchController отвечает за поиск карт по всем коллекциям пользователя
+// @Tags Search
+// @BasePath /
+type SearchController struct {
+	log           *zap.Logger
+	searchService SearchServicer
+}
+
+type SearchServicer interface {
+	SearchCards(userID string, query *domain.CardSearchQuery) (*domain.CardSearchResult, *domain.ResponseErr)
+}
+
+func NewSearchController(log *zap.Logger, searchService SearchServicer) *SearchController {
+	return &SearchController{
+		log:           log.With(zap.String("controller", "search")),
+		searchService: searchService,
+	}
+}
+
+// @Summary     Search cards across user's collections
+// @Description Найти карты во всех коллекциях пользователя: по Scryfall ID выпуска, по словам названия или, если ничего не нашлось, по похожим названиям. Запрос с ключевыми словами (например, c:r t:creature cmc<=3 set:mh3 is:foil) ищет карты, подходящие под все условия
+// @Tags        Search
+// @Security    BearerAuth
+// @Produce     json
+// @Param       q     query string true  "Название карты, его часть, Scryfall ID или запрос с ключевыми словами"
+// @Param       limit query int    false "Максимум записей (по умолчанию 50, максимум 200)"
+// @Success     200 {object} dto.CardSearchResult
+// @Failure     400,401 {object} dto.ErrorResponse
+// @Router      /cards/search [get]
+func (sc SearchController) SearchCards(ctx *gin.Context) {
+	userID, respErr := getUserFromCtx(ctx)
+	if respErr != nil {
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	limit := 0
+	if raw := ctx.Query("limit"); raw != "" {
+		var err error
+		if limit, err = strconv.Atoi(raw); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, dto.ErrorResponse{Message: fmt.Sprintf("invalid limit %q", raw)})
+			return
+		}
+	}
+
+	query := &domain.CardSearchQuery{Text: ctx.Query("q"), Limit: limit}
+	filter, err := cardquery.Parse(query.Text)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, queryErrorResponse(err))
+		return
+	}
+	query.Filter = filter
+
+	result, respErr := sc.searchService.SearchCards(userID, query)
+	if respErr != nil {
+		sc.log.Error("SearchCards: failed to search cards", zap.String("userID", userID), zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	out := dto.CardSearchResult{
+		Query: result.Query,
+		Match: string(result.Match),
+		Hits:  make([]dto.CardSearchHit, len(result.Hits)),
+	}
+	for i, hit := range result.Hits {
+		out.Hits[i] = dto.CardSearchHit{
+			CollectionID:   hit.CollectionID,
+			CollectionName: hit.CollectionName,
+			Card:           cardToDTO(hit.Card),
+		}
+	}
+
+	ctx.JSON(http.StatusOK, out)
+}
@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	mocks "github.com/ShenokZlob/collector-service/internal/controllers/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSearchCards(t *testing.T) {
+	// Arrange
+	mockSearchService := new(mocks.MockSearchServicer)
+	ctrl := SearchController{
+		log:           zap.NewNop(),
+		searchService: mockSearchService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/cards/search?q=sheoldred&limit=5", nil)
+	c.Set("userID", "64a9b66b2db8b91234a6e8e0")
+
+	mockSearchService.
+		On("SearchCards", "64a9b66b2db8b91234a6e8e0", mock.MatchedBy(func(query *domain.CardSearchQuery) bool {
+			return query.Text == "sheoldred" && query.Limit == 5 && query.Filter.String() == "name:sheoldred"
+		})).
+		Return(&domain.CardSearchResult{
+			Query: "sheoldred",
+			Match: domain.MatchText,
+			Hits: []domain.CardSearchHit{{
+				CollectionID:   "64a9b66b2db8b91234a6e8e3",
+				CollectionName: "Binder",
+				Card:           domain.Card{ID: "64a9b66b2db8b91234a6e8e5", Name: "Sheoldred, the Apocalypse", Count: 1, Zone: domain.ZoneMain, Finish: domain.FinishFoil},
+			}},
+		}, nil)
+
+	// Act
+	ctrl.SearchCards(c)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"match":"text"`)
+	assert.Contains(t, w.Body.String(), `"collection_name":"Binder"`)
+	assert.Contains(t, w.Body.String(), `"finish":"foil"`)
+	mockSearchService.AssertExpectations(t)
+}
+
+func TestSearchCardsInvalidLimit(t *testing.T) {
+	// Arrange
+	mockSearchService := new(mocks.MockSearchServicer)
+	ctrl := SearchController{
+		log:           zap.NewNop(),
+		searchService: mockSearchService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/cards/search?q=bolt&limit=many", nil)
+	c.Set("userID", "64a9b66b2db8b91234a6e8e0")
+
+	// Act
+	ctrl.SearchCards(c)
+
+	// Assert
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	mockSearchService.AssertNotCalled(t, "SearchCards")
+}
+
+func TestSearchCardsQuerySyntaxError(t *testing.T) {
+	// Arrange
+	mockSearchService := new(mocks.MockSearchServicer)
+	ctrl := SearchController{
+		log:           zap.NewNop(),
+		searchService: mockSearchService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/cards/search?q="+url.QueryEscape("c:r foo:bar"), nil)
+	c.Set("userID", "64a9b66b2db8b91234a6e8e0")
+
+	// Act
+	ctrl.SearchCards(c)
+
+	// Assert
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `unknown keyword`)
+	assert.Contains(t, w.Body.String(), `"position":5`)
+	mockSearchService.AssertNotCalled(t, "SearchCards")
+}
@@ -0,0 +1,257 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	dto "github.com/ShenokZlob/collector-service/pkg/contracts"
+	"go.uber.org/zap"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SnapshotController отвечает за снимки коллекции, их сравнение и восстановление
+// @Tags Snapshots
+// @BasePath /
+type SnapshotController struct {
+	log             *zap.Logger
+	snapshotService SnapshotServicer
+}
+
+type SnapshotServicer interface {
+	Create(userId, collectionId, label string) (*domain.Snapshot, *domain.ResponseErr)
+	List(userId, collectionId string) ([]domain.Snapshot, *domain.ResponseErr)
+	Get(userId, collectionId, snapshotId string) (*domain.Snapshot, *domain.ResponseErr)
+	Delete(userId, collectionId, snapshotId string) *domain.ResponseErr
+	Diff(userId, collectionId, snapshotId, toSnapshotId string) (*domain.SnapshotDiff, *domain.ResponseErr)
+	Restore(actor domain.Actor, collectionId, snapshotId string) *domain.ResponseErr
+}
+
+func NewSnapshotController(log *zap.Logger, snapshotService SnapshotServicer) *SnapshotController {
+	return &SnapshotController{
+		log:             log.With(zap.String("controller", "snapshot")),
+		snapshotService: snapshotService,
+	}
+}
+
+// @Summary     Create a snapshot
+// @Description Сохранить текущие карты коллекции как неизменяемый снимок с меткой. Если снимок с такой меткой уже есть, вернёт 409
+// @Tags        Snapshots
+// @Security    BearerAuth
+// @Accept      json
+// @Produce     json
+// @Param       id    path string                    true "ID коллекции"
+// @Param       input body dto.CreateSnapshotRequest true "Метка снимка"
+// @Success     201 {object} dto.Snapshot
+// @Failure     400,401,404,409 {object} dto.ErrorResponse
+// @Router      /collections/{id}/snapshots [post]
+func (sc SnapshotController) Create(ctx *gin.Context) {
+	userID, respErr := getUserFromCtx(ctx)
+	if respErr != nil {
+		sc.log.Error("Create: failed to get userID", zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	var req dto.CreateSnapshotRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, dto.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	collectionId := ctx.Param("id")
+	snapshot, respErr := sc.snapshotService.Create(userID, collectionId, req.Label)
+	if respErr != nil {
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	sc.log.Info("Create: success", zap.String("collectionID", collectionId), zap.String("snapshotID", snapshot.ID))
+	ctx.JSON(http.StatusCreated, snapshotToDTO(*snapshot))
+}
+
+// @Summary     List snapshots
+// @Description Получить снимки коллекции без карт, последние первыми
+// @Tags        Snapshots
+// @Security    BearerAuth
+// @Produce     json
+// @Param       id path string true "ID коллекции"
+// @Success     200 {array} dto.Snapshot
+// @Failure     400,401,404 {object} dto.ErrorResponse
+// @Router      /collections/{id}/snapshots [get]
+func (sc SnapshotController) List(ctx *gin.Context) {
+	userID, respErr := getUserFromCtx(ctx)
+	if respErr != nil {
+		sc.log.Error("List: failed to get userID", zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	collectionId := ctx.Param("id")
+	snapshots, respErr := sc.snapshotService.List(userID, collectionId)
+	if respErr != nil {
+		sc.log.Error("List: failed to list snapshots", zap.String("collectionID", collectionId), zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	out := make([]dto.Snapshot, len(snapshots))
+	for i, snapshot := range snapshots {
+		out[i] = snapshotToDTO(snapshot)
+	}
+	ctx.JSON(http.StatusOK, out)
+}
+
+// @Summary     Get a snapshot
+// @Description Получить снимок коллекции вместе с его картами
+// @Tags        Snapshots
+// @Security    BearerAuth
+// @Produce     json
+// @Param       id          path string true "ID коллекции"
+// @Param       snapshot_id path string true "ID снимка"
+// @Success     200 {object} dto.Snapshot
+// @Failure     400,401,404 {object} dto.ErrorResponse
+// @Router      /collections/{id}/snapshots/{snapshot_id} [get]
+func (sc SnapshotController) Get(ctx *gin.Context) {
+	userID, respErr := getUserFromCtx(ctx)
+	if respErr != nil {
+		sc.log.Error("Get: failed to get userID", zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	collectionId := ctx.Param("id")
+	snapshotId := ctx.Param("snapshot_id")
+	snapshot, respErr := sc.snapshotService.Get(userID, collectionId, snapshotId)
+	if respErr != nil {
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, snapshotToDTO(*snapshot))
+}
+
+// @Summary     Delete a snapshot
+// @Description Удалить снимок коллекции. Карты коллекции не меняются
+// @Tags        Snapshots
+// @Security    BearerAuth
+// @Param       id          path string true "ID коллекции"
+// @Param       snapshot_id path string true "ID снимка"
+// @Success     204 "No Content"
+// @Failure     400,401,404 {object} dto.ErrorResponse
+// @Router      /collections/{id}/snapshots/{snapshot_id} [delete]
+func (sc SnapshotController) Delete(ctx *gin.Context) {
+	userID, respErr := getUserFromCtx(ctx)
+	if respErr != nil {
+		sc.log.Error("Delete: failed to get userID", zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	collectionId := ctx.Param("id")
+	snapshotId := ctx.Param("snapshot_id")
+	if respErr := sc.snapshotService.Delete(userID, collectionId, snapshotId); respErr != nil {
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	sc.log.Info("Delete: success", zap.String("collectionID", collectionId), zap.String("snapshotID", snapshotId))
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Summary     Diff a snapshot
+// @Description Сравнить снимок с другим снимком коллекции или, если to не указан, с текущим состоянием коллекции. Возвращает добавленные, удалённые и изменившиеся по количеству копий варианты карт
+// @Tags        Snapshots
+// @Security    BearerAuth
+// @Produce     json
+// @Param       id          path  string true  "ID коллекции"
+// @Param       snapshot_id path  string true  "ID снимка, с которого считаются изменения"
+// @Param       to          query string false "ID снимка, с которым сравнивать"
+// @Success     200 {object} dto.SnapshotDiff
+// @Failure     400,401,404 {object} dto.ErrorResponse
+// @Router      /collections/{id}/snapshots/{snapshot_id}/diff [get]
+func (sc SnapshotController) Diff(ctx *gin.Context) {
+	userID, respErr := getUserFromCtx(ctx)
+	if respErr != nil {
+		sc.log.Error("Diff: failed to get userID", zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	collectionId := ctx.Param("id")
+	snapshotId := ctx.Param("snapshot_id")
+	diff, respErr := sc.snapshotService.Diff(userID, collectionId, snapshotId, ctx.Query("to"))
+	if respErr != nil {
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	out := dto.SnapshotDiff{
+		FromSnapshotID: diff.FromSnapshotID,
+		ToSnapshotID:   diff.ToSnapshotID,
+		Added:          make([]dto.Card, len(diff.Added)),
+		Removed:        make([]dto.Card, len(diff.Removed)),
+		Changed:        make([]dto.CardCountChange, len(diff.Changed)),
+	}
+	for i, card := range diff.Added {
+		out.Added[i] = cardToDTO(card)
+	}
+	for i, card := range diff.Removed {
+		out.Removed[i] = cardToDTO(card)
+	}
+	for i, change := range diff.Changed {
+		out.Changed[i] = dto.CardCountChange{
+			Card:   cardToDTO(change.Card),
+			Before: change.Before,
+			After:  change.After,
+		}
+	}
+	ctx.JSON(http.StatusOK, out)
+}
+
+// @Summary     Restore a snapshot
+// @Description Вернуть карты коллекции к состоянию снимка одной транзакцией. Записи вариантов, которых нет в снимке, уходят в корзину. Восстановление записывается в историю как изменение snapshot_restore и может быть отменено
+// @Tags        Snapshots
+// @Security    BearerAuth
+// @Param       id          path   string true  "ID коллекции"
+// @Param       snapshot_id path   string true  "ID снимка"
+// @Param       If-Match    header string false "ETag версии коллекции, которую ожидает запрос"
+// @Success     204 "No Content"
+// @Failure     400,401,404,412 {object} dto.ErrorResponse
+// @Router      /collections/{id}/snapshots/{snapshot_id}/restore [post]
+func (sc SnapshotController) Restore(ctx *gin.Context) {
+	actor, respErr := getActorFromCtx(ctx)
+	if respErr != nil {
+		sc.log.Error("Restore: failed to get userID", zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	collectionId := ctx.Param("id")
+	snapshotId := ctx.Param("snapshot_id")
+	if respErr := sc.snapshotService.Restore(actor, collectionId, snapshotId); respErr != nil {
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	sc.log.Info("Restore: success", zap.String("collectionID", collectionId), zap.String("snapshotID", snapshotId))
+	ctx.Status(http.StatusNoContent)
+}
+
+func snapshotToDTO(snapshot domain.Snapshot) dto.Snapshot {
+	out := dto.Snapshot{
+		ID:           snapshot.ID,
+		CollectionID: snapshot.CollectionID,
+		Label:        snapshot.Label,
+		CreatedAt:    snapshot.CreatedAt,
+		Entries:      snapshot.Entries,
+		Copies:       snapshot.Copies,
+	}
+	if snapshot.Cards != nil {
+		out.Cards = make([]dto.Card, len(snapshot.Cards))
+		for i, card := range snapshot.Cards {
+			out.Cards[i] = cardToDTO(card)
+		}
+	}
+	return out
+}
@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	mocks "github.com/ShenokZlob/collector-service/internal/controllers/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCreateSnapshot(t *testing.T) {
+	// Arrange
+	mockSnapshotService := new(mocks.MockSnapshotServicer)
+	ctrl := SnapshotController{
+		log:             zap.NewNop(),
+		snapshotService: mockSnapshotService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/collections/64a9b66b2db8b91234a6e8e3/snapshots", strings.NewReader(`{"label":"Before FNM"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"}}
+	c.Set("userID", testActor.UserID)
+
+	mockSnapshotService.
+		On("Create", testActor.UserID, "64a9b66b2db8b91234a6e8e3", "Before FNM").
+		Return(&domain.Snapshot{
+			ID:           "6710a0b2c3d4e5f601234570",
+			CollectionID: "64a9b66b2db8b91234a6e8e3",
+			Label:        "Before FNM",
+			CreatedAt:    time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+			Entries:      2,
+			Copies:       7,
+			Cards:        []domain.Card{{ID: "64a9b66b2db8b91234a6e8e4", Name: "Lightning Bolt", Count: 4}},
+		}, nil)
+
+	// Act
+	ctrl.Create(c)
+
+	// Assert
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"label":"Before FNM","created_at":"2026-10-19T12:00:00Z","entries":2,"copies":7`)
+	assert.Contains(t, w.Body.String(), `"name":"Lightning Bolt"`)
+	mockSnapshotService.AssertExpectations(t)
+}
+
+func TestDiffSnapshot(t *testing.T) {
+	// Arrange
+	mockSnapshotService := new(mocks.MockSnapshotServicer)
+	ctrl := SnapshotController{
+		log:             zap.NewNop(),
+		snapshotService: mockSnapshotService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/collections/64a9b66b2db8b91234a6e8e3/snapshots/6710a0b2c3d4e5f601234570/diff", nil)
+	c.Params = gin.Params{
+		{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"},
+		{Key: "snapshot_id", Value: "6710a0b2c3d4e5f601234570"},
+	}
+	c.Set("userID", testActor.UserID)
+
+	mockSnapshotService.
+		On("Diff", testActor.UserID, "64a9b66b2db8b91234a6e8e3", "6710a0b2c3d4e5f601234570", "").
+		Return(&domain.SnapshotDiff{
+			FromSnapshotID: "6710a0b2c3d4e5f601234570",
+			Added:          []domain.Card{},
+			Removed:        []domain.Card{{Name: "Goblin Guide", Count: 4}},
+			Changed:        []domain.CardCountChange{{Card: domain.Card{Name: "Lightning Bolt", Count: 3}, Before: 4, After: 3}},
+		}, nil)
+
+	// Act
+	ctrl.Diff(c)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `{"from_snapshot_id":"6710a0b2c3d4e5f601234570","added":[],"removed":[`)
+	assert.Contains(t, w.Body.String(), `"before":4,"after":3`)
+	mockSnapshotService.AssertExpectations(t)
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	// Arrange
+	mockSnapshotService := new(mocks.MockSnapshotServicer)
+	ctrl := SnapshotController{
+		log:             zap.NewNop(),
+		snapshotService: mockSnapshotService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/collections/64a9b66b2db8b91234a6e8e3/snapshots/6710a0b2c3d4e5f601234570/restore", nil)
+	c.Params = gin.Params{
+		{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"},
+		{Key: "snapshot_id", Value: "6710a0b2c3d4e5f601234570"},
+	}
+	c.Set("userID", testActor.UserID)
+
+	mockSnapshotService.
+		On("Restore", testActor, "64a9b66b2db8b91234a6e8e3", "6710a0b2c3d4e5f601234570").
+		Return(nil)
+
+	// Act
+	ctrl.Restore(c)
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, c.Writer.Status())
+	mockSnapshotService.AssertExpectations(t)
+}
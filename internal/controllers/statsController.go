@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	dto "github.com/ShenokZlob/collector-service/pkg/contracts"
+	"go.uber.org/zap"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StatsController отвечает за статистику коллекций
+// @Tags Stats
+// @BasePath /
+type StatsController struct {
+	log          *zap.Logger
+	statsService StatsServicer
+}
+
+type StatsServicer interface {
+	CollectionStats(collectionId string) (*domain.CollectionStats, *domain.ResponseErr)
+	UserStats(userID string) (*domain.CollectionStats, *domain.ResponseErr)
+}
+
+func NewStatsController(log *zap.Logger, statsService StatsServicer) *StatsController {
+	return &StatsController{
+		log:          log.With(zap.String("controller", "stats")),
+		statsService: statsService,
+	}
+}
+
+// @Summary     Get collection statistics
+// @Description Получить статистику коллекции: количество карт, разбивки по цвету, редкости, сету, типу и мана-стоимости, кривую маны для колод, долю фольги и добавления по месяцам
+// @Tags        Stats
+// @Security    BearerAuth
+// @Produce     json
+// @Param       id path string true "ID коллекции"
+// @Success     200 {object} dto.CollectionStats
+// @Failure     400,401,404 {object} dto.ErrorResponse
+// @Router      /collections/{id}/stats [get]
+func (sc StatsController) CollectionStats(ctx *gin.Context) {
+	collectionID := ctx.Param("id")
+
+	stats, respErr := sc.statsService.CollectionStats(collectionID)
+	if respErr != nil {
+		sc.log.Error("CollectionStats: failed to get stats", zap.String("collectionID", collectionID), zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, statsToDTO(stats))
+}
+
+// @Summary     Get statistics of all user's collections
+// @Description Получить статистику всех коллекций текущего пользователя вместе
+// @Tags        Stats
+// @Security    BearerAuth
+// @Produce     json
+// @Success     200 {object} dto.CollectionStats
+// @Failure     400,401 {object} dto.ErrorResponse
+// @Router      /collections/stats [get]
+func (sc StatsController) UserStats(ctx *gin.Context) {
+	userID, respErr := getUserFromCtx(ctx)
+	if respErr != nil {
+		sc.log.Error("UserStats: failed to get userID", zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	stats, respErr := sc.statsService.UserStats(userID)
+	if respErr != nil {
+		sc.log.Error("UserStats: failed to get stats", zap.String("userID", userID), zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, statsToDTO(stats))
+}
+
+func statsToDTO(stats *domain.CollectionStats) dto.CollectionStats {
+	out := dto.CollectionStats{
+		Cards:         stats.Cards,
+		Unique:        stats.Unique,
+		Printings:     stats.Printings,
+		Foil:          stats.Foil,
+		FoilRatio:     stats.FoilRatio,
+		Multicolor:    stats.Multicolor,
+		Uncataloged:   stats.Uncataloged,
+		ByColor:       statsBucketsToDTO(stats.ByColor),
+		ByRarity:      statsBucketsToDTO(stats.ByRarity),
+		BySet:         statsBucketsToDTO(stats.BySet),
+		ByType:        statsBucketsToDTO(stats.ByType),
+		ByManaValue:   statsBucketsToDTO(stats.ByManaValue),
+		AddedPerMonth: make([]dto.MonthCount, len(stats.AddedPerMonth)),
+		UpdatedAt:     stats.UpdatedAt,
+	}
+	if stats.ManaCurve != nil {
+		out.ManaCurve = make([]dto.CurveBar, len(stats.ManaCurve))
+		for i, bar := range stats.ManaCurve {
+			out.ManaCurve[i] = dto.CurveBar{ManaValue: bar.ManaValue, Cards: bar.Cards}
+		}
+	}
+	for i, month := range stats.AddedPerMonth {
+		out.AddedPerMonth[i] = dto.MonthCount{Month: month.Month, Cards: month.Cards}
+	}
+	return out
+}
+
+func statsBucketsToDTO(buckets []domain.StatsBucket) []dto.StatsBucket {
+	out := make([]dto.StatsBucket, len(buckets))
+	for i, b := range buckets {
+		out[i] = dto.StatsBucket{Key: b.Key, Cards: b.Cards, Unique: b.Unique}
+	}
+	return out
+}
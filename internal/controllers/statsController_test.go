@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	mocks "github.com/ShenokZlob/collector-service/internal/controllers/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCollectionStats(t *testing.T) {
+	// Arrange
+	mockStatsService := new(mocks.MockStatsServicer)
+	ctrl := StatsController{
+		log:          zap.NewNop(),
+		statsService: mockStatsService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/collections/64a9b66b2db8b91234a6e8e3/stats", nil)
+	c.Params = gin.Params{{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"}}
+
+	mockStatsService.
+		On("CollectionStats", "64a9b66b2db8b91234a6e8e3").
+		Return(&domain.CollectionStats{
+			Cards:     60,
+			Unique:    20,
+			ByColor:   []domain.StatsBucket{{Key: "R", Cards: 36, Unique: 9}},
+			ManaCurve: []domain.CurveBar{{ManaValue: 1, Cards: 16}},
+		}, nil)
+
+	// Act
+	ctrl.CollectionStats(c)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"by_color":[{"key":"R","cards":36,"unique":9}]`)
+	assert.Contains(t, w.Body.String(), `"mana_curve":[{"mana_value":1,"cards":16}]`)
+	mockStatsService.AssertExpectations(t)
+}
+
+func TestUserStats(t *testing.T) {
+	// Arrange
+	mockStatsService := new(mocks.MockStatsServicer)
+	ctrl := StatsController{
+		log:          zap.NewNop(),
+		statsService: mockStatsService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/collections/stats", nil)
+	c.Set("userID", "64a9b66b2db8b91234a6e8e0")
+
+	mockStatsService.
+		On("UserStats", "64a9b66b2db8b91234a6e8e0").
+		Return(&domain.CollectionStats{Cards: 250}, nil)
+
+	// Act
+	ctrl.UserStats(c)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"cards":250`)
+	assert.NotContains(t, w.Body.String(), "mana_curve")
+	mockStatsService.AssertExpectations(t)
+}
@@ -0,0 +1,252 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	dto "github.com/ShenokZlob/collector-service/pkg/contracts"
+	"go.uber.org/zap"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TradeController отвечает за группы игроков и предложения обмена
+// @Tags Trades
+// @BasePath /
+type TradeController struct {
+	log          *zap.Logger
+	groupService GroupServicer
+	matchService TradeMatchServicer
+}
+
+type GroupServicer interface {
+	CreateGroup(userID, name string) (*domain.Group, *domain.ResponseErr)
+	JoinGroup(userID, inviteCode string) (*domain.Group, *domain.ResponseErr)
+	LeaveGroup(userID, groupID string) *domain.ResponseErr
+	ListGroups(userID string) ([]domain.Group, *domain.ResponseErr)
+}
+
+type TradeMatchServicer interface {
+	ListMatches(userID string, offset, limit int) (*domain.TradeMatchesPage, *domain.ResponseErr)
+}
+
+func NewTradeController(log *zap.Logger, groupService GroupServicer, matchService TradeMatchServicer) *TradeController {
+	return &TradeController{
+		log:          log.With(zap.String("controller", "trade")),
+		groupService: groupService,
+		matchService: matchService,
+	}
+}
+
+// @Summary     List user's groups
+// @Description Получить группы игроков, в которых состоит пользователь
+// @Tags        Trades
+// @Security    BearerAuth
+// @Produce     json
+// @Success     200 {array}  dto.Group
+// @Failure     401 {object} dto.ErrorResponse
+// @Router      /groups [get]
+func (tc TradeController) ListGroups(ctx *gin.Context) {
+	userID, respErr := getUserFromCtx(ctx)
+	if respErr != nil {
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	groups, respErr := tc.groupService.ListGroups(userID)
+	if respErr != nil {
+		tc.log.Error("ListGroups: failed to list groups", zap.String("userID", userID), zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	out := make([]dto.Group, len(groups))
+	for i := range groups {
+		out[i] = groupToDTO(&groups[i])
+	}
+	ctx.JSON(http.StatusOK, out)
+}
+
+// @Summary     Create a group
+// @Description Создать группу игроков; создатель становится её участником и получает код приглашения
+// @Tags        Trades
+// @Security    BearerAuth
+// @Accept      json
+// @Produce     json
+// @Param       input body     dto.CreateGroupRequest true "Название группы"
+// @Success     201   {object} dto.Group
+// @Failure     400,401 {object} dto.ErrorResponse
+// @Router      /groups [post]
+func (tc TradeController) CreateGroup(ctx *gin.Context) {
+	userID, respErr := getUserFromCtx(ctx)
+	if respErr != nil {
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	var req dto.CreateGroupRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, dto.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	group, respErr := tc.groupService.CreateGroup(userID, req.Name)
+	if respErr != nil {
+		tc.log.Error("CreateGroup: failed to create group", zap.String("userID", userID), zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, groupToDTO(group))
+}
+
+// @Summary     Join a group
+// @Description Вступить в группу игроков по коду приглашения
+// @Tags        Trades
+// @Security    BearerAuth
+// @Accept      json
+// @Produce     json
+// @Param       input body     dto.JoinGroupRequest true "Код приглашения"
+// @Success     200   {object} dto.Group
+// @Failure     400,401,404 {object} dto.ErrorResponse
+// @Router      /groups/join [post]
+func (tc TradeController) JoinGroup(ctx *gin.Context) {
+	userID, respErr := getUserFromCtx(ctx)
+	if respErr != nil {
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	var req dto.JoinGroupRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, dto.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	group, respErr := tc.groupService.JoinGroup(userID, req.InviteCode)
+	if respErr != nil {
+		tc.log.Error("JoinGroup: failed to join group", zap.String("userID", userID), zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, groupToDTO(group))
+}
+
+// @Summary     Leave a group
+// @Description Выйти из группы игроков; группа удаляется, когда из неё выходит последний участник
+// @Tags        Trades
+// @Security    BearerAuth
+// @Param       id path string true "ID группы"
+// @Success     204
+// @Failure     400,401,404 {object} dto.ErrorResponse
+// @Router      /groups/{id}/leave [post]
+func (tc TradeController) LeaveGroup(ctx *gin.Context) {
+	userID, respErr := getUserFromCtx(ctx)
+	if respErr != nil {
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	groupID := ctx.Param("id")
+	if respErr := tc.groupService.LeaveGroup(userID, groupID); respErr != nil {
+		tc.log.Error("LeaveGroup: failed to leave group", zap.String("userID", userID), zap.String("groupID", groupID), zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Summary     List trade matches
+// @Description Получить предложения обмена с участниками общих групп: карты из их списков обмена, которые есть в списках желаемого пользователя, и наоборот. Самые равноценные обмены первыми. Предложения пересчитываются в фоне
+// @Tags        Trades
+// @Security    BearerAuth
+// @Produce     json
+// @Param       offset query int false "Смещение"
+// @Param       limit  query int false "Размер страницы (по умолчанию 20, максимум 100)"
+// @Success     200 {object} dto.TradeMatchesPage
+// @Failure     400,401 {object} dto.ErrorResponse
+// @Router      /trades/matches [get]
+func (tc TradeController) ListMatches(ctx *gin.Context) {
+	userID, respErr := getUserFromCtx(ctx)
+	if respErr != nil {
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	var offset, limit int
+	for _, p := range []struct {
+		name string
+		dst  *int
+	}{{"offset", &offset}, {"limit", &limit}} {
+		raw := ctx.Query(p.name)
+		if raw == "" {
+			continue
+		}
+		v, err := strconv.Atoi(raw)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, dto.ErrorResponse{Message: fmt.Sprintf("invalid %s: %q is not a number", p.name, raw)})
+			return
+		}
+		*p.dst = v
+	}
+
+	page, respErr := tc.matchService.ListMatches(userID, offset, limit)
+	if respErr != nil {
+		tc.log.Error("ListMatches: failed to list trade matches", zap.String("userID", userID), zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	out := dto.TradeMatchesPage{
+		Matches: make([]dto.TradeMatch, len(page.Matches)),
+		Pagination: dto.Pagination{
+			Total:  page.Total,
+			Offset: page.Offset,
+			Limit:  page.Limit,
+		},
+	}
+	for i, match := range page.Matches {
+		out.Matches[i] = dto.TradeMatch{
+			PartnerID:   match.PartnerID,
+			PartnerName: match.PartnerName,
+			Gets:        tradeItemsToDTO(match.Gets),
+			Gives:       tradeItemsToDTO(match.Gives),
+			GetsValue:   match.GetsValue,
+			GivesValue:  match.GivesValue,
+			Balance:     match.Balance,
+			Score:       match.Score,
+			UpdatedAt:   match.UpdatedAt,
+		}
+	}
+
+	ctx.JSON(http.StatusOK, out)
+}
+
+func groupToDTO(group *domain.Group) dto.Group {
+	return dto.Group{
+		ID:         group.ID,
+		Name:       group.Name,
+		OwnerID:    group.OwnerID,
+		InviteCode: group.InviteCode,
+		Members:    group.Members,
+	}
+}
+
+func tradeItemsToDTO(items []domain.TradeItem) []dto.TradeItem {
+	out := make([]dto.TradeItem, len(items))
+	for i, item := range items {
+		out[i] = dto.TradeItem{
+			Name:       item.Name,
+			ScryfallID: item.ScryfallID,
+			Finish:     string(item.Finish),
+			Count:      item.Count,
+			UnitPrice:  item.UnitPrice,
+			Value:      item.Value,
+		}
+	}
+	return out
+}
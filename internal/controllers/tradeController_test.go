@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	mocks "github.com/ShenokZlob/collector-service/internal/controllers/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestListTradeMatches(t *testing.T) {
+	// Arrange
+	mockMatchService := new(mocks.MockTradeMatchServicer)
+	ctrl := TradeController{
+		log:          zap.NewNop(),
+		matchService: mockMatchService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/trades/matches?offset=20&limit=10", nil)
+	c.Set("userID", "64a9b66b2db8b91234a6e8e0")
+
+	updated := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	mockMatchService.
+		On("ListMatches", "64a9b66b2db8b91234a6e8e0", 20, 10).
+		Return(&domain.TradeMatchesPage{
+			Matches: []domain.TradeMatch{{
+				PartnerID:   "64a9b66b2db8b91234a6e8e1",
+				PartnerName: "bob",
+				Gets:        []domain.TradeItem{{Name: "Lightning Bolt", ScryfallID: "bolt", Finish: domain.FinishFoil, Count: 2, UnitPrice: 5, Value: 10}},
+				Gives:       []domain.TradeItem{},
+				GetsValue:   10,
+				Balance:     10,
+				UpdatedAt:   updated,
+			}},
+			Total:  21,
+			Offset: 20,
+			Limit:  10,
+		}, nil)
+
+	// Act
+	ctrl.ListMatches(c)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{
+		"matches": [{
+			"partner_id": "64a9b66b2db8b91234a6e8e1",
+			"partner_name": "bob",
+			"gets": [{"name": "Lightning Bolt", "scryfall_id": "bolt", "finish": "foil", "count": 2, "unit_price": 5, "value": 10}],
+			"gives": [],
+			"gets_value": 10,
+			"gives_value": 0,
+			"balance": 10,
+			"score": 0,
+			"updated_at": "2026-10-19T12:00:00Z"
+		}],
+		"pagination": {"total": 21, "offset": 20, "limit": 10}
+	}`, w.Body.String())
+	mockMatchService.AssertExpectations(t)
+}
+
+func TestListTradeMatchesInvalidLimit(t *testing.T) {
+	// Arrange
+	mockMatchService := new(mocks.MockTradeMatchServicer)
+	ctrl := TradeController{
+		log:          zap.NewNop(),
+		matchService: mockMatchService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/trades/matches?limit=ten", nil)
+	c.Set("userID", "64a9b66b2db8b91234a6e8e0")
+
+	// Act
+	ctrl.ListMatches(c)
+
+	// Assert
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	mockMatchService.AssertNotCalled(t, "ListMatches")
+}
@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	dto "github.com/ShenokZlob/collector-service/pkg/contracts"
+	"go.uber.org/zap"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TrashController отвечает за корзину удалённых коллекций и карт
+// @Tags Trash
+// @BasePath /
+type TrashController struct {
+	log          *zap.Logger
+	trashService TrashServicer
+}
+
+type TrashServicer interface {
+	Trash(userID string) (*domain.Trash, *domain.ResponseErr)
+	RestoreCollection(userID, collectionID string) (*domain.Collection, *domain.ResponseErr)
+	RestoreCard(actor domain.Actor, collectionId, entryId string) (*domain.Card, *domain.ResponseErr)
+}
+
+func NewTrashController(log *zap.Logger, trashService TrashServicer) *TrashController {
+	return &TrashController{
+		log:          log.With(zap.String("controller", "trash")),
+		trashService: trashService,
+	}
+}
+
+// @Summary     List trash
+// @Description Получить удалённые коллекции и карты текущего пользователя, которые ещё можно восстановить
+// @Tags        Trash
+// @Security    BearerAuth
+// @Produce     json
+// @Success     200 {object} dto.Trash
+// @Failure     400,401 {object} dto.ErrorResponse
+// @Router      /trash [get]
+func (tc TrashController) List(ctx *gin.Context) {
+	userID, respErr := getUserFromCtx(ctx)
+	if respErr != nil {
+		tc.log.Error("ListTrash: failed to get userID", zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	trash, respErr := tc.trashService.Trash(userID)
+	if respErr != nil {
+		tc.log.Error("ListTrash: failed to list trash", zap.String("userID", userID), zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	out := dto.Trash{
+		Collections: make([]dto.TrashedCollection, len(trash.Collections)),
+		Cards:       make([]dto.TrashedCard, len(trash.Cards)),
+	}
+	for i, c := range trash.Collections {
+		out.Collections[i] = dto.TrashedCollection{ID: c.ID, Name: c.Name, Kind: string(c.Kind), DeletedAt: c.DeletedAt}
+	}
+	for i, c := range trash.Cards {
+		out.Cards[i] = dto.TrashedCard{
+			CollectionID:   c.CollectionID,
+			CollectionName: c.CollectionName,
+			Card:           cardToDTO(c.Card),
+			DeletedAt:      c.DeletedAt,
+		}
+	}
+	ctx.JSON(http.StatusOK, out)
+}
+
+// @Summary     Restore collection
+// @Description Восстановить удалённую коллекцию вместе с картами. Если у пользователя уже есть коллекция с таким именем, вернёт 409
+// @Tags        Trash
+// @Security    BearerAuth
+// @Produce     json
+// @Param       id path string true "ID коллекции"
+// @Success     200 {object} dto.Collection
+// @Failure     400,401,404,409 {object} dto.ErrorResponse
+// @Router      /trash/collections/{id}/restore [post]
+func (tc TrashController) RestoreCollection(ctx *gin.Context) {
+	userID, respErr := getUserFromCtx(ctx)
+	if respErr != nil {
+		tc.log.Error("RestoreCollection: failed to get userID", zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	collectionID := ctx.Param("id")
+	restored, respErr := tc.trashService.RestoreCollection(userID, collectionID)
+	if respErr != nil {
+		tc.log.Error("RestoreCollection: failed to restore collection", zap.String("userID", userID), zap.String("collectionID", collectionID), zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	tc.log.Info("RestoreCollection: success", zap.String("userID", userID), zap.String("collectionID", collectionID))
+	ctx.JSON(http.StatusOK, collectionToDTO(restored))
+}
+
+// @Summary     Restore card
+// @Description Восстановить удалённую запись карты. Если в коллекции уже есть запись того же варианта карты, копии добавляются к ней
+// @Tags        Trash
+// @Security    BearerAuth
+// @Produce     json
+// @Param       id       path   string true  "ID коллекции"
+// @Param       entry_id path   string true  "Card entry ID"
+// @Param       If-Match header string false "ETag версии коллекции, которую ожидает запрос"
+// @Success     200 {object} dto.Card "Восстановленная запись"
+// @Failure     400,401,404,412 {object} dto.ErrorResponse
+// @Router      /trash/collections/{id}/cards/{entry_id}/restore [post]
+func (tc TrashController) RestoreCard(ctx *gin.Context) {
+	actor, respErr := getActorFromCtx(ctx)
+	if respErr != nil {
+		tc.log.Error("RestoreCard: failed to get userID", zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	collectionId := ctx.Param("id")
+	entryId := ctx.Param("entry_id")
+
+	restored, respErr := tc.trashService.RestoreCard(actor, collectionId, entryId)
+	if respErr != nil {
+		tc.log.Error("RestoreCard: failed to restore card", zap.String("collectionID", collectionId), zap.String("entryID", entryId), zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, cardToDTO(*restored))
+}
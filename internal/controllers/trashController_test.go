@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	mocks "github.com/ShenokZlob/collector-service/internal/controllers/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestListTrash(t *testing.T) {
+	// Arrange
+	mockTrashService := new(mocks.MockTrashServicer)
+	ctrl := TrashController{
+		log:          zap.NewNop(),
+		trashService: mockTrashService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/trash", nil)
+	c.Set("userID", "64a9b66b2db8b91234a6e8e0")
+
+	deletedAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	mockTrashService.
+		On("Trash", "64a9b66b2db8b91234a6e8e0").
+		Return(&domain.Trash{
+			Collections: []domain.Collection{{ID: "64a9b66b2db8b91234a6e8e3", Name: "Old binder", Kind: domain.KindBinder, DeletedAt: deletedAt}},
+			Cards: []domain.TrashedCard{{
+				CollectionID:   "64a9b66b2db8b91234a6e8e5",
+				CollectionName: "Burn",
+				Card:           domain.Card{ID: "64a9b66b2db8b91234a6e8e4", Name: "Lightning Bolt", Count: 4},
+				DeletedAt:      deletedAt,
+			}},
+		}, nil)
+
+	// Act
+	ctrl.List(c)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"name":"Old binder","kind":"binder","deleted_at":"2026-10-19T12:00:00Z"`)
+	assert.Contains(t, w.Body.String(), `"collection_name":"Burn"`)
+	assert.Contains(t, w.Body.String(), `"name":"Lightning Bolt"`)
+	mockTrashService.AssertExpectations(t)
+}
+
+func TestRestoreCollectionNameTaken(t *testing.T) {
+	// Arrange
+	mockTrashService := new(mocks.MockTrashServicer)
+	ctrl := TrashController{
+		log:          zap.NewNop(),
+		trashService: mockTrashService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/trash/collections/64a9b66b2db8b91234a6e8e3/restore", nil)
+	c.Params = gin.Params{{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"}}
+	c.Set("userID", "64a9b66b2db8b91234a6e8e0")
+
+	mockTrashService.
+		On("RestoreCollection", "64a9b66b2db8b91234a6e8e0", "64a9b66b2db8b91234a6e8e3").
+		Return(nil, &domain.ResponseErr{Status: http.StatusConflict, Message: "Collection with this name already exists"})
+
+	// Act
+	ctrl.RestoreCollection(c)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockTrashService.AssertExpectations(t)
+}
+
+func TestRestoreCard(t *testing.T) {
+	// Arrange
+	mockTrashService := new(mocks.MockTrashServicer)
+	ctrl := TrashController{
+		log:          zap.NewNop(),
+		trashService: mockTrashService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", testActor.UserID)
+	c.Request, _ = http.NewRequest("POST", "/trash/collections/64a9b66b2db8b91234a6e8e3/cards/64a9b66b2db8b91234a6e8e4/restore", nil)
+	c.Params = gin.Params{
+		{Key: "id", Value: "64a9b66b2db8b91234a6e8e3"},
+		{Key: "entry_id", Value: "64a9b66b2db8b91234a6e8e4"},
+	}
+
+	mockTrashService.
+		On("RestoreCard", testActor, "64a9b66b2db8b91234a6e8e3", "64a9b66b2db8b91234a6e8e4").
+		Return(&domain.Card{ID: "64a9b66b2db8b91234a6e8e4", Name: "Lightning Bolt", Count: 4}, nil)
+
+	// Act
+	ctrl.RestoreCard(c)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"count":4`)
+	mockTrashService.AssertExpectations(t)
+}
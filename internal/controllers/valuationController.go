@@ -0,0 +1,246 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	dto "github.com/ShenokZlob/collector-service/pkg/contracts"
+	"go.uber.org/zap"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ValuationController отвечает за стоимость коллекций
+// @Tags Valuation
+// @BasePath /
+type ValuationController struct {
+	log              *zap.Logger
+	valuationService ValuationServicer
+}
+
+type ValuationServicer interface {
+	ValueCollection(collectionId string, query domain.ValuationQuery) (*domain.Valuation, *domain.ResponseErr)
+	ValueUser(userId string, query domain.ValuationQuery) (*domain.Valuation, *domain.ResponseErr)
+	CollectionHistory(collectionId string, from, to time.Time) ([]domain.ValuePoint, *domain.ResponseErr)
+	UserHistory(userId string, from, to time.Time) ([]domain.ValuePoint, *domain.ResponseErr)
+}
+
+func NewValuationController(log *zap.Logger, valuationService ValuationServicer) *ValuationController {
+	return &ValuationController{
+		log:              log.With(zap.String("controller", "valuation")),
+		valuationService: valuationService,
+	}
+}
+
+// @Summary     Get the value of a collection
+// @Description Получить стоимость коллекции по последним ценам, самые дорогие карты и изменение стоимости с даты since
+// @Tags        Valuation
+// @Security    BearerAuth
+// @Produce     json
+// @Param       id       path  string true  "ID коллекции"
+// @Param       currency query string false "usd, eur или tix; по умолчанию usd"
+// @Param       top      query int    false "Число самых дорогих карт, по умолчанию 10, не больше 100"
+// @Param       since    query string false "Дата для сравнения цен (YYYY-MM-DD или RFC3339)"
+// @Success     200 {object} dto.Valuation
+// @Failure     400,401,404 {object} dto.ErrorResponse
+// @Router      /collections/{id}/value [get]
+func (vc ValuationController) ValueCollection(ctx *gin.Context) {
+	collectionID := ctx.Param("id")
+
+	query, err := parseValuationQuery(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, dto.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	valuation, respErr := vc.valuationService.ValueCollection(collectionID, *query)
+	if respErr != nil {
+		vc.log.Error("ValueCollection: failed to value collection", zap.String("collectionID", collectionID), zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, valuationToDTO(valuation))
+}
+
+// @Summary     Get the value of all user's collections
+// @Description Получить стоимость всех коллекций текущего пользователя по последним ценам
+// @Tags        Valuation
+// @Security    BearerAuth
+// @Produce     json
+// @Param       currency query string false "usd, eur или tix; по умолчанию usd"
+// @Param       top      query int    false "Число самых дорогих карт, по умолчанию 10, не больше 100"
+// @Param       since    query string false "Дата для сравнения цен (YYYY-MM-DD или RFC3339)"
+// @Success     200 {object} dto.Valuation
+// @Failure     400,401 {object} dto.ErrorResponse
+// @Router      /collections/value [get]
+func (vc ValuationController) ValueUser(ctx *gin.Context) {
+	userID, respErr := getUserFromCtx(ctx)
+	if respErr != nil {
+		vc.log.Error("ValueUser: failed to get userID", zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	query, err := parseValuationQuery(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, dto.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	valuation, respErr := vc.valuationService.ValueUser(userID, *query)
+	if respErr != nil {
+		vc.log.Error("ValueUser: failed to value collections", zap.String("userID", userID), zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, valuationToDTO(valuation))
+}
+
+// @Summary     Get the daily value history of a collection
+// @Description Получить стоимость коллекции по дням во всех валютах, по умолчанию за последние 90 дней
+// @Tags        Valuation
+// @Security    BearerAuth
+// @Produce     json
+// @Param       id   path  string true  "ID коллекции"
+// @Param       from query string false "Первый день (YYYY-MM-DD или RFC3339)"
+// @Param       to   query string false "Последний день (YYYY-MM-DD или RFC3339), по умолчанию сегодня"
+// @Success     200 {array} dto.ValuePoint
+// @Failure     400,401,404 {object} dto.ErrorResponse
+// @Router      /collections/{id}/value/history [get]
+func (vc ValuationController) CollectionHistory(ctx *gin.Context) {
+	collectionID := ctx.Param("id")
+
+	from, to, err := parseDateRange(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, dto.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	points, respErr := vc.valuationService.CollectionHistory(collectionID, from, to)
+	if respErr != nil {
+		vc.log.Error("CollectionHistory: failed to get value history", zap.String("collectionID", collectionID), zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, valuePointsToDTO(points))
+}
+
+// @Summary     Get the daily value history of all user's collections
+// @Description Получить суммарную стоимость коллекций текущего пользователя по дням, по умолчанию за последние 90 дней
+// @Tags        Valuation
+// @Security    BearerAuth
+// @Produce     json
+// @Param       from query string false "Первый день (YYYY-MM-DD или RFC3339)"
+// @Param       to   query string false "Последний день (YYYY-MM-DD или RFC3339), по умолчанию сегодня"
+// @Success     200 {array} dto.ValuePoint
+// @Failure     400,401 {object} dto.ErrorResponse
+// @Router      /collections/value/history [get]
+func (vc ValuationController) UserHistory(ctx *gin.Context) {
+	userID, respErr := getUserFromCtx(ctx)
+	if respErr != nil {
+		vc.log.Error("UserHistory: failed to get userID", zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	from, to, err := parseDateRange(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, dto.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	points, respErr := vc.valuationService.UserHistory(userID, from, to)
+	if respErr != nil {
+		vc.log.Error("UserHistory: failed to get value history", zap.String("userID", userID), zap.Error(respErr))
+		ctx.AbortWithStatusJSON(respErr.Status, respErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, valuePointsToDTO(points))
+}
+
+func parseValuationQuery(ctx *gin.Context) (*domain.ValuationQuery, error) {
+	query := &domain.ValuationQuery{Currency: domain.Currency(ctx.Query("currency"))}
+
+	if raw := ctx.Query("top"); raw != "" {
+		top, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid top: %q is not a number", raw)
+		}
+		query.Top = top
+	}
+
+	since, err := parseDateQuery(ctx, "since")
+	if err != nil {
+		return nil, err
+	}
+	query.Since = since
+
+	return query, nil
+}
+
+func parseDateRange(ctx *gin.Context) (time.Time, time.Time, error) {
+	from, err := parseDateQuery(ctx, "from")
+	if err != nil {
+		return from, time.Time{}, err
+	}
+	to, err := parseDateQuery(ctx, "to")
+	return from, to, err
+}
+
+// parseDateQuery reads a date or a timestamp from the query parameter, zero when it's missing.
+func parseDateQuery(ctx *gin.Context, name string) (time.Time, error) {
+	raw := ctx.Query(name)
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	date, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		date, err = time.Parse(time.DateOnly, raw)
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s: %q is not a date", name, raw)
+	}
+	return date, nil
+}
+
+func valuationToDTO(valuation *domain.Valuation) dto.Valuation {
+	out := dto.Valuation{
+		Currency: string(valuation.Currency),
+		Total:    valuation.Total,
+		Cards:    valuation.Cards,
+		Unpriced: valuation.Unpriced,
+		Top:      make([]dto.CardValue, len(valuation.Top)),
+	}
+	if !valuation.PriceDate.IsZero() {
+		out.PriceDate = &valuation.PriceDate
+	}
+	if !valuation.Since.IsZero() {
+		out.Since = &valuation.Since
+		out.SinceTotal = &valuation.SinceTotal
+		out.Change = &valuation.Change
+	}
+	for i, value := range valuation.Top {
+		out.Top[i] = dto.CardValue{
+			CollectionID: value.CollectionID,
+			Card:         cardToDTO(value.Card),
+			UnitPrice:    value.UnitPrice,
+			Value:        value.Value,
+		}
+	}
+	return out
+}
+
+func valuePointsToDTO(points []domain.ValuePoint) []dto.ValuePoint {
+	out := make([]dto.ValuePoint, len(points))
+	for i, point := range points {
+		out[i] = dto.ValuePoint{Date: point.Date, USD: point.USD, EUR: point.EUR, Tix: point.Tix}
+	}
+	return out
+}
@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	mocks "github.com/ShenokZlob/collector-service/internal/controllers/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestValueCollection(t *testing.T) {
+	// Arrange
+	mockValuationService := new(mocks.MockValuationServicer)
+	ctrl := ValuationController{
+		log:              zap.NewNop(),
+		valuationService: mockValuationService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/collections/64a9b66b2db8b91234a6e8e4/value?currency=eur&top=1&since=2026-09-01", nil)
+	c.Params = gin.Params{{Key: "id", Value: "64a9b66b2db8b91234a6e8e4"}}
+
+	since := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	priceDate := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	mockValuationService.
+		On("ValueCollection", "64a9b66b2db8b91234a6e8e4", domain.ValuationQuery{Currency: domain.CurrencyEUR, Top: 1, Since: since}).
+		Return(&domain.Valuation{
+			Currency:  domain.CurrencyEUR,
+			PriceDate: priceDate,
+			Total:     7.2,
+			Cards:     4,
+			Top: []domain.CardValue{{
+				CollectionID: "64a9b66b2db8b91234a6e8e4",
+				Card:         domain.Card{ID: "64a9b66b2db8b91234a6e8e5", ScryfallID: "bolt", Name: "Lightning Bolt", Count: 4},
+				UnitPrice:    1.8,
+				Value:        7.2,
+			}},
+			Since:      since,
+			SinceTotal: 6,
+			Change:     1.2,
+		}, nil)
+
+	// Act
+	ctrl.ValueCollection(c)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{
+		"currency": "eur",
+		"price_date": "2026-10-19T00:00:00Z",
+		"total": 7.2,
+		"cards": 4,
+		"unpriced": 0,
+		"top": [{
+			"collection_id": "64a9b66b2db8b91234a6e8e4",
+			"card": {"id": "64a9b66b2db8b91234a6e8e5", "scryfall_id": "bolt", "name": "Lightning Bolt", "card_url": "", "count": 4},
+			"unit_price": 1.8,
+			"value": 7.2
+		}],
+		"since": "2026-09-01T00:00:00Z",
+		"since_total": 6,
+		"change": 1.2
+	}`, w.Body.String())
+	mockValuationService.AssertExpectations(t)
+}
+
+func TestValueCollectionInvalidSince(t *testing.T) {
+	mockValuationService := new(mocks.MockValuationServicer)
+	ctrl := ValuationController{
+		log:              zap.NewNop(),
+		valuationService: mockValuationService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/collections/64a9b66b2db8b91234a6e8e4/value?since=yesterday", nil)
+	c.Params = gin.Params{{Key: "id", Value: "64a9b66b2db8b91234a6e8e4"}}
+
+	ctrl.ValueCollection(c)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	mockValuationService.AssertNotCalled(t, "ValueCollection")
+}
+
+func TestUserValueHistory(t *testing.T) {
+	mockValuationService := new(mocks.MockValuationServicer)
+	ctrl := ValuationController{
+		log:              zap.NewNop(),
+		valuationService: mockValuationService,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/collections/value/history?from=2026-10-18", nil)
+	c.Set("userID", "64a9b66b2db8b91234a6e8e0")
+
+	from := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	mockValuationService.
+		On("UserHistory", "64a9b66b2db8b91234a6e8e0", from, time.Time{}).
+		Return([]domain.ValuePoint{
+			{Date: from, USD: 10.5, EUR: 9, Tix: 1},
+			{Date: from.AddDate(0, 0, 1), USD: 11, EUR: 9.5, Tix: 1},
+		}, nil)
+
+	ctrl.UserHistory(c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `[
+		{"date": "2026-10-18T00:00:00Z", "usd": 10.5, "eur": 9, "tix": 1},
+		{"date": "2026-10-19T00:00:00Z", "usd": 11, "eur": 9.5, "tix": 1}
+	]`, w.Body.String())
+}
@@ -0,0 +1,192 @@
+package mongorep
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/ShenokZlob/collector-service/pkg/cardquery"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// cardQueryStages filters card entries by a parsed query. Entries are joined
+// with their catalog printing as "printing" only when the query needs it, and
+// the printing is dropped again after filtering. A nil query adds no stages.
+func cardQueryStages(expr cardquery.Expr) mongo.Pipeline {
+	if expr == nil {
+		return nil
+	}
+	if !cardquery.UsesCatalog(expr) {
+		return mongo.Pipeline{{{Key: "$match", Value: compileCardQuery(expr)}}}
+	}
+	return mongo.Pipeline{
+		{{Key: "$lookup", Value: bson.M{
+			"from":         catalog_collection,
+			"localField":   "scryfall_id",
+			"foreignField": "_id",
+			"as":           "printing",
+		}}},
+		{{Key: "$set", Value: bson.M{"printing": bson.M{"$arrayElemAt": bson.A{"$printing", 0}}}}},
+		{{Key: "$match", Value: compileCardQuery(expr)}},
+		{{Key: "$unset", Value: "printing"}},
+	}
+}
+
+// compileCardQuery turns a parsed query into a filter of card entries joined with "printing"
+func compileCardQuery(expr cardquery.Expr) bson.M {
+	switch e := expr.(type) {
+	case *cardquery.And:
+		return bson.M{"$and": compileCardQueries(e.Terms)}
+	case *cardquery.Or:
+		return bson.M{"$or": compileCardQueries(e.Terms)}
+	case *cardquery.Not:
+		return bson.M{"$nor": bson.A{compileCardQuery(e.Term)}}
+	case *cardquery.Condition:
+		if e.Op == cardquery.OpNe {
+			eq := *e
+			eq.Op = cardquery.OpEq
+			return bson.M{"$nor": bson.A{compileCondition(&eq)}}
+		}
+		return compileCondition(e)
+	}
+	return bson.M{}
+}
+
+func compileCardQueries(exprs []cardquery.Expr) bson.A {
+	filters := make(bson.A, len(exprs))
+	for i, expr := range exprs {
+		filters[i] = compileCardQuery(expr)
+	}
+	return filters
+}
+
+// compileCondition compiles conditions with any operator but !=
+func compileCondition(c *cardquery.Condition) bson.M {
+	switch c.Field {
+	case cardquery.FieldName:
+		return bson.M{"name": textRegex(c)}
+	case cardquery.FieldType:
+		// Entries added before the catalog import have their own type line only
+		return entryOrPrinting("type_line", textRegex(c))
+	case cardquery.FieldSet:
+		return entryOrPrinting("set", c.Value)
+	case cardquery.FieldRarity:
+		return entryOrPrinting("rarity", rarityFilter(c))
+	case cardquery.FieldColor, cardquery.FieldIdentity:
+		return colorFilter(c)
+	case cardquery.FieldManaValue:
+		return bson.M{"printing.cmc": numberFilter(c)}
+	case cardquery.FieldCount:
+		return bson.M{"count": numberFilter(c)}
+	case cardquery.FieldFinish:
+		return bson.M{"finish": c.Value}
+	case cardquery.FieldZone:
+		return bson.M{"zone": c.Value}
+	case cardquery.FieldCondition:
+		return bson.M{"condition": c.Value}
+	case cardquery.FieldLanguage:
+		return bson.M{"language": c.Value}
+	}
+	return bson.M{}
+}
+
+func entryOrPrinting(field string, value any) bson.M {
+	return bson.M{"$or": bson.A{
+		bson.M{field: value},
+		bson.M{"printing." + field: value},
+	}}
+}
+
+// textRegex matches text containing the value, or the whole text for =, ignoring case
+func textRegex(c *cardquery.Condition) bson.Regex {
+	pattern := regexp.QuoteMeta(c.Value)
+	if c.Op == cardquery.OpEq {
+		pattern = "^" + pattern + "$"
+	}
+	return bson.Regex{Pattern: pattern, Options: "i"}
+}
+
+var numberOps = map[cardquery.Operator]string{
+	cardquery.OpMatch: "$eq",
+	cardquery.OpEq:    "$eq",
+	cardquery.OpLt:    "$lt",
+	cardquery.OpLte:   "$lte",
+	cardquery.OpGt:    "$gt",
+	cardquery.OpGte:   "$gte",
+}
+
+func numberFilter(c *cardquery.Condition) bson.M {
+	return bson.M{numberOps[c.Op]: c.Number}
+}
+
+// rarityFilter matches the rarities the condition compares true with
+func rarityFilter(c *cardquery.Condition) bson.M {
+	value := slices.Index(cardquery.Rarities, c.Value)
+	var rarities bson.A
+	for i, rarity := range cardquery.Rarities {
+		var ok bool
+		switch c.Op {
+		case cardquery.OpLt:
+			ok = i < value
+		case cardquery.OpLte:
+			ok = i <= value
+		case cardquery.OpGt:
+			ok = i > value
+		case cardquery.OpGte:
+			ok = i >= value
+		default:
+			ok = i == value
+		}
+		if ok {
+			rarities = append(rarities, rarity)
+		}
+	}
+	return bson.M{"$in": rarities}
+}
+
+// colorFilter compares the colors or the color identity of the printing as sets.
+// For colors `:` means at least these colors, for identity it means within
+// this identity, so `id:rg` finds the cards of a Gruul commander deck.
+func colorFilter(c *cardquery.Condition) bson.M {
+	field := "printing.colors"
+	op := c.Op
+	if c.Field == cardquery.FieldIdentity {
+		field = "printing.color_identity"
+		if op == cardquery.OpMatch {
+			op = cardquery.OpLte
+		}
+	} else if op == cardquery.OpMatch {
+		op = cardquery.OpGte
+	}
+
+	// Empty color lists are left out of catalog documents, so only cards missing
+	// from the catalog have no printing
+	filters := bson.A{bson.M{"printing._id": bson.M{"$exists": true}}}
+	switch c.Value {
+	case cardquery.Colorless:
+		filters = append(filters, bson.M{field + ".0": bson.M{"$exists": false}})
+	case cardquery.Multicolor:
+		filters = append(filters, bson.M{field + ".1": bson.M{"$exists": true}})
+	default:
+		colors := bson.A{}
+		for _, r := range c.Value {
+			colors = append(colors, string(r))
+		}
+		sizeIndex := field + "." + strconv.Itoa(len(colors))
+		within := bson.M{field: bson.M{"$not": bson.M{"$elemMatch": bson.M{"$nin": colors}}}}
+		switch op {
+		case cardquery.OpEq:
+			filters = append(filters, bson.M{field: bson.M{"$all": colors, "$size": len(colors)}})
+		case cardquery.OpGte:
+			filters = append(filters, bson.M{field: bson.M{"$all": colors}})
+		case cardquery.OpGt:
+			filters = append(filters, bson.M{field: bson.M{"$all": colors}}, bson.M{sizeIndex: bson.M{"$exists": true}})
+		case cardquery.OpLte:
+			filters = append(filters, within)
+		case cardquery.OpLt:
+			filters = append(filters, within, bson.M{field: bson.M{"$not": bson.M{"$size": len(colors)}}})
+		}
+	}
+	return bson.M{"$and": filters}
+}
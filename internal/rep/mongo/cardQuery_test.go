@@ -0,0 +1,110 @@
+package mongorep
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/ShenokZlob/collector-service/pkg/cardquery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestListCardsWithQuery(t *testing.T) {
+	r := newTestRepository(t)
+	collDoc := newTestCollection(t, r)
+	coll := collDoc.ToDomain()
+	prefix := "query-" + bson.NewObjectID().Hex()
+
+	printings := []domain.CatalogCard{
+		{ScryfallID: prefix + "-bolt", OracleID: prefix + "-bolt", Name: "Lightning Bolt", SetCode: "m10", CollectorNumber: "146",
+			Rarity: "common", TypeLine: "Instant", CMC: 1, Colors: []string{"R"}, ColorIdentity: []string{"R"}},
+		{ScryfallID: prefix + "-guide", OracleID: prefix + "-guide", Name: "Goblin Guide", SetCode: "zen", CollectorNumber: "126",
+			Rarity: "rare", TypeLine: "Creature — Goblin Scout", CMC: 1, Colors: []string{"R"}, ColorIdentity: []string{"R"}},
+		{ScryfallID: prefix + "-charm", OracleID: prefix + "-charm", Name: "Boros Charm", SetCode: "rtr", CollectorNumber: "148",
+			Rarity: "uncommon", TypeLine: "Instant", CMC: 2, Colors: []string{"W", "R"}, ColorIdentity: []string{"W", "R"}},
+		{ScryfallID: prefix + "-sol", OracleID: prefix + "-sol", Name: "Sol Ring", SetCode: "cmm", CollectorNumber: "410",
+			Rarity: "uncommon", TypeLine: "Artifact", CMC: 1},
+	}
+	t.Cleanup(func() {
+		_, _ = r.client.Database(database).Collection(catalog_collection).DeleteMany(context.Background(), bson.M{"_id": bson.M{"$regex": "^" + prefix}})
+	})
+	require.Nil(t, r.UpsertCatalogCards(printings))
+
+	add := func(card domain.Card) {
+		card.SetVariantDefaults()
+		_, respErr := r.AddCardToCollection(ownerOf(collDoc), coll.ID, &card)
+		require.Nil(t, respErr)
+	}
+	add(domain.Card{ScryfallID: prefix + "-bolt", Name: "Lightning Bolt", Count: 4})
+	add(domain.Card{ScryfallID: prefix + "-guide", Name: "Goblin Guide", Count: 2, Finish: domain.FinishFoil})
+	add(domain.Card{ScryfallID: prefix + "-charm", Name: "Boros Charm", Count: 1, Zone: domain.ZoneSide})
+	add(domain.Card{ScryfallID: prefix + "-sol", Name: "Sol Ring", Count: 1})
+	add(domain.Card{ScryfallID: prefix + "-unknown", Name: "Unknown Card", Count: 3})
+
+	tests := []struct {
+		query string
+		names []string
+	}{
+		{query: "c:r", names: []string{"Boros Charm", "Goblin Guide", "Lightning Bolt"}},
+		{query: "c=r", names: []string{"Goblin Guide", "Lightning Bolt"}},
+		{query: "c:m", names: []string{"Boros Charm"}},
+		{query: "c:c", names: []string{"Sol Ring"}},
+		{query: "id:r", names: []string{"Goblin Guide", "Lightning Bolt", "Sol Ring"}},
+		{query: "c<wr", names: []string{"Goblin Guide", "Lightning Bolt", "Sol Ring"}},
+		{query: "t:instant cmc<=1", names: []string{"Lightning Bolt"}},
+		{query: "r>=uncommon -t:artifact", names: []string{"Boros Charm", "Goblin Guide"}},
+		{query: "set:ZEN or zone:side", names: []string{"Boros Charm", "Goblin Guide"}},
+		{query: "is:foil", names: []string{"Goblin Guide"}},
+		{query: "count>=3", names: []string{"Lightning Bolt", "Unknown Card"}},
+		{query: `!"lightning bolt"`, names: []string{"Lightning Bolt"}},
+		{query: "-c:r", names: []string{"Sol Ring", "Unknown Card"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			filter, err := cardquery.Parse(tt.query)
+			require.NoError(t, err)
+
+			page, respErr := r.ListCards(coll.ID, &domain.CardsQuery{Filter: filter, SortBy: domain.SortByName, Limit: 10})
+			require.Nil(t, respErr)
+			names := []string{}
+			for _, card := range page.Cards {
+				names = append(names, card.Name)
+			}
+			assert.Equal(t, tt.names, names)
+			assert.Equal(t, len(tt.names), page.Total)
+		})
+	}
+
+	filter, err := cardquery.Parse("t:instant")
+	require.NoError(t, err)
+	hits, respErr := r.SearchCardsByQuery([]string{coll.ID}, filter, 10)
+	require.Nil(t, respErr)
+	require.Len(t, hits, 2)
+	assert.Equal(t, "Boros Charm", hits[0].Card.Name)
+	assert.Equal(t, coll.ID, hits[0].CollectionID)
+}
+
+func TestCardQueryStages(t *testing.T) {
+	assert.Nil(t, cardQueryStages(nil))
+
+	filter, err := cardquery.Parse("is:foil -zone:side")
+	require.NoError(t, err)
+	stages := cardQueryStages(filter)
+	require.Len(t, stages, 1, "entry fields don't need the catalog")
+	assert.Equal(t, bson.M{"$and": bson.A{
+		bson.M{"finish": "foil"},
+		bson.M{"$nor": bson.A{bson.M{"zone": "side"}}},
+	}}, stages[0][0].Value)
+
+	filter, err = cardquery.Parse("id:wr")
+	require.NoError(t, err)
+	stages = cardQueryStages(filter)
+	require.Len(t, stages, 4)
+	assert.Equal(t, "$lookup", stages[0][0].Key)
+	assert.Equal(t, bson.M{"$and": bson.A{
+		bson.M{"printing._id": bson.M{"$exists": true}},
+		bson.M{"printing.color_identity": bson.M{"$not": bson.M{"$elemMatch": bson.M{"$nin": bson.A{"W", "R"}}}}},
+	}}, stages[2][0].Value)
+}
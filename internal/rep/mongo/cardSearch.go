@@ -0,0 +1,154 @@
+package mongorep
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/ShenokZlob/collector-service/pkg/cardquery"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// FindCardsByScryfallID returns the entries of the printing in the collections
+func (r Repository) FindCardsByScryfallID(collectionIds []string, scryfallId string, limit int) ([]domain.CardSearchHit, *domain.ResponseErr) {
+	objectIds, respErr := collectionObjectIDs(collectionIds)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	filter := bson.M{"collection_id": bson.M{"$in": objectIds}, "scryfall_id": scryfallId}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "collection_id", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+	return r.findSearchHits(filter, opts)
+}
+
+// SearchCardsByText returns the entries of the collections whose names contain words
+// of the text, using the text index on card names. The best matches come first.
+func (r Repository) SearchCardsByText(collectionIds []string, text string, limit int) ([]domain.CardSearchHit, *domain.ResponseErr) {
+	objectIds, respErr := collectionObjectIDs(collectionIds)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	filter := bson.M{"collection_id": bson.M{"$in": objectIds}, "$text": bson.M{"$search": text}}
+	opts := options.Find().
+		SetProjection(bson.M{"score": bson.M{"$meta": "textScore"}}).
+		SetSort(bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}, {Key: "name", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+	return r.findSearchHits(filter, opts)
+}
+
+// FindCardNames returns the distinct card names in the collections in name order
+func (r Repository) FindCardNames(collectionIds []string) ([]string, *domain.ResponseErr) {
+	objectIds, respErr := collectionObjectIDs(collectionIds)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	storage := r.client.Database(database).Collection(cards_collection)
+	var names []string
+	err := storage.Distinct(context.TODO(), "name", bson.M{"collection_id": bson.M{"$in": objectIds}, "deleted_at": notTrashed()}).Decode(&names)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Find card names error: %v", err),
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// FindCardsByNames returns the entries of the collections with one of the names
+func (r Repository) FindCardsByNames(collectionIds []string, names []string, limit int) ([]domain.CardSearchHit, *domain.ResponseErr) {
+	objectIds, respErr := collectionObjectIDs(collectionIds)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	filter := bson.M{"collection_id": bson.M{"$in": objectIds}, "name": bson.M{"$in": names}}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+	return r.findSearchHits(filter, opts)
+}
+
+// SearchCardsByQuery returns the entries of the collections matching a parsed query in name order
+func (r Repository) SearchCardsByQuery(collectionIds []string, query cardquery.Expr, limit int) ([]domain.CardSearchHit, *domain.ResponseErr) {
+	objectIds, respErr := collectionObjectIDs(collectionIds)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: bson.M{"collection_id": bson.M{"$in": objectIds}, "deleted_at": notTrashed()}}}}
+	pipeline = append(pipeline, cardQueryStages(query)...)
+	pipeline = append(pipeline,
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}}},
+		bson.D{{Key: "$limit", Value: limit}},
+	)
+
+	ctx := context.TODO()
+	storage := r.client.Database(database).Collection(cards_collection)
+	cursor, err := storage.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Search cards error: %v", err),
+		}
+	}
+	defer cursor.Close(ctx)
+	return decodeSearchHits(ctx, cursor)
+}
+
+func (r Repository) findSearchHits(filter bson.M, opts *options.FindOptionsBuilder) ([]domain.CardSearchHit, *domain.ResponseErr) {
+	filter["deleted_at"] = notTrashed()
+	ctx := context.TODO()
+	storage := r.client.Database(database).Collection(cards_collection)
+	cursor, err := storage.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Search cards error: %v", err),
+		}
+	}
+	defer cursor.Close(ctx)
+	return decodeSearchHits(ctx, cursor)
+}
+
+func decodeSearchHits(ctx context.Context, cursor *mongo.Cursor) ([]domain.CardSearchHit, *domain.ResponseErr) {
+	var entries []Card
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Search cards error: %v", err),
+		}
+	}
+
+	hits := make([]domain.CardSearchHit, len(entries))
+	for i := range entries {
+		hits[i] = domain.CardSearchHit{
+			CollectionID: entries[i].CollectionID.Hex(),
+			Card:         entries[i].ToDomain(),
+		}
+	}
+	return hits, nil
+}
+
+func collectionObjectIDs(collectionIds []string) ([]bson.ObjectID, *domain.ResponseErr) {
+	objectIds := make([]bson.ObjectID, len(collectionIds))
+	for i, id := range collectionIds {
+		objectId, err := bson.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, &domain.ResponseErr{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid collection ID format",
+			}
+		}
+		objectIds[i] = objectId
+	}
+	return objectIds, nil
+}
@@ -0,0 +1,44 @@
+package mongorep
+
+import (
+	"testing"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchCardsAcrossCollections(t *testing.T) {
+	r := newTestRepository(t)
+	firstDoc, secondDoc := newTestCollection(t, r), newTestCollection(t, r)
+	first, second := firstDoc.ToDomain(), secondDoc.ToDomain()
+	ids := []string{first.ID, second.ID}
+	owners := map[string]domain.Actor{first.ID: ownerOf(firstDoc), second.ID: ownerOf(secondDoc)}
+
+	add := func(collectionId string, card domain.Card) {
+		card.SetVariantDefaults()
+		_, respErr := r.AddCardToCollection(owners[collectionId], collectionId, &card)
+		require.Nil(t, respErr)
+	}
+	add(first.ID, domain.Card{ScryfallID: "search-sheoldred", Name: "Sheoldred, the Apocalypse", Count: 1})
+	add(second.ID, domain.Card{ScryfallID: "search-sheoldred", Name: "Sheoldred, the Apocalypse", Count: 2, Finish: domain.FinishFoil})
+	add(second.ID, domain.Card{ScryfallID: "search-bolt", Name: "Lightning Bolt", Count: 4})
+
+	hits, respErr := r.SearchCardsByText(ids, "sheoldred", 10)
+	require.Nil(t, respErr)
+	require.Len(t, hits, 2)
+
+	hits, respErr = r.FindCardsByScryfallID(ids, "search-bolt", 10)
+	require.Nil(t, respErr)
+	require.Len(t, hits, 1)
+	assert.Equal(t, second.ID, hits[0].CollectionID)
+
+	names, respErr := r.FindCardNames(ids)
+	require.Nil(t, respErr)
+	assert.Equal(t, []string{"Lightning Bolt", "Sheoldred, the Apocalypse"}, names)
+
+	hits, respErr = r.FindCardsByNames(ids, []string{"Lightning Bolt"}, 10)
+	require.Nil(t, respErr)
+	require.Len(t, hits, 1)
+	assert.Equal(t, 4, hits[0].Card.Count)
+}
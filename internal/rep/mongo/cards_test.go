@@ -0,0 +1,232 @@
+package mongorep
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/stretchr/testify/require"
+)
+
+const concurrentWorkers = 50
+
+func TestAddCardToCollectionConcurrent(t *testing.T) {
+	r := newTestRepository(t)
+	collection := newTestCollection(t, r)
+	collectionId := collection.ObjectID.Hex()
+	owner := ownerOf(collection)
+
+	var wg sync.WaitGroup
+	errs := make(chan *domain.ResponseErr, concurrentWorkers)
+	for i := 0; i < concurrentWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			entry := testCard(1)
+			card := entry.ToDomain()
+			card.ID = ""
+			if _, respErr := r.AddCardToCollection(owner, collectionId, &card); respErr != nil {
+				errs <- respErr
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for respErr := range errs {
+		require.Nil(t, respErr)
+	}
+
+	page, respErr := r.ListCards(collectionId, &domain.CardsQuery{SortBy: domain.SortByName, Limit: 10})
+	require.Nil(t, respErr)
+	require.Equal(t, 1, page.Total, "concurrent adds of one variant must share an entry")
+	require.Equal(t, concurrentWorkers, page.Cards[0].Count)
+}
+
+func TestAdjustCardCountConcurrent(t *testing.T) {
+	r := newTestRepository(t)
+	collection := newTestCollection(t, r)
+	collectionId := collection.ObjectID.Hex()
+	owner := ownerOf(collection)
+
+	const copies = concurrentWorkers / 2
+	entry := testCard(1)
+	card := entry.ToDomain()
+	card.ID = ""
+	card.Count = copies
+	stored, respErr := r.AddCardToCollection(owner, collectionId, &card)
+	require.Nil(t, respErr)
+
+	var wg sync.WaitGroup
+	statuses := make(chan int, concurrentWorkers)
+	for i := 0; i < concurrentWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, respErr := r.AdjustCardCount(owner, collectionId, &domain.CardAdjustment{EntryID: stored.ID, Delta: -1})
+			if respErr != nil {
+				statuses <- respErr.Status
+				return
+			}
+			statuses <- http.StatusOK
+		}()
+	}
+	wg.Wait()
+	close(statuses)
+
+	byStatus := map[int]int{}
+	for status := range statuses {
+		byStatus[status]++
+	}
+	require.Equal(t, copies, byStatus[http.StatusOK])
+	// Once the entry is gone, decrements report it as missing or as short of copies
+	require.Equal(t, concurrentWorkers-copies, byStatus[http.StatusConflict]+byStatus[http.StatusNotFound])
+
+	page, respErr := r.ListCards(collectionId, &domain.CardsQuery{SortBy: domain.SortByName, Limit: 10})
+	require.Nil(t, respErr)
+	require.Zero(t, page.Total, "entry must be removed at zero copies")
+}
+
+func TestAdjustCardCountIncrement(t *testing.T) {
+	r := newTestRepository(t)
+	collection := newTestCollection(t, r)
+	collectionId := collection.ObjectID.Hex()
+	owner := ownerOf(collection)
+
+	entry := testCard(1)
+	card := entry.ToDomain()
+	card.ID = ""
+	stored, respErr := r.AddCardToCollection(owner, collectionId, &card)
+	require.Nil(t, respErr)
+
+	adjusted, respErr := r.AdjustCardCount(owner, collectionId, &domain.CardAdjustment{EntryID: stored.ID, Delta: 3})
+	require.Nil(t, respErr)
+	require.Equal(t, 4, adjusted.Count)
+
+	_, respErr = r.AdjustCardCount(owner, collectionId, &domain.CardAdjustment{EntryID: stored.ID, Delta: -5})
+	require.NotNil(t, respErr)
+	require.Equal(t, http.StatusConflict, respErr.Status)
+
+	adjusted, respErr = r.AdjustCardCount(owner, collectionId, &domain.CardAdjustment{EntryID: stored.ID, Delta: -4})
+	require.Nil(t, respErr)
+	require.Zero(t, adjusted.Count)
+
+	_, respErr = r.AdjustCardCount(owner, collectionId, &domain.CardAdjustment{EntryID: stored.ID, Delta: 1})
+	require.NotNil(t, respErr)
+	require.Equal(t, http.StatusNotFound, respErr.Status)
+
+	// The removed copies can be restored from the trash
+	trashed, respErr := r.FindTrashedCards([]string{collectionId})
+	require.Nil(t, respErr)
+	require.Len(t, trashed, 1)
+	require.Equal(t, stored.ID, trashed[0].Card.ID)
+	require.Equal(t, 4, trashed[0].Card.Count)
+}
+
+func TestApplyCardOperations(t *testing.T) {
+	r := newTestRepository(t)
+	collection := newTestCollection(t, r)
+	collectionId := collection.ObjectID.Hex()
+	owner := ownerOf(collection)
+
+	entry := testCard(1)
+	card := entry.ToDomain()
+	card.ID = ""
+	stored, respErr := r.AddCardToCollection(owner, collectionId, &card)
+	require.Nil(t, respErr)
+
+	added := testCard(2)
+	addedCard := added.ToDomain()
+	addedCard.ID = ""
+	missing := testCard(3).ObjectID.Hex()
+	operations := []domain.CardOperation{
+		{Type: domain.CardOperationAdd, Card: addedCard},
+		{Type: domain.CardOperationAdd, Card: addedCard},
+		{Type: domain.CardOperationSetCount, Card: domain.Card{ID: stored.ID, Count: 4}},
+		{Type: domain.CardOperationDelete, Card: domain.Card{ID: missing}},
+	}
+	query := &domain.CardsQuery{SortBy: domain.SortByName, Limit: 10}
+
+	// An atomic batch with a failed operation writes nothing
+	result, respErr := r.ApplyCardOperations(owner, collectionId, &domain.CardBatch{Mode: domain.BatchAtomic, Operations: operations})
+	require.Nil(t, respErr)
+	require.False(t, result.Applied)
+	require.Equal(t, http.StatusNotFound, result.Results[3].Err.Status)
+
+	page, respErr := r.ListCards(collectionId, query)
+	require.Nil(t, respErr)
+	require.Equal(t, 1, page.Total)
+	require.Equal(t, 1, page.Cards[0].Count)
+
+	// A best effort batch skips it
+	result, respErr = r.ApplyCardOperations(owner, collectionId, &domain.CardBatch{Mode: domain.BatchBestEffort, Operations: operations})
+	require.Nil(t, respErr)
+	require.True(t, result.Applied)
+	require.Equal(t, result.Results[0].Entry.ID, result.Results[1].Entry.ID, "adds of one variant must share an entry")
+	require.Equal(t, 2, result.Results[1].Entry.Count)
+	require.Equal(t, 4, result.Results[2].Entry.Count)
+	require.NotNil(t, result.Results[3].Err)
+
+	page, respErr = r.ListCards(collectionId, query)
+	require.Nil(t, respErr)
+	require.Equal(t, 2, page.Total)
+
+	// A best effort batch which changes nothing keeps the collection version
+	before, respErr := r.GetCollection(collectionId)
+	require.Nil(t, respErr)
+	result, respErr = r.ApplyCardOperations(owner, collectionId, &domain.CardBatch{Mode: domain.BatchBestEffort, Operations: operations[3:]})
+	require.Nil(t, respErr)
+	require.NotNil(t, result.Results[0].Err)
+	after, respErr := r.GetCollection(collectionId)
+	require.Nil(t, respErr)
+	require.Equal(t, before.Version, after.Version)
+}
+
+func TestCardChangesNeedCollectionOwner(t *testing.T) {
+	r := newTestRepository(t)
+	collection := newTestCollection(t, r)
+	collectionId := collection.ObjectID.Hex()
+
+	entry := testCard(1)
+	card := entry.ToDomain()
+	card.ID = ""
+	stored, respErr := r.AddCardToCollection(ownerOf(collection), collectionId, &card)
+	require.Nil(t, respErr)
+
+	// Collections of other users look missing
+	operations := []domain.CardOperation{{Type: domain.CardOperationDelete, Card: domain.Card{ID: stored.ID}}}
+	changes := map[string]func() *domain.ResponseErr{
+		"add": func() *domain.ResponseErr {
+			_, respErr := r.AddCardToCollection(testActor, collectionId, &card)
+			return respErr
+		},
+		"set count": func() *domain.ResponseErr {
+			return r.SetCardCountInCollection(testActor, collectionId, &domain.Card{ID: stored.ID, Count: 4})
+		},
+		"adjust": func() *domain.ResponseErr {
+			_, respErr := r.AdjustCardCount(testActor, collectionId, &domain.CardAdjustment{EntryID: stored.ID, Delta: 1})
+			return respErr
+		},
+		"move": func() *domain.ResponseErr {
+			return r.MoveCardBetweenZones(testActor, collectionId, &domain.CardMove{EntryID: stored.ID, To: domain.ZoneSide, Count: 1})
+		},
+		"batch": func() *domain.ResponseErr {
+			_, respErr := r.ApplyCardOperations(testActor, collectionId, &domain.CardBatch{Mode: domain.BatchAtomic, Operations: operations})
+			return respErr
+		},
+		"delete": func() *domain.ResponseErr {
+			return r.DeleteCardFromCollection(testActor, collectionId, &domain.Card{ID: stored.ID})
+		},
+	}
+	for name, change := range changes {
+		respErr := change()
+		require.NotNil(t, respErr, name)
+		require.Equal(t, http.StatusNotFound, respErr.Status, name)
+	}
+
+	page, respErr := r.ListCards(collectionId, &domain.CardsQuery{SortBy: domain.SortByName, Limit: 10})
+	require.Nil(t, respErr)
+	require.Len(t, page.Cards, 1)
+	require.Equal(t, 1, page.Cards[0].Count)
+}
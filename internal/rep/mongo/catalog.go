@@ -0,0 +1,219 @@
+package mongorep
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// FindCard resolves a card name to a printing. The catalog is searched for the printing
+// with the set code and collector number first, then for any printing with the name.
+func (r Repository) FindCard(name, setCode, collectorNumber string) (*domain.CatalogCard, *domain.ResponseErr) {
+	ctx := context.TODO()
+	catalog := r.client.Database(database).Collection(catalog_collection)
+
+	filters := []bson.M{{"name_key": strings.ToLower(name)}}
+	if setCode != "" {
+		exact := bson.M{"name_key": strings.ToLower(name), "set": strings.ToLower(setCode)}
+		if collectorNumber != "" {
+			exact["collector_number"] = collectorNumber
+		}
+		filters = append([]bson.M{exact}, filters...)
+	}
+	for _, filter := range filters {
+		var card CatalogCard
+		err := catalog.FindOne(ctx, filter).Decode(&card)
+		if err == nil {
+			domainCard := card.ToDomain()
+			return &domainCard, nil
+		}
+		if err != mongo.ErrNoDocuments {
+			return nil, &domain.ResponseErr{
+				Status:  http.StatusInternalServerError,
+				Message: fmt.Sprintf("Find card error: %v", err),
+			}
+		}
+	}
+
+	return nil, &domain.ResponseErr{
+		Status:  http.StatusNotFound,
+		Message: "Card not found",
+	}
+}
+
+// FindPrintings returns catalog printings by their Scryfall IDs. Printings
+// missing from the catalog are left out of the result.
+func (r Repository) FindPrintings(scryfallIds []string) (map[string]domain.CatalogCard, *domain.ResponseErr) {
+	printings := make(map[string]domain.CatalogCard, len(scryfallIds))
+	if len(scryfallIds) == 0 {
+		return printings, nil
+	}
+
+	cards, respErr := r.findCatalogCards(bson.M{"_id": bson.M{"$in": scryfallIds}}, options.Find())
+	if respErr != nil {
+		return nil, respErr
+	}
+	for _, card := range cards {
+		printings[card.ScryfallID] = card
+	}
+
+	return printings, nil
+}
+
+// GetCatalogCard returns a catalog printing by its Scryfall ID.
+func (r Repository) GetCatalogCard(scryfallId string) (*domain.CatalogCard, *domain.ResponseErr) {
+	return r.findCatalogCard(bson.M{"_id": scryfallId})
+}
+
+// FindCatalogPrinting returns the printing with the collector number in the set.
+func (r Repository) FindCatalogPrinting(setCode, collectorNumber string) (*domain.CatalogCard, *domain.ResponseErr) {
+	return r.findCatalogCard(bson.M{"set": strings.ToLower(setCode), "collector_number": collectorNumber})
+}
+
+// SearchCatalogByName returns printings whose names start with the prefix, ignoring case.
+func (r Repository) SearchCatalogByName(prefix string, limit int) ([]domain.CatalogCard, *domain.ResponseErr) {
+	filter := bson.M{"name_key": bson.M{"$regex": "^" + regexp.QuoteMeta(strings.ToLower(prefix))}}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "name_key", Value: 1}, {Key: "set", Value: 1}, {Key: "collector_number", Value: 1}}).
+		SetLimit(int64(limit))
+	return r.findCatalogCards(filter, opts)
+}
+
+// FindCatalogByOracleID returns all printings of the card.
+func (r Repository) FindCatalogByOracleID(oracleId string) ([]domain.CatalogCard, *domain.ResponseErr) {
+	opts := options.Find().SetSort(bson.D{{Key: "set", Value: 1}, {Key: "collector_number", Value: 1}})
+	return r.findCatalogCards(bson.M{"oracle_id": oracleId}, opts)
+}
+
+// UpsertCatalogCards stores printings by their Scryfall IDs, replacing stored ones,
+// so importing the same cards again doesn't change the catalog.
+func (r Repository) UpsertCatalogCards(cards []domain.CatalogCard) *domain.ResponseErr {
+	if len(cards) == 0 {
+		return nil
+	}
+
+	models := make([]mongo.WriteModel, len(cards))
+	for i, card := range cards {
+		doc := CatalogCardFromDomain(card)
+		models[i] = mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"_id": doc.ScryfallID}).
+			SetReplacement(doc).
+			SetUpsert(true)
+	}
+
+	storage := r.client.Database(database).Collection(catalog_collection)
+	if _, err := storage.BulkWrite(context.TODO(), models, options.BulkWrite().SetOrdered(false)); err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Upsert catalog cards error: %v", err),
+		}
+	}
+
+	return nil
+}
+
+// GetCatalogImport returns the progress of importing the file.
+func (r Repository) GetCatalogImport(source string) (*domain.CatalogImport, *domain.ResponseErr) {
+	storage := r.client.Database(database).Collection(catalog_imports_collection)
+
+	var state CatalogImport
+	if err := storage.FindOne(context.TODO(), bson.M{"_id": source}).Decode(&state); err != nil {
+		return nil, catalogFindError(err, "Catalog import not found")
+	}
+
+	return &domain.CatalogImport{
+		Source:    state.Source,
+		Size:      state.Size,
+		ModTime:   state.ModTime,
+		Processed: state.Processed,
+		BytesRead: state.BytesRead,
+		Done:      state.Done,
+		StartedAt: state.StartedAt,
+		UpdatedAt: state.UpdatedAt,
+	}, nil
+}
+
+// SaveCatalogImport stores the progress of importing a file.
+func (r Repository) SaveCatalogImport(state *domain.CatalogImport) *domain.ResponseErr {
+	storage := r.client.Database(database).Collection(catalog_imports_collection)
+	doc := CatalogImport{
+		Source:    state.Source,
+		Size:      state.Size,
+		ModTime:   state.ModTime,
+		Processed: state.Processed,
+		BytesRead: state.BytesRead,
+		Done:      state.Done,
+		StartedAt: state.StartedAt,
+		UpdatedAt: state.UpdatedAt,
+	}
+
+	opts := options.Replace().SetUpsert(true)
+	if _, err := storage.ReplaceOne(context.TODO(), bson.M{"_id": doc.Source}, doc, opts); err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Save catalog import error: %v", err),
+		}
+	}
+
+	return nil
+}
+
+func (r Repository) findCatalogCard(filter bson.M) (*domain.CatalogCard, *domain.ResponseErr) {
+	storage := r.client.Database(database).Collection(catalog_collection)
+
+	var card CatalogCard
+	if err := storage.FindOne(context.TODO(), filter).Decode(&card); err != nil {
+		return nil, catalogFindError(err, "Card not found")
+	}
+
+	domainCard := card.ToDomain()
+	return &domainCard, nil
+}
+
+func (r Repository) findCatalogCards(filter bson.M, opts *options.FindOptionsBuilder) ([]domain.CatalogCard, *domain.ResponseErr) {
+	ctx := context.TODO()
+	storage := r.client.Database(database).Collection(catalog_collection)
+
+	cursor, err := storage.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Find catalog cards error: %v", err),
+		}
+	}
+	defer cursor.Close(ctx)
+
+	var cards []CatalogCard
+	if err := cursor.All(ctx, &cards); err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Find catalog cards error: %v", err),
+		}
+	}
+
+	domainCards := make([]domain.CatalogCard, len(cards))
+	for i := range cards {
+		domainCards[i] = cards[i].ToDomain()
+	}
+	return domainCards, nil
+}
+
+func catalogFindError(err error, notFoundMessage string) *domain.ResponseErr {
+	if err == mongo.ErrNoDocuments {
+		return &domain.ResponseErr{
+			Status:  http.StatusNotFound,
+			Message: notFoundMessage,
+		}
+	}
+	return &domain.ResponseErr{
+		Status:  http.StatusInternalServerError,
+		Message: fmt.Sprintf("Find catalog error: %v", err),
+	}
+}
@@ -0,0 +1,69 @@
+package mongorep
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestCatalogSearch(t *testing.T) {
+	r := newTestRepository(t)
+	prefix := "Zzcatalog" + bson.NewObjectID().Hex()
+	oracleID := "oracle-" + prefix
+
+	cards := []domain.CatalogCard{
+		{ScryfallID: prefix + "-1", OracleID: oracleID, Name: prefix + " Bolt", SetCode: "M10", CollectorNumber: "146"},
+		{ScryfallID: prefix + "-2", OracleID: oracleID, Name: prefix + " Bolt", SetCode: "2xm", CollectorNumber: "141"},
+		{ScryfallID: prefix + "-3", Name: prefix + " Shock", SetCode: "m19", CollectorNumber: "156"},
+	}
+	t.Cleanup(func() {
+		ids := make([]string, len(cards))
+		for i, c := range cards {
+			ids[i] = c.ScryfallID
+		}
+		_, _ = r.client.Database(database).Collection(catalog_collection).DeleteMany(context.Background(), bson.M{"_id": bson.M{"$in": ids}})
+	})
+
+	// Upserting twice leaves one document per printing
+	require.Nil(t, r.UpsertCatalogCards(cards))
+	require.Nil(t, r.UpsertCatalogCards(cards))
+
+	found, respErr := r.SearchCatalogByName(fmt.Sprintf("%s b", prefix), 10)
+	require.Nil(t, respErr)
+	require.Len(t, found, 2)
+	assert.Equal(t, "2xm", found[0].SetCode)
+	assert.Equal(t, "m10", found[1].SetCode)
+
+	printing, respErr := r.FindCatalogPrinting("M10", "146")
+	require.Nil(t, respErr)
+	assert.Equal(t, prefix+"-1", printing.ScryfallID)
+
+	printings, respErr := r.FindCatalogByOracleID(oracleID)
+	require.Nil(t, respErr)
+	assert.Len(t, printings, 2)
+
+	byName, respErr := r.FindCard(prefix+" bolt", "2XM", "141")
+	require.Nil(t, respErr)
+	assert.Equal(t, prefix+"-2", byName.ScryfallID)
+
+	// Names are resolved by the catalog only, not by entries users stored
+	collection := newTestCollection(t, r)
+	stored := testCard(1)
+	stored.CollectionID = collection.ObjectID
+	stored.Name = prefix + " unknown"
+	_, err := r.client.Database(database).Collection(cards_collection).InsertOne(context.Background(), stored)
+	require.NoError(t, err)
+	_, respErr = r.FindCard(prefix+" unknown", "", "")
+	require.NotNil(t, respErr)
+	assert.Equal(t, http.StatusNotFound, respErr.Status)
+
+	_, respErr = r.GetCatalogCard(prefix + "-missing")
+	require.NotNil(t, respErr)
+	assert.Equal(t, http.StatusNotFound, respErr.Status)
+}
@@ -0,0 +1,172 @@
+package mongorep
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// CreateGroup stores a group with its owner as the only member
+func (r Repository) CreateGroup(group *domain.Group) (*domain.Group, *domain.ResponseErr) {
+	ownerObjectID, err := bson.ObjectIDFromHex(group.OwnerID)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid user ID format",
+		}
+	}
+
+	doc := Group{
+		ObjectID:   bson.NewObjectID(),
+		Name:       group.Name,
+		OwnerID:    ownerObjectID,
+		InviteCode: group.InviteCode,
+		Members:    []bson.ObjectID{ownerObjectID},
+		CreatedAt:  time.Now(),
+	}
+
+	storage := r.client.Database(database).Collection(groups_collection)
+	if _, err := storage.InsertOne(context.TODO(), doc); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, &domain.ResponseErr{
+				Status:  http.StatusConflict,
+				Message: "Invite code is taken",
+			}
+		}
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Create group error: %v", err),
+		}
+	}
+
+	created := doc.ToDomain()
+	return &created, nil
+}
+
+// JoinGroup adds the user to the group with the invite code. Joining again is a no-op.
+func (r Repository) JoinGroup(inviteCode, userID string) (*domain.Group, *domain.ResponseErr) {
+	userObjectID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid user ID format",
+		}
+	}
+
+	storage := r.client.Database(database).Collection(groups_collection)
+	filter := bson.M{"invite_code": inviteCode}
+	update := bson.M{"$addToSet": bson.M{"members": userObjectID}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var group Group
+	if err := storage.FindOneAndUpdate(context.TODO(), filter, update, opts).Decode(&group); err != nil {
+		return nil, groupFindError(err)
+	}
+
+	joined := group.ToDomain()
+	return &joined, nil
+}
+
+// LeaveGroup removes the user from the group. The group is deleted when its last member leaves.
+func (r Repository) LeaveGroup(groupID, userID string) *domain.ResponseErr {
+	userObjectID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid user ID format",
+		}
+	}
+	groupObjectID, err := bson.ObjectIDFromHex(groupID)
+	if err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid group ID format",
+		}
+	}
+
+	ctx := context.TODO()
+	storage := r.client.Database(database).Collection(groups_collection)
+	filter := bson.M{"_id": groupObjectID, "members": userObjectID}
+	update := bson.M{"$pull": bson.M{"members": userObjectID}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var group Group
+	if err := storage.FindOneAndUpdate(ctx, filter, update, opts).Decode(&group); err != nil {
+		return groupFindError(err)
+	}
+
+	if len(group.Members) == 0 {
+		if _, err := storage.DeleteOne(ctx, bson.M{"_id": groupObjectID, "members": bson.M{"$size": 0}}); err != nil {
+			return &domain.ResponseErr{
+				Status:  http.StatusInternalServerError,
+				Message: fmt.Sprintf("Delete group error: %v", err),
+			}
+		}
+	}
+
+	return nil
+}
+
+// FindUserGroups returns the groups the user is a member of in name order
+func (r Repository) FindUserGroups(userID string) ([]domain.Group, *domain.ResponseErr) {
+	userObjectID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid user ID format",
+		}
+	}
+
+	return r.findGroups(bson.M{"members": userObjectID})
+}
+
+// ListGroups returns all groups, it's used to refresh trade matches
+func (r Repository) ListGroups() ([]domain.Group, *domain.ResponseErr) {
+	return r.findGroups(bson.M{})
+}
+
+func (r Repository) findGroups(filter bson.M) ([]domain.Group, *domain.ResponseErr) {
+	ctx := context.TODO()
+	storage := r.client.Database(database).Collection(groups_collection)
+	cursor, err := storage.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Find groups error: %v", err),
+		}
+	}
+	defer cursor.Close(ctx)
+
+	var docs []Group
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Find groups error: %v", err),
+		}
+	}
+
+	groups := make([]domain.Group, len(docs))
+	for i := range docs {
+		groups[i] = docs[i].ToDomain()
+	}
+	return groups, nil
+}
+
+func groupFindError(err error) *domain.ResponseErr {
+	if err == mongo.ErrNoDocuments {
+		return &domain.ResponseErr{
+			Status:  http.StatusNotFound,
+			Message: "Group not found",
+		}
+	}
+	return &domain.ResponseErr{
+		Status:  http.StatusInternalServerError,
+		Message: fmt.Sprintf("Find group error: %v", err),
+	}
+}
@@ -0,0 +1,406 @@
+package mongorep
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// changeLog collects how a mutation changes card entries to record it in the collection history.
+// An entry changed more than once keeps the count it had before the first change.
+type changeLog struct {
+	entries []ChangeEntry
+	index   map[bson.ObjectID]int
+}
+
+// add logs that the entry count changed from before to after
+func (l *changeLog) add(entry Card, before, after int) {
+	if l.index == nil {
+		l.index = make(map[bson.ObjectID]int)
+	}
+	entry.Count = after
+	entry.DeletedAt = time.Time{}
+
+	if i, ok := l.index[entry.ObjectID]; ok {
+		l.entries[i].Card.Count = after
+		l.entries[i].After = after
+		return
+	}
+	l.index[entry.ObjectID] = len(l.entries)
+	l.entries = append(l.entries, ChangeEntry{Card: entry, Before: before, After: after})
+}
+
+// recordChange appends the change to the history of the collections of its entries in the
+// transaction of ctx. A change which didn't change any count isn't recorded unless it reverts
+// other changes. It returns the recorded change or nil.
+func (r Repository) recordChange(ctx context.Context, actor domain.Actor, kind domain.ChangeKind, log *changeLog, reverts ...bson.ObjectID) (*CollectionChange, *domain.ResponseErr) {
+	change := CollectionChange{
+		ObjectID:      bson.NewObjectID(),
+		CollectionIDs: []bson.ObjectID{},
+		Kind:          string(kind),
+		UserID:        actor.UserID,
+		RequestID:     actor.RequestID,
+		At:            time.Now(),
+		Entries:       []ChangeEntry{},
+		Reverts:       reverts,
+	}
+	seen := make(map[bson.ObjectID]bool)
+	for _, e := range log.entries {
+		if e.Before == e.After {
+			continue
+		}
+		change.Entries = append(change.Entries, e)
+		if !seen[e.Card.CollectionID] {
+			seen[e.Card.CollectionID] = true
+			change.CollectionIDs = append(change.CollectionIDs, e.Card.CollectionID)
+		}
+	}
+	if len(change.Entries) == 0 && len(reverts) == 0 {
+		return nil, nil
+	}
+
+	storage := r.client.Database(database).Collection(collection_changes_collection)
+	if _, err := storage.InsertOne(ctx, change); err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Record change error: %v", err),
+		}
+	}
+
+	return &change, nil
+}
+
+// changeWithUndo is a change with the undo change which reverted it
+type changeWithUndo struct {
+	CollectionChange `bson:",inline"`
+	UndoneBy         []struct {
+		ObjectID bson.ObjectID `bson:"_id"`
+	} `bson:"undone_by"`
+}
+
+func (c *changeWithUndo) ToDomain() domain.CollectionChange {
+	change := c.CollectionChange.ToDomain()
+	if len(c.UndoneBy) > 0 {
+		change.UndoneBy = c.UndoneBy[0].ObjectID.Hex()
+	}
+	return change
+}
+
+// undoneByLookup joins the undo change which reverted the change as undone_by
+func undoneByLookup() bson.D {
+	return bson.D{{Key: "$lookup", Value: bson.M{
+		"from":         collection_changes_collection,
+		"localField":   "_id",
+		"foreignField": "reverts",
+		"pipeline":     bson.A{bson.M{"$project": bson.M{"_id": 1}}},
+		"as":           "undone_by",
+	}}}
+}
+
+// FindCollectionChanges returns a page of the collection history, most recent changes first.
+// Collections of other users look missing.
+func (r Repository) FindCollectionChanges(userId, collectionId string, offset, limit int) (*domain.ChangesPage, *domain.ResponseErr) {
+	objectId, err := bson.ObjectIDFromHex(collectionId)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID format",
+		}
+	}
+
+	ctx := context.TODO()
+	if respErr := r.checkCollectionOwner(ctx, userId, objectId); respErr != nil {
+		return nil, respErr
+	}
+
+	storage := r.client.Database(database).Collection(collection_changes_collection)
+	filter := bson.M{"collection_ids": objectId}
+	total, err := storage.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Count changes error: %v", err),
+		}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: -1}}}},
+		{{Key: "$skip", Value: offset}},
+		{{Key: "$limit", Value: limit}},
+		undoneByLookup(),
+	}
+	cursor, err := storage.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Find changes error: %v", err),
+		}
+	}
+
+	var changes []changeWithUndo
+	if err := cursor.All(ctx, &changes); err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Decode changes error: %v", err),
+		}
+	}
+
+	page := &domain.ChangesPage{
+		Changes: make([]domain.CollectionChange, len(changes)),
+		Total:   int(total),
+		Offset:  offset,
+		Limit:   limit,
+	}
+	for i := range changes {
+		page.Changes[i] = changes[i].ToDomain()
+	}
+
+	return page, nil
+}
+
+// UndoChange reverts the change of the collection history and returns the undo change.
+// It fails with a conflict when entries of the change were changed later and those
+// changes aren't undone first. Collections of other users look missing.
+func (r Repository) UndoChange(actor domain.Actor, collectionId, changeId string) (*domain.CollectionChange, *domain.ResponseErr) {
+	objectId, err := bson.ObjectIDFromHex(collectionId)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID format",
+		}
+	}
+
+	changeObjectId, err := bson.ObjectIDFromHex(changeId)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid change ID format",
+		}
+	}
+
+	owned, respErr := ownedCollectionFilter(actor.UserID, objectId)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	var undo *CollectionChange
+	respErr = r.runInTransaction(func(ctx context.Context) error {
+		if respErr := r.touchCollection(ctx, withVersion(owned, actor.IfMatch), "Collection not found"); respErr != nil {
+			return respErr
+		}
+
+		storage := r.client.Database(database).Collection(collection_changes_collection)
+		var change CollectionChange
+		if err := storage.FindOne(ctx, bson.M{"_id": changeObjectId, "collection_ids": objectId}).Decode(&change); err != nil {
+			if err == mongo.ErrNoDocuments {
+				return &domain.ResponseErr{
+					Status:  http.StatusNotFound,
+					Message: "Change not found",
+				}
+			}
+			return &domain.ResponseErr{
+				Status:  http.StatusInternalServerError,
+				Message: fmt.Sprintf("Find change error: %v", err),
+			}
+		}
+		if change.Kind == string(domain.ChangeUndo) {
+			return &domain.ResponseErr{
+				Status:  http.StatusBadRequest,
+				Message: "Undo changes can't be undone",
+			}
+		}
+
+		undone, err := storage.CountDocuments(ctx, bson.M{"reverts": changeObjectId})
+		if err != nil {
+			return &domain.ResponseErr{
+				Status:  http.StatusInternalServerError,
+				Message: fmt.Sprintf("Find change error: %v", err),
+			}
+		}
+		if undone > 0 {
+			return &domain.ResponseErr{
+				Status:  http.StatusConflict,
+				Message: "Change is already undone",
+			}
+		}
+
+		var respErr *domain.ResponseErr
+		if undo, respErr = r.undoChanges(ctx, actor, objectId, []CollectionChange{change}); respErr != nil {
+			return respErr
+		}
+		return nil
+	})
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	domainChange := undo.ToDomain()
+	return &domainChange, nil
+}
+
+// UndoLastChanges reverts up to count most recent changes of the collection history which
+// aren't undone yet and returns the undo change. Either all of them are reverted or none.
+// Collections of other users look missing.
+func (r Repository) UndoLastChanges(actor domain.Actor, collectionId string, count int) (*domain.CollectionChange, *domain.ResponseErr) {
+	objectId, err := bson.ObjectIDFromHex(collectionId)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID format",
+		}
+	}
+
+	owned, respErr := ownedCollectionFilter(actor.UserID, objectId)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	var undo *CollectionChange
+	respErr = r.runInTransaction(func(ctx context.Context) error {
+		if respErr := r.touchCollection(ctx, withVersion(owned, actor.IfMatch), "Collection not found"); respErr != nil {
+			return respErr
+		}
+
+		storage := r.client.Database(database).Collection(collection_changes_collection)
+		pipeline := mongo.Pipeline{
+			{{Key: "$match", Value: bson.M{"collection_ids": objectId, "kind": bson.M{"$ne": string(domain.ChangeUndo)}}}},
+			{{Key: "$sort", Value: bson.D{{Key: "_id", Value: -1}}}},
+			undoneByLookup(),
+			{{Key: "$match", Value: bson.M{"undone_by": bson.M{"$size": 0}}}},
+			{{Key: "$limit", Value: count}},
+		}
+		cursor, err := storage.Aggregate(ctx, pipeline)
+		if err != nil {
+			return &domain.ResponseErr{
+				Status:  http.StatusInternalServerError,
+				Message: fmt.Sprintf("Find changes error: %v", err),
+			}
+		}
+
+		var changes []CollectionChange
+		if err := cursor.All(ctx, &changes); err != nil {
+			return &domain.ResponseErr{
+				Status:  http.StatusInternalServerError,
+				Message: fmt.Sprintf("Decode changes error: %v", err),
+			}
+		}
+		if len(changes) == 0 {
+			return &domain.ResponseErr{
+				Status:  http.StatusNotFound,
+				Message: "No changes to undo",
+			}
+		}
+
+		var respErr *domain.ResponseErr
+		if undo, respErr = r.undoChanges(ctx, actor, objectId, changes); respErr != nil {
+			return respErr
+		}
+		return nil
+	})
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	domainChange := undo.ToDomain()
+	return &domainChange, nil
+}
+
+// undoChanges reverts the changes, most recent first, and records the undo change.
+// Other collections a change touched are written first like the touched collection.
+func (r Repository) undoChanges(ctx context.Context, actor domain.Actor, touched bson.ObjectID, changes []CollectionChange) (*CollectionChange, *domain.ResponseErr) {
+	log := &changeLog{}
+	reverts := make([]bson.ObjectID, len(changes))
+	collections := map[bson.ObjectID]bool{touched: true}
+	for i, change := range changes {
+		reverts[i] = change.ObjectID
+		for _, id := range change.CollectionIDs {
+			if collections[id] {
+				continue
+			}
+			collections[id] = true
+			if respErr := r.touchCollection(ctx, bson.M{"_id": id}, "Collection of the change not found"); respErr != nil {
+				return nil, respErr
+			}
+		}
+
+		for j := len(change.Entries) - 1; j >= 0; j-- {
+			entry := change.Entries[j]
+			reverted, respErr := r.revertEntry(ctx, change.ObjectID, entry)
+			if respErr != nil {
+				return nil, respErr
+			}
+			log.add(reverted, entry.After, entry.Before)
+		}
+	}
+
+	return r.recordChange(ctx, actor, domain.ChangeUndo, log, reverts...)
+}
+
+// revertEntry sets the count of the entry back to the count before the change and returns
+// the entry. The entry must still have the count the change left, an entry which isn't in
+// the collection has no copies. Entries the change added go to the trash, entries it deleted
+// come back from the trash or are inserted again.
+func (r Repository) revertEntry(ctx context.Context, changeObjectId bson.ObjectID, entry ChangeEntry) (Card, *domain.ResponseErr) {
+	storage := r.client.Database(database).Collection(cards_collection)
+
+	var current Card
+	err := storage.FindOne(ctx, bson.M{"_id": entry.Card.ObjectID}).Decode(&current)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return Card{}, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Find card error: %v", err),
+		}
+	}
+	found := err == nil
+	count := 0
+	if found && current.DeletedAt.IsZero() {
+		count = current.Count
+	}
+	if count != entry.After {
+		return Card{}, &domain.ResponseErr{
+			Status:  http.StatusConflict,
+			Message: fmt.Sprintf("Card %s was changed after change %s", entry.Card.Name, changeObjectId.Hex()),
+		}
+	}
+
+	reverted := entry.Card
+	if found {
+		reverted = current
+	}
+	reverted.Count = entry.Before
+	reverted.DeletedAt = time.Time{}
+
+	filter := bson.M{"_id": entry.Card.ObjectID}
+	switch {
+	case entry.Before == 0:
+		_, err = storage.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"deleted_at": time.Now()}})
+	case found:
+		update := bson.M{
+			"$set":   bson.M{"count": entry.Before},
+			"$unset": bson.M{"deleted_at": ""},
+		}
+		_, err = storage.UpdateOne(ctx, filter, update)
+	default:
+		_, err = storage.InsertOne(ctx, reverted)
+	}
+	if mongo.IsDuplicateKeyError(err) {
+		return Card{}, &domain.ResponseErr{
+			Status:  http.StatusConflict,
+			Message: fmt.Sprintf("Collection has another entry of card %s now", entry.Card.Name),
+		}
+	}
+	if err != nil {
+		return Card{}, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Revert card error: %v", err),
+		}
+	}
+
+	return reverted, nil
+}
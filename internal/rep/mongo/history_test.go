@@ -0,0 +1,100 @@
+package mongorep
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardChangesAreRecordedAndUndone(t *testing.T) {
+	r := newTestRepository(t)
+	collection := newTestCollection(t, r)
+	collectionId := collection.ObjectID.Hex()
+	owner := ownerOf(collection)
+
+	entry := testCard(1)
+	card := entry.ToDomain()
+	card.ID = ""
+	card.Count = 2
+	stored, respErr := r.AddCardToCollection(owner, collectionId, &card)
+	require.Nil(t, respErr)
+	_, respErr = r.AdjustCardCount(owner, collectionId, &domain.CardAdjustment{EntryID: stored.ID, Delta: 3})
+	require.Nil(t, respErr)
+
+	page, respErr := r.FindCollectionChanges(owner.UserID, collectionId, 0, 10)
+	require.Nil(t, respErr)
+	require.Equal(t, 2, page.Total)
+	adjust := page.Changes[0]
+	require.Equal(t, domain.ChangeAdjust, adjust.Kind)
+	require.Equal(t, owner.RequestID, adjust.RequestID)
+	require.Equal(t, 2, adjust.Entries[0].Before)
+	require.Equal(t, 5, adjust.Entries[0].After)
+
+	// Collections of other users look missing
+	_, respErr = r.FindCollectionChanges(testActor.UserID, collectionId, 0, 10)
+	require.NotNil(t, respErr)
+	require.Equal(t, http.StatusNotFound, respErr.Status)
+	_, respErr = r.UndoLastChanges(testActor, collectionId, 1)
+	require.NotNil(t, respErr)
+	require.Equal(t, http.StatusNotFound, respErr.Status)
+
+	// The add can't be undone while the adjust after it isn't
+	_, respErr = r.UndoChange(owner, collectionId, page.Changes[1].ID)
+	require.NotNil(t, respErr)
+	require.Equal(t, http.StatusConflict, respErr.Status)
+
+	undo, respErr := r.UndoLastChanges(owner, collectionId, 2)
+	require.Nil(t, respErr)
+	require.Len(t, undo.Reverts, 2)
+
+	cards, respErr := r.ListCards(collectionId, &domain.CardsQuery{SortBy: domain.SortByName, Limit: 10})
+	require.Nil(t, respErr)
+	require.Zero(t, cards.Total)
+
+	page, respErr = r.FindCollectionChanges(owner.UserID, collectionId, 0, 10)
+	require.Nil(t, respErr)
+	require.Equal(t, domain.ChangeUndo, page.Changes[0].Kind)
+	require.Equal(t, undo.ID, page.Changes[1].UndoneBy)
+
+	_, respErr = r.UndoLastChanges(owner, collectionId, 1)
+	require.NotNil(t, respErr)
+	require.Equal(t, http.StatusNotFound, respErr.Status)
+}
+
+func TestUndoDeleteBringsEntryBack(t *testing.T) {
+	r := newTestRepository(t)
+	collection := newTestCollection(t, r)
+	collectionId := collection.ObjectID.Hex()
+	owner := ownerOf(collection)
+
+	entry := testCard(1)
+	card := entry.ToDomain()
+	card.ID = ""
+	card.Count = 4
+	stored, respErr := r.AddCardToCollection(owner, collectionId, &card)
+	require.Nil(t, respErr)
+	require.Nil(t, r.DeleteCardFromCollection(owner, collectionId, &domain.Card{ID: stored.ID}))
+
+	page, respErr := r.FindCollectionChanges(owner.UserID, collectionId, 0, 1)
+	require.Nil(t, respErr)
+	require.Equal(t, domain.ChangeDelete, page.Changes[0].Kind)
+
+	_, respErr = r.UndoChange(testActor, collectionId, page.Changes[0].ID)
+	require.NotNil(t, respErr)
+	require.Equal(t, http.StatusNotFound, respErr.Status)
+
+	_, respErr = r.UndoChange(owner, collectionId, page.Changes[0].ID)
+	require.Nil(t, respErr)
+
+	cards, respErr := r.ListCards(collectionId, &domain.CardsQuery{SortBy: domain.SortByName, Limit: 10})
+	require.Nil(t, respErr)
+	require.Len(t, cards.Cards, 1)
+	require.Equal(t, stored.ID, cards.Cards[0].ID)
+	require.Equal(t, 4, cards.Cards[0].Count)
+
+	_, respErr = r.UndoChange(owner, collectionId, page.Changes[0].ID)
+	require.NotNil(t, respErr)
+	require.Equal(t, http.StatusConflict, respErr.Status)
+}
@@ -0,0 +1,135 @@
+package mongorep
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// cardHoldings is a result document of the FindHoldings aggregation
+type cardHoldings struct {
+	OracleKey string `bson:"_id"`
+	Entries   []Card `bson:"entries"`
+	Owned     []struct {
+		CollectionID bson.ObjectID `bson:"collection_id"`
+		Count        int           `bson:"count"`
+	} `bson:"owned"`
+}
+
+// FindHoldings groups the cards of the target collection by oracle identity, so any
+// printing of a card is the same card, and sums the copies of every card the source
+// collections hold. Cards missing from the catalog are matched by name. Maybeboard
+// entries of the target are left out, and so are cards the target doesn't have.
+func (r Repository) FindHoldings(targetId string, sourceIds []string) ([]domain.CardHoldings, *domain.ResponseErr) {
+	targetObjectId, err := bson.ObjectIDFromHex(targetId)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID format",
+		}
+	}
+	sourceObjectIds := make([]bson.ObjectID, 0, len(sourceIds))
+	for _, id := range sourceIds {
+		objectId, err := bson.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, &domain.ResponseErr{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid collection ID format",
+			}
+		}
+		if objectId != targetObjectId {
+			sourceObjectIds = append(sourceObjectIds, objectId)
+		}
+	}
+
+	isTarget := bson.M{"$eq": bson.A{"$$this.collection_id", targetObjectId}}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"deleted_at": notTrashed(),
+			"$or": bson.A{
+				bson.M{"collection_id": targetObjectId, "zone": bson.M{"$ne": string(domain.ZoneMaybe)}},
+				bson.M{"collection_id": bson.M{"$in": sourceObjectIds}},
+			},
+		}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         catalog_collection,
+			"localField":   "scryfall_id",
+			"foreignField": "_id",
+			"as":           "printing",
+		}}},
+		{{Key: "$set", Value: bson.M{"oracle_key": bson.M{"$ifNull": bson.A{
+			bson.M{"$arrayElemAt": bson.A{"$printing.oracle_id", 0}},
+			bson.M{"$concat": bson.A{"name:", bson.M{"$toLower": "$name"}}},
+		}}}}},
+		{{Key: "$unset", Value: "printing"}},
+		{{Key: "$sort", Value: bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}}},
+		// Copies of the card in every collection
+		{{Key: "$group", Value: bson.M{
+			"_id":     bson.M{"key": "$oracle_key", "collection_id": "$collection_id"},
+			"count":   bson.M{"$sum": "$count"},
+			"entries": bson.M{"$push": "$$ROOT"},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": "$_id.key",
+			"parts": bson.M{"$push": bson.M{
+				"collection_id": "$_id.collection_id",
+				"count":         "$count",
+				"entries":       "$entries",
+			}},
+		}}},
+		// Entries come from the target, owned copies from the sources
+		{{Key: "$project", Value: bson.M{
+			"entries": bson.M{"$reduce": bson.M{
+				"input":        bson.M{"$filter": bson.M{"input": "$parts", "cond": isTarget}},
+				"initialValue": bson.A{},
+				"in":           bson.M{"$concatArrays": bson.A{"$$value", "$$this.entries"}},
+			}},
+			"owned": bson.M{"$map": bson.M{
+				"input": bson.M{"$filter": bson.M{"input": "$parts", "cond": bson.M{"$not": bson.A{isTarget}}}},
+				"in":    bson.M{"collection_id": "$$this.collection_id", "count": "$$this.count"},
+			}},
+		}}},
+		{{Key: "$match", Value: bson.M{"entries.0": bson.M{"$exists": true}}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	ctx := context.TODO()
+	storage := r.client.Database(database).Collection(cards_collection)
+	cursor, err := storage.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Find holdings error: %v", err),
+		}
+	}
+	defer cursor.Close(ctx)
+
+	var docs []cardHoldings
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Find holdings error: %v", err),
+		}
+	}
+
+	holdings := make([]domain.CardHoldings, len(docs))
+	for i, doc := range docs {
+		holding := domain.CardHoldings{
+			OracleKey: doc.OracleKey,
+			Entries:   make([]domain.Card, len(doc.Entries)),
+			Owned:     make(map[string]int, len(doc.Owned)),
+		}
+		for j := range doc.Entries {
+			holding.Entries[j] = doc.Entries[j].ToDomain()
+		}
+		for _, owned := range doc.Owned {
+			holding.Owned[owned.CollectionID.Hex()] = owned.Count
+		}
+		holdings[i] = holding
+	}
+	return holdings, nil
+}
@@ -0,0 +1,55 @@
+package mongorep
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestFindHoldingsMatchesAnyPrinting(t *testing.T) {
+	r := newTestRepository(t)
+	deckDoc, binderDoc := newTestCollection(t, r), newTestCollection(t, r)
+	deck, binder := deckDoc.ToDomain(), binderDoc.ToDomain()
+	owners := map[string]domain.Actor{deck.ID: ownerOf(deckDoc), binder.ID: ownerOf(binderDoc)}
+	prefix := "holdings-" + bson.NewObjectID().Hex()
+	oracleID := "oracle-" + prefix
+
+	printings := []domain.CatalogCard{
+		{ScryfallID: prefix + "-m10", OracleID: oracleID, Name: "Lightning Bolt", SetCode: "m10", CollectorNumber: "146"},
+		{ScryfallID: prefix + "-2xm", OracleID: oracleID, Name: "Lightning Bolt", SetCode: "2xm", CollectorNumber: "141"},
+	}
+	t.Cleanup(func() {
+		_, _ = r.client.Database(database).Collection(catalog_collection).DeleteMany(context.Background(), bson.M{"oracle_id": oracleID})
+	})
+	require.Nil(t, r.UpsertCatalogCards(printings))
+
+	add := func(collectionId string, card domain.Card) {
+		card.SetVariantDefaults()
+		_, respErr := r.AddCardToCollection(owners[collectionId], collectionId, &card)
+		require.Nil(t, respErr)
+	}
+	add(deck.ID, domain.Card{ScryfallID: prefix + "-m10", Name: "Lightning Bolt", Count: 4})
+	add(deck.ID, domain.Card{ScryfallID: prefix + "-m10", Name: "Lightning Bolt", Count: 2, Zone: domain.ZoneSide})
+	add(deck.ID, domain.Card{ScryfallID: prefix + "-rats", Name: "Relentless Rats", Count: 1, Zone: domain.ZoneMaybe})
+	add(deck.ID, domain.Card{ScryfallID: prefix + "-goblin", Name: "Goblin Guide", Count: 4})
+	add(binder.ID, domain.Card{ScryfallID: prefix + "-2xm", Name: "Lightning Bolt", Count: 3})
+	add(binder.ID, domain.Card{ScryfallID: prefix + "-2xm", Name: "Lightning Bolt", Count: 1, Finish: domain.FinishFoil})
+	add(binder.ID, domain.Card{ScryfallID: prefix + "-goblin-old", Name: "Goblin Guide", Count: 2})
+	add(binder.ID, domain.Card{ScryfallID: prefix + "-shock", Name: "Shock", Count: 4})
+
+	holdings, respErr := r.FindHoldings(deck.ID, []string{binder.ID, deck.ID})
+	require.Nil(t, respErr)
+	require.Len(t, holdings, 2, "maybeboard and cards the deck doesn't have are left out")
+
+	goblin, bolt := holdings[0], holdings[1]
+	assert.Equal(t, "name:goblin guide", goblin.OracleKey, "cards missing from the catalog match by name")
+	assert.Equal(t, map[string]int{binder.ID: 2}, goblin.Owned)
+
+	assert.Equal(t, oracleID, bolt.OracleKey)
+	assert.Len(t, bolt.Entries, 2)
+	assert.Equal(t, map[string]int{binder.ID: 4}, bolt.Owned, "the deck isn't a source of its own cards")
+}
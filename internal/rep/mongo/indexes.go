@@ -0,0 +1,236 @@
+package mongorep
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// caseInsensitive compares strings ignoring case. Queries must use the same
+// collation as an index to use it.
+var caseInsensitive = &options.Collation{Locale: "en", Strength: 2}
+
+// Server error codes of dropping an index that doesn't exist
+const (
+	namespaceNotFound = 26
+	indexNotFound     = 27
+)
+
+// EnsureIndexes creates indexes the repository relies on. Creating an existing index is a no-op.
+func (r Repository) EnsureIndexes() error {
+	ctx := context.TODO()
+
+	// Collection names are unique per user ignoring case. Trashed collections have
+	// their deletion time in the key, so they don't take names of active ones.
+	storage := r.client.Database(database).Collection(collections_collection)
+	_, err := storage.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "user_id", Value: 1},
+			{Key: "name", Value: 1},
+			{Key: "deleted_at", Value: 1},
+		},
+		Options: options.Index().
+			SetName("user_id_name_deleted_at_unique").
+			SetUnique(true).
+			SetCollation(caseInsensitive),
+	})
+	if err != nil {
+		return err
+	}
+	if err := dropIndex(ctx, storage, "user_id_name_unique"); err != nil {
+		return err
+	}
+
+	// One entry per card variant in a collection, not counting trashed entries. The
+	// (collection_id, scryfall_id) prefix also serves lookups of a card's entries in a collection.
+	storage = r.client.Database(database).Collection(cards_collection)
+	_, err = storage.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "collection_id", Value: 1},
+				{Key: "scryfall_id", Value: 1},
+				{Key: "zone", Value: 1},
+				{Key: "finish", Value: 1},
+				{Key: "condition", Value: 1},
+				{Key: "language", Value: 1},
+				{Key: "deleted_at", Value: 1},
+			},
+			Options: options.Index().
+				SetName("collection_id_scryfall_id_variant_deleted_at_unique").
+				SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				{Key: "collection_id", Value: 1},
+				{Key: "name", Value: 1},
+			},
+			Options: options.Index().SetName("collection_id_name"),
+		},
+		{
+			// Card search across a user's collections. Names aren't stemmed.
+			Keys: bson.D{{Key: "name", Value: "text"}},
+			Options: options.Index().
+				SetName("name_text").
+				SetDefaultLanguage("none"),
+		},
+		{
+			// Trash purges
+			Keys:    bson.D{{Key: "deleted_at", Value: 1}},
+			Options: options.Index().SetName("deleted_at").SetSparse(true),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if err := dropIndex(ctx, storage, "collection_id_scryfall_id_variant_unique"); err != nil {
+		return err
+	}
+
+	// Catalog lookups by name prefix, printing and oracle card
+	storage = r.client.Database(database).Collection(catalog_collection)
+	_, err = storage.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "name_key", Value: 1}, {Key: "set", Value: 1}},
+			Options: options.Index().SetName("name_key_set"),
+		},
+		{
+			Keys:    bson.D{{Key: "set", Value: 1}, {Key: "collector_number", Value: 1}},
+			Options: options.Index().SetName("set_collector_number"),
+		},
+		{
+			Keys:    bson.D{{Key: "oracle_id", Value: 1}},
+			Options: options.Index().SetName("oracle_id"),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// One price snapshot per printing and day, newest first for valuations
+	storage = r.client.Database(database).Collection(card_prices_collection)
+	_, err = storage.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "scryfall_id", Value: 1},
+			{Key: "date", Value: -1},
+		},
+		Options: options.Index().
+			SetName("scryfall_id_date_unique").
+			SetUnique(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	// One value per collection and day, user totals are summed by date
+	storage = r.client.Database(database).Collection(collection_values_collection)
+	_, err = storage.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "collection_id", Value: 1},
+				{Key: "date", Value: 1},
+			},
+			Options: options.Index().
+				SetName("collection_id_date_unique").
+				SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "date", Value: 1}},
+			Options: options.Index().SetName("user_id_date"),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Groups are joined by invite code and listed by member
+	storage = r.client.Database(database).Collection(groups_collection)
+	_, err = storage.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "invite_code", Value: 1}},
+			Options: options.Index().
+				SetName("invite_code_unique").
+				SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "members", Value: 1}},
+			Options: options.Index().SetName("members"),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// One match per user and partner, listed most even trades first
+	storage = r.client.Database(database).Collection(trade_matches_collection)
+	_, err = storage.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "user_id", Value: 1},
+				{Key: "partner_id", Value: 1},
+			},
+			Options: options.Index().
+				SetName("user_id_partner_id_unique").
+				SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				{Key: "user_id", Value: 1},
+				{Key: "score", Value: -1},
+				{Key: "total_value", Value: -1},
+			},
+			Options: options.Index().SetName("user_id_score_total_value"),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// The history of a collection is listed most recent first, undone changes by their undo
+	storage = r.client.Database(database).Collection(collection_changes_collection)
+	_, err = storage.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "collection_ids", Value: 1},
+				{Key: "_id", Value: -1},
+			},
+			Options: options.Index().SetName("collection_ids_id"),
+		},
+		{
+			Keys:    bson.D{{Key: "reverts", Value: 1}},
+			Options: options.Index().SetName("reverts"),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Snapshot labels are unique within a collection
+	storage = r.client.Database(database).Collection(snapshots_collection)
+	_, err = storage.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "collection_id", Value: 1},
+			{Key: "label", Value: 1},
+		},
+		Options: options.Index().
+			SetName("collection_id_label_unique").
+			SetUnique(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// dropIndex drops an index replaced by another one, a missing index or collection is fine
+func dropIndex(ctx context.Context, storage *mongo.Collection, name string) error {
+	err := storage.Indexes().DropOne(ctx, name)
+	var serverErr mongo.ServerError
+	if errors.As(err, &serverErr) && (serverErr.HasErrorCode(indexNotFound) || serverErr.HasErrorCode(namespaceNotFound)) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,145 @@
+package mongorep
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// Benchmarks compare card entries embedded in the collection document with
+// entries stored in cards_collection. Run them against a replica set:
+//
+//	MONGO_TEST_CONN_STRING=mongodb://localhost:27017/?directConnection=true go test -run '^$' -bench Layout ./internal/rep/mongo
+
+const embeddedBenchCollection = "bench_embedded_collections"
+
+var benchSizes = []int{100, 1000, 10000}
+
+func BenchmarkLayoutAddCard(b *testing.B) {
+	for _, size := range benchSizes {
+		b.Run(fmt.Sprintf("embedded/%d", size), func(b *testing.B) {
+			r := newTestRepository(b)
+			storage, collectionObjectId := seedEmbeddedCollection(b, r, size)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				addEmbeddedCard(b, storage, collectionObjectId, testCard(size+i))
+			}
+		})
+
+		b.Run(fmt.Sprintf("separate/%d", size), func(b *testing.B) {
+			r := newTestRepository(b)
+			collection := seedSeparateCollection(b, r, size)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				entry := testCard(size + i)
+				card := entry.ToDomain()
+				_, respErr := r.AddCardToCollection(ownerOf(collection), collection.ObjectID.Hex(), &card)
+				require.Nil(b, respErr)
+			}
+		})
+	}
+}
+
+func BenchmarkLayoutListCards(b *testing.B) {
+	for _, size := range benchSizes {
+		b.Run(fmt.Sprintf("embedded/%d", size), func(b *testing.B) {
+			r := newTestRepository(b)
+			storage, collectionObjectId := seedEmbeddedCollection(b, r, size)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				var collection embeddedCollection
+				err := storage.FindOne(context.Background(), bson.M{"_id": collectionObjectId}).Decode(&collection)
+				require.NoError(b, err)
+			}
+		})
+
+		b.Run(fmt.Sprintf("separate/%d", size), func(b *testing.B) {
+			r := newTestRepository(b)
+			collection := seedSeparateCollection(b, r, size)
+			query := domain.CardsQuery{SortBy: domain.SortByName, Limit: domain.DefaultCardsLimit}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				q := query
+				_, respErr := r.ListCards(collection.ObjectID.Hex(), &q)
+				require.Nil(b, respErr)
+			}
+		})
+	}
+}
+
+// seedEmbeddedCollection stores a collection document with size embedded card entries
+func seedEmbeddedCollection(b *testing.B, r *Repository, size int) (*mongo.Collection, bson.ObjectID) {
+	b.Helper()
+
+	collection := embeddedCollection{
+		ObjectID: bson.NewObjectID(),
+		Cards:    make([]Card, size),
+	}
+	for i := range collection.Cards {
+		collection.Cards[i] = testCard(i)
+	}
+
+	ctx := context.Background()
+	storage := r.client.Database(database).Collection(embeddedBenchCollection)
+	_, err := storage.InsertOne(ctx, collection)
+	require.NoError(b, err)
+	b.Cleanup(func() {
+		_, _ = storage.DeleteOne(ctx, bson.M{"_id": collection.ObjectID})
+	})
+
+	return storage, collection.ObjectID
+}
+
+// seedSeparateCollection stores a collection with size card entries in cards_collection
+func seedSeparateCollection(b *testing.B, r *Repository, size int) Collection {
+	b.Helper()
+
+	collection := newTestCollection(b, r)
+	cards := make([]Card, size)
+	for i := range cards {
+		cards[i] = testCard(i)
+		cards[i].CollectionID = collection.ObjectID
+	}
+
+	storage := r.client.Database(database).Collection(cards_collection)
+	_, err := storage.InsertMany(context.Background(), cards)
+	require.NoError(b, err)
+
+	return collection
+}
+
+// addEmbeddedCard adds a card the way the embedded layout did: increment the
+// entry of the variant in the array or push a new entry to it
+func addEmbeddedCard(b *testing.B, storage *mongo.Collection, collectionObjectId bson.ObjectID, card Card) {
+	b.Helper()
+
+	ctx := context.Background()
+	domainCard := card.ToDomain()
+	filter := bson.M{
+		"_id":   collectionObjectId,
+		"cards": bson.M{"$elemMatch": cardVariantFilter(&domainCard)},
+	}
+	update := bson.M{"$inc": bson.M{"cards.$.count": card.Count}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var updated embeddedCollection
+	err := storage.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated)
+	if err == nil {
+		return
+	}
+	require.ErrorIs(b, err, mongo.ErrNoDocuments)
+
+	update = bson.M{"$push": bson.M{"cards": card}}
+	_, err = storage.UpdateOne(ctx, bson.M{"_id": collectionObjectId}, update)
+	require.NoError(b, err)
+}
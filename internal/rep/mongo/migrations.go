@@ -0,0 +1,172 @@
+package mongorep
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// embeddedCollection is a collection document stored before card entries
+// were moved out of the collection document to cards_collection
+type embeddedCollection struct {
+	ObjectID bson.ObjectID `bson:"_id"`
+	Cards    []Card        `bson:"cards"`
+}
+
+// MigrateEmbeddedCards moves card entries embedded in collection documents to cards_collection.
+// Entries get default variant fields and copies of the same variant are summed into one entry.
+// Each collection is migrated in its own transaction and loses its cards array, so the migration
+// can be interrupted and run again. It returns the number of migrated collections.
+func (r Repository) MigrateEmbeddedCards() (int, error) {
+	ctx := context.TODO()
+	storage := r.client.Database(database).Collection(collections_collection)
+
+	cursor, err := storage.Find(ctx, bson.M{"cards": bson.M{"$exists": true}})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	migrated := 0
+	for cursor.Next(ctx) {
+		var collection embeddedCollection
+		if err := cursor.Decode(&collection); err != nil {
+			return migrated, err
+		}
+
+		var writes []mongo.WriteModel
+		for _, v := range collection.Cards {
+			card := v.ToDomain()
+			card.SetVariantDefaults()
+
+			entryObjectId := v.ObjectID
+			if entryObjectId.IsZero() {
+				entryObjectId = bson.NewObjectID()
+			}
+			addedAt := v.AddedAt
+			if addedAt.IsZero() {
+				addedAt = entryObjectId.Timestamp()
+			}
+
+			filter := cardVariantFilter(&card)
+			filter["collection_id"] = collection.ObjectID
+			filter["deleted_at"] = notTrashed()
+			update := bson.M{
+				"$inc": bson.M{"count": card.Count},
+				"$setOnInsert": bson.M{
+					"_id":      entryObjectId,
+					"name":     card.Name,
+					"card_url": card.CardUrl,
+					"added_at": addedAt,
+				},
+			}
+			writes = append(writes, mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(update).SetUpsert(true))
+		}
+
+		respErr := r.runInTransaction(func(ctx context.Context) error {
+			if len(writes) > 0 {
+				cards := r.client.Database(database).Collection(cards_collection)
+				if _, err := cards.BulkWrite(ctx, writes); err != nil {
+					return err
+				}
+			}
+
+			update := bson.M{"$unset": bson.M{"cards": ""}}
+			_, err := storage.UpdateOne(ctx, bson.M{"_id": collection.ObjectID}, update)
+			return err
+		})
+		if respErr != nil {
+			return migrated, respErr
+		}
+		migrated++
+	}
+
+	return migrated, cursor.Err()
+}
+
+// duplicateCollectionNames are collections of a user sharing a name ignoring case, oldest first
+type duplicateCollectionNames struct {
+	Key struct {
+		UserID    bson.ObjectID `bson:"user_id"`
+		Name      string        `bson:"name"`
+		DeletedAt *time.Time    `bson:"deleted_at"`
+	} `bson:"_id"`
+	IDs []bson.ObjectID `bson:"ids"`
+}
+
+// RenameDuplicateCollections renames collections of a user sharing a name ignoring case to
+// "Name (2)", "Name (3)" and so on, the oldest one keeps the name. Names were stored unchecked
+// before they got a unique index, and the index can't be built while duplicates exist, so this
+// runs before EnsureIndexes. It returns the number of renamed collections.
+func (r Repository) RenameDuplicateCollections() (int, error) {
+	ctx := context.TODO()
+	storage := r.client.Database(database).Collection(collections_collection)
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"user_id": "$user_id",
+				"name":    "$name",
+				// Missing and null deletion times are the same key of the index
+				"deleted_at": bson.M{"$ifNull": bson.A{"$deleted_at", nil}},
+			},
+			"ids": bson.M{"$push": "$_id"},
+		}}},
+		{{Key: "$match", Value: bson.M{"ids.1": bson.M{"$exists": true}}}},
+	}
+	cursor, err := storage.Aggregate(ctx, pipeline, options.Aggregate().SetCollation(caseInsensitive))
+	if err != nil {
+		return 0, err
+	}
+	var groups []duplicateCollectionNames
+	if err := cursor.All(ctx, &groups); err != nil {
+		return 0, err
+	}
+
+	renamed := 0
+	for _, group := range groups {
+		suffix := 2
+		for _, collectionId := range group.IDs[1:] {
+			var name string
+			for {
+				name = fmt.Sprintf("%s (%d)", group.Key.Name, suffix)
+				suffix++
+				filter := bson.M{"user_id": group.Key.UserID, "name": name, "deleted_at": group.Key.DeletedAt}
+				taken, err := storage.CountDocuments(ctx, filter, options.Count().SetCollation(caseInsensitive).SetLimit(1))
+				if err != nil {
+					return renamed, err
+				}
+				if taken == 0 {
+					break
+				}
+			}
+
+			respErr := r.runInTransaction(func(ctx context.Context) error {
+				now := time.Now()
+				update := bson.M{
+					"$set": bson.M{"name": name, "updated_at": now},
+					"$inc": bson.M{"version": 1},
+				}
+				if _, err := storage.UpdateOne(ctx, bson.M{"_id": collectionId}, update); err != nil {
+					return err
+				}
+
+				users := r.client.Database(database).Collection(users_collection)
+				update = bson.M{"$set": bson.M{"collections.$.name": name, "updated_at": now}}
+				_, err := users.UpdateOne(ctx, bson.M{"collections._id": collectionId}, update)
+				return err
+			})
+			if respErr != nil {
+				return renamed, respErr
+			}
+			renamed++
+		}
+	}
+
+	return renamed, nil
+}
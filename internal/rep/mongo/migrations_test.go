@@ -0,0 +1,61 @@
+package mongorep
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestRenameDuplicateCollections(t *testing.T) {
+	r := newTestRepository(t)
+	ctx := context.Background()
+	db := r.client.Database(database)
+	storage := db.Collection(collections_collection)
+
+	// Duplicates are stored as they were before the unique index
+	require.NoError(t, dropIndex(ctx, storage, "user_id_name_deleted_at_unique"))
+
+	userObjectId := bson.NewObjectID()
+	now := time.Now()
+	var collections []Collection
+	var refs []UserCollectionRef
+	for _, name := range []string{"Burn", "burn", "Burn (2)", "BURN"} {
+		collection := Collection{
+			ObjectID:  bson.NewObjectID(),
+			UserID:    userObjectId,
+			Name:      name,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		collections = append(collections, collection)
+		refs = append(refs, UserCollectionRef{ObjectID: collection.ObjectID, Name: name})
+		_, err := storage.InsertOne(ctx, collection)
+		require.NoError(t, err)
+	}
+	_, err := db.Collection(users_collection).InsertOne(ctx, User{ObjectID: userObjectId, Collections: refs})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, _ = storage.DeleteMany(ctx, bson.M{"user_id": userObjectId})
+		_, _ = db.Collection(users_collection).DeleteOne(ctx, bson.M{"_id": userObjectId})
+	})
+
+	renamed, err := r.RenameDuplicateCollections()
+	require.NoError(t, err)
+	require.Equal(t, 2, renamed)
+
+	want := []string{"Burn", "Burn (3)", "Burn (2)", "Burn (4)"}
+	for i, collection := range collections {
+		var stored Collection
+		require.NoError(t, storage.FindOne(ctx, bson.M{"_id": collection.ObjectID}).Decode(&stored))
+		require.Equal(t, want[i], stored.Name)
+	}
+
+	var user User
+	require.NoError(t, db.Collection(users_collection).FindOne(ctx, bson.M{"_id": userObjectId}).Decode(&user))
+	require.Equal(t, "Burn (3)", user.Collections[1].Name)
+
+	require.NoError(t, r.EnsureIndexes())
+}
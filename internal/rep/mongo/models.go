@@ -1,6 +1,7 @@
 package mongorep
 
 import (
+	"strings"
 	"time"
 
 	"github.com/ShenokZlob/collector-service/domain"
@@ -8,10 +9,21 @@ import (
 )
 
 const (
-	database               = "collector_ouphe_db"
-	users_collection       = "users"
-	collections_collection = "collections"
-	tokens_collection      = "tokens"
+	database                      = "collector_ouphe_db"
+	users_collection              = "users"
+	collections_collection        = "collections"
+	cards_collection              = "collection_cards"
+	catalog_collection            = "cards_catalog"
+	scryfall_cache_collection     = "scryfall_cache"
+	catalog_imports_collection    = "catalog_imports"
+	card_prices_collection        = "card_prices"
+	collection_values_collection  = "collection_values"
+	groups_collection             = "groups"
+	trade_matches_collection      = "trade_matches"
+	stats_cache_collection        = "stats_cache"
+	tokens_collection             = "tokens"
+	collection_changes_collection = "collection_changes"
+	snapshots_collection          = "snapshots"
 )
 
 // user collection
@@ -98,45 +110,525 @@ type Collection struct {
 	ObjectID  bson.ObjectID `bson:"_id,omitempty"`
 	UserID    bson.ObjectID `bson:"user_id"`
 	Name      string        `bson:"name"`
-	Cards     []Card        `bson:"cards,omitempty"`
+	Kind      string        `bson:"kind,omitempty"` // empty for binders created before kinds
+	Cards     []Card        `bson:"-"`              // stored in cards_collection
 	CreatedAt time.Time     `bson:"created_at"`
 	UpdatedAt time.Time     `bson:"updated_at"`
+	DeletedAt time.Time     `bson:"deleted_at,omitempty"` // set while the collection is in the trash
+	Version   int64         `bson:"version"`              // missing in collections which weren't changed since versions were added
 }
 
+// cards_collection, one document per card entry of a collection
 type Card struct {
+	ObjectID     bson.ObjectID `bson:"_id,omitempty"`
+	CollectionID bson.ObjectID `bson:"collection_id,omitempty"`
+	ScryfallID   string        `bson:"scryfall_id"`
+	Name         string        `bson:"name"`
+	CardUrl      string        `bson:"card_url"`
+	Count        int           `bson:"count"`
+	Zone         string        `bson:"zone"`
+	Finish       string        `bson:"finish"`
+	Condition    string        `bson:"condition"`
+	Language     string        `bson:"language"`
+	SetCode      string        `bson:"set,omitempty"`
+	Rarity       string        `bson:"rarity,omitempty"`
+	TypeLine     string        `bson:"type_line,omitempty"`
+	AddedAt      time.Time     `bson:"added_at"`
+	DeletedAt    time.Time     `bson:"deleted_at,omitempty"` // set while the entry is in the trash
+}
+
+// collection_changes_collection, append-only history of card changes
+type CollectionChange struct {
+	ObjectID      bson.ObjectID   `bson:"_id"`
+	CollectionIDs []bson.ObjectID `bson:"collection_ids"`
+	Kind          string          `bson:"kind"`
+	UserID        string          `bson:"user_id"`
+	RequestID     string          `bson:"request_id,omitempty"`
+	At            time.Time       `bson:"at"`
+	Entries       []ChangeEntry   `bson:"entries"`
+	Reverts       []bson.ObjectID `bson:"reverts,omitempty"` // changes reverted by an undo change
+}
+
+type ChangeEntry struct {
+	Card   Card `bson:"card"` // snapshot of the entry, it's inserted again when an undo needs it back
+	Before int  `bson:"before"`
+	After  int  `bson:"after"`
+}
+
+// snapshots_collection, immutable copies of the card entries of a collection
+type Snapshot struct {
+	ObjectID     bson.ObjectID `bson:"_id"`
+	CollectionID bson.ObjectID `bson:"collection_id"`
+	Label        string        `bson:"label"`
+	CreatedAt    time.Time     `bson:"created_at"`
+	Entries      int           `bson:"entries"`
+	Copies       int           `bson:"copies"`
+	Cards        []Card        `bson:"cards,omitempty"`
+}
+
+// catalog_collection, one document per Scryfall printing
+type CatalogCard struct {
+	ScryfallID      string            `bson:"_id"`
+	OracleID        string            `bson:"oracle_id,omitempty"`
+	Name            string            `bson:"name"`
+	NameKey         string            `bson:"name_key"` // lower case name for prefix search
+	Lang            string            `bson:"lang,omitempty"`
+	SetCode         string            `bson:"set"`
+	SetName         string            `bson:"set_name,omitempty"`
+	CollectorNumber string            `bson:"collector_number"`
+	Rarity          string            `bson:"rarity,omitempty"`
+	TypeLine        string            `bson:"type_line,omitempty"`
+	ManaCost        string            `bson:"mana_cost,omitempty"`
+	CMC             float64           `bson:"cmc"`
+	Colors          []string          `bson:"colors,omitempty"`
+	ColorIdentity   []string          `bson:"color_identity,omitempty"`
+	ImageURI        string            `bson:"image_uri,omitempty"`
+	Legalities      map[string]string `bson:"legalities,omitempty"`
+}
+
+func (c *CatalogCard) ToDomain() domain.CatalogCard {
+	return domain.CatalogCard{
+		ScryfallID:      c.ScryfallID,
+		OracleID:        c.OracleID,
+		Name:            c.Name,
+		Lang:            c.Lang,
+		SetCode:         c.SetCode,
+		SetName:         c.SetName,
+		CollectorNumber: c.CollectorNumber,
+		Rarity:          c.Rarity,
+		TypeLine:        c.TypeLine,
+		ManaCost:        c.ManaCost,
+		CMC:             c.CMC,
+		Colors:          c.Colors,
+		ColorIdentity:   c.ColorIdentity,
+		ImageURI:        c.ImageURI,
+		Legalities:      c.Legalities,
+	}
+}
+
+func CatalogCardFromDomain(card domain.CatalogCard) CatalogCard {
+	return CatalogCard{
+		ScryfallID:      card.ScryfallID,
+		OracleID:        card.OracleID,
+		Name:            card.Name,
+		NameKey:         strings.ToLower(card.Name),
+		Lang:            card.Lang,
+		SetCode:         strings.ToLower(card.SetCode),
+		SetName:         card.SetName,
+		CollectorNumber: card.CollectorNumber,
+		Rarity:          card.Rarity,
+		TypeLine:        card.TypeLine,
+		ManaCost:        card.ManaCost,
+		CMC:             card.CMC,
+		Colors:          card.Colors,
+		ColorIdentity:   card.ColorIdentity,
+		ImageURI:        card.ImageURI,
+		Legalities:      card.Legalities,
+	}
+}
+
+// catalog_imports_collection, progress of bulk data imports by file path
+type CatalogImport struct {
+	Source    string    `bson:"_id"`
+	Size      int64     `bson:"size"`
+	ModTime   time.Time `bson:"mod_time"`
+	Processed int       `bson:"processed"`
+	BytesRead int64     `bson:"bytes_read"`
+	Done      bool      `bson:"done"`
+	StartedAt time.Time `bson:"started_at"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+// card_prices_collection, prices of a printing on a day
+type CardPrice struct {
 	ScryfallID string    `bson:"scryfall_id"`
-	Name       string    `bson:"name"`
-	CardUrl    string    `bson:"card_url"`
-	Count      int       `bson:"count"`
-	AddedAt    time.Time `bson:"added_at"`
+	Date       time.Time `bson:"date"`
+	USD        float64   `bson:"usd,omitempty"`
+	USDFoil    float64   `bson:"usd_foil,omitempty"`
+	USDEtched  float64   `bson:"usd_etched,omitempty"`
+	EUR        float64   `bson:"eur,omitempty"`
+	EURFoil    float64   `bson:"eur_foil,omitempty"`
+	Tix        float64   `bson:"tix,omitempty"`
+}
+
+// collection_values_collection, value of a collection on a day
+type CollectionValue struct {
+	CollectionID bson.ObjectID `bson:"collection_id"`
+	UserID       bson.ObjectID `bson:"user_id"`
+	Date         time.Time     `bson:"date"`
+	USD          float64       `bson:"usd"`
+	EUR          float64       `bson:"eur"`
+	Tix          float64       `bson:"tix"`
+}
+
+// groups_collection, playgroups of users who trade with each other
+type Group struct {
+	ObjectID   bson.ObjectID   `bson:"_id,omitempty"`
+	Name       string          `bson:"name"`
+	OwnerID    bson.ObjectID   `bson:"owner_id"`
+	InviteCode string          `bson:"invite_code"`
+	Members    []bson.ObjectID `bson:"members"`
+	CreatedAt  time.Time       `bson:"created_at"`
+}
+
+func (g *Group) ToDomain() domain.Group {
+	members := make([]string, len(g.Members))
+	for i, member := range g.Members {
+		members[i] = member.Hex()
+	}
+
+	return domain.Group{
+		ID:         g.ObjectID.Hex(),
+		Name:       g.Name,
+		OwnerID:    g.OwnerID.Hex(),
+		InviteCode: g.InviteCode,
+		Members:    members,
+		CreatedAt:  g.CreatedAt,
+	}
+}
+
+// trade_matches_collection, a possible trade between a user and a partner,
+// recomputed in the background
+type TradeMatch struct {
+	UserID      bson.ObjectID `bson:"user_id"`
+	PartnerID   bson.ObjectID `bson:"partner_id"`
+	PartnerName string        `bson:"partner_name"`
+	Gets        []TradeItem   `bson:"gets"`
+	Gives       []TradeItem   `bson:"gives"`
+	GetsValue   float64       `bson:"gets_value"`
+	GivesValue  float64       `bson:"gives_value"`
+	TotalValue  float64       `bson:"total_value"` // ranks equally even trades
+	Balance     float64       `bson:"balance"`
+	Score       float64       `bson:"score"`
+	UpdatedAt   time.Time     `bson:"updated_at"`
+}
+
+type TradeItem struct {
+	Name       string  `bson:"name"`
+	ScryfallID string  `bson:"scryfall_id"`
+	Finish     string  `bson:"finish"`
+	Count      int     `bson:"count"`
+	UnitPrice  float64 `bson:"unit_price"`
+	Value      float64 `bson:"value"`
+}
+
+func (m *TradeMatch) ToDomain() domain.TradeMatch {
+	return domain.TradeMatch{
+		UserID:      m.UserID.Hex(),
+		PartnerID:   m.PartnerID.Hex(),
+		PartnerName: m.PartnerName,
+		Gets:        tradeItemsToDomain(m.Gets),
+		Gives:       tradeItemsToDomain(m.Gives),
+		GetsValue:   m.GetsValue,
+		GivesValue:  m.GivesValue,
+		Balance:     m.Balance,
+		Score:       m.Score,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+func TradeMatchFromDomain(match domain.TradeMatch) (TradeMatch, error) {
+	userObjectID, err := bson.ObjectIDFromHex(match.UserID)
+	if err != nil {
+		return TradeMatch{}, err
+	}
+	partnerObjectID, err := bson.ObjectIDFromHex(match.PartnerID)
+	if err != nil {
+		return TradeMatch{}, err
+	}
+
+	return TradeMatch{
+		UserID:      userObjectID,
+		PartnerID:   partnerObjectID,
+		PartnerName: match.PartnerName,
+		Gets:        tradeItemsFromDomain(match.Gets),
+		Gives:       tradeItemsFromDomain(match.Gives),
+		GetsValue:   match.GetsValue,
+		GivesValue:  match.GivesValue,
+		TotalValue:  match.GetsValue + match.GivesValue,
+		Balance:     match.Balance,
+		Score:       match.Score,
+		UpdatedAt:   match.UpdatedAt,
+	}, nil
+}
+
+// stats_cache_collection, statistics of a collection or of all collections of a
+// user, valid while the version they were computed for is current
+type CachedStats struct {
+	Key      string          `bson:"_id"`
+	Version  string          `bson:"version"`
+	Stats    CollectionStats `bson:"stats"`
+	StoredAt time.Time       `bson:"stored_at"`
+}
+
+type CollectionStats struct {
+	Cards         int           `bson:"cards"`
+	Unique        int           `bson:"unique"`
+	Printings     int           `bson:"printings"`
+	Foil          int           `bson:"foil"`
+	FoilRatio     float64       `bson:"foil_ratio"`
+	Multicolor    int           `bson:"multicolor"`
+	Uncataloged   int           `bson:"uncataloged"`
+	ByColor       []StatsBucket `bson:"by_color"`
+	ByRarity      []StatsBucket `bson:"by_rarity"`
+	BySet         []StatsBucket `bson:"by_set"`
+	ByType        []StatsBucket `bson:"by_type"`
+	ByManaValue   []StatsBucket `bson:"by_mana_value"`
+	ManaCurve     []CurveBar    `bson:"mana_curve"`
+	AddedPerMonth []MonthCount  `bson:"added_per_month"`
+	UpdatedAt     time.Time     `bson:"updated_at"`
+}
+
+type StatsBucket struct {
+	Key    string `bson:"key"`
+	Cards  int    `bson:"cards"`
+	Unique int    `bson:"unique"`
+}
+
+type CurveBar struct {
+	ManaValue int `bson:"mana_value"`
+	Cards     int `bson:"cards"`
+}
+
+type MonthCount struct {
+	Month string `bson:"month"`
+	Cards int    `bson:"cards"`
+}
+
+func (s *CollectionStats) ToDomain() domain.CollectionStats {
+	stats := domain.CollectionStats{
+		Cards:         s.Cards,
+		Unique:        s.Unique,
+		Printings:     s.Printings,
+		Foil:          s.Foil,
+		FoilRatio:     s.FoilRatio,
+		Multicolor:    s.Multicolor,
+		Uncataloged:   s.Uncataloged,
+		ByColor:       statsBucketsToDomain(s.ByColor),
+		ByRarity:      statsBucketsToDomain(s.ByRarity),
+		BySet:         statsBucketsToDomain(s.BySet),
+		ByType:        statsBucketsToDomain(s.ByType),
+		ByManaValue:   statsBucketsToDomain(s.ByManaValue),
+		AddedPerMonth: make([]domain.MonthCount, len(s.AddedPerMonth)),
+		UpdatedAt:     s.UpdatedAt,
+	}
+	if s.ManaCurve != nil {
+		stats.ManaCurve = make([]domain.CurveBar, len(s.ManaCurve))
+		for i, bar := range s.ManaCurve {
+			stats.ManaCurve[i] = domain.CurveBar{ManaValue: bar.ManaValue, Cards: bar.Cards}
+		}
+	}
+	for i, month := range s.AddedPerMonth {
+		stats.AddedPerMonth[i] = domain.MonthCount{Month: month.Month, Cards: month.Cards}
+	}
+	return stats
+}
+
+func CollectionStatsFromDomain(stats *domain.CollectionStats) CollectionStats {
+	model := CollectionStats{
+		Cards:         stats.Cards,
+		Unique:        stats.Unique,
+		Printings:     stats.Printings,
+		Foil:          stats.Foil,
+		FoilRatio:     stats.FoilRatio,
+		Multicolor:    stats.Multicolor,
+		Uncataloged:   stats.Uncataloged,
+		ByColor:       statsBucketsFromDomain(stats.ByColor),
+		ByRarity:      statsBucketsFromDomain(stats.ByRarity),
+		BySet:         statsBucketsFromDomain(stats.BySet),
+		ByType:        statsBucketsFromDomain(stats.ByType),
+		ByManaValue:   statsBucketsFromDomain(stats.ByManaValue),
+		AddedPerMonth: make([]MonthCount, len(stats.AddedPerMonth)),
+		UpdatedAt:     stats.UpdatedAt,
+	}
+	if stats.ManaCurve != nil {
+		model.ManaCurve = make([]CurveBar, len(stats.ManaCurve))
+		for i, bar := range stats.ManaCurve {
+			model.ManaCurve[i] = CurveBar{ManaValue: bar.ManaValue, Cards: bar.Cards}
+		}
+	}
+	for i, month := range stats.AddedPerMonth {
+		model.AddedPerMonth[i] = MonthCount{Month: month.Month, Cards: month.Cards}
+	}
+	return model
+}
+
+func statsBucketsToDomain(buckets []StatsBucket) []domain.StatsBucket {
+	out := make([]domain.StatsBucket, len(buckets))
+	for i, b := range buckets {
+		out[i] = domain.StatsBucket{Key: b.Key, Cards: b.Cards, Unique: b.Unique}
+	}
+	return out
+}
+
+func statsBucketsFromDomain(buckets []domain.StatsBucket) []StatsBucket {
+	out := make([]StatsBucket, len(buckets))
+	for i, b := range buckets {
+		out[i] = StatsBucket{Key: b.Key, Cards: b.Cards, Unique: b.Unique}
+	}
+	return out
+}
+
+func tradeItemsToDomain(items []TradeItem) []domain.TradeItem {
+	out := make([]domain.TradeItem, len(items))
+	for i, item := range items {
+		out[i] = domain.TradeItem{
+			Name:       item.Name,
+			ScryfallID: item.ScryfallID,
+			Finish:     domain.Finish(item.Finish),
+			Count:      item.Count,
+			UnitPrice:  item.UnitPrice,
+			Value:      item.Value,
+		}
+	}
+	return out
+}
+
+func tradeItemsFromDomain(items []domain.TradeItem) []TradeItem {
+	out := make([]TradeItem, len(items))
+	for i, item := range items {
+		out[i] = TradeItem{
+			Name:       item.Name,
+			ScryfallID: item.ScryfallID,
+			Finish:     string(item.Finish),
+			Count:      item.Count,
+			UnitPrice:  item.UnitPrice,
+			Value:      item.Value,
+		}
+	}
+	return out
+}
+
+func (c *CollectionChange) ToDomain() domain.CollectionChange {
+	change := domain.CollectionChange{
+		ID:            c.ObjectID.Hex(),
+		CollectionIDs: make([]string, len(c.CollectionIDs)),
+		Kind:          domain.ChangeKind(c.Kind),
+		UserID:        c.UserID,
+		RequestID:     c.RequestID,
+		At:            c.At,
+		Entries:       make([]domain.ChangeEntry, len(c.Entries)),
+		Reverts:       make([]string, len(c.Reverts)),
+	}
+	for i, id := range c.CollectionIDs {
+		change.CollectionIDs[i] = id.Hex()
+	}
+	for i, e := range c.Entries {
+		change.Entries[i] = domain.ChangeEntry{
+			CollectionID: e.Card.CollectionID.Hex(),
+			Card:         e.Card.ToDomain(),
+			Before:       e.Before,
+			After:        e.After,
+		}
+	}
+	for i, id := range c.Reverts {
+		change.Reverts[i] = id.Hex()
+	}
+	return change
+}
+
+func (s *Snapshot) ToDomain() domain.Snapshot {
+	snapshot := domain.Snapshot{
+		ID:           s.ObjectID.Hex(),
+		CollectionID: s.CollectionID.Hex(),
+		Label:        s.Label,
+		CreatedAt:    s.CreatedAt,
+		Entries:      s.Entries,
+		Copies:       s.Copies,
+	}
+	if s.Cards != nil {
+		snapshot.Cards = make([]domain.Card, len(s.Cards))
+		for i, c := range s.Cards {
+			snapshot.Cards[i] = c.ToDomain()
+		}
+	}
+	return snapshot
+}
+
+func (c *Card) ToDomain() domain.Card {
+	card := domain.Card{
+		ID:         c.ObjectID.Hex(),
+		ScryfallID: c.ScryfallID,
+		Name:       c.Name,
+		CardUrl:    c.CardUrl,
+		Count:      c.Count,
+		Zone:       domain.Zone(c.Zone),
+		Finish:     domain.Finish(c.Finish),
+		Condition:  domain.Condition(c.Condition),
+		Language:   c.Language,
+		SetCode:    c.SetCode,
+		Rarity:     c.Rarity,
+		TypeLine:   c.TypeLine,
+		AddedAt:    c.AddedAt,
+	}
+	if c.ObjectID.IsZero() {
+		card.ID = ""
+	}
+
+	return card
+}
+
+func CardFromDomain(domainCard domain.Card) (Card, error) {
+	var entryObjectID bson.ObjectID
+	var err error
+
+	if domainCard.ID != "" {
+		entryObjectID, err = bson.ObjectIDFromHex(domainCard.ID)
+		if err != nil {
+			return Card{}, err
+		}
+	}
+
+	return Card{
+		ObjectID:   entryObjectID,
+		ScryfallID: domainCard.ScryfallID,
+		Name:       domainCard.Name,
+		CardUrl:    domainCard.CardUrl,
+		Count:      domainCard.Count,
+		Zone:       string(domainCard.Zone),
+		Finish:     string(domainCard.Finish),
+		Condition:  string(domainCard.Condition),
+		Language:   domainCard.Language,
+		SetCode:    domainCard.SetCode,
+		Rarity:     domainCard.Rarity,
+		TypeLine:   domainCard.TypeLine,
+		AddedAt:    domainCard.AddedAt,
+	}, nil
 }
 
 func (c *Collection) ToDomain() domain.Collection {
 	domainCards := make([]domain.Card, len(c.Cards))
 	for i, v := range c.Cards {
-		domainCards[i] = domain.Card{
-			ScryfallID: v.ScryfallID,
-			Name:       v.Name,
-			CardUrl:    v.CardUrl,
-			Count:      v.Count,
-			AddedAt:    v.AddedAt,
-		}
+		domainCards[i] = v.ToDomain()
+	}
+
+	kind := domain.CollectionKind(c.Kind)
+	if kind == "" {
+		kind = domain.KindBinder
 	}
 
 	return domain.Collection{
 		ID:        c.ObjectID.Hex(),
 		UserID:    c.UserID.Hex(),
 		Name:      c.Name,
+		Kind:      kind,
 		Cards:     domainCards,
 		CreatedAt: c.CreatedAt,
 		UpdatedAt: c.UpdatedAt,
+		DeletedAt: c.DeletedAt,
+		Version:   c.Version,
 	}
 }
 
 func CollectionFromDomain(domainCollection domain.Collection) (Collection, error) {
-	collObjectID, err := bson.ObjectIDFromHex(domainCollection.ID)
-	if err != nil {
-		return Collection{}, err
+	var collObjectID bson.ObjectID
+	var err error
+
+	if domainCollection.ID != "" {
+		collObjectID, err = bson.ObjectIDFromHex(domainCollection.ID)
+		if err != nil {
+			return Collection{}, err
+		}
 	}
 
 	userIdObjectID, err := bson.ObjectIDFromHex(domainCollection.UserID)
@@ -146,12 +638,9 @@ func CollectionFromDomain(domainCollection domain.Collection) (Collection, error
 
 	cards := make([]Card, len(domainCollection.Cards))
 	for i, v := range domainCollection.Cards {
-		cards[i] = Card{
-			ScryfallID: v.ScryfallID,
-			Name:       v.Name,
-			CardUrl:    v.CardUrl,
-			Count:      v.Count,
-			AddedAt:    v.AddedAt,
+		cards[i], err = CardFromDomain(v)
+		if err != nil {
+			return Collection{}, err
 		}
 	}
 
@@ -159,8 +648,16 @@ func CollectionFromDomain(domainCollection domain.Collection) (Collection, error
 		ObjectID:  collObjectID,
 		UserID:    userIdObjectID,
 		Name:      domainCollection.Name,
+		Kind:      string(domainCollection.Kind),
 		Cards:     cards,
 		CreatedAt: domainCollection.CreatedAt,
 		UpdatedAt: domainCollection.UpdatedAt,
+		DeletedAt: domainCollection.DeletedAt,
+		Version:   domainCollection.Version,
 	}, nil
 }
+
+// variantKey identifies the card variant of the entry inside its collection
+func (c *Card) variantKey() string {
+	return c.ToDomain().VariantKey()
+}
@@ -0,0 +1,254 @@
+package mongorep
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// UpsertCardPrices stores price snapshots by Scryfall ID and day, replacing
+// stored ones, so importing prices of a day again doesn't add snapshots.
+func (r Repository) UpsertCardPrices(prices []domain.CardPrice) *domain.ResponseErr {
+	if len(prices) == 0 {
+		return nil
+	}
+
+	models := make([]mongo.WriteModel, len(prices))
+	for i, price := range prices {
+		doc := CardPrice{
+			ScryfallID: price.ScryfallID,
+			Date:       domain.PriceDate(price.Date),
+			USD:        price.USD,
+			USDFoil:    price.USDFoil,
+			USDEtched:  price.USDEtched,
+			EUR:        price.EUR,
+			EURFoil:    price.EURFoil,
+			Tix:        price.Tix,
+		}
+		models[i] = mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"scryfall_id": doc.ScryfallID, "date": doc.Date}).
+			SetReplacement(doc).
+			SetUpsert(true)
+	}
+
+	storage := r.client.Database(database).Collection(card_prices_collection)
+	if _, err := storage.BulkWrite(context.TODO(), models, options.BulkWrite().SetOrdered(false)); err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Upsert card prices error: %v", err),
+		}
+	}
+
+	return nil
+}
+
+// FindCardPrices returns the newest price snapshot of every printing taken on
+// the day of date or before it. Printings without snapshots are left out.
+func (r Repository) FindCardPrices(scryfallIds []string, date time.Time) (map[string]domain.CardPrice, *domain.ResponseErr) {
+	prices := make(map[string]domain.CardPrice, len(scryfallIds))
+	if len(scryfallIds) == 0 {
+		return prices, nil
+	}
+
+	ctx := context.TODO()
+	storage := r.client.Database(database).Collection(card_prices_collection)
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"scryfall_id": bson.M{"$in": scryfallIds},
+			"date":        bson.M{"$lte": domain.PriceDate(date)},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "scryfall_id", Value: 1}, {Key: "date", Value: -1}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$scryfall_id", "price": bson.M{"$first": "$$ROOT"}}}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$price"}}},
+	}
+
+	cursor, err := storage.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Find card prices error: %v", err),
+		}
+	}
+	defer cursor.Close(ctx)
+
+	var docs []CardPrice
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Find card prices error: %v", err),
+		}
+	}
+
+	for _, doc := range docs {
+		prices[doc.ScryfallID] = domain.CardPrice{
+			ScryfallID: doc.ScryfallID,
+			Date:       doc.Date,
+			USD:        doc.USD,
+			USDFoil:    doc.USDFoil,
+			USDEtched:  doc.USDEtched,
+			EUR:        doc.EUR,
+			EURFoil:    doc.EURFoil,
+			Tix:        doc.Tix,
+		}
+	}
+
+	return prices, nil
+}
+
+// StreamCollections calls fn for every collection of every user, without cards and trashed collections.
+// An error of fn stops the stream and is returned as an internal error.
+func (r Repository) StreamCollections(fn func(domain.Collection) error) *domain.ResponseErr {
+	ctx := context.TODO()
+	storage := r.client.Database(database).Collection(collections_collection)
+
+	cursor, err := storage.Find(ctx, bson.M{"deleted_at": notTrashed()}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Stream collections error: %v", err),
+		}
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var collection Collection
+		if err := cursor.Decode(&collection); err != nil {
+			return &domain.ResponseErr{
+				Status:  http.StatusInternalServerError,
+				Message: fmt.Sprintf("Stream collections error: %v", err),
+			}
+		}
+		if err := fn(collection.ToDomain()); err != nil {
+			return &domain.ResponseErr{
+				Status:  http.StatusInternalServerError,
+				Message: fmt.Sprintf("Stream collections error: %v", err),
+			}
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Stream collections error: %v", err),
+		}
+	}
+
+	return nil
+}
+
+// SaveCollectionValue stores the value of the collection on the day of the point,
+// replacing a value stored for that day.
+func (r Repository) SaveCollectionValue(collection *domain.Collection, point domain.ValuePoint) *domain.ResponseErr {
+	collectionObjectId, err := bson.ObjectIDFromHex(collection.ID)
+	if err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID format",
+		}
+	}
+	userObjectId, err := bson.ObjectIDFromHex(collection.UserID)
+	if err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid user ID format",
+		}
+	}
+
+	doc := CollectionValue{
+		CollectionID: collectionObjectId,
+		UserID:       userObjectId,
+		Date:         domain.PriceDate(point.Date),
+		USD:          point.USD,
+		EUR:          point.EUR,
+		Tix:          point.Tix,
+	}
+
+	storage := r.client.Database(database).Collection(collection_values_collection)
+	filter := bson.M{"collection_id": doc.CollectionID, "date": doc.Date}
+	if _, err := storage.ReplaceOne(context.TODO(), filter, doc, options.Replace().SetUpsert(true)); err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Save collection value error: %v", err),
+		}
+	}
+
+	return nil
+}
+
+// CollectionValueHistory returns the daily values of the collection between the days
+// of from and to inclusive, oldest first.
+func (r Repository) CollectionValueHistory(collectionId string, from, to time.Time) ([]domain.ValuePoint, *domain.ResponseErr) {
+	objectId, err := bson.ObjectIDFromHex(collectionId)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID format",
+		}
+	}
+
+	return r.valueHistory(mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"collection_id": objectId, "date": dateRange(from, to)}}},
+		{{Key: "$sort", Value: bson.M{"date": 1}}},
+	})
+}
+
+// UserValueHistory returns the daily totals of the user's collections between the days
+// of from and to inclusive, oldest first.
+func (r Repository) UserValueHistory(userId string, from, to time.Time) ([]domain.ValuePoint, *domain.ResponseErr) {
+	objectId, err := bson.ObjectIDFromHex(userId)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid user ID format",
+		}
+	}
+
+	return r.valueHistory(mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"user_id": objectId, "date": dateRange(from, to)}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": "$date",
+			"usd": bson.M{"$sum": "$usd"},
+			"eur": bson.M{"$sum": "$eur"},
+			"tix": bson.M{"$sum": "$tix"},
+		}}},
+		{{Key: "$set", Value: bson.M{"date": "$_id"}}},
+		{{Key: "$sort", Value: bson.M{"date": 1}}},
+	})
+}
+
+func (r Repository) valueHistory(pipeline mongo.Pipeline) ([]domain.ValuePoint, *domain.ResponseErr) {
+	ctx := context.TODO()
+	storage := r.client.Database(database).Collection(collection_values_collection)
+
+	cursor, err := storage.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Find value history error: %v", err),
+		}
+	}
+	defer cursor.Close(ctx)
+
+	var docs []CollectionValue
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Find value history error: %v", err),
+		}
+	}
+
+	points := make([]domain.ValuePoint, len(docs))
+	for i, doc := range docs {
+		points[i] = domain.ValuePoint{Date: doc.Date, USD: doc.USD, EUR: doc.EUR, Tix: doc.Tix}
+	}
+	return points, nil
+}
+
+func dateRange(from, to time.Time) bson.M {
+	return bson.M{"$gte": domain.PriceDate(from), "$lte": domain.PriceDate(to)}
+}
@@ -0,0 +1,69 @@
+package mongorep
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestFindCardPricesReturnsNewestSnapshotBeforeDate(t *testing.T) {
+	r := newTestRepository(t)
+	id := "price-" + bson.NewObjectID().Hex()
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	t.Cleanup(func() {
+		_, _ = r.client.Database(database).Collection(card_prices_collection).DeleteMany(context.Background(), bson.M{"scryfall_id": id})
+	})
+
+	require.Nil(t, r.UpsertCardPrices([]domain.CardPrice{
+		{ScryfallID: id, Date: day, USD: 1},
+		{ScryfallID: id, Date: day.AddDate(0, 0, 1), USD: 2},
+		{ScryfallID: id, Date: day.AddDate(0, 0, 3), USD: 3},
+	}))
+	// The same day again replaces the snapshot
+	require.Nil(t, r.UpsertCardPrices([]domain.CardPrice{{ScryfallID: id, Date: day.Add(12 * time.Hour), USD: 1.5}}))
+
+	prices, respErr := r.FindCardPrices([]string{id, "missing"}, day.AddDate(0, 0, 2).Add(time.Hour))
+	require.Nil(t, respErr)
+	require.Len(t, prices, 1)
+	assert.Equal(t, 2.0, prices[id].USD)
+	assert.Equal(t, day.AddDate(0, 0, 1), prices[id].Date.UTC())
+
+	prices, respErr = r.FindCardPrices([]string{id}, day)
+	require.Nil(t, respErr)
+	assert.Equal(t, 1.5, prices[id].USD)
+}
+
+func TestValueHistory(t *testing.T) {
+	r := newTestRepository(t)
+	firstDoc, secondDoc := newTestCollection(t, r), newTestCollection(t, r)
+	first, second := firstDoc.ToDomain(), secondDoc.ToDomain()
+	second.UserID = first.UserID
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	userObjectId, _ := bson.ObjectIDFromHex(first.UserID)
+	t.Cleanup(func() {
+		_, _ = r.client.Database(database).Collection(collection_values_collection).DeleteMany(context.Background(), bson.M{"user_id": userObjectId})
+	})
+
+	require.Nil(t, r.SaveCollectionValue(&first, domain.ValuePoint{Date: day, USD: 10}))
+	require.Nil(t, r.SaveCollectionValue(&first, domain.ValuePoint{Date: day.AddDate(0, 0, 1), USD: 12}))
+	require.Nil(t, r.SaveCollectionValue(&first, domain.ValuePoint{Date: day.AddDate(0, 0, 1), USD: 11}))
+	require.Nil(t, r.SaveCollectionValue(&second, domain.ValuePoint{Date: day.AddDate(0, 0, 1), USD: 5, EUR: 4}))
+
+	points, respErr := r.CollectionValueHistory(first.ID, day, day.AddDate(0, 0, 1))
+	require.Nil(t, respErr)
+	require.Len(t, points, 2)
+	assert.Equal(t, 10.0, points[0].USD)
+	assert.Equal(t, 11.0, points[1].USD)
+
+	points, respErr = r.UserValueHistory(first.UserID, day.AddDate(0, 0, 1), day.AddDate(0, 0, 1))
+	require.Nil(t, respErr)
+	require.Len(t, points, 1)
+	assert.Equal(t, day.AddDate(0, 0, 1), points[0].Date.UTC())
+	assert.Equal(t, 16.0, points[0].USD)
+	assert.Equal(t, 4.0, points[0].EUR)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
 	"time"
 
 	"github.com/ShenokZlob/collector-service/domain"
@@ -224,26 +225,19 @@ func (r Repository) CreateCollection(domainCollection *domain.Collection) (*doma
 		}
 	}
 
-	ctx := context.TODO()
-	session, err := r.client.StartSession()
-	if err != nil {
-		return nil, &domain.ResponseErr{
-			Status:  http.StatusInternalServerError,
-			Message: fmt.Sprintf("Failed to start session: %v", err),
-		}
-	}
-	defer session.EndSession(ctx)
-
-	// Start session (transaction)
 	var domainCreatedCollection domain.Collection
-	err = mongo.WithSession(ctx, session, func(ctx context.Context) error {
+	respErr := r.runInTransaction(func(ctx context.Context) error {
 		// Add collection to collections_collection
 		storage := r.client.Database(database).Collection(collections_collection)
+		collection.ObjectID = bson.NewObjectID()
 		collection.CreatedAt = time.Now()
 		collection.UpdatedAt = collection.CreatedAt
 
 		result, err := storage.InsertOne(ctx, collection)
 		if err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return collectionNameTakenError()
+			}
 			return &domain.ResponseErr{
 				Status:  http.StatusInternalServerError,
 				Message: err.Error(),
@@ -269,40 +263,23 @@ func (r Repository) CreateCollection(domainCollection *domain.Collection) (*doma
 		}
 
 		// Add created collection to collections_users
-		storage = r.client.Database(database).Collection(users_collection)
-		filter = bson.M{"_id": createdCollection.UserID}
-		update := bson.D{
-			{Key: "$push", Value: bson.D{{Key: "collections", Value: UserCollectionRef{
-				ObjectID: collection.ObjectID,
-				Name:     collection.Name,
-			}}}},
-			{Key: "$set", Value: bson.D{{Key: "updated_at", Value: time.Now()}}},
-		}
-		_, err = storage.UpdateOne(ctx, filter, update)
-		if err != nil {
-			return &domain.ResponseErr{
-				Status:  http.StatusInternalServerError,
-				Message: err.Error(),
-			}
+		if respErr := r.pushUserCollectionRef(ctx, createdCollection); respErr != nil {
+			return respErr
 		}
 
 		domainCreatedCollection = createdCollection.ToDomain()
 		return nil
 	})
-	if err != nil {
-		if e, ok := err.(*domain.ResponseErr); ok {
-			return nil, e
-		}
-		return nil, &domain.ResponseErr{
-			Status:  http.StatusInternalServerError,
-			Message: err.Error(),
-		}
+	if respErr != nil {
+		return nil, respErr
 	}
 
 	return &domainCreatedCollection, nil
 }
 
-func (r Repository) RenameCollection(domainCollection *domain.Collection) (*domain.Collection, *domain.ResponseErr) {
+// RenameCollection renames the collection, it fails with 412 when the collection doesn't have
+// the version the actor expects.
+func (r Repository) RenameCollection(actor domain.Actor, domainCollection *domain.Collection) (*domain.Collection, *domain.ResponseErr) {
 	collection, err := CollectionFromDomain(*domainCollection)
 	if err != nil {
 		return nil, &domain.ResponseErr{
@@ -311,33 +288,35 @@ func (r Repository) RenameCollection(domainCollection *domain.Collection) (*doma
 		}
 	}
 
-	ctx := context.TODO()
-	session, err := r.client.StartSession()
-	if err != nil {
-		return nil, &domain.ResponseErr{
-			Status:  http.StatusInternalServerError,
-			Message: fmt.Sprintf("Failed to start session: %v", err),
-		}
+	filter, respErr := ownedCollectionFilter(actor.UserID, collection.ObjectID)
+	if respErr != nil {
+		return nil, respErr
 	}
-	defer session.EndSession(ctx)
+	filter["deleted_at"] = notTrashed()
+	filter = withVersion(filter, actor.IfMatch)
 
-	// Start session (transaction)
 	var domainRenamedCollection domain.Collection
-	err = mongo.WithSession(ctx, session, func(ctx context.Context) error {
+	respErr = r.runInTransaction(func(ctx context.Context) error {
 		// Rename collection in collections_collection
 		storage := r.client.Database(database).Collection(collections_collection)
-		filter := bson.M{"_id": collection.ObjectID}
 		update := bson.M{
 			"$set": bson.M{
 				"name":       collection.Name,
 				"updated_at": time.Now(),
 			},
+			"$inc": bson.M{"version": 1},
 		}
 		opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
 
 		var updatedCollection Collection
-		err = storage.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updatedCollection)
+		err := storage.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updatedCollection)
 		if err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return collectionNameTakenError()
+			}
+			if err == mongo.ErrNoDocuments {
+				return r.collectionMissError(ctx, filter, "Collection not found")
+			}
 			return &domain.ResponseErr{
 				Status:  http.StatusNotFound,
 				Message: fmt.Sprintf("Failed to find collection: %v", err),
@@ -346,14 +325,14 @@ func (r Repository) RenameCollection(domainCollection *domain.Collection) (*doma
 
 		// Update collection name in user's collections
 		storage = r.client.Database(database).Collection(users_collection)
-		filter = bson.M{"collections._id": updatedCollection.ObjectID}
+		userFilter := bson.M{"collections._id": updatedCollection.ObjectID}
 		update = bson.M{
 			"$set": bson.M{
 				"collections.$.name": collection.Name,
 				"updated_at":         time.Now(),
 			},
 		}
-		_, err = storage.UpdateOne(ctx, filter, update)
+		_, err = storage.UpdateOne(ctx, userFilter, update)
 		if err != nil {
 			return &domain.ResponseErr{
 				Status:  http.StatusInternalServerError,
@@ -364,21 +343,17 @@ func (r Repository) RenameCollection(domainCollection *domain.Collection) (*doma
 		domainRenamedCollection = updatedCollection.ToDomain()
 		return nil
 	})
-	if err != nil {
-		if e, ok := err.(*domain.ResponseErr); ok {
-			return nil, e
-		}
-		return nil, &domain.ResponseErr{
-			Status:  http.StatusInternalServerError,
-			Message: err.Error(),
-		}
+	if respErr != nil {
+		return nil, respErr
 	}
 
 	return &domainRenamedCollection, nil
 }
 
-func (r Repository) DeleteCollection(userID, collectionID string) *domain.ResponseErr {
-	userObjectID, err := bson.ObjectIDFromHex(userID)
+// DeleteCollection moves the user's collection to the trash with its cards. It's
+// left out of the user's collections until it's restored or purged.
+func (r Repository) DeleteCollection(actor domain.Actor, collectionID string) *domain.ResponseErr {
+	userObjectID, err := bson.ObjectIDFromHex(actor.UserID)
 	if err != nil {
 		return &domain.ResponseErr{
 			Status:  http.StatusBadRequest,
@@ -394,226 +369,1452 @@ func (r Repository) DeleteCollection(userID, collectionID string) *domain.Respon
 		}
 	}
 
-	ctx := context.TODO()
-	session, err := r.client.StartSession()
+	return r.runInTransaction(func(ctx context.Context) error {
+		if respErr := r.trashCollection(ctx, userObjectID, collectionObjectID, actor.IfMatch); respErr != nil {
+			return respErr
+		}
+		return nil
+	})
+}
+
+// trashCollection marks the collection deleted and removes it from the user's collection refs.
+// A non-nil version is the version the collection must have.
+func (r Repository) trashCollection(ctx context.Context, userObjectID, collectionObjectID bson.ObjectID, versions []int64) *domain.ResponseErr {
+	now := time.Now()
+	storage := r.client.Database(database).Collection(collections_collection)
+	filter := withVersion(bson.M{"_id": collectionObjectID, "user_id": userObjectID, "deleted_at": notTrashed()}, versions)
+	update := bson.M{
+		"$set": bson.M{"deleted_at": now, "updated_at": now},
+		"$inc": bson.M{"version": 1},
+	}
+	result, err := storage.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return &domain.ResponseErr{
 			Status:  http.StatusInternalServerError,
-			Message: fmt.Sprintf("Failed to start session: %v", err),
+			Message: fmt.Sprintf("Delete collection error: %v", err),
+		}
+	}
+	if result.MatchedCount == 0 {
+		return r.collectionMissError(ctx, filter, "Collection not found")
+	}
+
+	storage = r.client.Database(database).Collection(users_collection)
+	update = bson.M{
+		"$pull": bson.M{
+			"collections": bson.M{"_id": collectionObjectID},
+		},
+		"$set": bson.M{"updated_at": now},
+	}
+	if _, err := storage.UpdateOne(ctx, bson.M{"_id": userObjectID}, update); err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Error updating user collections: %v", err),
 		}
 	}
-	defer session.EndSession(ctx)
 
-	// Start session (transaction)
-	err = mongo.WithSession(ctx, session, func(ctx context.Context) error {
-		// Delete collection from collections_collection
+	return nil
+}
+
+// CloneCollection copies the user's collection with its cards under a new name
+func (r Repository) CloneCollection(userID, collectionID, name string) (*domain.Collection, *domain.ResponseErr) {
+	userObjectID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid user ID format",
+		}
+	}
+
+	collectionObjectID, err := bson.ObjectIDFromHex(collectionID)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID format",
+		}
+	}
+
+	var domainClonedCollection domain.Collection
+	respErr := r.runInTransaction(func(ctx context.Context) error {
 		storage := r.client.Database(database).Collection(collections_collection)
-		filter := bson.M{"_id": collectionObjectID}
-		_, err = storage.DeleteOne(ctx, filter)
+
+		var source Collection
+		filter := bson.M{"_id": collectionObjectID, "user_id": userObjectID, "deleted_at": notTrashed()}
+		if err := storage.FindOne(ctx, filter).Decode(&source); err != nil {
+			return collectionFindError(err, "Collection not found")
+		}
+
+		cards, err := r.findCardEntries(ctx, collectionObjectID)
 		if err != nil {
 			return &domain.ResponseErr{
 				Status:  http.StatusInternalServerError,
-				Message: fmt.Sprintf("Delete collection error: %v", err),
+				Message: fmt.Sprintf("Find cards error: %v", err),
 			}
 		}
 
-		// Delete collection from user's collections
-		storage = r.client.Database(database).Collection(users_collection)
-		filter = bson.M{"_id": userObjectID}
-		update := bson.M{
-			"$pull": bson.M{
-				"collections": bson.M{"_id": collectionObjectID},
-			},
-			"$set": bson.M{"updated_at": time.Now()},
+		now := time.Now()
+		clone := Collection{
+			ObjectID:  bson.NewObjectID(),
+			UserID:    userObjectID,
+			Name:      name,
+			Kind:      source.Kind,
+			Cards:     cards,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		for i := range clone.Cards {
+			clone.Cards[i].ObjectID = bson.NewObjectID()
+			clone.Cards[i].CollectionID = clone.ObjectID
 		}
 
-		_, err = storage.UpdateOne(ctx, filter, update)
-		if err != nil {
+		if _, err := storage.InsertOne(ctx, clone); err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return collectionNameTakenError()
+			}
 			return &domain.ResponseErr{
 				Status:  http.StatusInternalServerError,
-				Message: fmt.Sprintf("Error updating user collections: %v", err),
+				Message: err.Error(),
 			}
 		}
 
-		return nil
-	})
-	if err != nil {
-		if e, ok := err.(*domain.ResponseErr); ok {
-			return e
+		if len(clone.Cards) > 0 {
+			storage = r.client.Database(database).Collection(cards_collection)
+			if _, err := storage.InsertMany(ctx, clone.Cards); err != nil {
+				return &domain.ResponseErr{
+					Status:  http.StatusInternalServerError,
+					Message: fmt.Sprintf("Error copying cards: %v", err),
+				}
+			}
 		}
-		return &domain.ResponseErr{
-			Status:  http.StatusInternalServerError,
-			Message: err.Error(),
+
+		if respErr := r.pushUserCollectionRef(ctx, clone); respErr != nil {
+			return respErr
 		}
+
+		domainClonedCollection = clone.ToDomain()
+		return nil
+	})
+	if respErr != nil {
+		return nil, respErr
 	}
 
-	return nil
+	return &domainClonedCollection, nil
 }
 
-// GetCollection gets all information about collection by ID
-func (r Repository) GetCollection(collectionId string) (*domain.Collection, *domain.ResponseErr) {
-	collObjectID, err := bson.ObjectIDFromHex(collectionId)
+// MergeCollections merges cards of the source collection into the target one.
+// The source collection is moved to the trash when merge.DeleteSource is set.
+func (r Repository) MergeCollections(actor domain.Actor, merge *domain.CollectionMerge) (*domain.Collection, *domain.ResponseErr) {
+	userObjectID, err := bson.ObjectIDFromHex(merge.UserID)
 	if err != nil {
 		return nil, &domain.ResponseErr{
 			Status:  http.StatusBadRequest,
-			Message: "Invalid collection ID format",
+			Message: "Invalid user ID format",
 		}
 	}
-	storage := r.client.Database(database).Collection(collections_collection)
-	filter := bson.M{"_id": collObjectID}
 
-	var collection Collection
-	err = storage.FindOne(context.TODO(), filter).Decode(&collection)
+	sourceObjectID, err := bson.ObjectIDFromHex(merge.SourceID)
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			return nil, &domain.ResponseErr{
-				Status:  http.StatusNotFound,
-				Message: "Collection not found",
-			}
-		}
 		return nil, &domain.ResponseErr{
-			Status:  http.StatusInternalServerError,
-			Message: fmt.Sprintf("Find collection error: %v", err),
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID format",
 		}
 	}
 
-	domainCollection := collection.ToDomain()
-	return &domainCollection, nil
-}
-
-func (r Repository) AddCardToCollection(collectionId string, card *domain.Card) *domain.ResponseErr {
-	// TODO: need to use transaction here
-	// TODO: replace collectionId on ObjectID in service layer
-	objectId, err := bson.ObjectIDFromHex(collectionId)
+	targetObjectID, err := bson.ObjectIDFromHex(merge.TargetID)
 	if err != nil {
-		return &domain.ResponseErr{
+		return nil, &domain.ResponseErr{
 			Status:  http.StatusBadRequest,
 			Message: "Invalid collection ID format",
 		}
 	}
 
-	collection := r.client.Database(database).Collection(collections_collection)
-	card.AddedAt = time.Now()
+	var domainMergedCollection domain.Collection
+	respErr := r.runInTransaction(func(ctx context.Context) error {
+		storage := r.client.Database(database).Collection(collections_collection)
 
-	// Try to update the card count first
-	filter := bson.M{
-		"_id":               objectId,
-		"cards.scryfall_id": card.ScryfallID,
-	}
-	update := bson.M{
-		"$inc": bson.M{"cards.$.count": card.Count},
-		"$set": bson.M{"updated_at": time.Now()},
-	}
-	result, err := collection.UpdateOne(context.TODO(), filter, update)
-	if err != nil {
-		return &domain.ResponseErr{
-			Status:  http.StatusInternalServerError,
-			Message: fmt.Sprintf("Error updating card count: %v", err),
+		var source, target Collection
+		filter := bson.M{"_id": sourceObjectID, "user_id": userObjectID, "deleted_at": notTrashed()}
+		if err := storage.FindOne(ctx, filter).Decode(&source); err != nil {
+			return collectionFindError(err, "Source collection not found")
+		}
+		filter = withVersion(bson.M{"_id": targetObjectID, "user_id": userObjectID, "deleted_at": notTrashed()}, actor.IfMatch)
+		if err := storage.FindOne(ctx, filter).Decode(&target); err != nil {
+			if err == mongo.ErrNoDocuments {
+				return r.collectionMissError(ctx, filter, "Target collection not found")
+			}
+			return collectionFindError(err, "Target collection not found")
+		}
+
+		if source.Cards, err = r.findCardEntries(ctx, sourceObjectID); err == nil {
+			target.Cards, err = r.findCardEntries(ctx, targetObjectID)
+		}
+		if err != nil {
+			return &domain.ResponseErr{
+				Status:  http.StatusInternalServerError,
+				Message: fmt.Sprintf("Find cards error: %v", err),
+			}
+		}
+
+		counts := make(map[bson.ObjectID]int, len(target.Cards))
+		for _, c := range target.Cards {
+			counts[c.ObjectID] = c.Count
+		}
+
+		domainTarget := target.ToDomain()
+		domainSource := source.ToDomain()
+		domainTarget.Cards = merge.Strategy.Merge(domainTarget.Cards, domainSource.Cards)
+
+		merged, err := CollectionFromDomain(domainTarget)
+		if err != nil {
+			return &domain.ResponseErr{
+				Status:  http.StatusInternalServerError,
+				Message: err.Error(),
+			}
+		}
+
+		// Write only entries the merge added or changed
+		var writes []mongo.WriteModel
+		log := &changeLog{}
+		for i := range merged.Cards {
+			card := &merged.Cards[i]
+			card.CollectionID = targetObjectID
+			if card.ObjectID.IsZero() {
+				card.ObjectID = bson.NewObjectID()
+				writes = append(writes, mongo.NewInsertOneModel().SetDocument(card))
+				log.add(*card, 0, card.Count)
+				continue
+			}
+			if counts[card.ObjectID] != card.Count {
+				writes = append(writes, mongo.NewUpdateOneModel().
+					SetFilter(bson.M{"_id": card.ObjectID}).
+					SetUpdate(bson.M{"$set": bson.M{"count": card.Count}}))
+				log.add(*card, counts[card.ObjectID], card.Count)
+			}
+		}
+		if len(writes) > 0 {
+			cards := r.client.Database(database).Collection(cards_collection)
+			if _, err := cards.BulkWrite(ctx, writes); err != nil {
+				return &domain.ResponseErr{
+					Status:  http.StatusInternalServerError,
+					Message: fmt.Sprintf("Update cards error: %v", err),
+				}
+			}
+		}
+		if _, respErr := r.recordChange(ctx, actor, domain.ChangeMerge, log); respErr != nil {
+			return respErr
+		}
+
+		merged.UpdatedAt = time.Now()
+		merged.Version++
+		update := bson.M{
+			"$set": bson.M{"updated_at": merged.UpdatedAt},
+			"$inc": bson.M{"version": 1},
+		}
+		if _, err := storage.UpdateOne(ctx, bson.M{"_id": targetObjectID}, update); err != nil {
+			return &domain.ResponseErr{
+				Status:  http.StatusInternalServerError,
+				Message: fmt.Sprintf("Update collection error: %v", err),
+			}
+		}
+
+		if merge.DeleteSource {
+			if respErr := r.trashCollection(ctx, userObjectID, sourceObjectID, nil); respErr != nil {
+				return respErr
+			}
 		}
-	}
 
-	if result.MatchedCount > 0 {
+		domainMergedCollection = merged.ToDomain()
 		return nil
+	})
+	if respErr != nil {
+		return nil, respErr
 	}
 
-	// If the card doesn't exist, add it to the collection
-	filter = bson.M{"_id": objectId}
-	update = bson.M{
-		"$push": bson.M{"cards": card},
-		"$set":  bson.M{"updated_at": time.Now()},
+	return &domainMergedCollection, nil
+}
+
+// pushUserCollectionRef adds the collection to the user's collection refs
+func (r Repository) pushUserCollectionRef(ctx context.Context, collection Collection) *domain.ResponseErr {
+	storage := r.client.Database(database).Collection(users_collection)
+	filter := bson.M{"_id": collection.UserID}
+	update := bson.D{
+		{Key: "$push", Value: bson.D{{Key: "collections", Value: UserCollectionRef{
+			ObjectID: collection.ObjectID,
+			Name:     collection.Name,
+		}}}},
+		{Key: "$set", Value: bson.D{{Key: "updated_at", Value: time.Now()}}},
 	}
-	_, err = collection.UpdateOne(context.TODO(), filter, update)
-	if err != nil {
+	if _, err := storage.UpdateOne(ctx, filter, update); err != nil {
 		return &domain.ResponseErr{
 			Status:  http.StatusInternalServerError,
-			Message: fmt.Sprintf("Error adding new card: %v", err),
+			Message: err.Error(),
 		}
 	}
 
 	return nil
 }
 
-func (r Repository) SetCardCountInCollection(collectionId string, card *domain.Card) *domain.ResponseErr {
-	// TODO: need to use transaction here
-	// TODO: replace collectionId on ObjectID in service layer
-	objectId, err := bson.ObjectIDFromHex(collectionId)
+// FindCollectionByName finds the user's collection by its name ignoring case
+func (r Repository) FindCollectionByName(userID, name string) (*domain.Collection, *domain.ResponseErr) {
+	userObjectID, err := bson.ObjectIDFromHex(userID)
 	if err != nil {
-		return &domain.ResponseErr{
+		return nil, &domain.ResponseErr{
 			Status:  http.StatusBadRequest,
-			Message: "Invalid collection ID format",
+			Message: "Invalid user ID format",
 		}
 	}
 
-	collection := r.client.Database(database).Collection(collections_collection)
-	filter := bson.D{
-		{Key: "_id", Value: objectId},
-		{Key: "cards.scryfall_id", Value: card.ScryfallID},
-	}
-	update := bson.D{
-		{Key: "$set", Value: bson.D{
-			{Key: "cards.$.count", Value: card.Count},
-			{Key: "updated_at", Value: time.Now()},
-		}},
-	}
-	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	storage := r.client.Database(database).Collection(collections_collection)
+	filter := bson.M{"user_id": userObjectID, "name": name, "deleted_at": notTrashed()}
+	opts := options.FindOne().SetCollation(caseInsensitive)
 
-	// var updatedColection Collection
-	res := collection.FindOneAndUpdate(context.TODO(), filter, update, opts)
-	if res.Err() != nil {
-		if res.Err() == mongo.ErrNoDocuments {
-			return &domain.ResponseErr{
-				Status:  http.StatusNotFound,
-				Message: "Collection not found",
-			}
-		}
-		return &domain.ResponseErr{
-			Status:  http.StatusInternalServerError,
-			Message: fmt.Sprintf("Update collection error: %v", err),
-		}
+	var collection Collection
+	err = storage.FindOne(context.TODO(), filter, opts).Decode(&collection)
+	if err != nil {
+		return nil, collectionFindError(err, "Collection not found")
 	}
 
-	return nil
+	domainCollection := collection.ToDomain()
+	return &domainCollection, nil
 }
 
-func (r Repository) DeleteCardFromCollection(collectionId string, card *domain.Card) *domain.ResponseErr {
-	// TODO: replace collectionId on ObjectID in service layer
-	objectId, err := bson.ObjectIDFromHex(collectionId)
+// SetCollectionKind changes the kind of the user's collection
+func (r Repository) SetCollectionKind(actor domain.Actor, collectionID string, kind domain.CollectionKind) (*domain.Collection, *domain.ResponseErr) {
+	userObjectID, err := bson.ObjectIDFromHex(actor.UserID)
 	if err != nil {
-		return &domain.ResponseErr{
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid user ID format",
+		}
+	}
+	collectionObjectID, err := bson.ObjectIDFromHex(collectionID)
+	if err != nil {
+		return nil, &domain.ResponseErr{
 			Status:  http.StatusBadRequest,
 			Message: "Invalid collection ID format",
 		}
 	}
 
-	collection := r.client.Database(database).Collection(collections_collection)
-	filter := bson.D{
-		{Key: "_id", Value: objectId},
-		{Key: "cards.scryfall_id", Value: card.ScryfallID},
-	}
-	update := bson.D{
-		{Key: "$pull", Value: bson.D{
-			{Key: "cards", Value: bson.D{
-				{Key: "scryfall_id", Value: card.ScryfallID},
-			}},
-		}},
-		{Key: "$set", Value: bson.D{
-			{Key: "updated_at", Value: time.Now()},
-		}},
+	ctx := context.TODO()
+	storage := r.client.Database(database).Collection(collections_collection)
+	filter := withVersion(bson.M{"_id": collectionObjectID, "user_id": userObjectID, "deleted_at": notTrashed()}, actor.IfMatch)
+	update := bson.M{
+		"$set": bson.M{"kind": string(kind), "updated_at": time.Now()},
+		"$inc": bson.M{"version": 1},
 	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
 
-	res := collection.FindOneAndUpdate(context.TODO(), filter, update)
-	if res.Err() != nil {
-		if res.Err() == mongo.ErrNoDocuments {
-			return &domain.ResponseErr{
-				Status:  http.StatusNotFound,
-				Message: "Collection not found",
-			}
+	var collection Collection
+	if err := storage.FindOneAndUpdate(ctx, filter, update, opts).Decode(&collection); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, r.collectionMissError(ctx, filter, "Collection not found")
 		}
-		return &domain.ResponseErr{
-			Status:  http.StatusInternalServerError,
-			Message: fmt.Sprintf("Update collection error: %v", err),
+		return nil, collectionFindError(err, "Collection not found")
+	}
+
+	domainCollection := collection.ToDomain()
+	return &domainCollection, nil
+}
+
+// FindCollectionsByKind returns the user's collections of the kind in name order, without cards.
+// Collections stored before kinds were added are binders.
+func (r Repository) FindCollectionsByKind(userID string, kind domain.CollectionKind) ([]domain.Collection, *domain.ResponseErr) {
+	userObjectID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid user ID format",
 		}
 	}
 
-	return nil
+	filter := bson.M{"user_id": userObjectID, "kind": string(kind)}
+	if kind == domain.KindBinder {
+		filter["kind"] = bson.M{"$in": bson.A{string(kind), nil}}
+	}
+	return r.findCollections(filter)
+}
+
+// FindUserCollections returns all collections of the user in name order, without cards.
+func (r Repository) FindUserCollections(userID string) ([]domain.Collection, *domain.ResponseErr) {
+	userObjectID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid user ID format",
+		}
+	}
+	return r.findCollections(bson.M{"user_id": userObjectID})
+}
+
+// findCollections returns collections matching the filter in name order, trashed ones are left out
+func (r Repository) findCollections(filter bson.M) ([]domain.Collection, *domain.ResponseErr) {
+	filter["deleted_at"] = notTrashed()
+	ctx := context.TODO()
+	storage := r.client.Database(database).Collection(collections_collection)
+	cursor, err := storage.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Find collections error: %v", err),
+		}
+	}
+	defer cursor.Close(ctx)
+
+	var collections []Collection
+	if err := cursor.All(ctx, &collections); err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Find collections error: %v", err),
+		}
+	}
+
+	domainCollections := make([]domain.Collection, len(collections))
+	for i := range collections {
+		domainCollections[i] = collections[i].ToDomain()
+	}
+	return domainCollections, nil
+}
+
+// GetCollection gets information about collection by ID.
+// Card entries are not loaded, they are listed with ListCards.
+func (r Repository) GetCollection(collectionId string) (*domain.Collection, *domain.ResponseErr) {
+	collObjectID, err := bson.ObjectIDFromHex(collectionId)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID format",
+		}
+	}
+	storage := r.client.Database(database).Collection(collections_collection)
+	filter := bson.M{"_id": collObjectID, "deleted_at": notTrashed()}
+
+	var collection Collection
+	err = storage.FindOne(context.TODO(), filter).Decode(&collection)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &domain.ResponseErr{
+				Status:  http.StatusNotFound,
+				Message: "Collection not found",
+			}
+		}
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Find collection error: %v", err),
+		}
+	}
+
+	domainCollection := collection.ToDomain()
+	return &domainCollection, nil
+}
+
+// StreamCards calls fn for every card entry of the collection in name order without
+// loading the whole collection into memory. An error of fn stops the stream and is returned
+// as an internal error.
+func (r Repository) StreamCards(collectionId string, fn func(domain.Card) error) *domain.ResponseErr {
+	objectId, err := bson.ObjectIDFromHex(collectionId)
+	if err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID format",
+		}
+	}
+
+	ctx := context.TODO()
+	collections := r.client.Database(database).Collection(collections_collection)
+	opts := options.FindOne().SetProjection(bson.M{"_id": 1})
+	if err := collections.FindOne(ctx, bson.M{"_id": objectId, "deleted_at": notTrashed()}, opts).Err(); err != nil {
+		return collectionFindError(err, "Collection not found")
+	}
+
+	storage := r.client.Database(database).Collection(cards_collection)
+	findOpts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := storage.Find(ctx, bson.M{"collection_id": objectId, "deleted_at": notTrashed()}, findOpts)
+	if err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Stream cards error: %v", err),
+		}
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var entry Card
+		if err := cursor.Decode(&entry); err != nil {
+			return &domain.ResponseErr{
+				Status:  http.StatusInternalServerError,
+				Message: fmt.Sprintf("Stream cards error: %v", err),
+			}
+		}
+		if err := fn(entry.ToDomain()); err != nil {
+			return &domain.ResponseErr{
+				Status:  http.StatusInternalServerError,
+				Message: fmt.Sprintf("Stream cards error: %v", err),
+			}
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Stream cards error: %v", err),
+		}
+	}
+
+	return nil
+}
+
+// ListCards returns a page of the collection's card entries matching the query.
+// Filtering, sorting and pagination run as an aggregation in Mongo.
+func (r Repository) ListCards(collectionId string, query *domain.CardsQuery) (*domain.CardsPage, *domain.ResponseErr) {
+	objectId, err := bson.ObjectIDFromHex(collectionId)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID format",
+		}
+	}
+
+	ctx := context.TODO()
+	collections := r.client.Database(database).Collection(collections_collection)
+	opts := options.FindOne().SetProjection(bson.M{"_id": 1})
+	if err := collections.FindOne(ctx, bson.M{"_id": objectId, "deleted_at": notTrashed()}, opts).Err(); err != nil {
+		return nil, collectionFindError(err, "Collection not found")
+	}
+
+	match := bson.D{{Key: "collection_id", Value: objectId}, {Key: "deleted_at", Value: notTrashed()}}
+	if query.Name != "" {
+		match = append(match, bson.E{Key: "name", Value: bson.M{"$regex": regexp.QuoteMeta(query.Name), "$options": "i"}})
+	}
+	if !query.AddedSince.IsZero() {
+		match = append(match, bson.E{Key: "added_at", Value: bson.M{"$gte": query.AddedSince}})
+	}
+	if query.MinCount > 0 {
+		match = append(match, bson.E{Key: "count", Value: bson.M{"$gte": query.MinCount}})
+	}
+
+	order := 1
+	if query.Desc {
+		order = -1
+	}
+
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: match}}}
+	pipeline = append(pipeline, cardQueryStages(query.Filter)...)
+	pipeline = append(pipeline, bson.D{{Key: "$facet", Value: bson.M{
+		"total": bson.A{bson.M{"$count": "n"}},
+		"cards": bson.A{
+			bson.M{"$sort": bson.D{{Key: string(query.SortBy), Value: order}, {Key: "_id", Value: order}}},
+			bson.M{"$skip": query.Offset},
+			bson.M{"$limit": query.Limit},
+		},
+	}}})
+
+	storage := r.client.Database(database).Collection(cards_collection)
+	cursor, err := storage.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("List cards error: %v", err),
+		}
+	}
+	defer cursor.Close(ctx)
+
+	var result []struct {
+		Total []struct {
+			N int `bson:"n"`
+		} `bson:"total"`
+		Cards []Card `bson:"cards"`
+	}
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("List cards error: %v", err),
+		}
+	}
+
+	page := &domain.CardsPage{
+		Cards:  []domain.Card{},
+		Offset: query.Offset,
+		Limit:  query.Limit,
+	}
+	if len(result) == 0 {
+		return page, nil
+	}
+	if len(result[0].Total) > 0 {
+		page.Total = result[0].Total[0].N
+	}
+	for _, v := range result[0].Cards {
+		page.Cards = append(page.Cards, v.ToDomain())
+	}
+
+	return page, nil
+}
+
+// AddCardToCollection adds copies of a card variant to a collection and returns the stored entry.
+// Copies of a variant which is already in the collection are added to its entry.
+func (r Repository) AddCardToCollection(actor domain.Actor, collectionId string, card *domain.Card) (*domain.Card, *domain.ResponseErr) {
+	// TODO: replace collectionId on ObjectID in service layer
+	objectId, err := bson.ObjectIDFromHex(collectionId)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID format",
+		}
+	}
+
+	entry, err := CardFromDomain(*card)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	var stored Card
+	respErr := r.runInTransaction(func(ctx context.Context) error {
+		if respErr := r.touchOwnedCollection(ctx, actor, objectId); respErr != nil {
+			return respErr
+		}
+
+		var err error
+		stored, err = r.upsertCardEntry(ctx, objectId, entry)
+		if err != nil {
+			return &domain.ResponseErr{
+				Status:  http.StatusInternalServerError,
+				Message: fmt.Sprintf("Error adding card: %v", err),
+			}
+		}
+
+		kind := domain.ChangeAdd
+		if actor.Import {
+			kind = domain.ChangeImport
+		}
+		log := &changeLog{}
+		log.add(stored, stored.Count-entry.Count, stored.Count)
+		if _, respErr := r.recordChange(ctx, actor, kind, log); respErr != nil {
+			return respErr
+		}
+
+		return nil
+	})
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	domainCard := stored.ToDomain()
+	return &domainCard, nil
+}
+
+// SetCardCountInCollection sets the count of the card entry with card.ID
+func (r Repository) SetCardCountInCollection(actor domain.Actor, collectionId string, card *domain.Card) *domain.ResponseErr {
+	// TODO: replace collectionId on ObjectID in service layer
+	objectId, err := bson.ObjectIDFromHex(collectionId)
+	if err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID format",
+		}
+	}
+
+	entryObjectId, err := bson.ObjectIDFromHex(card.ID)
+	if err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid card entry ID format",
+		}
+	}
+
+	return r.runInTransaction(func(ctx context.Context) error {
+		if respErr := r.touchOwnedCollection(ctx, actor, objectId); respErr != nil {
+			return respErr
+		}
+
+		storage := r.client.Database(database).Collection(cards_collection)
+		filter := bson.D{
+			{Key: "_id", Value: entryObjectId},
+			{Key: "collection_id", Value: objectId},
+			{Key: "deleted_at", Value: notTrashed()},
+		}
+		update := bson.D{
+			{Key: "$set", Value: bson.D{
+				{Key: "count", Value: card.Count},
+			}},
+		}
+
+		var previous Card
+		err := storage.FindOneAndUpdate(ctx, filter, update).Decode(&previous)
+		if err == mongo.ErrNoDocuments {
+			return &domain.ResponseErr{
+				Status:  http.StatusNotFound,
+				Message: "Card not found",
+			}
+		}
+		if err != nil {
+			return &domain.ResponseErr{
+				Status:  http.StatusInternalServerError,
+				Message: fmt.Sprintf("Update card error: %v", err),
+			}
+		}
+
+		log := &changeLog{}
+		log.add(previous, previous.Count, card.Count)
+		if _, respErr := r.recordChange(ctx, actor, domain.ChangeSetCount, log); respErr != nil {
+			return respErr
+		}
+
+		return nil
+	})
+}
+
+// DeleteCardFromCollection moves the card entry with card.ID to the trash
+func (r Repository) DeleteCardFromCollection(actor domain.Actor, collectionId string, card *domain.Card) *domain.ResponseErr {
+	// TODO: replace collectionId on ObjectID in service layer
+	objectId, err := bson.ObjectIDFromHex(collectionId)
+	if err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID format",
+		}
+	}
+
+	entryObjectId, err := bson.ObjectIDFromHex(card.ID)
+	if err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid card entry ID format",
+		}
+	}
+
+	return r.runInTransaction(func(ctx context.Context) error {
+		if respErr := r.touchOwnedCollection(ctx, actor, objectId); respErr != nil {
+			return respErr
+		}
+
+		storage := r.client.Database(database).Collection(cards_collection)
+		filter := bson.D{
+			{Key: "_id", Value: entryObjectId},
+			{Key: "collection_id", Value: objectId},
+			{Key: "deleted_at", Value: notTrashed()},
+		}
+		update := bson.M{"$set": bson.M{"deleted_at": time.Now()}}
+
+		var previous Card
+		err := storage.FindOneAndUpdate(ctx, filter, update).Decode(&previous)
+		if err == mongo.ErrNoDocuments {
+			return &domain.ResponseErr{
+				Status:  http.StatusNotFound,
+				Message: "Card not found",
+			}
+		}
+		if err != nil {
+			return &domain.ResponseErr{
+				Status:  http.StatusInternalServerError,
+				Message: fmt.Sprintf("Delete card error: %v", err),
+			}
+		}
+
+		log := &changeLog{}
+		log.add(previous, previous.Count, 0)
+		if _, respErr := r.recordChange(ctx, actor, domain.ChangeDelete, log); respErr != nil {
+			return respErr
+		}
+
+		return nil
+	})
+}
+
+// AdjustCardCount atomically adds or removes copies of the card entry and returns the entry.
+// The entry goes to the trash with the removed copies when its count reaches zero, the returned
+// entry has zero count then.
+func (r Repository) AdjustCardCount(actor domain.Actor, collectionId string, adjust *domain.CardAdjustment) (*domain.Card, *domain.ResponseErr) {
+	objectId, err := bson.ObjectIDFromHex(collectionId)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID format",
+		}
+	}
+
+	entryObjectId, err := bson.ObjectIDFromHex(adjust.EntryID)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid card entry ID format",
+		}
+	}
+
+	var adjusted Card
+	respErr := r.runInTransaction(func(ctx context.Context) error {
+		if respErr := r.touchOwnedCollection(ctx, actor, objectId); respErr != nil {
+			return respErr
+		}
+
+		storage := r.client.Database(database).Collection(cards_collection)
+		filter := bson.M{"_id": entryObjectId, "collection_id": objectId, "deleted_at": notTrashed()}
+		if adjust.Delta < 0 {
+			filter["count"] = bson.M{"$gte": -adjust.Delta}
+		}
+		update := bson.M{"$inc": bson.M{"count": adjust.Delta}}
+		opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+		err := storage.FindOneAndUpdate(ctx, filter, update, opts).Decode(&adjusted)
+		if err == mongo.ErrNoDocuments {
+			// Tell a missing entry from an entry with too few copies
+			if _, respErr := r.findCardEntry(ctx, objectId, entryObjectId, "Card not found"); respErr != nil {
+				return respErr
+			}
+			return &domain.ResponseErr{
+				Status:  http.StatusConflict,
+				Message: "Not enough copies to remove",
+			}
+		}
+		if err != nil {
+			return &domain.ResponseErr{
+				Status:  http.StatusInternalServerError,
+				Message: fmt.Sprintf("Update card error: %v", err),
+			}
+		}
+
+		if adjusted.Count == 0 {
+			update := bson.M{"$set": bson.M{"count": -adjust.Delta, "deleted_at": time.Now()}}
+			if _, err := storage.UpdateOne(ctx, bson.M{"_id": entryObjectId}, update); err != nil {
+				return &domain.ResponseErr{
+					Status:  http.StatusInternalServerError,
+					Message: fmt.Sprintf("Delete card error: %v", err),
+				}
+			}
+		}
+
+		log := &changeLog{}
+		log.add(adjusted, adjusted.Count-adjust.Delta, adjusted.Count)
+		if _, respErr := r.recordChange(ctx, actor, domain.ChangeAdjust, log); respErr != nil {
+			return respErr
+		}
+
+		return nil
+	})
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	domainCard := adjusted.ToDomain()
+	return &domainCard, nil
+}
+
+// MoveCardBetweenZones moves copies of a card entry to another zone in one transaction.
+// The source entry is removed when all of its copies are moved.
+func (r Repository) MoveCardBetweenZones(actor domain.Actor, collectionId string, move *domain.CardMove) *domain.ResponseErr {
+	objectId, err := bson.ObjectIDFromHex(collectionId)
+	if err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID format",
+		}
+	}
+
+	entryObjectId, err := bson.ObjectIDFromHex(move.EntryID)
+	if err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid card entry ID format",
+		}
+	}
+
+	return r.runInTransaction(func(ctx context.Context) error {
+		if respErr := r.touchOwnedCollection(ctx, actor, objectId); respErr != nil {
+			return respErr
+		}
+
+		source, respErr := r.findCardEntry(ctx, objectId, entryObjectId, "Card not found")
+		if respErr != nil {
+			return respErr
+		}
+		if source.Zone == string(move.To) {
+			return &domain.ResponseErr{
+				Status:  http.StatusBadRequest,
+				Message: "Card is already in this zone",
+			}
+		}
+		if source.Count < move.Count {
+			return &domain.ResponseErr{
+				Status:  http.StatusConflict,
+				Message: "Not enough copies to move",
+			}
+		}
+
+		// Take copies from the source zone
+		if err := r.takeCardCopies(ctx, source, move.Count); err != nil {
+			return &domain.ResponseErr{
+				Status:  http.StatusInternalServerError,
+				Message: fmt.Sprintf("Error updating source zone: %v", err),
+			}
+		}
+
+		// Put copies into the destination zone
+		moved := *source
+		moved.Zone = string(move.To)
+		moved.Count = move.Count
+		stored, err := r.upsertCardEntry(ctx, objectId, moved)
+		if err != nil {
+			return &domain.ResponseErr{
+				Status:  http.StatusInternalServerError,
+				Message: fmt.Sprintf("Error updating destination zone: %v", err),
+			}
+		}
+
+		log := &changeLog{}
+		log.add(*source, source.Count, source.Count-move.Count)
+		log.add(stored, stored.Count-move.Count, stored.Count)
+		if _, respErr := r.recordChange(ctx, actor, domain.ChangeMove, log); respErr != nil {
+			return respErr
+		}
+
+		return nil
+	})
+}
+
+// TransferCards moves copies of card entries between two collections of the user in one transaction.
+// Copies join the entry of the same variant in the destination, source entries are removed at zero.
+func (r Repository) TransferCards(actor domain.Actor, transfer *domain.CardTransfer) *domain.ResponseErr {
+	userObjectId, err := bson.ObjectIDFromHex(transfer.UserID)
+	if err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid user ID format",
+		}
+	}
+
+	fromObjectId, err := bson.ObjectIDFromHex(transfer.FromCollectionID)
+	if err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID format",
+		}
+	}
+
+	toObjectId, err := bson.ObjectIDFromHex(transfer.ToCollectionID)
+	if err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID format",
+		}
+	}
+
+	return r.runInTransaction(func(ctx context.Context) error {
+		filter := withVersion(bson.M{"_id": fromObjectId, "user_id": userObjectId}, actor.IfMatch)
+		if respErr := r.touchCollection(ctx, filter, "Source collection not found"); respErr != nil {
+			return respErr
+		}
+		filter = bson.M{"_id": toObjectId, "user_id": userObjectId}
+		if respErr := r.touchCollection(ctx, filter, "Destination collection not found"); respErr != nil {
+			return respErr
+		}
+
+		log := &changeLog{}
+		for _, item := range transfer.Items {
+			entryObjectId, err := bson.ObjectIDFromHex(item.EntryID)
+			if err != nil {
+				return &domain.ResponseErr{
+					Status:  http.StatusBadRequest,
+					Message: "Invalid card entry ID format",
+				}
+			}
+
+			source, respErr := r.findCardEntry(ctx, fromObjectId, entryObjectId, fmt.Sprintf("Card %s not found", item.EntryID))
+			if respErr != nil {
+				return respErr
+			}
+			if source.Count < item.Count {
+				return &domain.ResponseErr{
+					Status:  http.StatusConflict,
+					Message: fmt.Sprintf("Not enough copies of card %s to transfer", item.EntryID),
+				}
+			}
+
+			if err := r.takeCardCopies(ctx, source, item.Count); err != nil {
+				return &domain.ResponseErr{
+					Status:  http.StatusInternalServerError,
+					Message: fmt.Sprintf("Error updating source collection: %v", err),
+				}
+			}
+
+			moved := *source
+			moved.Count = item.Count
+			stored, err := r.upsertCardEntry(ctx, toObjectId, moved)
+			if err != nil {
+				return &domain.ResponseErr{
+					Status:  http.StatusInternalServerError,
+					Message: fmt.Sprintf("Error updating destination collection: %v", err),
+				}
+			}
+
+			log.add(*source, source.Count, source.Count-item.Count)
+			log.add(stored, stored.Count-item.Count, stored.Count)
+		}
+
+		if _, respErr := r.recordChange(ctx, actor, domain.ChangeTransfer, log); respErr != nil {
+			return respErr
+		}
+
+		return nil
+	})
+}
+
+// runInTransaction runs fn inside a transaction and converts its error to ResponseErr
+func (r Repository) runInTransaction(fn func(ctx context.Context) error) *domain.ResponseErr {
+	ctx := context.TODO()
+	session, err := r.client.StartSession()
+	if err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Failed to start session: %v", err),
+		}
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(ctx context.Context) (any, error) {
+		return nil, fn(ctx)
+	})
+	if err != nil {
+		if e, ok := err.(*domain.ResponseErr); ok {
+			return e
+		}
+		return &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: err.Error(),
+		}
+	}
+
+	return nil
+}
+
+// cardVariantFilter matches a card entry by its identity tuple
+func cardVariantFilter(card *domain.Card) bson.M {
+	return bson.M{
+		"scryfall_id": card.ScryfallID,
+		"zone":        string(card.Zone),
+		"finish":      string(card.Finish),
+		"condition":   string(card.Condition),
+		"language":    card.Language,
+	}
+}
+
+func collectionNameTakenError() *domain.ResponseErr {
+	return &domain.ResponseErr{
+		Status:  http.StatusConflict,
+		Message: "Collection with this name already exists",
+	}
+}
+
+func collectionVersionError() *domain.ResponseErr {
+	return &domain.ResponseErr{
+		Status:  http.StatusPreconditionFailed,
+		Message: "Collection was changed by another request",
+	}
+}
+
+// collectionFindError converts an error of finding a collection to ResponseErr
+func collectionFindError(err error, notFoundMessage string) *domain.ResponseErr {
+	if err == mongo.ErrNoDocuments {
+		return &domain.ResponseErr{
+			Status:  http.StatusNotFound,
+			Message: notFoundMessage,
+		}
+	}
+	return &domain.ResponseErr{
+		Status:  http.StatusInternalServerError,
+		Message: fmt.Sprintf("Find collection error: %v", err),
+	}
+}
+
+// touchCollection sets updated_at and increments the version of the collection matched by filter
+// unless it's in the trash. Writing the collection document first makes concurrent transactions
+// on its cards conflict.
+func (r Repository) touchCollection(ctx context.Context, filter bson.M, notFoundMessage string) *domain.ResponseErr {
+	filter["deleted_at"] = notTrashed()
+	storage := r.client.Database(database).Collection(collections_collection)
+	update := bson.M{
+		"$set": bson.M{"updated_at": time.Now()},
+		"$inc": bson.M{"version": 1},
+	}
+
+	result, err := storage.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Update collection error: %v", err),
+		}
+	}
+	if result.MatchedCount == 0 {
+		return r.collectionMissError(ctx, filter, notFoundMessage)
+	}
+
+	return nil
+}
+
+// ownedCollectionFilter matches the collection only when it belongs to the user,
+// so collections of other users look missing to them.
+func ownedCollectionFilter(userId string, collectionObjectId bson.ObjectID) (bson.M, *domain.ResponseErr) {
+	userObjectId, err := bson.ObjectIDFromHex(userId)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid user ID format",
+		}
+	}
+
+	return bson.M{"_id": collectionObjectId, "user_id": userObjectId}, nil
+}
+
+// checkCollectionOwner fails with not found unless the collection belongs to the user and
+// isn't in the trash.
+func (r Repository) checkCollectionOwner(ctx context.Context, userId string, collectionObjectId bson.ObjectID) *domain.ResponseErr {
+	owned, respErr := ownedCollectionFilter(userId, collectionObjectId)
+	if respErr != nil {
+		return respErr
+	}
+	owned["deleted_at"] = notTrashed()
+
+	storage := r.client.Database(database).Collection(collections_collection)
+	opts := options.FindOne().SetProjection(bson.M{"_id": 1})
+	if err := storage.FindOne(ctx, owned, opts).Err(); err != nil {
+		return collectionFindError(err, "Collection not found")
+	}
+	return nil
+}
+
+// withVersion makes the collection filter match only one of the versions, unless versions is nil.
+// Collections which weren't changed since versions were added have no version, they're version 0.
+func withVersion(filter bson.M, versions []int64) bson.M {
+	if versions == nil {
+		return filter
+	}
+	in := bson.A{}
+	for _, version := range versions {
+		in = append(in, version)
+		if version == 0 {
+			in = append(in, nil)
+		}
+	}
+	filter["version"] = bson.M{"$in": in}
+	return filter
+}
+
+// touchOwnedCollection touches the collection as touchCollection does when it belongs to the
+// actor and has the version the actor expects. Collections of other users look missing.
+func (r Repository) touchOwnedCollection(ctx context.Context, actor domain.Actor, collectionObjectId bson.ObjectID) *domain.ResponseErr {
+	owned, respErr := ownedCollectionFilter(actor.UserID, collectionObjectId)
+	if respErr != nil {
+		return respErr
+	}
+	return r.touchCollection(ctx, withVersion(owned, actor.IfMatch), "Collection not found")
+}
+
+// collectionMissError tells why no collection matched the filter of a change: the collection
+// is missing or, when the filter has a version, another request changed it.
+func (r Repository) collectionMissError(ctx context.Context, filter bson.M, notFoundMessage string) *domain.ResponseErr {
+	if _, ok := filter["version"]; ok {
+		anyVersion := make(bson.M, len(filter))
+		for k, v := range filter {
+			if k != "version" {
+				anyVersion[k] = v
+			}
+		}
+		storage := r.client.Database(database).Collection(collections_collection)
+		found, err := storage.CountDocuments(ctx, anyVersion, options.Count().SetLimit(1))
+		if err != nil {
+			return &domain.ResponseErr{
+				Status:  http.StatusInternalServerError,
+				Message: fmt.Sprintf("Find collection error: %v", err),
+			}
+		}
+		if found > 0 {
+			return collectionVersionError()
+		}
+	}
+
+	return &domain.ResponseErr{
+		Status:  http.StatusNotFound,
+		Message: notFoundMessage,
+	}
+}
+
+// upsertCardEntry adds entry.Count copies to the collection's entry of the same variant,
+// creating the entry if the collection doesn't have the variant yet. It returns the stored entry.
+func (r Repository) upsertCardEntry(ctx context.Context, collectionObjectId bson.ObjectID, entry Card) (Card, error) {
+	domainCard := entry.ToDomain()
+	filter := cardVariantFilter(&domainCard)
+	filter["collection_id"] = collectionObjectId
+	filter["deleted_at"] = notTrashed()
+	// Imported entries keep the date they were added in another tool
+	addedAt := entry.AddedAt
+	if addedAt.IsZero() {
+		addedAt = time.Now()
+	}
+	update := bson.M{
+		"$inc": bson.M{"count": entry.Count},
+		"$setOnInsert": bson.M{
+			"_id":       bson.NewObjectID(),
+			"name":      entry.Name,
+			"card_url":  entry.CardUrl,
+			"set":       entry.SetCode,
+			"rarity":    entry.Rarity,
+			"type_line": entry.TypeLine,
+			"added_at":  addedAt,
+		},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	storage := r.client.Database(database).Collection(cards_collection)
+	var stored Card
+	err := storage.FindOneAndUpdate(ctx, filter, update, opts).Decode(&stored)
+	return stored, err
+}
+
+// takeCardCopies removes count copies from the entry, the entry is deleted when no copies are left.
+// Unlike deleted entries it doesn't go to the trash: its copies are moved to another entry, so
+// restoring it would duplicate them.
+func (r Repository) takeCardCopies(ctx context.Context, entry *Card, count int) error {
+	storage := r.client.Database(database).Collection(cards_collection)
+	filter := bson.M{"_id": entry.ObjectID}
+
+	var err error
+	if entry.Count == count {
+		_, err = storage.DeleteOne(ctx, filter)
+	} else {
+		_, err = storage.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"count": -count}})
+	}
+	return err
+}
+
+// findCardEntry finds the card entry of the collection by its ID
+func (r Repository) findCardEntry(ctx context.Context, collectionObjectId, entryObjectId bson.ObjectID, notFoundMessage string) (*Card, *domain.ResponseErr) {
+	storage := r.client.Database(database).Collection(cards_collection)
+	filter := bson.M{"_id": entryObjectId, "collection_id": collectionObjectId, "deleted_at": notTrashed()}
+
+	var entry Card
+	if err := storage.FindOne(ctx, filter).Decode(&entry); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &domain.ResponseErr{
+				Status:  http.StatusNotFound,
+				Message: notFoundMessage,
+			}
+		}
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Find card error: %v", err),
+		}
+	}
+
+	return &entry, nil
+}
+
+// findCardEntries loads all card entries of the collection
+func (r Repository) findCardEntries(ctx context.Context, collectionObjectId bson.ObjectID) ([]Card, error) {
+	storage := r.client.Database(database).Collection(cards_collection)
+	cursor, err := storage.Find(ctx, bson.M{"collection_id": collectionObjectId, "deleted_at": notTrashed()})
+	if err != nil {
+		return nil, err
+	}
+
+	cards := []Card{}
+	if err := cursor.All(ctx, &cards); err != nil {
+		return nil, err
+	}
+	return cards, nil
+}
+
+// ApplyCardOperations applies the batch of card operations to the collection in one transaction
+// with a single ordered bulk write. Operations are checked against entries loaded in the transaction
+// first, so every operation gets its result and an atomic batch with failed operations writes nothing.
+func (r Repository) ApplyCardOperations(actor domain.Actor, collectionId string, batch *domain.CardBatch) (*domain.CardBatchResult, *domain.ResponseErr) {
+	objectId, err := bson.ObjectIDFromHex(collectionId)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID format",
+		}
+	}
+
+	var result *domain.CardBatchResult
+	rejected := &domain.ResponseErr{
+		Status:  http.StatusConflict,
+		Message: "Batch is rejected",
+	}
+	respErr := r.runInTransaction(func(ctx context.Context) error {
+		result = &domain.CardBatchResult{
+			Applied: true,
+			Results: make([]domain.CardOperationResult, len(batch.Operations)),
+		}
+
+		if respErr := r.touchOwnedCollection(ctx, actor, objectId); respErr != nil {
+			return respErr
+		}
+
+		state, err := r.loadBatchEntries(ctx, objectId, batch.Operations)
+		if err != nil {
+			return &domain.ResponseErr{
+				Status:  http.StatusInternalServerError,
+				Message: fmt.Sprintf("Find cards error: %v", err),
+			}
+		}
+
+		var writes []mongo.WriteModel
+		for i, op := range batch.Operations {
+			entry, write, respErr := state.apply(objectId, op)
+			if respErr != nil {
+				result.Results[i].Err = respErr
+				result.Applied = batch.Mode != domain.BatchAtomic
+				continue
+			}
+			domainCard := entry.ToDomain()
+			result.Results[i].Entry = &domainCard
+			writes = append(writes, write)
+		}
+
+		if !result.Applied || len(writes) == 0 {
+			// Roll back touching the collection as well, so a batch which
+			// changed nothing doesn't change the collection version
+			return rejected
+		}
+
+		storage := r.client.Database(database).Collection(cards_collection)
+		if _, err := storage.BulkWrite(ctx, writes); err != nil {
+			return &domain.ResponseErr{
+				Status:  http.StatusInternalServerError,
+				Message: fmt.Sprintf("Apply card operations error: %v", err),
+			}
+		}
+		if _, respErr := r.recordChange(ctx, actor, domain.ChangeBatch, &state.log); respErr != nil {
+			return respErr
+		}
+
+		return nil
+	})
+	if respErr == rejected {
+		// Results of operations which would succeed don't describe stored entries
+		for i := range result.Results {
+			result.Results[i].Entry = nil
+		}
+		return result, nil
+	}
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	return result, nil
+}
+
+// batchEntries is the state of collection entries touched by a card batch
+type batchEntries struct {
+	byID      map[bson.ObjectID]*Card
+	byVariant map[string]*Card
+	log       changeLog
+}
+
+// loadBatchEntries loads entries addressed by the operations or having their cards
+func (r Repository) loadBatchEntries(ctx context.Context, collectionObjectId bson.ObjectID, operations []domain.CardOperation) (*batchEntries, error) {
+	ids := []bson.ObjectID{}
+	scryfallIds := []string{}
+	for _, op := range operations {
+		if op.Type == domain.CardOperationAdd {
+			scryfallIds = append(scryfallIds, op.Card.ScryfallID)
+			continue
+		}
+		if id, err := bson.ObjectIDFromHex(op.Card.ID); err == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	storage := r.client.Database(database).Collection(cards_collection)
+	filter := bson.M{
+		"collection_id": collectionObjectId,
+		"deleted_at":    notTrashed(),
+		"$or": bson.A{
+			bson.M{"_id": bson.M{"$in": ids}},
+			bson.M{"scryfall_id": bson.M{"$in": scryfallIds}},
+		},
+	}
+	cursor, err := storage.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var cards []Card
+	if err := cursor.All(ctx, &cards); err != nil {
+		return nil, err
+	}
+
+	state := &batchEntries{
+		byID:      make(map[bson.ObjectID]*Card, len(cards)),
+		byVariant: make(map[string]*Card, len(cards)),
+	}
+	for i := range cards {
+		state.byID[cards[i].ObjectID] = &cards[i]
+		state.byVariant[cards[i].variantKey()] = &cards[i]
+	}
+
+	return state, nil
+}
+
+// apply applies the operation to the state and returns the entry after it with the write doing the same in Mongo
+func (s *batchEntries) apply(collectionObjectId bson.ObjectID, op domain.CardOperation) (Card, mongo.WriteModel, *domain.ResponseErr) {
+	if op.Type == domain.CardOperationAdd {
+		card, err := CardFromDomain(op.Card)
+		if err != nil {
+			return Card{}, nil, &domain.ResponseErr{
+				Status:  http.StatusBadRequest,
+				Message: err.Error(),
+			}
+		}
+
+		if entry, ok := s.byVariant[card.variantKey()]; ok {
+			s.log.add(*entry, entry.Count, entry.Count+card.Count)
+			entry.Count += card.Count
+			write := mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"_id": entry.ObjectID}).
+				SetUpdate(bson.M{"$inc": bson.M{"count": card.Count}})
+			return *entry, write, nil
+		}
+
+		card.ObjectID = bson.NewObjectID()
+		card.CollectionID = collectionObjectId
+		card.AddedAt = time.Now()
+		s.byID[card.ObjectID] = &card
+		s.byVariant[card.variantKey()] = &card
+		s.log.add(card, 0, card.Count)
+		return card, mongo.NewInsertOneModel().SetDocument(card), nil
+	}
+
+	entryObjectId, err := bson.ObjectIDFromHex(op.Card.ID)
+	if err != nil {
+		return Card{}, nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid card entry ID format",
+		}
+	}
+	entry, ok := s.byID[entryObjectId]
+	if !ok {
+		return Card{}, nil, &domain.ResponseErr{
+			Status:  http.StatusNotFound,
+			Message: "Card not found",
+		}
+	}
+
+	if op.Type == domain.CardOperationSetCount {
+		s.log.add(*entry, entry.Count, op.Card.Count)
+		entry.Count = op.Card.Count
+		write := mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": entryObjectId}).
+			SetUpdate(bson.M{"$set": bson.M{"count": entry.Count}})
+		return *entry, write, nil
+	}
+
+	// Deleted entries go to the trash like entries deleted one by one
+	s.log.add(*entry, entry.Count, 0)
+	delete(s.byID, entryObjectId)
+	delete(s.byVariant, entry.variantKey())
+	deleted := *entry
+	deleted.Count = 0
+	write := mongo.NewUpdateOneModel().
+		SetFilter(bson.M{"_id": entryObjectId}).
+		SetUpdate(bson.M{"$set": bson.M{"deleted_at": time.Now()}})
+	return deleted, write, nil
 }
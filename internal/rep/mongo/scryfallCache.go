@@ -0,0 +1,60 @@
+package mongorep
+
+import (
+	"context"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/pkg/scryfall"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// scryfall_cache_collection, one document per Scryfall API path
+type cachedScryfallResponse struct {
+	Key      string    `bson:"_id"`
+	ETag     string    `bson:"etag"`
+	Body     []byte    `bson:"body"`
+	StoredAt time.Time `bson:"stored_at"`
+}
+
+// ScryfallCache keeps Scryfall API responses in Mongo, so they survive restarts.
+type ScryfallCache struct {
+	client *mongo.Client
+}
+
+// ScryfallCache returns the Scryfall response cache backed by the repository database.
+func (r Repository) ScryfallCache() *ScryfallCache {
+	return &ScryfallCache{client: r.client}
+}
+
+func (c *ScryfallCache) Load(ctx context.Context, key string) (*scryfall.CachedResponse, error) {
+	storage := c.client.Database(database).Collection(scryfall_cache_collection)
+
+	var cached cachedScryfallResponse
+	if err := storage.FindOne(ctx, bson.M{"_id": key}).Decode(&cached); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &scryfall.CachedResponse{
+		ETag:     cached.ETag,
+		Body:     cached.Body,
+		StoredAt: cached.StoredAt,
+	}, nil
+}
+
+func (c *ScryfallCache) Store(ctx context.Context, key string, resp *scryfall.CachedResponse) error {
+	storage := c.client.Database(database).Collection(scryfall_cache_collection)
+
+	cached := cachedScryfallResponse{
+		Key:      key,
+		ETag:     resp.ETag,
+		Body:     resp.Body,
+		StoredAt: resp.StoredAt,
+	}
+	_, err := storage.ReplaceOne(ctx, bson.M{"_id": key}, cached, options.Replace().SetUpsert(true))
+	return err
+}
@@ -0,0 +1,37 @@
+package mongorep
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/pkg/scryfall"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestScryfallCache(t *testing.T) {
+	r := newTestRepository(t)
+	cache := r.ScryfallCache()
+	ctx := context.Background()
+	key := "/cards/test-" + bson.NewObjectID().Hex()
+	t.Cleanup(func() {
+		_, _ = r.client.Database(database).Collection(scryfall_cache_collection).DeleteOne(ctx, bson.M{"_id": key})
+	})
+
+	missing, err := cache.Load(ctx, key)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	storedAt := time.Now().Truncate(time.Millisecond).UTC()
+	require.NoError(t, cache.Store(ctx, key, &scryfall.CachedResponse{ETag: `"v1"`, Body: []byte(`{"name":"Lightning Bolt"}`), StoredAt: storedAt}))
+	require.NoError(t, cache.Store(ctx, key, &scryfall.CachedResponse{ETag: `"v2"`, Body: []byte(`{"name":"Lightning Bolt"}`), StoredAt: storedAt}))
+
+	cached, err := cache.Load(ctx, key)
+	require.NoError(t, err)
+	require.NotNil(t, cached)
+	assert.Equal(t, `"v2"`, cached.ETag)
+	assert.Equal(t, `{"name":"Lightning Bolt"}`, string(cached.Body))
+	assert.True(t, storedAt.Equal(cached.StoredAt))
+}
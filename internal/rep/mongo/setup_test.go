@@ -0,0 +1,88 @@
+package mongorep
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// newTestRepository connects to the Mongo replica set from MONGO_TEST_CONN_STRING.
+// Tests and benchmarks which need a database are skipped when it is not set.
+func newTestRepository(tb testing.TB) *Repository {
+	tb.Helper()
+
+	connString := os.Getenv("MONGO_TEST_CONN_STRING")
+	if connString == "" {
+		tb.Skip("MONGO_TEST_CONN_STRING is not set")
+	}
+
+	client, err := mongo.Connect(options.Client().ApplyURI(connString))
+	require.NoError(tb, err)
+	tb.Cleanup(func() {
+		_ = client.Disconnect(context.Background())
+	})
+
+	r := NewRepository(client)
+	require.NoError(tb, r.EnsureIndexes())
+	return r
+}
+
+// newTestCollection stores an empty collection of a random user.
+// The collection and its cards are removed when the test ends.
+func newTestCollection(tb testing.TB, r *Repository) Collection {
+	tb.Helper()
+
+	now := time.Now()
+	collection := Collection{
+		ObjectID:  bson.NewObjectID(),
+		UserID:    bson.NewObjectID(),
+		Name:      fmt.Sprintf("test-%s", bson.NewObjectID().Hex()),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	ctx := context.Background()
+	db := r.client.Database(database)
+	_, err := db.Collection(collections_collection).InsertOne(ctx, collection)
+	require.NoError(tb, err)
+	tb.Cleanup(func() {
+		_, _ = db.Collection(collections_collection).DeleteOne(ctx, bson.M{"_id": collection.ObjectID})
+		_, _ = db.Collection(cards_collection).DeleteMany(ctx, bson.M{"collection_id": collection.ObjectID})
+		_, _ = db.Collection(collection_changes_collection).DeleteMany(ctx, bson.M{"collection_ids": collection.ObjectID})
+		_, _ = db.Collection(snapshots_collection).DeleteMany(ctx, bson.M{"collection_id": collection.ObjectID})
+	})
+
+	return collection
+}
+
+// testActor changes cards in tests, it doesn't own test collections
+var testActor = domain.Actor{UserID: bson.NewObjectID().Hex(), RequestID: "test-request"}
+
+// ownerOf returns the actor owning the test collection
+func ownerOf(collection Collection) domain.Actor {
+	return domain.Actor{UserID: collection.UserID.Hex(), RequestID: testActor.RequestID}
+}
+
+// testCard returns the i-th distinct card entry with default variant fields
+func testCard(i int) Card {
+	return Card{
+		ObjectID:   bson.NewObjectID(),
+		ScryfallID: fmt.Sprintf("00000000-0000-0000-0000-%012d", i),
+		Name:       fmt.Sprintf("Test Card %d", i),
+		CardUrl:    fmt.Sprintf("https://example.com/%d.jpg", i),
+		Count:      1,
+		Zone:       "main",
+		Finish:     "nonfoil",
+		Condition:  "NM",
+		Language:   "en",
+		AddedAt:    time.Now(),
+	}
+}
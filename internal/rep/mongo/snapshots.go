@@ -0,0 +1,312 @@
+package mongorep
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// CreateSnapshot copies the card entries of the user's collection into a new snapshot with the label.
+// The entries are read in a transaction, so the copy doesn't mix states of concurrent changes.
+func (r Repository) CreateSnapshot(userId, collectionId, label string) (*domain.Snapshot, *domain.ResponseErr) {
+	objectId, err := bson.ObjectIDFromHex(collectionId)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID format",
+		}
+	}
+
+	snapshot := Snapshot{
+		ObjectID:     bson.NewObjectID(),
+		CollectionID: objectId,
+		Label:        label,
+	}
+	respErr := r.runInTransaction(func(ctx context.Context) error {
+		if respErr := r.checkCollectionOwner(ctx, userId, objectId); respErr != nil {
+			return respErr
+		}
+
+		cards, err := r.findCardEntries(ctx, objectId)
+		if err != nil {
+			return &domain.ResponseErr{
+				Status:  http.StatusInternalServerError,
+				Message: fmt.Sprintf("Find cards error: %v", err),
+			}
+		}
+
+		snapshot.Cards = cards
+		snapshot.Entries = len(cards)
+		snapshot.Copies = 0
+		for _, c := range cards {
+			snapshot.Copies += c.Count
+		}
+		snapshot.CreatedAt = time.Now()
+
+		storage := r.client.Database(database).Collection(snapshots_collection)
+		if _, err := storage.InsertOne(ctx, snapshot); err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return &domain.ResponseErr{
+					Status:  http.StatusConflict,
+					Message: "Snapshot with this label already exists",
+				}
+			}
+			return &domain.ResponseErr{
+				Status:  http.StatusInternalServerError,
+				Message: fmt.Sprintf("Insert snapshot error: %v", err),
+			}
+		}
+
+		return nil
+	})
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	domainSnapshot := snapshot.ToDomain()
+	return &domainSnapshot, nil
+}
+
+// FindSnapshots lists snapshots of the user's collection without their cards, most recent first
+func (r Repository) FindSnapshots(userId, collectionId string) ([]domain.Snapshot, *domain.ResponseErr) {
+	objectId, err := bson.ObjectIDFromHex(collectionId)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID format",
+		}
+	}
+
+	ctx := context.TODO()
+	if respErr := r.checkCollectionOwner(ctx, userId, objectId); respErr != nil {
+		return nil, respErr
+	}
+
+	storage := r.client.Database(database).Collection(snapshots_collection)
+	findOpts := options.Find().
+		SetProjection(bson.M{"cards": 0}).
+		SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := storage.Find(ctx, bson.M{"collection_id": objectId}, findOpts)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Find snapshots error: %v", err),
+		}
+	}
+
+	var snapshots []Snapshot
+	if err := cursor.All(ctx, &snapshots); err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Decode snapshots error: %v", err),
+		}
+	}
+
+	domainSnapshots := make([]domain.Snapshot, len(snapshots))
+	for i := range snapshots {
+		domainSnapshots[i] = snapshots[i].ToDomain()
+	}
+	return domainSnapshots, nil
+}
+
+// GetSnapshot gets the snapshot of the user's collection with its cards
+func (r Repository) GetSnapshot(userId, collectionId, snapshotId string) (*domain.Snapshot, *domain.ResponseErr) {
+	objectId, err := bson.ObjectIDFromHex(collectionId)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID format",
+		}
+	}
+
+	snapshotObjectId, err := bson.ObjectIDFromHex(snapshotId)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid snapshot ID format",
+		}
+	}
+
+	ctx := context.TODO()
+	if respErr := r.checkCollectionOwner(ctx, userId, objectId); respErr != nil {
+		return nil, respErr
+	}
+
+	snapshot, respErr := r.findSnapshot(ctx, objectId, snapshotObjectId)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	domainSnapshot := snapshot.ToDomain()
+	if domainSnapshot.Cards == nil {
+		domainSnapshot.Cards = []domain.Card{}
+	}
+	return &domainSnapshot, nil
+}
+
+// DeleteSnapshot removes the snapshot of the user's collection for good
+func (r Repository) DeleteSnapshot(userId, collectionId, snapshotId string) *domain.ResponseErr {
+	objectId, err := bson.ObjectIDFromHex(collectionId)
+	if err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID format",
+		}
+	}
+
+	snapshotObjectId, err := bson.ObjectIDFromHex(snapshotId)
+	if err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid snapshot ID format",
+		}
+	}
+
+	ctx := context.TODO()
+	if respErr := r.checkCollectionOwner(ctx, userId, objectId); respErr != nil {
+		return respErr
+	}
+
+	storage := r.client.Database(database).Collection(snapshots_collection)
+	result, err := storage.DeleteOne(ctx, bson.M{"_id": snapshotObjectId, "collection_id": objectId})
+	if err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Delete snapshot error: %v", err),
+		}
+	}
+	if result.DeletedCount == 0 {
+		return &domain.ResponseErr{
+			Status:  http.StatusNotFound,
+			Message: "Snapshot not found",
+		}
+	}
+
+	return nil
+}
+
+// RestoreSnapshot sets the card entries of the collection to the ones of the snapshot in one
+// transaction. Entries of variants missing from the snapshot go to the trash, variants missing
+// from the collection get new entries. The restore is recorded in the collection history.
+// Collections of other users look missing.
+func (r Repository) RestoreSnapshot(actor domain.Actor, collectionId, snapshotId string) *domain.ResponseErr {
+	objectId, err := bson.ObjectIDFromHex(collectionId)
+	if err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID format",
+		}
+	}
+
+	snapshotObjectId, err := bson.ObjectIDFromHex(snapshotId)
+	if err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid snapshot ID format",
+		}
+	}
+
+	owned, respErr := ownedCollectionFilter(actor.UserID, objectId)
+	if respErr != nil {
+		return respErr
+	}
+
+	return r.runInTransaction(func(ctx context.Context) error {
+		if respErr := r.touchCollection(ctx, withVersion(owned, actor.IfMatch), "Collection not found"); respErr != nil {
+			return respErr
+		}
+
+		snapshot, respErr := r.findSnapshot(ctx, objectId, snapshotObjectId)
+		if respErr != nil {
+			return respErr
+		}
+
+		live, err := r.findCardEntries(ctx, objectId)
+		if err != nil {
+			return &domain.ResponseErr{
+				Status:  http.StatusInternalServerError,
+				Message: fmt.Sprintf("Find cards error: %v", err),
+			}
+		}
+		byVariant := make(map[string]*Card, len(live))
+		for i := range live {
+			byVariant[live[i].variantKey()] = &live[i]
+		}
+
+		var writes []mongo.WriteModel
+		log := &changeLog{}
+		for _, card := range snapshot.Cards {
+			key := card.variantKey()
+			if entry, ok := byVariant[key]; ok {
+				delete(byVariant, key)
+				if entry.Count != card.Count {
+					writes = append(writes, mongo.NewUpdateOneModel().
+						SetFilter(bson.M{"_id": entry.ObjectID}).
+						SetUpdate(bson.M{"$set": bson.M{"count": card.Count}}))
+					log.add(*entry, entry.Count, card.Count)
+				}
+				continue
+			}
+
+			// The entry the snapshot was taken from may still be in the trash
+			card.ObjectID = bson.NewObjectID()
+			card.CollectionID = objectId
+			card.DeletedAt = time.Time{}
+			writes = append(writes, mongo.NewInsertOneModel().SetDocument(card))
+			log.add(card, 0, card.Count)
+		}
+		now := time.Now()
+		for _, entry := range live {
+			if _, ok := byVariant[entry.variantKey()]; !ok {
+				continue
+			}
+			writes = append(writes, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"_id": entry.ObjectID}).
+				SetUpdate(bson.M{"$set": bson.M{"deleted_at": now}}))
+			log.add(entry, entry.Count, 0)
+		}
+
+		if len(writes) == 0 {
+			return nil
+		}
+		storage := r.client.Database(database).Collection(cards_collection)
+		if _, err := storage.BulkWrite(ctx, writes); err != nil {
+			return &domain.ResponseErr{
+				Status:  http.StatusInternalServerError,
+				Message: fmt.Sprintf("Restore snapshot error: %v", err),
+			}
+		}
+		if _, respErr := r.recordChange(ctx, actor, domain.ChangeSnapshot, log); respErr != nil {
+			return respErr
+		}
+
+		return nil
+	})
+}
+
+// findSnapshot finds the snapshot of the collection with its cards
+func (r Repository) findSnapshot(ctx context.Context, collectionObjectId, snapshotObjectId bson.ObjectID) (*Snapshot, *domain.ResponseErr) {
+	storage := r.client.Database(database).Collection(snapshots_collection)
+
+	var snapshot Snapshot
+	if err := storage.FindOne(ctx, bson.M{"_id": snapshotObjectId, "collection_id": collectionObjectId}).Decode(&snapshot); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &domain.ResponseErr{
+				Status:  http.StatusNotFound,
+				Message: "Snapshot not found",
+			}
+		}
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Find snapshot error: %v", err),
+		}
+	}
+
+	return &snapshot, nil
+}
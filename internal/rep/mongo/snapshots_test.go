@@ -0,0 +1,81 @@
+package mongorep
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotIsRestored(t *testing.T) {
+	r := newTestRepository(t)
+	collection := newTestCollection(t, r)
+	collectionId := collection.ObjectID.Hex()
+	owner := ownerOf(collection)
+
+	var stored []*domain.Card
+	for i := 1; i <= 2; i++ {
+		entry := testCard(i)
+		card := entry.ToDomain()
+		card.ID = ""
+		card.Count = i
+		added, respErr := r.AddCardToCollection(owner, collectionId, &card)
+		require.Nil(t, respErr)
+		stored = append(stored, added)
+	}
+
+	snapshot, respErr := r.CreateSnapshot(owner.UserID, collectionId, "before")
+	require.Nil(t, respErr)
+	require.Equal(t, 2, snapshot.Entries)
+	require.Equal(t, 3, snapshot.Copies)
+
+	_, respErr = r.CreateSnapshot(owner.UserID, collectionId, "before")
+	require.NotNil(t, respErr)
+	require.Equal(t, http.StatusConflict, respErr.Status)
+
+	// Change one entry, delete the other and add a third one
+	_, respErr = r.AdjustCardCount(owner, collectionId, &domain.CardAdjustment{EntryID: stored[0].ID, Delta: 4})
+	require.Nil(t, respErr)
+	require.Nil(t, r.DeleteCardFromCollection(owner, collectionId, &domain.Card{ID: stored[1].ID}))
+	entry := testCard(3)
+	card := entry.ToDomain()
+	card.ID = ""
+	_, respErr = r.AddCardToCollection(owner, collectionId, &card)
+	require.Nil(t, respErr)
+
+	snapshots, respErr := r.FindSnapshots(owner.UserID, collectionId)
+	require.Nil(t, respErr)
+	require.Len(t, snapshots, 1)
+	require.Nil(t, snapshots[0].Cards)
+
+	// Snapshots of collections of other users look missing
+	_, respErr = r.GetSnapshot(testActor.UserID, collectionId, snapshot.ID)
+	require.NotNil(t, respErr)
+	require.Equal(t, http.StatusNotFound, respErr.Status)
+	respErr = r.RestoreSnapshot(testActor, collectionId, snapshot.ID)
+	require.NotNil(t, respErr)
+	require.Equal(t, http.StatusNotFound, respErr.Status)
+	respErr = r.DeleteSnapshot(testActor.UserID, collectionId, snapshot.ID)
+	require.NotNil(t, respErr)
+	require.Equal(t, http.StatusNotFound, respErr.Status)
+
+	require.Nil(t, r.RestoreSnapshot(owner, collectionId, snapshot.ID))
+
+	cards, respErr := r.ListCards(collectionId, &domain.CardsQuery{SortBy: domain.SortByName, Limit: 10})
+	require.Nil(t, respErr)
+	require.Len(t, cards.Cards, 2)
+	require.Equal(t, stored[0].ID, cards.Cards[0].ID)
+	require.Equal(t, 1, cards.Cards[0].Count)
+	require.Equal(t, 2, cards.Cards[1].Count)
+
+	page, respErr := r.FindCollectionChanges(owner.UserID, collectionId, 0, 1)
+	require.Nil(t, respErr)
+	require.Equal(t, domain.ChangeSnapshot, page.Changes[0].Kind)
+	require.Len(t, page.Changes[0].Entries, 3)
+
+	require.Nil(t, r.DeleteSnapshot(owner.UserID, collectionId, snapshot.ID))
+	respErr = r.DeleteSnapshot(owner.UserID, collectionId, snapshot.ID)
+	require.NotNil(t, respErr)
+	require.Equal(t, http.StatusNotFound, respErr.Status)
+}
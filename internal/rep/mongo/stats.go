@@ -0,0 +1,236 @@
+package mongorep
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// CollectionStats computes the statistics of the card entries of the collections
+// in a single aggregation. The mana curve is always computed, UpdatedAt is left zero.
+func (r Repository) CollectionStats(collectionIds []string) (*domain.CollectionStats, *domain.ResponseErr) {
+	objectIds, respErr := collectionObjectIDs(collectionIds)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	ifNull := func(expr ...any) bson.M { return bson.M{"$ifNull": bson.A(expr)} }
+	cardTypes := make(bson.A, len(domain.CardTypes))
+	for i, cardType := range domain.CardTypes {
+		cardTypes[i] = cardType
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"collection_id": bson.M{"$in": objectIds}, "deleted_at": notTrashed()}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         catalog_collection,
+			"localField":   "scryfall_id",
+			"foreignField": "_id",
+			"as":           "printing",
+		}}},
+		{{Key: "$set", Value: bson.M{"printing": bson.M{"$arrayElemAt": bson.A{"$printing", 0}}}}},
+		// Catalog data wins over the entry's own copy of it, the same as holdings
+		{{Key: "$set", Value: bson.M{
+			"cataloged":  bson.M{"$ne": bson.A{bson.M{"$type": "$printing"}, "missing"}},
+			"oracle_key": ifNull("$printing.oracle_id", bson.M{"$concat": bson.A{"name:", bson.M{"$toLower": "$name"}}}),
+			"set":        ifNull("$printing.set", "$set", ""),
+			"rarity":     ifNull("$printing.rarity", "$rarity", ""),
+			"type_line":  ifNull("$printing.type_line", "$type_line", ""),
+			"colors":     ifNull("$printing.colors", bson.A{}),
+			"cmc":        ifNull("$printing.cmc", 0),
+			"foil":       bson.M{"$in": bson.A{"$finish", bson.A{string(domain.FinishFoil), string(domain.FinishEtched)}}},
+		}}},
+		{{Key: "$facet", Value: bson.M{
+			"totals": bson.A{
+				bson.M{"$group": bson.M{
+					"_id":         nil,
+					"cards":       bson.M{"$sum": "$count"},
+					"foil":        bson.M{"$sum": bson.M{"$cond": bson.A{"$foil", "$count", 0}}},
+					"multicolor":  bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$gt": bson.A{bson.M{"$size": "$colors"}, 1}}, "$count", 0}}},
+					"uncataloged": bson.M{"$sum": bson.M{"$cond": bson.A{"$cataloged", 0, "$count"}}},
+					"oracle_keys": bson.M{"$addToSet": "$oracle_key"},
+					"printings":   bson.M{"$addToSet": "$scryfall_id"},
+				}},
+				bson.M{"$project": bson.M{
+					"cards":       1,
+					"foil":        1,
+					"multicolor":  1,
+					"uncataloged": 1,
+					"unique":      bson.M{"$size": "$oracle_keys"},
+					"printings":   bson.M{"$size": "$printings"},
+				}},
+			},
+			"by_color": statsBuckets(
+				bson.M{"$match": bson.M{"cataloged": true}},
+				bson.M{"$set": bson.M{"colors": bson.M{"$cond": bson.A{
+					bson.M{"$eq": bson.A{bson.M{"$size": "$colors"}, 0}}, bson.A{domain.ColorlessKey}, "$colors",
+				}}}},
+				bson.M{"$unwind": "$colors"},
+				bson.M{"$set": bson.M{"key": "$colors"}},
+			),
+			"by_rarity": statsBuckets(
+				bson.M{"$match": bson.M{"rarity": bson.M{"$ne": ""}}},
+				bson.M{"$set": bson.M{"key": "$rarity"}},
+			),
+			"by_set": statsBuckets(
+				bson.M{"$match": bson.M{"set": bson.M{"$ne": ""}}},
+				bson.M{"$set": bson.M{"key": "$set"}},
+			),
+			"by_type": statsBuckets(
+				bson.M{"$match": bson.M{"cataloged": true}},
+				bson.M{"$set": bson.M{"types": bson.M{"$filter": bson.M{
+					"input": cardTypes,
+					"as":    "type",
+					"cond":  bson.M{"$regexMatch": bson.M{"input": "$type_line", "regex": "$$type"}},
+				}}}},
+				bson.M{"$set": bson.M{"types": bson.M{"$cond": bson.A{
+					bson.M{"$eq": bson.A{bson.M{"$size": "$types"}, 0}}, bson.A{domain.OtherCardType}, "$types",
+				}}}},
+				bson.M{"$unwind": "$types"},
+				bson.M{"$set": bson.M{"key": "$types"}},
+			),
+			"by_mana_value": statsBuckets(
+				bson.M{"$match": bson.M{"cataloged": true}},
+				bson.M{"$set": bson.M{"key": bson.M{"$toString": bson.M{"$toInt": bson.M{"$floor": "$cmc"}}}}},
+			),
+			"mana_curve": bson.A{
+				bson.M{"$match": bson.M{
+					"cataloged": true,
+					"zone":      bson.M{"$in": bson.A{string(domain.ZoneMain), string(domain.ZoneCommander)}},
+					"type_line": bson.M{"$not": bson.Regex{Pattern: "Land"}},
+				}},
+				bson.M{"$group": bson.M{
+					"_id":   bson.M{"$min": bson.A{bson.M{"$toInt": bson.M{"$floor": "$cmc"}}, domain.MaxCurveManaValue}},
+					"cards": bson.M{"$sum": "$count"},
+				}},
+				bson.M{"$project": bson.M{"_id": 0, "mana_value": "$_id", "cards": 1}},
+			},
+			"added_per_month": bson.A{
+				// Entries stored before added_at was recorded have a zero date
+				bson.M{"$match": bson.M{"added_at": bson.M{"$gt": time.Unix(0, 0)}}},
+				bson.M{"$group": bson.M{
+					"_id":   bson.M{"$dateToString": bson.M{"format": "%Y-%m", "date": "$added_at"}},
+					"cards": bson.M{"$sum": "$count"},
+				}},
+				bson.M{"$project": bson.M{"_id": 0, "month": "$_id", "cards": 1}},
+			},
+		}}},
+	}
+
+	ctx := context.TODO()
+	storage := r.client.Database(database).Collection(cards_collection)
+	cursor, err := storage.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Collection stats error: %v", err),
+		}
+	}
+	defer cursor.Close(ctx)
+
+	var result []struct {
+		Totals []struct {
+			Cards       int `bson:"cards"`
+			Unique      int `bson:"unique"`
+			Printings   int `bson:"printings"`
+			Foil        int `bson:"foil"`
+			Multicolor  int `bson:"multicolor"`
+			Uncataloged int `bson:"uncataloged"`
+		} `bson:"totals"`
+		CollectionStats `bson:",inline"`
+	}
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Collection stats error: %v", err),
+		}
+	}
+
+	var model CollectionStats
+	if len(result) > 0 {
+		model = result[0].CollectionStats
+		if len(result[0].Totals) > 0 {
+			totals := result[0].Totals[0]
+			model.Cards = totals.Cards
+			model.Unique = totals.Unique
+			model.Printings = totals.Printings
+			model.Foil = totals.Foil
+			model.Multicolor = totals.Multicolor
+			model.Uncataloged = totals.Uncataloged
+		}
+	}
+	if model.Cards > 0 {
+		model.FoilRatio = math.Round(float64(model.Foil)/float64(model.Cards)*1000) / 1000
+	}
+	if model.ManaCurve == nil {
+		model.ManaCurve = []CurveBar{}
+	}
+
+	stats := model.ToDomain()
+	domain.SortStats(&stats)
+	return &stats, nil
+}
+
+// statsBuckets groups entries by the key the stages set, counting copies and distinct cards
+func statsBuckets(stages ...bson.M) bson.A {
+	pipeline := bson.A{}
+	for _, stage := range stages {
+		pipeline = append(pipeline, stage)
+	}
+	return append(pipeline,
+		bson.M{"$group": bson.M{
+			"_id":         "$key",
+			"cards":       bson.M{"$sum": "$count"},
+			"oracle_keys": bson.M{"$addToSet": "$oracle_key"},
+		}},
+		bson.M{"$project": bson.M{"_id": 0, "key": "$_id", "cards": 1, "unique": bson.M{"$size": "$oracle_keys"}}},
+	)
+}
+
+// GetCachedStats returns the statistics stored under the key when they were
+// computed for the version, nil when there are none.
+func (r Repository) GetCachedStats(key, version string) (*domain.CollectionStats, *domain.ResponseErr) {
+	storage := r.client.Database(database).Collection(stats_cache_collection)
+
+	var cached CachedStats
+	err := storage.FindOne(context.TODO(), bson.M{"_id": key, "version": version}).Decode(&cached)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Find cached stats error: %v", err),
+		}
+	}
+
+	stats := cached.Stats.ToDomain()
+	return &stats, nil
+}
+
+// SaveCachedStats stores the statistics under the key, replacing the ones of older versions
+func (r Repository) SaveCachedStats(key, version string, stats *domain.CollectionStats) *domain.ResponseErr {
+	storage := r.client.Database(database).Collection(stats_cache_collection)
+
+	cached := CachedStats{
+		Key:      key,
+		Version:  version,
+		Stats:    CollectionStatsFromDomain(stats),
+		StoredAt: time.Now(),
+	}
+	_, err := storage.ReplaceOne(context.TODO(), bson.M{"_id": key}, cached, options.Replace().SetUpsert(true))
+	if err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Save cached stats error: %v", err),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package mongorep
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestCollectionStats(t *testing.T) {
+	r := newTestRepository(t)
+	collDoc := newTestCollection(t, r)
+	coll := collDoc.ToDomain()
+	prefix := "stats-" + bson.NewObjectID().Hex()
+
+	printings := []domain.CatalogCard{
+		{ScryfallID: prefix + "-bolt", OracleID: prefix + "-bolt", Name: "Lightning Bolt", SetCode: "m10", CollectorNumber: "146",
+			Rarity: "common", TypeLine: "Instant", CMC: 1, Colors: []string{"R"}},
+		{ScryfallID: prefix + "-bolt-2xm", OracleID: prefix + "-bolt", Name: "Lightning Bolt", SetCode: "2xm", CollectorNumber: "141",
+			Rarity: "uncommon", TypeLine: "Instant", CMC: 1, Colors: []string{"R"}},
+		{ScryfallID: prefix + "-charm", OracleID: prefix + "-charm", Name: "Boros Charm", SetCode: "rtr", CollectorNumber: "148",
+			Rarity: "uncommon", TypeLine: "Instant", CMC: 2, Colors: []string{"W", "R"}},
+		{ScryfallID: prefix + "-titan", OracleID: prefix + "-titan", Name: "Myr Battlesphere", SetCode: "som", CollectorNumber: "180",
+			Rarity: "rare", TypeLine: "Artifact Creature — Myr Construct", CMC: 7},
+		{ScryfallID: prefix + "-mountain", OracleID: prefix + "-mountain", Name: "Mountain", SetCode: "m10", CollectorNumber: "242",
+			Rarity: "common", TypeLine: "Basic Land — Mountain"},
+	}
+	t.Cleanup(func() {
+		_, _ = r.client.Database(database).Collection(catalog_collection).DeleteMany(context.Background(), bson.M{"_id": bson.M{"$regex": "^" + prefix}})
+	})
+	require.Nil(t, r.UpsertCatalogCards(printings))
+
+	add := func(card domain.Card) {
+		card.SetVariantDefaults()
+		_, respErr := r.AddCardToCollection(ownerOf(collDoc), coll.ID, &card)
+		require.Nil(t, respErr)
+	}
+	add(domain.Card{ScryfallID: prefix + "-bolt", Name: "Lightning Bolt", Count: 3})
+	add(domain.Card{ScryfallID: prefix + "-bolt-2xm", Name: "Lightning Bolt", Count: 1, Finish: domain.FinishFoil})
+	add(domain.Card{ScryfallID: prefix + "-charm", Name: "Boros Charm", Count: 2, Zone: domain.ZoneSide})
+	add(domain.Card{ScryfallID: prefix + "-titan", Name: "Myr Battlesphere", Count: 1})
+	add(domain.Card{ScryfallID: prefix + "-mountain", Name: "Mountain", Count: 10})
+	add(domain.Card{ScryfallID: prefix + "-unknown", Name: "Unknown Card", Count: 3})
+
+	stats, respErr := r.CollectionStats([]string{coll.ID})
+	require.Nil(t, respErr)
+
+	assert.Equal(t, 20, stats.Cards)
+	assert.Equal(t, 5, stats.Unique, "both bolt printings are one card")
+	assert.Equal(t, 6, stats.Printings)
+	assert.Equal(t, 1, stats.Foil)
+	assert.Equal(t, 0.05, stats.FoilRatio)
+	assert.Equal(t, 2, stats.Multicolor)
+	assert.Equal(t, 3, stats.Uncataloged)
+
+	assert.Equal(t, []domain.StatsBucket{
+		{Key: "W", Cards: 2, Unique: 1},
+		{Key: "R", Cards: 6, Unique: 2},
+		{Key: "C", Cards: 11, Unique: 2},
+	}, stats.ByColor)
+	assert.Equal(t, []domain.StatsBucket{
+		{Key: "common", Cards: 13, Unique: 2},
+		{Key: "uncommon", Cards: 3, Unique: 2},
+		{Key: "rare", Cards: 1, Unique: 1},
+	}, stats.ByRarity)
+	assert.Equal(t, domain.StatsBucket{Key: "m10", Cards: 13, Unique: 2}, stats.BySet[0])
+	assert.Equal(t, []domain.StatsBucket{
+		{Key: "Land", Cards: 10, Unique: 1},
+		{Key: "Instant", Cards: 6, Unique: 2},
+		{Key: "Artifact", Cards: 1, Unique: 1},
+		{Key: "Creature", Cards: 1, Unique: 1},
+	}, stats.ByType)
+	assert.Equal(t, []domain.StatsBucket{
+		{Key: "0", Cards: 10, Unique: 1},
+		{Key: "1", Cards: 4, Unique: 1},
+		{Key: "2", Cards: 2, Unique: 1},
+		{Key: "7", Cards: 1, Unique: 1},
+	}, stats.ByManaValue)
+	assert.Equal(t, []domain.CurveBar{{ManaValue: 1, Cards: 4}, {ManaValue: 7, Cards: 1}}, stats.ManaCurve,
+		"lands, sideboard and uncataloged cards are left out of the curve")
+	require.Len(t, stats.AddedPerMonth, 1)
+	assert.Equal(t, 20, stats.AddedPerMonth[0].Cards)
+}
+
+func TestCachedStats(t *testing.T) {
+	r := newTestRepository(t)
+	key := "test:" + bson.NewObjectID().Hex()
+	t.Cleanup(func() {
+		_, _ = r.client.Database(database).Collection(stats_cache_collection).DeleteOne(context.Background(), bson.M{"_id": key})
+	})
+
+	stats, respErr := r.GetCachedStats(key, "v1")
+	require.Nil(t, respErr)
+	assert.Nil(t, stats)
+
+	require.Nil(t, r.SaveCachedStats(key, "v1", &domain.CollectionStats{
+		Cards:   4,
+		ByColor: []domain.StatsBucket{{Key: "R", Cards: 4, Unique: 1}},
+	}))
+
+	stats, respErr = r.GetCachedStats(key, "v1")
+	require.Nil(t, respErr)
+	require.NotNil(t, stats)
+	assert.Equal(t, 4, stats.Cards)
+	assert.Nil(t, stats.ManaCurve)
+	assert.Equal(t, []domain.StatsBucket{{Key: "R", Cards: 4, Unique: 1}}, stats.ByColor)
+
+	stats, respErr = r.GetCachedStats(key, "v2")
+	require.Nil(t, respErr)
+	assert.Nil(t, stats, "other versions are stale")
+}
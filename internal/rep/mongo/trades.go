@@ -0,0 +1,214 @@
+package mongorep
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// tradeCard is a result document of the FindTradeCards aggregation
+type tradeCard struct {
+	ID struct {
+		UserID    bson.ObjectID `bson:"user_id"`
+		Kind      string        `bson:"kind"`
+		OracleKey string        `bson:"oracle_key"`
+	} `bson:"_id"`
+	Name       string `bson:"name"`
+	ScryfallID string `bson:"scryfall_id"`
+	Finish     string `bson:"finish"`
+	Count      int    `bson:"count"`
+}
+
+// FindTradeCards sums the cards on the wishlists and trade lists of the users by
+// user, kind and oracle identity. Cards missing from the catalog are matched by
+// name, maybeboard entries are left out.
+func (r Repository) FindTradeCards(userIDs []string) ([]domain.TradeCard, *domain.ResponseErr) {
+	userObjectIDs := make([]bson.ObjectID, len(userIDs))
+	for i, id := range userIDs {
+		objectID, err := bson.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, &domain.ResponseErr{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid user ID format",
+			}
+		}
+		userObjectIDs[i] = objectID
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"user_id":    bson.M{"$in": userObjectIDs},
+			"kind":       bson.M{"$in": bson.A{string(domain.KindWishlist), string(domain.KindTrade)}},
+			"deleted_at": notTrashed(),
+		}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         cards_collection,
+			"localField":   "_id",
+			"foreignField": "collection_id",
+			"as":           "card",
+		}}},
+		{{Key: "$unwind", Value: "$card"}},
+		{{Key: "$match", Value: bson.M{
+			"card.zone":       bson.M{"$ne": string(domain.ZoneMaybe)},
+			"card.deleted_at": notTrashed(),
+		}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         catalog_collection,
+			"localField":   "card.scryfall_id",
+			"foreignField": "_id",
+			"as":           "printing",
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "card.name", Value: 1}, {Key: "card._id", Value: 1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"user_id": "$user_id",
+				"kind":    "$kind",
+				"oracle_key": bson.M{"$ifNull": bson.A{
+					bson.M{"$arrayElemAt": bson.A{"$printing.oracle_id", 0}},
+					bson.M{"$concat": bson.A{"name:", bson.M{"$toLower": "$card.name"}}},
+				}},
+			},
+			"name":        bson.M{"$first": "$card.name"},
+			"scryfall_id": bson.M{"$first": "$card.scryfall_id"},
+			"finish":      bson.M{"$first": "$card.finish"},
+			"count":       bson.M{"$sum": "$card.count"},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id.user_id", Value: 1}, {Key: "name", Value: 1}}}},
+	}
+
+	ctx := context.TODO()
+	storage := r.client.Database(database).Collection(collections_collection)
+	cursor, err := storage.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Find trade cards error: %v", err),
+		}
+	}
+	defer cursor.Close(ctx)
+
+	var docs []tradeCard
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Find trade cards error: %v", err),
+		}
+	}
+
+	cards := make([]domain.TradeCard, len(docs))
+	for i, doc := range docs {
+		cards[i] = domain.TradeCard{
+			UserID:     doc.ID.UserID.Hex(),
+			Kind:       domain.CollectionKind(doc.ID.Kind),
+			OracleKey:  doc.ID.OracleKey,
+			Name:       doc.Name,
+			ScryfallID: doc.ScryfallID,
+			Finish:     domain.Finish(doc.Finish),
+			Count:      doc.Count,
+		}
+	}
+	return cards, nil
+}
+
+// SaveTradeMatches stores the matches of a refresh started at refreshedAt, replacing
+// the stored match of the same user and partner, and removes matches the refresh
+// didn't find again.
+func (r Repository) SaveTradeMatches(matches []domain.TradeMatch, refreshedAt time.Time) *domain.ResponseErr {
+	ctx := context.TODO()
+	storage := r.client.Database(database).Collection(trade_matches_collection)
+
+	if len(matches) > 0 {
+		models := make([]mongo.WriteModel, len(matches))
+		for i, match := range matches {
+			doc, err := TradeMatchFromDomain(match)
+			if err != nil {
+				return &domain.ResponseErr{
+					Status:  http.StatusBadRequest,
+					Message: err.Error(),
+				}
+			}
+			models[i] = mongo.NewReplaceOneModel().
+				SetFilter(bson.M{"user_id": doc.UserID, "partner_id": doc.PartnerID}).
+				SetReplacement(doc).
+				SetUpsert(true)
+		}
+
+		if _, err := storage.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+			return &domain.ResponseErr{
+				Status:  http.StatusInternalServerError,
+				Message: fmt.Sprintf("Save trade matches error: %v", err),
+			}
+		}
+	}
+
+	if _, err := storage.DeleteMany(ctx, bson.M{"updated_at": bson.M{"$lt": refreshedAt}}); err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Delete stale trade matches error: %v", err),
+		}
+	}
+
+	return nil
+}
+
+// ListTradeMatches returns a page of the user's stored matches, most even trades first
+// and equally even ones by total value.
+func (r Repository) ListTradeMatches(userID string, offset, limit int) (*domain.TradeMatchesPage, *domain.ResponseErr) {
+	userObjectID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid user ID format",
+		}
+	}
+
+	ctx := context.TODO()
+	storage := r.client.Database(database).Collection(trade_matches_collection)
+	filter := bson.M{"user_id": userObjectID}
+
+	total, err := storage.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Count trade matches error: %v", err),
+		}
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "score", Value: -1}, {Key: "total_value", Value: -1}, {Key: "partner_id", Value: 1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	cursor, err := storage.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("List trade matches error: %v", err),
+		}
+	}
+	defer cursor.Close(ctx)
+
+	var docs []TradeMatch
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("List trade matches error: %v", err),
+		}
+	}
+
+	page := &domain.TradeMatchesPage{
+		Matches: make([]domain.TradeMatch, len(docs)),
+		Total:   int(total),
+		Offset:  offset,
+		Limit:   limit,
+	}
+	for i := range docs {
+		page.Matches[i] = docs[i].ToDomain()
+	}
+	return page, nil
+}
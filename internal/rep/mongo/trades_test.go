@@ -0,0 +1,76 @@
+package mongorep
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestFindTradeCardsSumsByKindAndCard(t *testing.T) {
+	r := newTestRepository(t)
+	wishlistDoc, tradeDoc := newTestCollection(t, r), newTestCollection(t, r)
+	wishlist, tradeList := wishlistDoc.ToDomain(), tradeDoc.ToDomain()
+	tradeList.UserID = wishlist.UserID
+	storage := r.client.Database(database).Collection(collections_collection)
+	_, err := storage.UpdateOne(context.Background(), bson.M{"_id": tradeDoc.ObjectID}, bson.M{"$set": bson.M{"user_id": wishlistDoc.UserID}})
+	require.NoError(t, err)
+	owner := domain.Actor{UserID: wishlist.UserID}
+	_, respErr := r.SetCollectionKind(owner, wishlist.ID, domain.KindWishlist)
+	require.Nil(t, respErr)
+	_, respErr = r.SetCollectionKind(owner, tradeList.ID, domain.KindTrade)
+	require.Nil(t, respErr)
+
+	add := func(collectionId string, card domain.Card) {
+		card.SetVariantDefaults()
+		_, respErr := r.AddCardToCollection(owner, collectionId, &card)
+		require.Nil(t, respErr)
+	}
+	add(wishlist.ID, domain.Card{ScryfallID: "trade-bolt-m10", Name: "Lightning Bolt", Count: 2})
+	add(wishlist.ID, domain.Card{ScryfallID: "trade-bolt-2xm", Name: "Lightning Bolt", Count: 1, Finish: domain.FinishFoil})
+	add(wishlist.ID, domain.Card{ScryfallID: "trade-rats", Name: "Relentless Rats", Count: 9, Zone: domain.ZoneMaybe})
+	add(tradeList.ID, domain.Card{ScryfallID: "trade-guide", Name: "Goblin Guide", Count: 3})
+
+	cards, respErr := r.FindTradeCards([]string{wishlist.UserID})
+	require.Nil(t, respErr)
+	require.Len(t, cards, 2)
+
+	assert.Equal(t, domain.KindTrade, cards[0].Kind)
+	assert.Equal(t, "Goblin Guide", cards[0].Name)
+	assert.Equal(t, 3, cards[0].Count)
+
+	assert.Equal(t, domain.KindWishlist, cards[1].Kind)
+	assert.Equal(t, "name:lightning bolt", cards[1].OracleKey)
+	assert.Equal(t, 3, cards[1].Count, "printings of a card are summed")
+}
+
+func TestSaveTradeMatchesRemovesStaleMatches(t *testing.T) {
+	r := newTestRepository(t)
+	userID, partnerID, otherID := bson.NewObjectID(), bson.NewObjectID(), bson.NewObjectID()
+	t.Cleanup(func() {
+		_, _ = r.client.Database(database).Collection(trade_matches_collection).DeleteMany(context.Background(), bson.M{"user_id": userID})
+	})
+	first := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+	second := first.Add(30 * time.Minute)
+
+	require.Nil(t, r.SaveTradeMatches([]domain.TradeMatch{
+		{UserID: userID.Hex(), PartnerID: partnerID.Hex(), Score: 0.5, UpdatedAt: first},
+		{UserID: userID.Hex(), PartnerID: otherID.Hex(), Score: 0.9, UpdatedAt: first},
+	}, first))
+	page, respErr := r.ListTradeMatches(userID.Hex(), 0, 10)
+	require.Nil(t, respErr)
+	require.Equal(t, 2, page.Total)
+	assert.Equal(t, otherID.Hex(), page.Matches[0].PartnerID, "the most even trade comes first")
+
+	require.Nil(t, r.SaveTradeMatches([]domain.TradeMatch{
+		{UserID: userID.Hex(), PartnerID: partnerID.Hex(), Score: 1, UpdatedAt: second},
+	}, second))
+	page, respErr = r.ListTradeMatches(userID.Hex(), 0, 10)
+	require.Nil(t, respErr)
+	require.Equal(t, 1, page.Total)
+	assert.Equal(t, 1.0, page.Matches[0].Score)
+}
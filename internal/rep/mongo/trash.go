@@ -0,0 +1,296 @@
+package mongorep
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// notTrashed matches collections and card entries which aren't in the trash.
+// Every query of active documents must filter deleted_at with it.
+func notTrashed() bson.M {
+	return bson.M{"$exists": false}
+}
+
+func trashed() bson.M {
+	return bson.M{"$exists": true}
+}
+
+// FindTrashedCollections returns the user's collections in the trash, most recently deleted first
+func (r Repository) FindTrashedCollections(userID string) ([]domain.Collection, *domain.ResponseErr) {
+	userObjectID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid user ID format",
+		}
+	}
+
+	ctx := context.TODO()
+	storage := r.client.Database(database).Collection(collections_collection)
+	filter := bson.M{"user_id": userObjectID, "deleted_at": trashed()}
+	opts := options.Find().SetSort(bson.D{{Key: "deleted_at", Value: -1}, {Key: "_id", Value: 1}})
+	cursor, err := storage.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Find trash error: %v", err),
+		}
+	}
+	defer cursor.Close(ctx)
+
+	var collections []Collection
+	if err := cursor.All(ctx, &collections); err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Find trash error: %v", err),
+		}
+	}
+
+	domainCollections := make([]domain.Collection, len(collections))
+	for i := range collections {
+		domainCollections[i] = collections[i].ToDomain()
+	}
+	return domainCollections, nil
+}
+
+// FindTrashedCards returns the card entries in the trash of the collections, most recently deleted first
+func (r Repository) FindTrashedCards(collectionIds []string) ([]domain.TrashedCard, *domain.ResponseErr) {
+	objectIds, respErr := collectionObjectIDs(collectionIds)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	ctx := context.TODO()
+	storage := r.client.Database(database).Collection(cards_collection)
+	filter := bson.M{"collection_id": bson.M{"$in": objectIds}, "deleted_at": trashed()}
+	opts := options.Find().SetSort(bson.D{{Key: "deleted_at", Value: -1}, {Key: "_id", Value: 1}})
+	cursor, err := storage.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Find trash error: %v", err),
+		}
+	}
+	defer cursor.Close(ctx)
+
+	var entries []Card
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Find trash error: %v", err),
+		}
+	}
+
+	cards := make([]domain.TrashedCard, len(entries))
+	for i := range entries {
+		cards[i] = domain.TrashedCard{
+			CollectionID: entries[i].CollectionID.Hex(),
+			Card:         entries[i].ToDomain(),
+			DeletedAt:    entries[i].DeletedAt,
+		}
+	}
+	return cards, nil
+}
+
+// RestoreCollection takes the user's collection with its cards out of the trash.
+// It fails with a conflict when the user has a collection with the same name now.
+func (r Repository) RestoreCollection(userID, collectionID string) (*domain.Collection, *domain.ResponseErr) {
+	userObjectID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid user ID format",
+		}
+	}
+
+	collectionObjectID, err := bson.ObjectIDFromHex(collectionID)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID format",
+		}
+	}
+
+	var restored Collection
+	respErr := r.runInTransaction(func(ctx context.Context) error {
+		storage := r.client.Database(database).Collection(collections_collection)
+		filter := bson.M{"_id": collectionObjectID, "user_id": userObjectID, "deleted_at": trashed()}
+		update := bson.M{
+			"$unset": bson.M{"deleted_at": ""},
+			"$set":   bson.M{"updated_at": time.Now()},
+			"$inc":   bson.M{"version": 1},
+		}
+		opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+		if err := storage.FindOneAndUpdate(ctx, filter, update, opts).Decode(&restored); err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return collectionNameTakenError()
+			}
+			return collectionFindError(err, "Collection not found in trash")
+		}
+
+		if respErr := r.pushUserCollectionRef(ctx, restored); respErr != nil {
+			return respErr
+		}
+		return nil
+	})
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	domainCollection := restored.ToDomain()
+	return &domainCollection, nil
+}
+
+// RestoreCard takes the card entry out of the trash of the actor's collection and returns the
+// restored entry. When the collection has an entry of the same variant now, the copies are added to it.
+func (r Repository) RestoreCard(actor domain.Actor, collectionId, entryId string) (*domain.Card, *domain.ResponseErr) {
+	objectId, err := bson.ObjectIDFromHex(collectionId)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID format",
+		}
+	}
+
+	entryObjectId, err := bson.ObjectIDFromHex(entryId)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid card entry ID format",
+		}
+	}
+
+	owned, respErr := ownedCollectionFilter(actor.UserID, objectId)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	var restored Card
+	respErr = r.runInTransaction(func(ctx context.Context) error {
+		if respErr := r.touchCollection(ctx, withVersion(owned, actor.IfMatch), "Collection not found"); respErr != nil {
+			return respErr
+		}
+
+		storage := r.client.Database(database).Collection(cards_collection)
+		var entry Card
+		filter := bson.M{"_id": entryObjectId, "collection_id": objectId, "deleted_at": trashed()}
+		if err := storage.FindOne(ctx, filter).Decode(&entry); err != nil {
+			if err == mongo.ErrNoDocuments {
+				return &domain.ResponseErr{
+					Status:  http.StatusNotFound,
+					Message: "Card not found in trash",
+				}
+			}
+			return &domain.ResponseErr{
+				Status:  http.StatusInternalServerError,
+				Message: fmt.Sprintf("Find card error: %v", err),
+			}
+		}
+
+		domainCard := entry.ToDomain()
+		filter = cardVariantFilter(&domainCard)
+		filter["collection_id"] = objectId
+		filter["deleted_at"] = notTrashed()
+		update := bson.M{"$inc": bson.M{"count": entry.Count}}
+		opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+		err := storage.FindOneAndUpdate(ctx, filter, update, opts).Decode(&restored)
+		if err == mongo.ErrNoDocuments {
+			restored = entry
+			restored.DeletedAt = time.Time{}
+			_, err = storage.UpdateOne(ctx, bson.M{"_id": entryObjectId}, bson.M{"$unset": bson.M{"deleted_at": ""}})
+		} else if err == nil {
+			_, err = storage.DeleteOne(ctx, bson.M{"_id": entryObjectId})
+		}
+		if err != nil {
+			return &domain.ResponseErr{
+				Status:  http.StatusInternalServerError,
+				Message: fmt.Sprintf("Restore card error: %v", err),
+			}
+		}
+
+		// An entry in the trash has no copies in the collection history
+		log := &changeLog{}
+		log.add(restored, restored.Count-entry.Count, restored.Count)
+		if _, respErr := r.recordChange(ctx, actor, domain.ChangeRestore, log); respErr != nil {
+			return respErr
+		}
+
+		return nil
+	})
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	domainCard := restored.ToDomain()
+	return &domainCard, nil
+}
+
+// PurgeTrash removes collections and card entries deleted before the time for good,
+// with the cards, the value history, the change history and the snapshots of the purged collections. Collections are
+// removed last, so a purge which fails halfway is finished by the next one.
+func (r Repository) PurgeTrash(deletedBefore time.Time) (*domain.TrashPurge, *domain.ResponseErr) {
+	ctx := context.TODO()
+	db := r.client.Database(database)
+	expired := bson.M{"deleted_at": bson.M{"$lt": deletedBefore}}
+	purge := &domain.TrashPurge{}
+
+	var ids []bson.ObjectID
+	err := db.Collection(collections_collection).Distinct(ctx, "_id", expired).Decode(&ids)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Purge trash error: %v", err),
+		}
+	}
+
+	if len(ids) > 0 {
+		byCollection := bson.M{"collection_id": bson.M{"$in": ids}}
+		result, err := db.Collection(cards_collection).DeleteMany(ctx, byCollection)
+		if err == nil {
+			purge.Cards += int(result.DeletedCount)
+			_, err = db.Collection(collection_values_collection).DeleteMany(ctx, byCollection)
+		}
+		if err == nil {
+			_, err = db.Collection(snapshots_collection).DeleteMany(ctx, byCollection)
+		}
+		if err == nil {
+			// Transfers stay in the history of the other collection, $in keeps
+			// changes without collections out of the match
+			onlyPurged := bson.M{"collection_ids": bson.M{
+				"$in":  ids,
+				"$not": bson.M{"$elemMatch": bson.M{"$nin": ids}},
+			}}
+			_, err = db.Collection(collection_changes_collection).DeleteMany(ctx, onlyPurged)
+		}
+		if err == nil {
+			result, err = db.Collection(collections_collection).DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+		}
+		if err != nil {
+			return nil, &domain.ResponseErr{
+				Status:  http.StatusInternalServerError,
+				Message: fmt.Sprintf("Purge trash error: %v", err),
+			}
+		}
+		purge.Collections = int(result.DeletedCount)
+	}
+
+	result, err := db.Collection(cards_collection).DeleteMany(ctx, expired)
+	if err != nil {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("Purge trash error: %v", err),
+		}
+	}
+	purge.Cards += int(result.DeletedCount)
+
+	return purge, nil
+}
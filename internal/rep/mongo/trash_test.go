@@ -0,0 +1,111 @@
+package mongorep
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestDeletedCardGoesToTrashAndRestoresIntoItsVariant(t *testing.T) {
+	r := newTestRepository(t)
+	collection := newTestCollection(t, r)
+	collectionId := collection.ObjectID.Hex()
+	owner := ownerOf(collection)
+
+	entry := testCard(1)
+	card := entry.ToDomain()
+	card.ID = ""
+	card.Count = 2
+	stored, respErr := r.AddCardToCollection(owner, collectionId, &card)
+	require.Nil(t, respErr)
+
+	require.Nil(t, r.DeleteCardFromCollection(owner, collectionId, &domain.Card{ID: stored.ID}))
+	page, respErr := r.ListCards(collectionId, &domain.CardsQuery{SortBy: domain.SortByName, Limit: 10})
+	require.Nil(t, respErr)
+	require.Zero(t, page.Total, "trashed entries aren't listed")
+
+	trashed, respErr := r.FindTrashedCards([]string{collectionId})
+	require.Nil(t, respErr)
+	require.Len(t, trashed, 1)
+	require.Equal(t, stored.ID, trashed[0].Card.ID)
+
+	// The variant is added again while the old entry is in the trash
+	card.Count = 1
+	_, respErr = r.AddCardToCollection(owner, collectionId, &card)
+	require.Nil(t, respErr)
+
+	// Only the owner restores cards of the collection
+	_, respErr = r.RestoreCard(testActor, collectionId, stored.ID)
+	require.NotNil(t, respErr)
+	require.Equal(t, http.StatusNotFound, respErr.Status)
+
+	restored, respErr := r.RestoreCard(owner, collectionId, stored.ID)
+	require.Nil(t, respErr)
+	require.Equal(t, 3, restored.Count)
+
+	trashed, respErr = r.FindTrashedCards([]string{collectionId})
+	require.Nil(t, respErr)
+	require.Empty(t, trashed)
+}
+
+func TestDeletedCollectionGoesToTrash(t *testing.T) {
+	r := newTestRepository(t)
+	collection := newTestCollection(t, r)
+	collectionId := collection.ObjectID.Hex()
+	userId := collection.UserID.Hex()
+
+	require.Nil(t, r.DeleteCollection(domain.Actor{UserID: userId}, collectionId))
+
+	_, respErr := r.GetCollection(collectionId)
+	require.NotNil(t, respErr)
+	require.Equal(t, http.StatusNotFound, respErr.Status)
+
+	trashed, respErr := r.FindTrashedCollections(userId)
+	require.Nil(t, respErr)
+	require.Len(t, trashed, 1)
+	require.False(t, trashed[0].DeletedAt.IsZero())
+
+	// The name is free while the collection is in the trash
+	// Only the owner renames the collection
+	taken := newTestCollection(t, r)
+	_, respErr = r.RenameCollection(domain.Actor{UserID: userId}, &domain.Collection{ID: taken.ObjectID.Hex(), UserID: userId, Name: collection.Name})
+	require.NotNil(t, respErr)
+	require.Equal(t, http.StatusNotFound, respErr.Status)
+	_, err := r.client.Database(database).Collection(collections_collection).UpdateOne(context.Background(), bson.M{"_id": taken.ObjectID}, bson.M{"$set": bson.M{"user_id": collection.UserID}})
+	require.NoError(t, err)
+	_, respErr = r.RenameCollection(domain.Actor{UserID: userId}, &domain.Collection{ID: taken.ObjectID.Hex(), UserID: userId, Name: collection.Name})
+	require.Nil(t, respErr)
+	_, respErr = r.RestoreCollection(userId, collectionId)
+	require.NotNil(t, respErr)
+	require.Equal(t, http.StatusConflict, respErr.Status)
+}
+
+func TestPurgeTrashKeepsRecentDeletions(t *testing.T) {
+	r := newTestRepository(t)
+	collection := newTestCollection(t, r)
+	collectionId := collection.ObjectID.Hex()
+
+	entry := testCard(1)
+	card := entry.ToDomain()
+	card.ID = ""
+	stored, respErr := r.AddCardToCollection(ownerOf(collection), collectionId, &card)
+	require.Nil(t, respErr)
+	require.Nil(t, r.DeleteCardFromCollection(ownerOf(collection), collectionId, &domain.Card{ID: stored.ID}))
+
+	_, respErr = r.PurgeTrash(time.Now().Add(-time.Hour))
+	require.Nil(t, respErr)
+	trashed, respErr := r.FindTrashedCards([]string{collectionId})
+	require.Nil(t, respErr)
+	require.Len(t, trashed, 1)
+
+	_, respErr = r.PurgeTrash(time.Now().Add(time.Minute))
+	require.Nil(t, respErr)
+	trashed, respErr = r.FindTrashedCards([]string{collectionId})
+	require.Nil(t, respErr)
+	require.Empty(t, trashed)
+}
@@ -0,0 +1,52 @@
+package mongorep
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectionVersionIsCheckedByIfMatch(t *testing.T) {
+	r := newTestRepository(t)
+	collection := newTestCollection(t, r)
+	collectionId := collection.ObjectID.Hex()
+	owner := domain.Actor{UserID: collection.UserID.Hex()}
+
+	// The collection is stored without a version, as the ones created before versions were added
+	owner.IfMatch = []int64{0}
+	entry := testCard(1)
+	card := entry.ToDomain()
+	card.ID = ""
+	stored, respErr := r.AddCardToCollection(owner, collectionId, &card)
+	require.Nil(t, respErr)
+
+	got, respErr := r.GetCollection(collectionId)
+	require.Nil(t, respErr)
+	require.Equal(t, int64(1), got.Version)
+
+	// The bot still expects the old version
+	stored.Count = 4
+	respErr = r.SetCardCountInCollection(owner, collectionId, stored)
+	require.NotNil(t, respErr)
+	require.Equal(t, http.StatusPreconditionFailed, respErr.Status)
+
+	renamed, respErr := r.RenameCollection(owner, &domain.Collection{ID: collectionId, UserID: owner.UserID, Name: collection.Name + "-renamed"})
+	require.NotNil(t, respErr)
+	require.Equal(t, http.StatusPreconditionFailed, respErr.Status)
+
+	// Any of the listed versions matches
+	owner.IfMatch = []int64{got.Version + 5, got.Version}
+	renamed, respErr = r.RenameCollection(owner, &domain.Collection{ID: collectionId, UserID: owner.UserID, Name: collection.Name + "-renamed"})
+	require.Nil(t, respErr)
+	require.Equal(t, int64(2), renamed.Version)
+
+	// Without If-Match the change isn't checked
+	owner.IfMatch = nil
+	require.Nil(t, r.SetCardCountInCollection(owner, collectionId, stored))
+
+	got, respErr = r.GetCollection(collectionId)
+	require.Nil(t, respErr)
+	require.Equal(t, int64(3), got.Version)
+}
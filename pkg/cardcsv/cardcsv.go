@@ -0,0 +1,309 @@
+// Package cardcsv reads and writes collection cards as CSV in the native
+// format and in formats of popular collection managers.
+package cardcsv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Record is a card row. Values are converted to native ones: conditions are
+// NM, LP, MP, HP or DMG, finishes are nonfoil, foil or etched and languages
+// are Scryfall codes. Fields the format doesn't have are empty.
+type Record struct {
+	Line int // line of the row in the file
+
+	ID              string
+	ScryfallID      string
+	Name            string
+	CardURL         string
+	Count           int
+	Zone            string
+	Finish          string
+	Condition       string
+	Language        string
+	SetCode         string
+	SetName         string
+	CollectorNumber string
+	AddedAt         time.Time
+}
+
+// RowError is a row which can't be read. Reading continues with the next row.
+type RowError struct {
+	Line int
+	Text string
+	Err  error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// Reader reads records row by row without loading the whole file.
+type Reader struct {
+	csv     *csv.Reader
+	format  Format
+	layout  layout
+	columns map[field]int
+}
+
+// NewReader reads the header row. An empty format is detected by the header.
+func NewReader(r io.Reader, format Format) (*Reader, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("csv is empty")
+		}
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+
+	if format == "" {
+		var ok bool
+		if format, ok = DetectFormat(header); !ok {
+			return nil, errors.New("unknown csv format")
+		}
+	}
+	l, ok := layouts[format]
+	if !ok {
+		return nil, fmt.Errorf("unknown csv format %q", format)
+	}
+
+	positions := make(map[string]int, len(header))
+	for i, h := range header {
+		positions[normalizeColumn(h)] = i
+	}
+	columns := make(map[field]int)
+	for _, c := range l.columns {
+		if i, ok := positions[normalizeColumn(c.name)]; ok && c.field != fieldNone {
+			columns[c.field] = i
+		}
+	}
+	for _, f := range l.required {
+		if _, ok := columns[f]; !ok {
+			return nil, fmt.Errorf("%s csv has no %q column", format, columnName(l, f))
+		}
+	}
+
+	return &Reader{csv: cr, format: format, layout: l, columns: columns}, nil
+}
+
+// Format is the format of the file.
+func (r *Reader) Format() Format {
+	return r.format
+}
+
+// Read returns the next record or io.EOF. A row which can't be read is returned as *RowError.
+func (r *Reader) Read() (Record, error) {
+	for {
+		row, err := r.csv.Read()
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return Record{}, &RowError{Line: parseErr.StartLine, Err: parseErr.Err}
+			}
+			return Record{}, err
+		}
+
+		line, _ := r.csv.FieldPos(0)
+		if isBlank(row) {
+			continue
+		}
+
+		record, err := r.record(row)
+		if err != nil {
+			return Record{}, &RowError{Line: line, Text: strings.Join(row, ","), Err: err}
+		}
+		record.Line = line
+		return record, nil
+	}
+}
+
+func (r *Reader) record(row []string) (Record, error) {
+	value := func(f field) string {
+		i, ok := r.columns[f]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	record := Record{
+		ID:              value(fieldID),
+		ScryfallID:      value(fieldScryfallID),
+		Name:            value(fieldName),
+		CardURL:         value(fieldCardURL),
+		Zone:            value(fieldZone),
+		SetCode:         strings.ToLower(value(fieldSetCode)),
+		SetName:         value(fieldSetName),
+		CollectorNumber: value(fieldCollectorNumber),
+	}
+
+	count, err := strconv.Atoi(value(fieldCount))
+	if err != nil || count <= 0 {
+		return Record{}, fmt.Errorf("invalid count %q", value(fieldCount))
+	}
+	record.Count = count
+
+	var ok bool
+	if record.Finish, ok = finishes[strings.ToLower(value(fieldFinish))]; !ok {
+		return Record{}, fmt.Errorf("invalid finish %q", value(fieldFinish))
+	}
+	if record.Condition, ok = r.layout.condition(value(fieldCondition)); !ok {
+		return Record{}, fmt.Errorf("invalid condition %q", value(fieldCondition))
+	}
+	if lang := value(fieldLanguage); lang != "" {
+		if record.Language, ok = languageCodes[strings.ToLower(lang)]; !ok {
+			return Record{}, fmt.Errorf("invalid language %q", lang)
+		}
+	}
+
+	if addedAt := value(fieldAddedAt); addedAt != "" {
+		record.AddedAt, err = parseTime(addedAt)
+		// Dates of other tools are informational, only native dates must be valid
+		if err != nil && r.format == FormatNative {
+			return Record{}, fmt.Errorf("invalid added_at %q", addedAt)
+		}
+	}
+
+	if record.Name == "" && record.ScryfallID == "" {
+		return Record{}, errors.New("card name is missing")
+	}
+	return record, nil
+}
+
+// Writer writes records of a format. The header is written with the first record or on Flush.
+type Writer struct {
+	csv           *csv.Writer
+	layout        layout
+	headerWritten bool
+}
+
+func NewWriter(w io.Writer, format Format) (*Writer, error) {
+	l, ok := layouts[format]
+	if !ok {
+		return nil, fmt.Errorf("unknown csv format %q", format)
+	}
+	return &Writer{csv: csv.NewWriter(w), layout: l}, nil
+}
+
+// Write adds a row, the header is written before the first one.
+func (w *Writer) Write(record Record) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	row := make([]string, len(w.layout.columns))
+	for i, c := range w.layout.columns {
+		row[i] = w.value(record, c.field)
+	}
+	return w.csv.Write(row)
+}
+
+// Flush writes buffered rows to the underlying writer.
+func (w *Writer) Flush() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+func (w *Writer) writeHeader() error {
+	if w.headerWritten {
+		return nil
+	}
+	w.headerWritten = true
+
+	header := make([]string, len(w.layout.columns))
+	for i, c := range w.layout.columns {
+		header[i] = c.name
+	}
+	return w.csv.Write(header)
+}
+
+func (w *Writer) value(record Record, f field) string {
+	switch f {
+	case fieldID:
+		return record.ID
+	case fieldScryfallID:
+		return record.ScryfallID
+	case fieldName:
+		return record.Name
+	case fieldCardURL:
+		return record.CardURL
+	case fieldCount:
+		return strconv.Itoa(record.Count)
+	case fieldZone:
+		return record.Zone
+	case fieldFinish:
+		return w.layout.finish(record.Finish)
+	case fieldCondition:
+		if v, ok := w.layout.conditions[record.Condition]; ok {
+			return v
+		}
+		return record.Condition
+	case fieldLanguage:
+		return w.layout.language(record.Language)
+	case fieldSetCode:
+		return record.SetCode
+	case fieldSetName:
+		return record.SetName
+	case fieldCollectorNumber:
+		return record.CollectorNumber
+	case fieldAddedAt:
+		if record.AddedAt.IsZero() {
+			return ""
+		}
+		return record.AddedAt.UTC().Format(time.RFC3339)
+	}
+	return ""
+}
+
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999",
+	"2006-01-02 15:04:05",
+	time.DateOnly,
+}
+
+func parseTime(value string) (time.Time, error) {
+	var err error
+	for _, l := range timeLayouts {
+		var t time.Time
+		if t, err = time.Parse(l, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+func columnName(l layout, f field) string {
+	for _, c := range l.columns {
+		if c.field == f {
+			return c.name
+		}
+	}
+	return ""
+}
+
+func isBlank(row []string) bool {
+	for _, v := range row {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
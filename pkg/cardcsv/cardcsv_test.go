@@ -0,0 +1,206 @@
+package cardcsv
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readAll(t *testing.T, r *Reader) ([]Record, []*RowError) {
+	t.Helper()
+
+	var records []Record
+	var rowErrors []*RowError
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return records, rowErrors
+		}
+		var rowErr *RowError
+		if errors.As(err, &rowErr) {
+			rowErrors = append(rowErrors, rowErr)
+			continue
+		}
+		require.NoError(t, err)
+		records = append(records, record)
+	}
+}
+
+func TestReadFormats(t *testing.T) {
+	tests := []struct {
+		name   string
+		csv    string
+		format Format
+		want   Record
+	}{
+		{
+			name: "moxfield",
+			csv: `"Count","Tradelist Count","Name","Edition","Condition","Language","Foil","Tags","Last Modified","Collector Number","Alter","Proxy","Purchase Price"
+"2","0","Lightning Bolt","m10","Lightly Played","Japanese","foil","","2024-05-01 10:20:30.000000","146","False","False",""
+`,
+			format: FormatMoxfield,
+			want: Record{
+				Line: 2, Name: "Lightning Bolt", Count: 2, SetCode: "m10", CollectorNumber: "146",
+				Condition: "LP", Language: "ja", Finish: "foil",
+				AddedAt: time.Date(2024, 5, 1, 10, 20, 30, 0, time.UTC),
+			},
+		},
+		{
+			name: "deckbox",
+			csv: `Count,Tradelist Count,Name,Edition,Card Number,Condition,Language,Foil,Signed,Artist Proof,Altered Art,Misprint,Promo,Textless,My Price
+4,0,Counterspell,Ice Age,64,Good (Lightly Played),English,,,,,,,,$1.00
+`,
+			format: FormatDeckbox,
+			want: Record{
+				Line: 2, Name: "Counterspell", Count: 4, SetName: "Ice Age", CollectorNumber: "64",
+				Condition: "LP", Language: "en", Finish: "",
+			},
+		},
+		{
+			name: "manabox",
+			csv: `Name,Set code,Set name,Collector number,Foil,Rarity,Quantity,ManaBox ID,Scryfall ID,Purchase price,Misprint,Altered,Condition,Language,Purchase price currency
+Sol Ring,C21,Commander 2021,263,etched,uncommon,1,123,0afa0e33-4804-4b00-b625-c2d6b61090fc,1.5,false,false,near_mint,en,USD
+`,
+			format: FormatManaBox,
+			want: Record{
+				Line: 2, Name: "Sol Ring", Count: 1, SetCode: "c21", SetName: "Commander 2021", CollectorNumber: "263",
+				ScryfallID: "0afa0e33-4804-4b00-b625-c2d6b61090fc", Condition: "NM", Language: "en", Finish: "etched",
+			},
+		},
+		{
+			name: "native",
+			csv: `id,scryfall_id,name,card_url,count,zone,finish,condition,language,added_at
+64a9b66b2db8b91234a6e8e4,0afa0e33-4804-4b00-b625-c2d6b61090fc,Sol Ring,https://example.com/sol-ring.jpg,3,commander,foil,MP,de,2024-05-01T10:20:30Z
+`,
+			format: FormatNative,
+			want: Record{
+				Line: 2, ID: "64a9b66b2db8b91234a6e8e4", ScryfallID: "0afa0e33-4804-4b00-b625-c2d6b61090fc", Name: "Sol Ring",
+				CardURL: "https://example.com/sol-ring.jpg", Count: 3, Zone: "commander", Finish: "foil", Condition: "MP", Language: "de",
+				AddedAt: time.Date(2024, 5, 1, 10, 20, 30, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The format is detected by the header
+			r, err := NewReader(strings.NewReader(tt.csv), "")
+			require.NoError(t, err)
+			assert.Equal(t, tt.format, r.Format())
+
+			records, rowErrors := readAll(t, r)
+			assert.Empty(t, rowErrors)
+			assert.Equal(t, []Record{tt.want}, records)
+		})
+	}
+}
+
+func TestReadRowErrors(t *testing.T) {
+	csv := `Count,Tradelist Count,Name,Edition,Condition,Language,Foil,Tags,Last Modified,Collector Number,Alter,Proxy,Purchase Price
+1,0,Island,,,,,,,,,,
+x,0,Forest,,,,,,,,,,
+
+1,0,Swamp,,Pristine,,,,,,,,
+1,0,Mountain,,,Klingon,,,,,,,
+1,0,Plains,,,,,,,,,,
+`
+	r, err := NewReader(strings.NewReader(csv), FormatMoxfield)
+	require.NoError(t, err)
+
+	records, rowErrors := readAll(t, r)
+
+	require.Len(t, records, 2)
+	assert.Equal(t, "Island", records[0].Name)
+	assert.Equal(t, "Plains", records[1].Name)
+	assert.Equal(t, 7, records[1].Line)
+
+	require.Len(t, rowErrors, 3)
+	assert.EqualError(t, rowErrors[0], `line 3: invalid count "x"`)
+	assert.Equal(t, "x,0,Forest,,,,,,,,,,", rowErrors[0].Text)
+	assert.EqualError(t, rowErrors[1], `line 5: invalid condition "Pristine"`)
+	assert.EqualError(t, rowErrors[2], `line 6: invalid language "Klingon"`)
+}
+
+func TestNewReaderErrors(t *testing.T) {
+	_, err := NewReader(strings.NewReader(""), "")
+	assert.EqualError(t, err, "csv is empty")
+
+	_, err = NewReader(strings.NewReader("a,b,c\n"), "")
+	assert.EqualError(t, err, "unknown csv format")
+
+	_, err = NewReader(strings.NewReader("Name,Edition\n"), FormatMoxfield)
+	assert.EqualError(t, err, `moxfield csv has no "Count" column`)
+}
+
+func TestWriteReadRoundTrip(t *testing.T) {
+	record := Record{
+		ID:              "64a9b66b2db8b91234a6e8e4",
+		ScryfallID:      "0afa0e33-4804-4b00-b625-c2d6b61090fc",
+		Name:            "Sol Ring, the \"Best\"",
+		CardURL:         "https://example.com/sol-ring.jpg",
+		Count:           2,
+		Zone:            "side",
+		Finish:          "foil",
+		Condition:       "HP",
+		Language:        "ja",
+		SetCode:         "c21",
+		CollectorNumber: "263",
+		AddedAt:         time.Date(2024, 5, 1, 10, 20, 30, 0, time.UTC),
+	}
+
+	// Fields each format keeps
+	tests := []struct {
+		format Format
+		want   Record
+	}{
+		{FormatNative, Record{
+			ID: record.ID, ScryfallID: record.ScryfallID, Name: record.Name, CardURL: record.CardURL, Count: 2,
+			Zone: "side", Finish: "foil", Condition: "HP", Language: "ja", AddedAt: record.AddedAt,
+		}},
+		{FormatMoxfield, Record{
+			Name: record.Name, Count: 2, SetCode: "c21", CollectorNumber: "263",
+			Finish: "foil", Condition: "HP", Language: "ja", AddedAt: record.AddedAt,
+		}},
+		{FormatDeckbox, Record{
+			Name: record.Name, Count: 2, CollectorNumber: "263", Finish: "foil", Condition: "HP", Language: "ja",
+		}},
+		{FormatManaBox, Record{
+			Name: record.Name, ScryfallID: record.ScryfallID, Count: 2, SetCode: "c21", CollectorNumber: "263",
+			Finish: "foil", Condition: "HP", Language: "ja",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := NewWriter(&buf, tt.format)
+			require.NoError(t, err)
+			require.NoError(t, w.Write(record))
+			require.NoError(t, w.Flush())
+
+			r, err := NewReader(&buf, "")
+			require.NoError(t, err)
+			assert.Equal(t, tt.format, r.Format())
+
+			records, rowErrors := readAll(t, r)
+			assert.Empty(t, rowErrors)
+			tt.want.Line = 2
+			assert.Equal(t, []Record{tt.want}, records)
+		})
+	}
+}
+
+func TestWriterEmptyExportHasHeader(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, FormatNative)
+	require.NoError(t, err)
+	require.NoError(t, w.Flush())
+
+	assert.Equal(t, "id,scryfall_id,name,card_url,count,zone,finish,condition,language,added_at\n", buf.String())
+}
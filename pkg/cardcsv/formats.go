@@ -0,0 +1,299 @@
+package cardcsv
+
+import (
+	"strings"
+)
+
+// Format is a CSV layout of a collection manager.
+type Format string
+
+const (
+	// FormatNative keeps every field of a collection card entry.
+	FormatNative   Format = "native"
+	FormatMoxfield Format = "moxfield"
+	FormatDeckbox  Format = "deckbox"
+	FormatManaBox  Format = "manabox"
+)
+
+func (f Format) IsValid() bool {
+	_, ok := layouts[f]
+	return ok
+}
+
+type field int
+
+const (
+	fieldNone field = iota
+	fieldID
+	fieldScryfallID
+	fieldName
+	fieldCardURL
+	fieldCount
+	fieldZone
+	fieldFinish
+	fieldCondition
+	fieldLanguage
+	fieldSetCode
+	fieldSetName
+	fieldCollectorNumber
+	fieldAddedAt
+)
+
+type column struct {
+	name  string
+	field field
+}
+
+// layout describes columns of a format in export order and how values are written
+type layout struct {
+	columns    []column
+	required   []field
+	conditions map[string]string // native condition to the format's value
+	finish     func(finish string) string
+	language   func(code string) string
+}
+
+var layouts = map[Format]layout{
+	FormatNative: {
+		columns: []column{
+			{"id", fieldID},
+			{"scryfall_id", fieldScryfallID},
+			{"name", fieldName},
+			{"card_url", fieldCardURL},
+			{"count", fieldCount},
+			{"zone", fieldZone},
+			{"finish", fieldFinish},
+			{"condition", fieldCondition},
+			{"language", fieldLanguage},
+			{"added_at", fieldAddedAt},
+		},
+		required: []field{fieldScryfallID, fieldCount},
+		finish:   func(finish string) string { return finish },
+		language: func(code string) string { return code },
+	},
+	FormatMoxfield: {
+		columns: []column{
+			{"Count", fieldCount},
+			{"Tradelist Count", fieldNone},
+			{"Name", fieldName},
+			{"Edition", fieldSetCode},
+			{"Condition", fieldCondition},
+			{"Language", fieldLanguage},
+			{"Foil", fieldFinish},
+			{"Tags", fieldNone},
+			{"Last Modified", fieldAddedAt},
+			{"Collector Number", fieldCollectorNumber},
+			{"Alter", fieldNone},
+			{"Proxy", fieldNone},
+			{"Purchase Price", fieldNone},
+		},
+		required: []field{fieldName, fieldCount},
+		conditions: map[string]string{
+			"NM":  "Near Mint",
+			"LP":  "Lightly Played",
+			"MP":  "Moderately Played",
+			"HP":  "Heavily Played",
+			"DMG": "Damaged",
+		},
+		finish:   foilColumn,
+		language: languageName,
+	},
+	FormatDeckbox: {
+		columns: []column{
+			{"Count", fieldCount},
+			{"Tradelist Count", fieldNone},
+			{"Name", fieldName},
+			{"Edition", fieldSetName},
+			{"Card Number", fieldCollectorNumber},
+			{"Condition", fieldCondition},
+			{"Language", fieldLanguage},
+			{"Foil", fieldFinish},
+			{"Signed", fieldNone},
+			{"Artist Proof", fieldNone},
+			{"Altered Art", fieldNone},
+			{"Misprint", fieldNone},
+			{"Promo", fieldNone},
+			{"Textless", fieldNone},
+			{"My Price", fieldNone},
+		},
+		required: []field{fieldName, fieldCount},
+		conditions: map[string]string{
+			"NM":  "Near Mint",
+			"LP":  "Good (Lightly Played)",
+			"MP":  "Played",
+			"HP":  "Heavily Played",
+			"DMG": "Poor",
+		},
+		// Deckbox has no etched finish
+		finish: func(finish string) string {
+			if finish == "nonfoil" {
+				return ""
+			}
+			return "foil"
+		},
+		language: languageName,
+	},
+	FormatManaBox: {
+		columns: []column{
+			{"Name", fieldName},
+			{"Set code", fieldSetCode},
+			{"Set name", fieldSetName},
+			{"Collector number", fieldCollectorNumber},
+			{"Foil", fieldFinish},
+			{"Rarity", fieldNone},
+			{"Quantity", fieldCount},
+			{"ManaBox ID", fieldNone},
+			{"Scryfall ID", fieldScryfallID},
+			{"Purchase price", fieldNone},
+			{"Misprint", fieldNone},
+			{"Altered", fieldNone},
+			{"Condition", fieldCondition},
+			{"Language", fieldLanguage},
+			{"Purchase price currency", fieldNone},
+		},
+		required: []field{fieldName, fieldCount},
+		conditions: map[string]string{
+			"NM":  "near_mint",
+			"LP":  "excellent",
+			"MP":  "good",
+			"HP":  "played",
+			"DMG": "poor",
+		},
+		finish: func(finish string) string {
+			if finish == "nonfoil" {
+				return "normal"
+			}
+			return finish
+		},
+		language: func(code string) string { return code },
+	},
+}
+
+// detectionOrder lists formats from the most specific header
+var detectionOrder = []Format{FormatNative, FormatManaBox, FormatDeckbox, FormatMoxfield}
+
+// DetectFormat guesses the format by the header row. A format matches when
+// the header has all of its columns.
+func DetectFormat(header []string) (Format, bool) {
+	names := make(map[string]bool, len(header))
+	for _, h := range header {
+		names[normalizeColumn(h)] = true
+	}
+
+	for _, f := range detectionOrder {
+		matches := true
+		for _, c := range layouts[f].columns {
+			if c.field != fieldNone && !names[normalizeColumn(c.name)] {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return f, true
+		}
+	}
+
+	return "", false
+}
+
+func normalizeColumn(name string) string {
+	return strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+}
+
+// condition converts the format's condition value to the native one. Values
+// of the format itself win over the shared table: "played" is MP in Deckbox
+// but HP in ManaBox.
+func (l layout) condition(value string) (string, bool) {
+	for native, v := range l.conditions {
+		if strings.EqualFold(v, value) {
+			return native, true
+		}
+	}
+	native, ok := conditions[strings.ToLower(value)]
+	return native, ok
+}
+
+func foilColumn(finish string) string {
+	if finish == "nonfoil" {
+		return ""
+	}
+	return finish
+}
+
+// conditions maps condition values of all formats to native conditions
+var conditions = map[string]string{
+	"":                      "",
+	"m":                     "NM",
+	"mint":                  "NM",
+	"nm":                    "NM",
+	"near mint":             "NM",
+	"near_mint":             "NM",
+	"lp":                    "LP",
+	"sp":                    "LP",
+	"ex":                    "LP",
+	"excellent":             "LP",
+	"lightly played":        "LP",
+	"light_played":          "LP",
+	"slightly played":       "LP",
+	"good (lightly played)": "LP",
+	"mp":                    "MP",
+	"good":                  "MP",
+	"moderately played":     "MP",
+	"played":                "MP",
+	"hp":                    "HP",
+	"heavily played":        "HP",
+	"dmg":                   "DMG",
+	"d":                     "DMG",
+	"damaged":               "DMG",
+	"poor":                  "DMG",
+}
+
+// finishes maps foil column values of all formats to native finishes
+var finishes = map[string]string{
+	"":        "",
+	"normal":  "nonfoil",
+	"nonfoil": "nonfoil",
+	"false":   "nonfoil",
+	"no":      "nonfoil",
+	"foil":    "foil",
+	"true":    "foil",
+	"yes":     "foil",
+	"etched":  "etched",
+}
+
+var languageNames = map[string]string{
+	"en":  "English",
+	"es":  "Spanish",
+	"fr":  "French",
+	"de":  "German",
+	"it":  "Italian",
+	"pt":  "Portuguese",
+	"ja":  "Japanese",
+	"ko":  "Korean",
+	"ru":  "Russian",
+	"zhs": "Chinese Simplified",
+	"zht": "Chinese Traditional",
+	"he":  "Hebrew",
+	"la":  "Latin",
+	"grc": "Ancient Greek",
+	"ar":  "Arabic",
+	"sa":  "Sanskrit",
+	"ph":  "Phyrexian",
+}
+
+// languageCodes maps language names and codes to codes
+var languageCodes = func() map[string]string {
+	codes := make(map[string]string, 2*len(languageNames))
+	for code, name := range languageNames {
+		codes[code] = code
+		codes[strings.ToLower(name)] = code
+	}
+	return codes
+}()
+
+func languageName(code string) string {
+	if name, ok := languageNames[code]; ok {
+		return name
+	}
+	return code
+}
@@ -0,0 +1,191 @@
+// Package cardquery parses Scryfall-style card queries like
+// `c:r t:creature cmc<=3 set:mh3 is:foil` into an expression tree.
+//
+// Terms are joined with AND by default; `or` joins alternatives, a leading `-`
+// negates a term and parentheses group terms. Bare words and quoted phrases
+// match card names, `!"Exact Name"` matches a whole name.
+package cardquery
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Field is a property of a card entry a condition tests.
+type Field string
+
+const (
+	FieldName      Field = "name"
+	FieldType      Field = "type"
+	FieldColor     Field = "color"
+	FieldIdentity  Field = "identity"
+	FieldManaValue Field = "cmc"
+	FieldSet       Field = "set"
+	FieldRarity    Field = "rarity"
+	FieldFinish    Field = "finish"
+	FieldZone      Field = "zone"
+	FieldCondition Field = "condition"
+	FieldLanguage  Field = "lang"
+	FieldCount     Field = "count"
+)
+
+// Operator compares a field with the value of a condition.
+type Operator string
+
+const (
+	OpMatch Operator = ":" // contains for text, the field's default comparison otherwise
+	OpEq    Operator = "="
+	OpNe    Operator = "!="
+	OpLt    Operator = "<"
+	OpLte   Operator = "<="
+	OpGt    Operator = ">"
+	OpGte   Operator = ">="
+)
+
+// Color values of color conditions besides combinations of WUBRG
+const (
+	Colorless  = "C"
+	Multicolor = "M"
+)
+
+// Rarities in ascending order
+var Rarities = []string{"common", "uncommon", "rare", "mythic"}
+
+// Zones, finishes and conditions of card entries
+var (
+	Zones      = []string{"main", "side", "maybe", "commander"}
+	Finishes   = []string{"nonfoil", "foil", "etched"}
+	Conditions = []string{"NM", "LP", "MP", "HP", "DMG"}
+)
+
+// Expr is a node of a parsed query.
+type Expr interface {
+	// Pos is the 1-based position of the node's first character in the query, in runes
+	Pos() int
+	// String formats the node back as a query, parenthesized and normalized
+	String() string
+}
+
+// And matches cards matching all terms.
+type And struct {
+	Terms    []Expr
+	Position int
+}
+
+// Or matches cards matching any of the terms.
+type Or struct {
+	Terms    []Expr
+	Position int
+}
+
+// Not matches cards the term doesn't match.
+type Not struct {
+	Term     Expr
+	Position int
+}
+
+// Condition compares a field of a card with a value. Values are normalized:
+// colors are upper case WUBRG letters in that order, Colorless or Multicolor;
+// rarities are full names; set codes and languages are lower case and numbers
+// are parsed into Number.
+type Condition struct {
+	Field    Field
+	Op       Operator
+	Value    string
+	Number   float64
+	Position int
+}
+
+func (e *And) Pos() int       { return e.Position }
+func (e *Or) Pos() int        { return e.Position }
+func (e *Not) Pos() int       { return e.Position }
+func (e *Condition) Pos() int { return e.Position }
+
+func (e *And) String() string { return "(" + joinExprs(e.Terms, " ") + ")" }
+func (e *Or) String() string  { return "(" + joinExprs(e.Terms, " or ") + ")" }
+func (e *Not) String() string { return "-" + e.Term.String() }
+
+func (e *Condition) String() string {
+	value := e.Value
+	if isNumeric(e.Field) {
+		value = strconv.FormatFloat(e.Number, 'f', -1, 64)
+	} else if strings.ContainsAny(value, " \t\"():<>=!") || value == "" {
+		value = strconv.Quote(value)
+	}
+	return fmt.Sprintf("%s%s%s", e.Field, e.Op, value)
+}
+
+func joinExprs(exprs []Expr, sep string) string {
+	parts := make([]string, len(exprs))
+	for i, expr := range exprs {
+		parts[i] = expr.String()
+	}
+	return strings.Join(parts, sep)
+}
+
+// Walk calls fn for the expression and all of its descendants, parents first.
+func Walk(expr Expr, fn func(Expr)) {
+	if expr == nil {
+		return
+	}
+	fn(expr)
+	switch e := expr.(type) {
+	case *And:
+		for _, term := range e.Terms {
+			Walk(term, fn)
+		}
+	case *Or:
+		for _, term := range e.Terms {
+			Walk(term, fn)
+		}
+	case *Not:
+		Walk(e.Term, fn)
+	}
+}
+
+// NameTerms returns the values of the name conditions when the expression is
+// only name words and phrases joined with AND, false otherwise.
+func NameTerms(expr Expr) ([]string, bool) {
+	var terms []string
+	var only func(Expr) bool
+	only = func(expr Expr) bool {
+		switch e := expr.(type) {
+		case *And:
+			for _, term := range e.Terms {
+				if !only(term) {
+					return false
+				}
+			}
+			return true
+		case *Condition:
+			if e.Field == FieldName && e.Op == OpMatch {
+				terms = append(terms, e.Value)
+				return true
+			}
+		}
+		return false
+	}
+	if expr == nil || !only(expr) {
+		return nil, false
+	}
+	return terms, true
+}
+
+// UsesCatalog reports whether the expression tests fields only the card catalog has.
+func UsesCatalog(expr Expr) bool {
+	uses := false
+	Walk(expr, func(e Expr) {
+		if c, ok := e.(*Condition); ok {
+			switch c.Field {
+			case FieldColor, FieldIdentity, FieldManaValue, FieldType, FieldSet, FieldRarity:
+				uses = true
+			}
+		}
+	})
+	return uses
+}
+
+func isNumeric(field Field) bool {
+	return field == FieldManaValue || field == FieldCount
+}
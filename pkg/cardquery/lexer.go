@@ -0,0 +1,115 @@
+package cardquery
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// SyntaxError is a query that can't be parsed, Pos points at the offending part.
+type SyntaxError struct {
+	Pos int // 1-based position in runes, one past the end for an unexpected end of the query
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Msg, e.Pos)
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokWord
+	tokString // quoted phrase, text is unquoted
+	tokOp
+	tokLParen
+	tokRParen
+	tokMinus
+	tokBang
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int // 1-based, in runes
+	end  int // position right after the token
+}
+
+// lex splits a query into tokens. A minus or an exclamation mark only starts
+// a negation or an exact name at the beginning of a term, inside words they
+// are literal, so `Lim-Dûl` is a single word.
+func lex(query string) ([]token, error) {
+	runes := []rune(query)
+	var tokens []token
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		start := i + 1
+		atTermStart := i == 0 || unicode.IsSpace(runes[i-1]) || runes[i-1] == '('
+		if last := len(tokens) - 1; last >= 0 && tokens[last].end == start {
+			atTermStart = atTermStart || tokens[last].kind == tokMinus
+		}
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokLParen, text: "(", pos: start, end: start + 1})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokRParen, text: ")", pos: start, end: start + 1})
+			i++
+		case r == '-' && atTermStart:
+			tokens = append(tokens, token{kind: tokMinus, text: "-", pos: start, end: start + 1})
+			i++
+		case r == '!' && atTermStart:
+			tokens = append(tokens, token{kind: tokBang, text: "!", pos: start, end: start + 1})
+			i++
+		case r == '"':
+			var text strings.Builder
+			j := i + 1
+			for ; j < len(runes) && runes[j] != '"'; j++ {
+				if runes[j] == '\\' && j+1 < len(runes) {
+					j++
+				}
+				text.WriteRune(runes[j])
+			}
+			if j == len(runes) {
+				return nil, &SyntaxError{Pos: start, Msg: "unterminated quoted string"}
+			}
+			tokens = append(tokens, token{kind: tokString, text: text.String(), pos: start, end: j + 2})
+			i = j + 1
+		case isOpStart(runes, i):
+			j := i + 1
+			if j < len(runes) && runes[j] == '=' && r != ':' && r != '=' {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokOp, text: string(runes[i:j]), pos: start, end: j + 1})
+			i = j
+		default:
+			j := i
+			for j < len(runes) && !isWordEnd(runes, j) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokWord, text: string(runes[i:j]), pos: start, end: j + 1})
+			i = j
+		}
+	}
+	tokens = append(tokens, token{kind: tokEOF, pos: len(runes) + 1, end: len(runes) + 1})
+	return tokens, nil
+}
+
+func isOpStart(runes []rune, i int) bool {
+	switch runes[i] {
+	case ':', '<', '>', '=':
+		return true
+	case '!':
+		return i+1 < len(runes) && runes[i+1] == '='
+	}
+	return false
+}
+
+func isWordEnd(runes []rune, i int) bool {
+	r := runes[i]
+	return unicode.IsSpace(r) || r == '(' || r == ')' || r == '"' || isOpStart(runes, i)
+}
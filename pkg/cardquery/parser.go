@@ -0,0 +1,359 @@
+package cardquery
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// fieldKeys are the keywords of conditions with their aliases
+var fieldKeys = map[string]Field{
+	"name": FieldName, "n": FieldName,
+	"type": FieldType, "t": FieldType,
+	"color": FieldColor, "c": FieldColor,
+	"identity": FieldIdentity, "id": FieldIdentity,
+	"cmc": FieldManaValue, "mv": FieldManaValue, "manavalue": FieldManaValue,
+	"set": FieldSet, "s": FieldSet, "e": FieldSet, "edition": FieldSet,
+	"rarity": FieldRarity, "r": FieldRarity,
+	"finish":    FieldFinish,
+	"zone":      FieldZone,
+	"condition": FieldCondition, "cond": FieldCondition,
+	"lang": FieldLanguage, "language": FieldLanguage,
+	"count": FieldCount, "qty": FieldCount,
+}
+
+var (
+	textOps    = []Operator{OpMatch, OpEq, OpNe}
+	orderedOps = []Operator{OpMatch, OpEq, OpNe, OpLt, OpLte, OpGt, OpGte}
+	fieldOps   = map[Field][]Operator{
+		FieldName:      textOps,
+		FieldType:      textOps,
+		FieldColor:     orderedOps,
+		FieldIdentity:  orderedOps,
+		FieldManaValue: orderedOps,
+		FieldSet:       textOps,
+		FieldRarity:    orderedOps,
+		FieldFinish:    textOps,
+		FieldZone:      textOps,
+		FieldCondition: textOps,
+		FieldLanguage:  textOps,
+		FieldCount:     orderedOps,
+	}
+)
+
+var colorNames = map[string]string{
+	"white": "W", "blue": "U", "black": "B", "red": "R", "green": "G",
+	"colorless": Colorless, "multicolor": Multicolor,
+	"azorius": "WU", "dimir": "UB", "rakdos": "BR", "gruul": "RG", "selesnya": "WG",
+	"orzhov": "WB", "izzet": "UR", "golgari": "BG", "boros": "WR", "simic": "UG",
+}
+
+var rarityNames = map[string]string{
+	"c": "common", "u": "uncommon", "r": "rare", "m": "mythic",
+	"common": "common", "uncommon": "uncommon", "rare": "rare", "mythic": "mythic",
+}
+
+// Parse parses a query. An empty query has no conditions, Parse returns a nil
+// expression for it. Errors are *SyntaxError.
+func Parse(query string) (Expr, error) {
+	tokens, err := lex(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokEOF {
+		return nil, nil
+	}
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokEOF {
+		return nil, p.unexpected()
+	}
+	return expr, nil
+}
+
+type parser struct {
+	tokens []token
+	i      int
+}
+
+func (p *parser) peek() token { return p.tokens[p.i] }
+
+func (p *parser) next() token {
+	tok := p.tokens[p.i]
+	if tok.kind != tokEOF {
+		p.i++
+	}
+	return tok
+}
+
+// isKeyword reports whether the next token is the bare word and or or
+func (p *parser) isKeyword(word string) bool {
+	tok := p.peek()
+	if tok.kind != tokWord || !strings.EqualFold(tok.text, word) {
+		return false
+	}
+	// `or:x` is a condition on an unknown key, not a keyword
+	following := p.tokens[p.i+1]
+	return following.kind != tokOp || following.pos != tok.end
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	terms := []Expr{first}
+	for p.isKeyword("or") {
+		or := p.next()
+		if !p.startsTerm() {
+			return nil, &SyntaxError{Pos: p.peek().pos, Msg: fmt.Sprintf("expected a term after %q", or.text)}
+		}
+		term, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+	}
+	if len(terms) == 1 {
+		return first, nil
+	}
+	return &Or{Terms: terms, Position: first.Pos()}, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	if p.isKeyword("and") || p.isKeyword("or") {
+		tok := p.peek()
+		return nil, &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("expected a term before %q", tok.text)}
+	}
+	if !p.startsTerm() {
+		return nil, p.unexpected()
+	}
+
+	var terms []Expr
+	for p.startsTerm() {
+		term, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+
+		if p.isKeyword("and") {
+			and := p.next()
+			if !p.startsTerm() {
+				return nil, &SyntaxError{Pos: p.peek().pos, Msg: fmt.Sprintf("expected a term after %q", and.text)}
+			}
+		}
+	}
+	if len(terms) == 1 {
+		return terms[0], nil
+	}
+	return &And{Terms: terms, Position: terms[0].Pos()}, nil
+}
+
+// startsTerm reports whether the next token begins a term
+func (p *parser) startsTerm() bool {
+	switch p.peek().kind {
+	case tokWord:
+		return !p.isKeyword("or") && !p.isKeyword("and")
+	case tokString, tokLParen, tokMinus, tokBang:
+		return true
+	}
+	return false
+}
+
+func (p *parser) unexpected() error {
+	tok := p.peek()
+	switch tok.kind {
+	case tokEOF:
+		return &SyntaxError{Pos: tok.pos, Msg: "unexpected end of query"}
+	case tokRParen:
+		return &SyntaxError{Pos: tok.pos, Msg: "unmatched closing parenthesis"}
+	case tokOp:
+		return &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("operator %q without a keyword", tok.text)}
+	}
+	return &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("unexpected %q", tok.text)}
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	tok := p.peek()
+	switch tok.kind {
+	case tokMinus:
+		p.next()
+		if next := p.peek(); !p.startsTerm() || next.pos != tok.end {
+			return nil, &SyntaxError{Pos: tok.pos, Msg: "expected a term right after -"}
+		}
+		term, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &Not{Term: term, Position: tok.pos}, nil
+	case tokLParen:
+		p.next()
+		if p.peek().kind == tokRParen {
+			return nil, &SyntaxError{Pos: tok.pos, Msg: "empty parentheses"}
+		}
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tokRParen {
+			return nil, &SyntaxError{Pos: tok.pos, Msg: "missing closing parenthesis"}
+		}
+		p.next()
+		return expr, nil
+	case tokBang:
+		p.next()
+		name := p.peek()
+		if (name.kind != tokWord && name.kind != tokString) || name.pos != tok.end {
+			return nil, &SyntaxError{Pos: tok.pos, Msg: "expected a card name right after !"}
+		}
+		p.next()
+		return &Condition{Field: FieldName, Op: OpEq, Value: name.text, Position: tok.pos}, nil
+	case tokString:
+		p.next()
+		return &Condition{Field: FieldName, Op: OpMatch, Value: tok.text, Position: tok.pos}, nil
+	case tokWord:
+		p.next()
+		if op := p.peek(); op.kind == tokOp && op.pos == tok.end {
+			return p.parseCondition(tok)
+		}
+		return &Condition{Field: FieldName, Op: OpMatch, Value: tok.text, Position: tok.pos}, nil
+	}
+	return nil, p.unexpected()
+}
+
+// parseCondition parses the operator and the value of a condition after its key
+func (p *parser) parseCondition(key token) (Expr, error) {
+	op := p.next()
+	value := p.peek()
+	if (value.kind != tokWord && value.kind != tokString) || value.pos != op.end {
+		return nil, &SyntaxError{Pos: op.end, Msg: fmt.Sprintf("missing value after %s%s", key.text, op.text)}
+	}
+	p.next()
+
+	keyword := strings.ToLower(key.text)
+	if keyword == "is" || keyword == "not" {
+		if Operator(op.text) != OpMatch {
+			return nil, &SyntaxError{Pos: op.pos, Msg: fmt.Sprintf("%s only supports ':'", keyword)}
+		}
+		cond := &Condition{Field: FieldFinish, Op: OpEq, Position: key.pos}
+		if err := normalizeValue(cond, value); err != nil {
+			return nil, err
+		}
+		if keyword == "not" {
+			return &Not{Term: cond, Position: key.pos}, nil
+		}
+		return cond, nil
+	}
+
+	field, ok := fieldKeys[keyword]
+	if !ok {
+		return nil, &SyntaxError{Pos: key.pos, Msg: fmt.Sprintf("unknown keyword %q", key.text)}
+	}
+	cond := &Condition{Field: field, Op: Operator(op.text), Position: key.pos}
+	if !slices.Contains(fieldOps[field], cond.Op) {
+		return nil, &SyntaxError{Pos: op.pos, Msg: fmt.Sprintf("%s doesn't support %q", field, op.text)}
+	}
+	if err := normalizeValue(cond, value); err != nil {
+		return nil, err
+	}
+	return cond, nil
+}
+
+// normalizeValue checks the value of a condition and stores it in the normal form of its field
+func normalizeValue(cond *Condition, value token) error {
+	raw := strings.TrimSpace(value.text)
+	invalid := func(expected string) error {
+		return &SyntaxError{Pos: value.pos, Msg: fmt.Sprintf("invalid %s %q, expected %s", cond.Field, value.text, expected)}
+	}
+	if raw == "" {
+		return invalid("a value")
+	}
+
+	switch cond.Field {
+	case FieldName, FieldType:
+		cond.Value = raw
+	case FieldColor, FieldIdentity:
+		colors, ok := parseColors(raw)
+		if !ok {
+			return invalid("letters of WUBRG, c for colorless, m for multicolor or a color name")
+		}
+		if (colors == Colorless || colors == Multicolor) && cond.Op != OpMatch && cond.Op != OpEq && cond.Op != OpNe {
+			return &SyntaxError{Pos: value.pos, Msg: fmt.Sprintf("%s can't be compared with %q", value.text, cond.Op)}
+		}
+		cond.Value = colors
+	case FieldManaValue, FieldCount:
+		number, err := strconv.ParseFloat(raw, 64)
+		if err != nil || strings.HasPrefix(raw, "-") {
+			return invalid("a non-negative number")
+		}
+		cond.Value = raw
+		cond.Number = number
+	case FieldSet:
+		cond.Value = strings.ToLower(raw)
+	case FieldRarity:
+		rarity, ok := rarityNames[strings.ToLower(raw)]
+		if !ok {
+			return invalid("common, uncommon, rare or mythic")
+		}
+		cond.Value = rarity
+	case FieldFinish:
+		return normalizeEnum(cond, raw, Finishes, invalid)
+	case FieldZone:
+		return normalizeEnum(cond, raw, Zones, invalid)
+	case FieldCondition:
+		for _, condition := range Conditions {
+			if strings.EqualFold(raw, condition) {
+				cond.Value = condition
+				return nil
+			}
+		}
+		return invalid(strings.Join(Conditions, ", "))
+	case FieldLanguage:
+		cond.Value = strings.ToLower(raw)
+	}
+	return nil
+}
+
+func normalizeEnum(cond *Condition, raw string, values []string, invalid func(string) error) error {
+	lower := strings.ToLower(raw)
+	if !slices.Contains(values, lower) {
+		return invalid(strings.Join(values, ", "))
+	}
+	cond.Value = lower
+	return nil
+}
+
+// parseColors turns color letters or a color name into WUBRG letters in that order
+func parseColors(raw string) (string, bool) {
+	lower := strings.ToLower(raw)
+	if colors, ok := colorNames[lower]; ok {
+		return colors, true
+	}
+	switch lower {
+	case "c":
+		return Colorless, true
+	case "m":
+		return Multicolor, true
+	}
+
+	seen := map[rune]bool{}
+	for _, r := range lower {
+		if !strings.ContainsRune("wubrg", r) {
+			return "", false
+		}
+		seen[r] = true
+	}
+	var colors strings.Builder
+	for _, r := range "WUBRG" {
+		if seen[r+'a'-'A'] {
+			colors.WriteRune(r)
+		}
+	}
+	return colors.String(), true
+}
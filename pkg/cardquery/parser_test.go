@@ -0,0 +1,179 @@
+package cardquery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string // String of the parsed expression
+	}{
+		{name: "bare word", query: "goblin", want: "name:goblin"},
+		{name: "bare words are anded", query: "goblin guide", want: "(name:goblin name:guide)"},
+		{name: "quoted phrase", query: `"goblin guide"`, want: `name:"goblin guide"`},
+		{name: "exact name", query: `!"Goblin Guide"`, want: `name="Goblin Guide"`},
+		{name: "exact single word name", query: "!Ornithopter", want: "name=Ornithopter"},
+		{name: "word with punctuation", query: "Lim-Dûl's", want: "name:Lim-Dûl's"},
+		{name: "scryfall example", query: "c:r t:creature cmc<=3 set:mh3 is:foil", want: "(color:R type:creature cmc<=3 set:mh3 finish=foil)"},
+		{name: "aliases", query: "n:bolt mv=1 e:M10 r:c id:rg qty>=4", want: "(name:bolt cmc=1 set:m10 rarity:common identity:RG count>=4)"},
+		{name: "keywords ignore case", query: "C:R T:Goblin SET:LEA", want: "(color:R type:Goblin set:lea)"},
+		{name: "colors in wubrg order", query: "c:gwu", want: "color:WUG"},
+		{name: "color names", query: "c:red id:izzet", want: "(color:R identity:UR)"},
+		{name: "colorless and multicolor", query: "c:c or c=m", want: "(color:C or color=M)"},
+		{name: "color comparisons", query: "c>=rg id<=wub c!=w", want: "(color>=RG identity<=WUB color!=W)"},
+		{name: "rarity names", query: "r>=rare r<uncommon", want: "(rarity>=rare rarity<uncommon)"},
+		{name: "decimal mana value", query: "cmc>2.5", want: "cmc>2.5"},
+		{name: "not foil", query: "not:foil", want: "-finish=foil"},
+		{name: "entry fields", query: "zone:side cond:lp lang:JA finish:etched", want: "(zone:side condition:LP lang:ja finish:etched)"},
+		{name: "or", query: "t:goblin or t:elf", want: "(type:goblin or type:elf)"},
+		{name: "or ignores case", query: "t:goblin OR t:elf", want: "(type:goblin or type:elf)"},
+		{name: "and binds tighter than or", query: "c:r t:goblin or c:g t:elf", want: "((color:R type:goblin) or (color:G type:elf))"},
+		{name: "explicit and", query: "c:r and t:goblin", want: "(color:R type:goblin)"},
+		{name: "parentheses", query: "c:r (t:goblin or t:elf)", want: "(color:R (type:goblin or type:elf))"},
+		{name: "nested parentheses", query: "((c:r))", want: "color:R"},
+		{name: "negation", query: "-t:land", want: "-type:land"},
+		{name: "negated group", query: "-(c:r or c:g) cmc<2", want: "(-(color:R or color:G) cmc<2)"},
+		{name: "double negation", query: "--c:r", want: "--color:R"},
+		{name: "negated phrase", query: `-"goblin guide"`, want: `-name:"goblin guide"`},
+		{name: "quoted value", query: `t:"legendary creature"`, want: `type:"legendary creature"`},
+		{name: "escaped quote", query: `"say \"hi\""`, want: `name:"say \"hi\""`},
+		{name: "minus inside words", query: "name:x-y Lim-Dûl", want: "(name:x-y name:Lim-Dûl)"},
+		{name: "extra spaces", query: "  c:r   t:goblin  ", want: "(color:R type:goblin)"},
+		{name: "or as part of a word", query: "orcish", want: "name:orcish"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := Parse(tt.query)
+			require.NoError(t, err)
+			require.NotNil(t, expr)
+			assert.Equal(t, tt.want, expr.String())
+		})
+	}
+}
+
+func TestParse_Empty(t *testing.T) {
+	for _, query := range []string{"", "   ", "\t"} {
+		expr, err := Parse(query)
+		require.NoError(t, err)
+		assert.Nil(t, expr)
+	}
+}
+
+func TestParse_Positions(t *testing.T) {
+	expr, err := Parse("c:r (t:elf or -is:foil)")
+	require.NoError(t, err)
+
+	and := expr.(*And)
+	assert.Equal(t, 1, and.Pos())
+	assert.Equal(t, 1, and.Terms[0].Pos())
+	or := and.Terms[1].(*Or)
+	assert.Equal(t, 6, or.Pos())
+	assert.Equal(t, 6, or.Terms[0].Pos())
+	not := or.Terms[1].(*Not)
+	assert.Equal(t, 15, not.Pos())
+	assert.Equal(t, 16, not.Term.Pos())
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		pos   int
+		msg   string
+	}{
+		{name: "unknown keyword", query: "c:r foo:bar", pos: 5, msg: `unknown keyword "foo"`},
+		{name: "missing value", query: "t: goblin", pos: 3, msg: "missing value after t:"},
+		{name: "missing value at end", query: "cmc>=", pos: 6, msg: "missing value after cmc>="},
+		{name: "bad color", query: "c:rx", pos: 3, msg: "invalid color"},
+		{name: "colorless compared", query: "c>=c", pos: 4, msg: `can't be compared`},
+		{name: "bad number", query: "cmc<=three", pos: 6, msg: "invalid cmc"},
+		{name: "negative number", query: "count>-1", pos: 7, msg: "invalid count"},
+		{name: "bad rarity", query: "r:legendary", pos: 3, msg: "invalid rarity"},
+		{name: "bad finish", query: "is:shiny", pos: 4, msg: "invalid finish"},
+		{name: "bad zone", query: "zone:deck", pos: 6, msg: "invalid zone"},
+		{name: "bad condition", query: "cond:mint", pos: 6, msg: "invalid condition"},
+		{name: "operator not supported", query: "t>creature", pos: 2, msg: `type doesn't support ">"`},
+		{name: "is with comparison", query: "is=foil", pos: 3, msg: "is only supports ':'"},
+		{name: "operator without keyword", query: "c:r :r", pos: 5, msg: "operator"},
+		{name: "double operator", query: "cmc==3", pos: 5, msg: "missing value"},
+		{name: "unterminated quote", query: `t:goblin "goblin gu`, pos: 10, msg: "unterminated quoted string"},
+		{name: "unmatched closing parenthesis", query: "c:r)", pos: 4, msg: "unmatched closing parenthesis"},
+		{name: "missing closing parenthesis", query: "c:r (t:elf or t:goblin", pos: 5, msg: "missing closing parenthesis"},
+		{name: "empty parentheses", query: "c:r ()", pos: 5, msg: "empty parentheses"},
+		{name: "dangling or", query: "c:r or", pos: 7, msg: `expected a term after "or"`},
+		{name: "leading or", query: "or c:r", pos: 1, msg: `expected a term before "or"`},
+		{name: "double or", query: "c:r or or c:g", pos: 8, msg: `expected a term after "or"`},
+		{name: "dangling and", query: "c:r and", pos: 8, msg: `expected a term after "and"`},
+		{name: "dangling minus", query: "c:r -", pos: 5, msg: "expected a term right after -"},
+		{name: "minus before space", query: "- c:r", pos: 1, msg: "expected a term right after -"},
+		{name: "dangling bang", query: "! bolt", pos: 1, msg: "expected a card name right after !"},
+		{name: "error inside group", query: "(c:r or t:)", pos: 11, msg: "missing value after t:"},
+		{name: "positions count runes", query: "Lim-Dûl foo:x", pos: 9, msg: `unknown keyword "foo"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := Parse(tt.query)
+			assert.Nil(t, expr)
+
+			var syntaxErr *SyntaxError
+			require.ErrorAs(t, err, &syntaxErr)
+			assert.Equal(t, tt.pos, syntaxErr.Pos)
+			assert.Contains(t, syntaxErr.Msg, tt.msg)
+		})
+	}
+}
+
+func TestSyntaxError_Error(t *testing.T) {
+	err := &SyntaxError{Pos: 5, Msg: `unknown keyword "foo"`}
+	assert.Equal(t, `unknown keyword "foo" at position 5`, err.Error())
+}
+
+func TestNameTerms(t *testing.T) {
+	tests := []struct {
+		query string
+		terms []string
+		ok    bool
+	}{
+		{query: "goblin guide", terms: []string{"goblin", "guide"}, ok: true},
+		{query: `"goblin guide"`, terms: []string{"goblin guide"}, ok: true},
+		{query: "name:bolt", terms: []string{"bolt"}, ok: true},
+		{query: "!Bolt", ok: false},
+		{query: "bolt c:r", ok: false},
+		{query: "bolt or shock", ok: false},
+		{query: "-bolt", ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			expr, err := Parse(tt.query)
+			require.NoError(t, err)
+
+			terms, ok := NameTerms(expr)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.terms, terms)
+		})
+	}
+}
+
+func TestUsesCatalog(t *testing.T) {
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{query: "bolt is:foil zone:main count>1 lang:en cond:nm", want: false},
+		{query: "bolt -(zone:side or c:r)", want: true},
+		{query: "cmc=1", want: true},
+		{query: "t:instant", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			expr, err := Parse(tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, UsesCatalog(expr))
+		})
+	}
+}
@@ -2,14 +2,40 @@ package collectorclient
 
 import (
 	"context"
+	"errors"
+	"io"
+	"time"
 
 	dto "github.com/ShenokZlob/collector-service/pkg/contracts"
 )
 
+// ErrCollectionChanged is returned when the request was made WithIfMatch
+// and the collection has another version now.
+var ErrCollectionChanged = errors.New("collection was changed by another request")
+
+// errNotModified is returned for a conditional request when the collection wasn't changed
+var errNotModified = errors.New("collection not modified")
+
+type ctxKeyPrecondition string
+
+const (
+	ifMatchKey     ctxKeyPrecondition = "ifMatch"
+	ifNoneMatchKey ctxKeyPrecondition = "ifNoneMatch"
+)
+
+// WithIfMatch makes the collection changes sent with ctx apply only
+// to the collection of the given version.
+func WithIfMatch(ctx context.Context, version int64) context.Context {
+	return context.WithValue(ctx, ifMatchKey, version)
+}
+
 type CollectorClient interface {
 	CollectorClientAuth
 	CollectorClientCollections
 	CollectorClientCards
+	CollectorClientCatalog
+	CollectorClientValuation
+	CollectorClientTrades
 }
 
 type CollectorClientAuth interface {
@@ -19,18 +45,93 @@ type CollectorClientAuth interface {
 
 type CollectorClientCollections interface {
 	GetUserCollections(ctx context.Context) ([]dto.Collection, error)
+	GetCollection(ctx context.Context, collectionID string) (*dto.Collection, error)
+	GetCollectionIfModified(ctx context.Context, collectionID string, version int64) (*dto.Collection, bool, error)
 	CreateCollection(ctx context.Context, req *dto.CreateCollectionRequest) (*dto.Collection, error)
 	RenameCollection(ctx context.Context, collectionID string, req *dto.RenameCollectionRequest) error
 	DeleteCollection(ctx context.Context, collectionID string) error
 	GetUsersCollectionByName(ctx context.Context, name string) (*dto.Collection, error)
+	CloneCollection(ctx context.Context, collectionID string, req *dto.CloneCollectionRequest) (*dto.Collection, error)
+	MergeCollections(ctx context.Context, collectionID string, req *dto.MergeCollectionsRequest) (*dto.Collection, error)
+	SetCollectionKind(ctx context.Context, collectionID string, req *dto.SetCollectionKindRequest) (*dto.Collection, error)
+	GetCollectionStats(ctx context.Context, collectionID string) (*dto.CollectionStats, error)
+	GetUserStats(ctx context.Context) (*dto.CollectionStats, error)
+	ListTrash(ctx context.Context) (*dto.Trash, error)
+	RestoreCollection(ctx context.Context, collectionID string) (*dto.Collection, error)
+	GetCollectionHistory(ctx context.Context, collectionID string, offset, limit int) (*dto.ChangesPage, error)
+	UndoChange(ctx context.Context, collectionID, changeID string) (*dto.CollectionChange, error)
+	UndoLastChanges(ctx context.Context, collectionID string, req *dto.UndoChangesRequest) (*dto.CollectionChange, error)
+	CreateSnapshot(ctx context.Context, collectionID string, req *dto.CreateSnapshotRequest) (*dto.Snapshot, error)
+	ListSnapshots(ctx context.Context, collectionID string) ([]dto.Snapshot, error)
+	GetSnapshot(ctx context.Context, collectionID, snapshotID string) (*dto.Snapshot, error)
+	DeleteSnapshot(ctx context.Context, collectionID, snapshotID string) error
+	DiffSnapshot(ctx context.Context, collectionID, snapshotID, toSnapshotID string) (*dto.SnapshotDiff, error)
+	RestoreSnapshot(ctx context.Context, collectionID, snapshotID string) error
 
 	// TODO: remove in future
-	ListCardsInCollection(ctx context.Context, collectionID string) ([]dto.Card, error)
+	ListCardsInCollection(ctx context.Context, collectionID string, opts *ListCardsOptions) (*dto.CardsPage, error)
 }
 
 type CollectorClientCards interface {
-	ListCardsInCollection(ctx context.Context, collectionID string) ([]dto.Card, error)
-	AddCardToCollection(ctx context.Context, collectionID string, card *dto.Card) error
+	ListCardsInCollection(ctx context.Context, collectionID string, opts *ListCardsOptions) (*dto.CardsPage, error)
+	AddCardToCollection(ctx context.Context, collectionID string, card *dto.Card) (*dto.Card, error)
 	SetCardCountInCollection(ctx context.Context, collectionID string, card *dto.Card) error
-	DeleteCardFromCollection(ctx context.Context, collectionID string, cardIdScryfall string) error
+	DeleteCardFromCollection(ctx context.Context, collectionID string, entryID string) error
+	RestoreCard(ctx context.Context, collectionID string, entryID string) (*dto.Card, error)
+	AdjustCardCount(ctx context.Context, collectionID string, entryID string, req *dto.AdjustCardCountRequest) (*dto.Card, error)
+	MoveCardBetweenZones(ctx context.Context, collectionID string, entryID string, req *dto.MoveCardRequest) error
+	TransferCard(ctx context.Context, collectionID string, entryID string, req *dto.TransferCardRequest) error
+	TransferCards(ctx context.Context, collectionID string, req *dto.TransferCardsRequest) error
+	BatchCardOperations(ctx context.Context, collectionID string, req *dto.CardBatchRequest) (*dto.CardBatchResponse, error)
+	ImportDecklist(ctx context.Context, collectionID string, req *dto.ImportDecklistRequest) (*dto.ImportResponse, error)
+	ImportCSV(ctx context.Context, collectionID string, csv io.Reader, format string, dryRun bool) (*dto.ImportResponse, error)
+	ExportCSV(ctx context.Context, collectionID string, format string) (io.ReadCloser, error)
+	ExportDecklist(ctx context.Context, collectionID string, format string) (string, error)
+	ValidateDeck(ctx context.Context, collectionID string, format string) (*dto.DeckValidation, error)
+	FindMissingCards(ctx context.Context, collectionID string, sourceIDs []string) (*dto.MissingReport, error)
+	SearchCards(ctx context.Context, query string, limit int) (*dto.CardSearchResult, error)
+}
+
+type CollectorClientCatalog interface {
+	SearchCatalog(ctx context.Context, namePrefix string, limit int) ([]dto.CatalogCard, error)
+	GetCatalogPrinting(ctx context.Context, setCode, collectorNumber string) (*dto.CatalogCard, error)
+	GetCatalogByOracleID(ctx context.Context, oracleID string) ([]dto.CatalogCard, error)
+}
+
+type CollectorClientValuation interface {
+	GetCollectionValue(ctx context.Context, collectionID string, opts *ValuationOptions) (*dto.Valuation, error)
+	GetUserValue(ctx context.Context, opts *ValuationOptions) (*dto.Valuation, error)
+	GetCollectionValueHistory(ctx context.Context, collectionID string, from, to time.Time) ([]dto.ValuePoint, error)
+	GetUserValueHistory(ctx context.Context, from, to time.Time) ([]dto.ValuePoint, error)
+}
+
+type CollectorClientTrades interface {
+	ListGroups(ctx context.Context) ([]dto.Group, error)
+	CreateGroup(ctx context.Context, req *dto.CreateGroupRequest) (*dto.Group, error)
+	JoinGroup(ctx context.Context, req *dto.JoinGroupRequest) (*dto.Group, error)
+	LeaveGroup(ctx context.Context, groupID string) error
+	ListTradeMatches(ctx context.Context, offset, limit int) (*dto.TradeMatchesPage, error)
+}
+
+// ValuationOptions configure GetCollectionValue and GetUserValue.
+// Zero values are left to the server defaults; nil options are allowed.
+type ValuationOptions struct {
+	Currency string    // usd, eur or tix
+	Top      int       // number of the most valuable cards returned
+	Since    time.Time // compare with prices on this day
+}
+
+// ListCardsOptions filters, sorts and paginates ListCardsInCollection.
+// Zero values are left to the server defaults; nil options are allowed.
+type ListCardsOptions struct {
+	Name       string    // substring of the card name
+	Query      string    // card query language filter, like "c:r t:creature cmc<=3 is:foil"
+	AddedSince time.Time // only cards added at or after this time
+	MinCount   int
+
+	Sort string // name, count or added_at
+	Desc bool
+
+	Offset int
+	Limit  int
 }
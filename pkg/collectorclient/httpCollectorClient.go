@@ -4,8 +4,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ShenokZlob/collector-service/pkg/authctx"
 	dto "github.com/ShenokZlob/collector-service/pkg/contracts"
@@ -61,387 +67,853 @@ func (c *HTTPCollectorClient) RegisterUser(reqdata *dto.RegisterTelegramRequest)
 // Need JWT token for this opperation
 // Authorization: Bearer TOKEN
 func (c *HTTPCollectorClient) GetUserCollections(ctx context.Context) ([]dto.Collection, error) {
-	token, ok := authctx.GetJWT(ctx)
-	if !ok || token == "" {
-		c.Log.Error("Authorization token is missing")
-		return nil, fmt.Errorf("authorization token is missing")
+	c.Log.Info("Get user's list of collections", zap.String("method", "HTTPCollectorClient.GetUserCollections"))
+
+	var collections []dto.Collection
+	if err := c.do(ctx, http.MethodGet, "/collections", nil, http.StatusOK, &collections); err != nil {
+		return nil, err
 	}
 
-	c.Log.Info("Get user's list of collections", zap.String("method", "HTTPCollectorClient.GetUserCollections"), zap.String("token_auth", token))
+	return collections, nil
+}
 
-	request, err := http.NewRequest(http.MethodGet, c.URL+"/collections", nil)
-	if err != nil {
-		c.Log.Error("Failed to create request", zap.Error(err))
+// Need JWT token for this opperation
+func (c *HTTPCollectorClient) CreateCollection(ctx context.Context, req *dto.CreateCollectionRequest) (*dto.Collection, error) {
+	c.Log.Info("Create collection", zap.String("method", "HTTPCollectorClient.CreateCollection"))
+
+	var collection dto.Collection
+	if err := c.do(ctx, http.MethodPost, "/collections", req, http.StatusCreated, &collection); err != nil {
 		return nil, err
 	}
 
-	request.Header.Set("Authorization", "Bearer "+token)
-	c.Log.Debug("Req token", zap.String("header", request.Header.Get("Authorization")))
+	return &collection, nil
+}
 
-	resp, err := c.ClientHTTP.Do(request)
+// RenameCollection renames the collection, with WithIfMatch it fails with
+// ErrCollectionChanged when the collection was changed since that version.
+func (c *HTTPCollectorClient) RenameCollection(ctx context.Context, collectionID string, req *dto.RenameCollectionRequest) error {
+	c.Log.Info("Rename collection", zap.String("method", "HTTPCollectorClient.RenameCollection"), zap.String("collection_id", collectionID))
+
+	return c.do(ctx, http.MethodPatch, "/collections/"+collectionID, req, http.StatusNoContent, nil)
+}
+
+// DeleteCollection moves the collection to the trash
+func (c *HTTPCollectorClient) DeleteCollection(ctx context.Context, collectionID string) error {
+	c.Log.Info("Delete collection", zap.String("method", "HTTPCollectorClient.DeleteCollection"), zap.String("collection_id", collectionID))
+
+	return c.do(ctx, http.MethodDelete, "/collections/"+collectionID, nil, http.StatusNoContent, nil)
+}
+
+// GetCollection gets the collection with its current version
+func (c *HTTPCollectorClient) GetCollection(ctx context.Context, collectionID string) (*dto.Collection, error) {
+	c.Log.Info("Get collection", zap.String("method", "HTTPCollectorClient.GetCollection"), zap.String("collection_id", collectionID))
+
+	var collection dto.Collection
+	if err := c.do(ctx, http.MethodGet, "/collections/"+collectionID, nil, http.StatusOK, &collection); err != nil {
+		return nil, err
+	}
+
+	return &collection, nil
+}
+
+// GetCollectionIfModified gets the collection only when its version isn't the given one.
+// It returns false without the collection when the collection wasn't changed.
+func (c *HTTPCollectorClient) GetCollectionIfModified(ctx context.Context, collectionID string, version int64) (*dto.Collection, bool, error) {
+	c.Log.Info("Get collection if modified", zap.String("method", "HTTPCollectorClient.GetCollectionIfModified"),
+		zap.String("collection_id", collectionID), zap.Int64("version", version))
+
+	var collection dto.Collection
+	ctx = context.WithValue(ctx, ifNoneMatchKey, version)
+	err := c.do(ctx, http.MethodGet, "/collections/"+collectionID, nil, http.StatusOK, &collection)
+	if errors.Is(err, errNotModified) {
+		return nil, false, nil
+	}
 	if err != nil {
-		c.Log.Error("Failed to do a request", zap.Error(err))
+		return nil, false, err
+	}
+
+	return &collection, true, nil
+}
+
+func (c *HTTPCollectorClient) GetUsersCollectionByName(ctx context.Context, collectionName string) (*dto.Collection, error) {
+	c.Log.Info("Get user's collection by name", zap.String("method", "HTTPCollectorClient.GetUsersCollectionByName"), zap.String("collection_name", collectionName))
+
+	var collection dto.Collection
+	if err := c.do(ctx, http.MethodGet, "/collections/name/"+url.PathEscape(collectionName), nil, http.StatusOK, &collection); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		var errorRepsonse dto.ErrorResponse
-		if err := json.NewDecoder(resp.Body).Decode(&errorRepsonse); err != nil {
-			c.Log.Error("Failed to decode error response", zap.Error(err))
-			return nil, fmt.Errorf("failed to decode error response, status code: %d", resp.StatusCode)
-		}
-		c.Log.Error("Failed to get user's collections", zap.String("message", errorRepsonse.Message))
-		return nil, fmt.Errorf("failed to get user's collections, status code: %d", resp.StatusCode)
+	return &collection, nil
+}
+
+func (c *HTTPCollectorClient) ListCardsInCollection(ctx context.Context, collectionID string, opts *ListCardsOptions) (*dto.CardsPage, error) {
+	c.Log.Info("Get cards from collection", zap.String("method", "HTTPCollectorClient.ListCardsInCollection"), zap.String("collection_id", collectionID))
+
+	path := fmt.Sprintf("/collections/%s/cards", collectionID)
+	if query := opts.values().Encode(); query != "" {
+		path += "?" + query
 	}
 
-	var collections []dto.Collection
-	err = json.NewDecoder(resp.Body).Decode(&collections)
-	if err != nil {
-		c.Log.Error("Failed to decode a body request", zap.Error(err))
+	var page dto.CardsPage
+	if err := c.do(ctx, http.MethodGet, path, nil, http.StatusOK, &page); err != nil {
 		return nil, err
 	}
 
-	return collections, nil
+	return &page, nil
 }
 
-// Need JWT token for this opperation
-func (c *HTTPCollectorClient) CreateCollection(ctx context.Context, req *dto.CreateCollectionRequest) (*dto.Collection, error) {
-	token, ok := authctx.GetJWT(ctx)
-	if !ok || token == "" {
-		c.Log.Error("Authorization token is missing")
-		return nil, fmt.Errorf("authorization token is missing")
+func (o *ListCardsOptions) values() url.Values {
+	values := url.Values{}
+	if o == nil {
+		return values
+	}
+
+	if o.Name != "" {
+		values.Set("name", o.Name)
+	}
+	if o.Query != "" {
+		values.Set("q", o.Query)
+	}
+	if !o.AddedSince.IsZero() {
+		values.Set("added_since", o.AddedSince.Format(time.RFC3339))
+	}
+	if o.MinCount > 0 {
+		values.Set("min_count", strconv.Itoa(o.MinCount))
+	}
+	if o.Sort != "" {
+		values.Set("sort", o.Sort)
+	}
+	if o.Desc {
+		values.Set("order", "desc")
+	}
+	if o.Offset > 0 {
+		values.Set("offset", strconv.Itoa(o.Offset))
+	}
+	if o.Limit > 0 {
+		values.Set("limit", strconv.Itoa(o.Limit))
 	}
 
-	// doesn't look good to logging this information!!!
-	c.Log.Info("Create collection", zap.String("metod", "HTTPCollectorClient.CreateCollection"), zap.String("token_auth", token))
+	return values
+}
 
-	body, err := json.Marshal(&req)
-	if err != nil {
-		c.Log.Error("Failed to marshal data", zap.Error(err))
+func (c *HTTPCollectorClient) AddCardToCollection(ctx context.Context, collectionID string, card *dto.Card) (*dto.Card, error) {
+	c.Log.Info("Add card to collection", zap.String("method", "HTTPCollectorClient.AddCardToCollection"), zap.String("collection_id", collectionID))
+
+	var entry dto.Card
+	path := fmt.Sprintf("/collections/%s/cards", collectionID)
+	if err := c.do(ctx, http.MethodPost, path, card, http.StatusCreated, &entry); err != nil {
 		return nil, err
 	}
 
-	request, err := http.NewRequest(http.MethodPost, c.URL+"/collections", bytes.NewBuffer(body))
-	if err != nil {
-		c.Log.Error("Failed to create request", zap.Error(err))
+	return &entry, nil
+}
+
+func (c *HTTPCollectorClient) SetCardCountInCollection(ctx context.Context, collectionID string, card *dto.Card) error {
+	c.Log.Info("Set card's count in collection", zap.String("method", "HTTPCollectorClient.SetCardCountInCollection"), zap.String("collection_id", collectionID))
+
+	req := dto.SetCardsCountRequest{ScryfallID: card.ScryfallID, Count: card.Count}
+	path := fmt.Sprintf("/collections/%s/cards/%s", collectionID, card.ID)
+	return c.do(ctx, http.MethodPatch, path, req, http.StatusNoContent, nil)
+}
+
+func (c *HTTPCollectorClient) DeleteCardFromCollection(ctx context.Context, collectionID string, entryID string) error {
+	c.Log.Info("Delete card from collection", zap.String("method", "HTTPCollectorClient.DeleteCardFromCollection"),
+		zap.String("collection_id", collectionID), zap.String("entry_id", entryID))
+
+	path := fmt.Sprintf("/collections/%s/cards/%s", collectionID, entryID)
+	return c.do(ctx, http.MethodDelete, path, nil, http.StatusNoContent, nil)
+}
+
+// AdjustCardCount adds or removes copies of the card entry. The returned entry
+// has zero count when its last copies were removed.
+func (c *HTTPCollectorClient) AdjustCardCount(ctx context.Context, collectionID string, entryID string, req *dto.AdjustCardCountRequest) (*dto.Card, error) {
+	c.Log.Info("Adjust card count", zap.String("method", "HTTPCollectorClient.AdjustCardCount"),
+		zap.String("collection_id", collectionID), zap.String("entry_id", entryID), zap.Int("delta", req.Delta))
+
+	var card dto.Card
+	path := fmt.Sprintf("/collections/%s/cards/%s/adjust", collectionID, entryID)
+	if err := c.do(ctx, http.MethodPost, path, req, http.StatusOK, &card); err != nil {
 		return nil, err
 	}
 
-	request.Header.Set("Authorization", "Bearer "+token)
+	return &card, nil
+}
 
-	resp, err := c.ClientHTTP.Do(request)
+func (c *HTTPCollectorClient) MoveCardBetweenZones(ctx context.Context, collectionID string, entryID string, req *dto.MoveCardRequest) error {
+	c.Log.Info("Move card between zones", zap.String("method", "HTTPCollectorClient.MoveCardBetweenZones"),
+		zap.String("collection_id", collectionID), zap.String("entry_id", entryID))
+
+	path := fmt.Sprintf("/collections/%s/cards/%s/move", collectionID, entryID)
+	return c.do(ctx, http.MethodPost, path, req, http.StatusNoContent, nil)
+}
+
+func (c *HTTPCollectorClient) TransferCard(ctx context.Context, collectionID string, entryID string, req *dto.TransferCardRequest) error {
+	c.Log.Info("Transfer card to another collection", zap.String("method", "HTTPCollectorClient.TransferCard"),
+		zap.String("collection_id", collectionID), zap.String("entry_id", entryID), zap.String("to_collection_id", req.ToCollectionID))
+
+	path := fmt.Sprintf("/collections/%s/cards/%s/transfer", collectionID, entryID)
+	return c.do(ctx, http.MethodPost, path, req, http.StatusNoContent, nil)
+}
+
+func (c *HTTPCollectorClient) TransferCards(ctx context.Context, collectionID string, req *dto.TransferCardsRequest) error {
+	c.Log.Info("Transfer cards to another collection", zap.String("method", "HTTPCollectorClient.TransferCards"),
+		zap.String("collection_id", collectionID), zap.String("to_collection_id", req.ToCollectionID), zap.Int("items", len(req.Items)))
+
+	path := fmt.Sprintf("/collections/%s/transfer", collectionID)
+	return c.do(ctx, http.MethodPost, path, req, http.StatusNoContent, nil)
+}
+
+func (c *HTTPCollectorClient) CloneCollection(ctx context.Context, collectionID string, req *dto.CloneCollectionRequest) (*dto.Collection, error) {
+	c.Log.Info("Clone collection", zap.String("method", "HTTPCollectorClient.CloneCollection"), zap.String("collection_id", collectionID))
+
+	var collection dto.Collection
+	err := c.do(ctx, http.MethodPost, "/collections/"+collectionID+"/clone", req, http.StatusCreated, &collection)
 	if err != nil {
-		c.Log.Error("Failed to send request to collector service", zap.Error(err))
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusCreated {
-		var errorResponse dto.ErrorResponse
-		if err := json.NewDecoder(resp.Body).Decode(&errorResponse); err != nil {
-			c.Log.Error("Failed to decode error response", zap.Error(err))
-			return nil, fmt.Errorf("failed to decode error response, status code: %d", resp.StatusCode)
-		}
-		c.Log.Error("Failed to create collection's for user", zap.String("message", errorResponse.Message))
-		return nil, fmt.Errorf("failed to create collection's, status code: %d", resp.StatusCode)
+	return &collection, nil
+}
+
+func (c *HTTPCollectorClient) SetCollectionKind(ctx context.Context, collectionID string, req *dto.SetCollectionKindRequest) (*dto.Collection, error) {
+	c.Log.Info("Set collection kind", zap.String("method", "HTTPCollectorClient.SetCollectionKind"),
+		zap.String("collection_id", collectionID), zap.String("kind", req.Kind))
+
+	var collection dto.Collection
+	err := c.do(ctx, http.MethodPut, "/collections/"+collectionID+"/kind", req, http.StatusOK, &collection)
+	if err != nil {
+		return nil, err
 	}
 
+	return &collection, nil
+}
+
+func (c *HTTPCollectorClient) MergeCollections(ctx context.Context, collectionID string, req *dto.MergeCollectionsRequest) (*dto.Collection, error) {
+	c.Log.Info("Merge collections", zap.String("method", "HTTPCollectorClient.MergeCollections"),
+		zap.String("collection_id", collectionID), zap.String("source_collection_id", req.SourceCollectionID))
+
 	var collection dto.Collection
-	err = json.NewDecoder(resp.Body).Decode(&collection)
+	err := c.do(ctx, http.MethodPost, "/collections/"+collectionID+"/merge", req, http.StatusOK, &collection)
 	if err != nil {
-		c.Log.Error("Failed to decode a responser body", zap.Error(err))
 		return nil, err
 	}
 
 	return &collection, nil
 }
 
-// Need JWT token for this opperation
-func (c *HTTPCollectorClient) RenameCollection(ctx context.Context, collectionID string, req *dto.RenameCollectionRequest) error {
-	token, ok := authctx.GetJWT(ctx)
-	if !ok || token == "" {
-		c.Log.Error("Authorization token is missing")
-		return fmt.Errorf("authorization token is missing")
+// BatchCardOperations applies add, set count and delete operations to the collection in one request.
+func (c *HTTPCollectorClient) BatchCardOperations(ctx context.Context, collectionID string, req *dto.CardBatchRequest) (*dto.CardBatchResponse, error) {
+	c.Log.Info("Apply batch of card operations", zap.String("method", "HTTPCollectorClient.BatchCardOperations"),
+		zap.String("collection_id", collectionID), zap.Int("operations", len(req.Operations)))
+
+	var resp dto.CardBatchResponse
+	path := fmt.Sprintf("/collections/%s/cards:batch", collectionID)
+	if err := c.do(ctx, http.MethodPost, path, req, http.StatusOK, &resp); err != nil {
+		return nil, err
 	}
 
-	c.Log.Info("Rename collection", zap.String("collection_id", collectionID), zap.String("method", "HTTPCollectorClient.RenameCollection"), zap.String("token_auth", token))
+	return &resp, nil
+}
+
+// ImportDecklist imports a text decklist into the collection, or previews the import with req.DryRun.
+func (c *HTTPCollectorClient) ImportDecklist(ctx context.Context, collectionID string, req *dto.ImportDecklistRequest) (*dto.ImportResponse, error) {
+	c.Log.Info("Import decklist", zap.String("method", "HTTPCollectorClient.ImportDecklist"),
+		zap.String("collection_id", collectionID), zap.Bool("dry_run", req.DryRun))
+
+	var resp dto.ImportResponse
+	path := fmt.Sprintf("/collections/%s/import", collectionID)
+	if err := c.do(ctx, http.MethodPost, path, req, http.StatusOK, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
 
-	body, err := json.Marshal(req)
+// ImportCSV imports a CSV export of Moxfield, Deckbox, ManaBox or the native format into the
+// collection. An empty format is detected by the server, dryRun only previews the import.
+func (c *HTTPCollectorClient) ImportCSV(ctx context.Context, collectionID string, csv io.Reader, format string, dryRun bool) (*dto.ImportResponse, error) {
+	c.Log.Info("Import CSV", zap.String("method", "HTTPCollectorClient.ImportCSV"),
+		zap.String("collection_id", collectionID), zap.String("format", format), zap.Bool("dry_run", dryRun))
+
+	query := url.Values{}
+	if format != "" {
+		query.Set("format", format)
+	}
+	if dryRun {
+		query.Set("dry_run", "true")
+	}
+	path := fmt.Sprintf("/collections/%s/import/csv", collectionID)
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	resp, err := c.send(ctx, http.MethodPost, path, csv, "text/csv", http.StatusOK)
 	if err != nil {
-		c.Log.Error("Failed to marshal request data")
-		return err
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var out dto.ImportResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		c.Log.Error("Failed to decode a response body", zap.Error(err))
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// ExportCSV streams the cards of the collection as CSV of the given format, the native
+// format is used when it's empty. The caller closes the returned reader.
+func (c *HTTPCollectorClient) ExportCSV(ctx context.Context, collectionID string, format string) (io.ReadCloser, error) {
+	c.Log.Info("Export CSV", zap.String("method", "HTTPCollectorClient.ExportCSV"),
+		zap.String("collection_id", collectionID), zap.String("format", format))
+
+	path := fmt.Sprintf("/collections/%s/export/csv", collectionID)
+	if format != "" {
+		path += "?" + url.Values{"format": {format}}.Encode()
 	}
 
-	request, err := http.NewRequest(http.MethodPatch, c.URL+"/collections/"+collectionID, bytes.NewBuffer(body))
+	resp, err := c.send(ctx, http.MethodGet, path, nil, "", http.StatusOK)
 	if err != nil {
-		c.Log.Error("Failed to create request", zap.Error(err))
-		return err
+		return nil, err
 	}
 
-	request.Header.Set("Authorization", "Bearer "+token)
+	return resp.Body, nil
+}
 
-	resp, err := c.ClientHTTP.Do(request)
+// ExportDecklist renders the collection as a text decklist grouped by zone, ready to be pasted
+// into MTG Arena or MTGO. Format is one of "arena", "mtgo", "text" or "markdown".
+func (c *HTTPCollectorClient) ExportDecklist(ctx context.Context, collectionID string, format string) (string, error) {
+	c.Log.Info("Export decklist", zap.String("method", "HTTPCollectorClient.ExportDecklist"),
+		zap.String("collection_id", collectionID), zap.String("format", format))
+
+	path := fmt.Sprintf("/collections/%s/export", collectionID)
+	if format != "" {
+		path += "?" + url.Values{"format": {format}}.Encode()
+	}
+
+	resp, err := c.send(ctx, http.MethodGet, path, nil, "", http.StatusOK)
 	if err != nil {
-		c.Log.Error("Failed to do a request", zap.Error(err))
-		return err
+		return "", err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusNoContent {
-		var errorResponse dto.ErrorResponse
-		if err := json.NewDecoder(resp.Body).Decode(&errorResponse); err != nil {
-			c.Log.Error("Failed to decode error response", zap.Error(err))
-			return fmt.Errorf("failed to decode error response, status code: %d", resp.StatusCode)
-		}
-		c.Log.Error("Failed to rename collection", zap.String("message", errorResponse.Message))
-		return fmt.Errorf("failed to rename the user's collection, status code: %d", resp.StatusCode)
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.Log.Error("Failed to read a response body", zap.Error(err))
+		return "", err
 	}
 
-	return nil
+	return string(data), nil
 }
 
-// Need JWT token for this opperation
-func (c *HTTPCollectorClient) DeleteCollection(ctx context.Context, collectionID string) error {
-	token, ok := authctx.GetJWT(ctx)
-	if !ok || token == "" {
-		c.Log.Error("Authorization token is missing")
-		return fmt.Errorf("authorization token is missing")
+// ValidateDeck checks the collection as a deck of the format (standard, modern, commander...).
+func (c *HTTPCollectorClient) ValidateDeck(ctx context.Context, collectionID string, format string) (*dto.DeckValidation, error) {
+	c.Log.Info("Validate deck", zap.String("method", "HTTPCollectorClient.ValidateDeck"),
+		zap.String("collection_id", collectionID), zap.String("format", format))
+
+	var resp dto.DeckValidation
+	path := fmt.Sprintf("/collections/%s/validate?%s", collectionID, url.Values{"format": {format}}.Encode())
+	if err := c.do(ctx, http.MethodGet, path, nil, http.StatusOK, &resp); err != nil {
+		return nil, err
 	}
 
-	// doesn't look good to logging this information!!!
-	c.Log.Info("Delete collection", zap.String("method", "HTTPCollectorClient.DeleteCollection"), zap.String("token_auth", token))
+	return &resp, nil
+}
 
-	request, err := http.NewRequest(http.MethodDelete, c.URL+"/collections/"+collectionID, nil)
-	if err != nil {
-		c.Log.Error("Failed to prepare a request", zap.Error(err))
-		return err
+// FindMissingCards reports the cards of the collection missing from the source
+// collections. No sources compares it to all of the user's binders.
+func (c *HTTPCollectorClient) FindMissingCards(ctx context.Context, collectionID string, sourceIDs []string) (*dto.MissingReport, error) {
+	c.Log.Info("Find missing cards", zap.String("method", "HTTPCollectorClient.FindMissingCards"),
+		zap.String("collection_id", collectionID), zap.Strings("sources", sourceIDs))
+
+	query := url.Values{}
+	if len(sourceIDs) > 0 {
+		query.Set("sources", strings.Join(sourceIDs, ","))
 	}
 
-	request.Header.Set("Authorization", "Bearer "+token)
+	var resp dto.MissingReport
+	if err := c.do(ctx, http.MethodGet, withQuery("/collections/"+collectionID+"/missing", query), nil, http.StatusOK, &resp); err != nil {
+		return nil, err
+	}
 
-	resp, err := c.ClientHTTP.Do(request)
-	if err != nil {
-		c.Log.Error("Failed to do a request", zap.Error(err))
-		return err
+	return &resp, nil
+}
+
+// SearchCards finds cards in all of the user's collections by name or Scryfall ID.
+// A zero limit is the server default.
+func (c *HTTPCollectorClient) SearchCards(ctx context.Context, query string, limit int) (*dto.CardSearchResult, error) {
+	c.Log.Info("Search cards", zap.String("method", "HTTPCollectorClient.SearchCards"), zap.String("query", query))
+
+	values := url.Values{"q": {query}}
+	if limit > 0 {
+		values.Set("limit", strconv.Itoa(limit))
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusNoContent {
-		var errorResponse dto.ErrorResponse
-		if err := json.NewDecoder(resp.Body).Decode(&errorResponse); err != nil {
-			c.Log.Error("Failed to decode error response", zap.Error(err))
-			return fmt.Errorf("failed to decode error response, status code: %d", resp.StatusCode)
-		}
-		c.Log.Error("Failed to delete the user's collection", zap.String("message", errorResponse.Message))
-		return fmt.Errorf("failed to delete the user's collection, status code: %d", resp.StatusCode)
+	var resp dto.CardSearchResult
+	if err := c.do(ctx, http.MethodGet, withQuery("/cards/search", values), nil, http.StatusOK, &resp); err != nil {
+		return nil, err
 	}
 
-	return nil
+	return &resp, nil
 }
 
-func (c *HTTPCollectorClient) GetUsersCollectionByName(ctx context.Context, collectionName string) (*dto.Collection, error) {
-	token, ok := authctx.GetJWT(ctx)
-	if !ok || token == "" {
-		c.Log.Error("Authorization token is missing")
-		return nil, fmt.Errorf("authorization token is missing")
+// SearchCatalog finds catalog printings whose names start with namePrefix. A zero limit is the server default.
+func (c *HTTPCollectorClient) SearchCatalog(ctx context.Context, namePrefix string, limit int) ([]dto.CatalogCard, error) {
+	c.Log.Info("Search catalog", zap.String("method", "HTTPCollectorClient.SearchCatalog"), zap.String("name", namePrefix))
+
+	query := url.Values{"name": {namePrefix}}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var resp []dto.CatalogCard
+	if err := c.do(ctx, http.MethodGet, "/catalog/cards?"+query.Encode(), nil, http.StatusOK, &resp); err != nil {
+		return nil, err
 	}
 
-	c.Log.Info("Get user's collection by name", zap.String("method", "HTTPCollectorClient.GetUsersCollectionByName"), zap.String("token_auth", token), zap.String("collection_name", collectionName))
+	return resp, nil
+}
 
-	request, err := http.NewRequest(http.MethodGet, c.URL+"/collections/name/"+collectionName, nil)
-	if err != nil {
-		c.Log.Error("Failed to create request", zap.Error(err))
+// GetCatalogPrinting returns the catalog printing with the collector number in the set.
+func (c *HTTPCollectorClient) GetCatalogPrinting(ctx context.Context, setCode, collectorNumber string) (*dto.CatalogCard, error) {
+	c.Log.Info("Get catalog printing", zap.String("method", "HTTPCollectorClient.GetCatalogPrinting"),
+		zap.String("set", setCode), zap.String("collector_number", collectorNumber))
+
+	var resp dto.CatalogCard
+	path := fmt.Sprintf("/catalog/cards/%s/%s", url.PathEscape(setCode), url.PathEscape(collectorNumber))
+	if err := c.do(ctx, http.MethodGet, path, nil, http.StatusOK, &resp); err != nil {
 		return nil, err
 	}
 
-	request.Header.Set("Authorization", "Bearer "+token)
+	return &resp, nil
+}
 
-	resp, err := c.ClientHTTP.Do(request)
-	if err != nil {
-		c.Log.Error("Failed to do a request", zap.Error(err))
+// GetCatalogByOracleID returns all catalog printings of the card.
+func (c *HTTPCollectorClient) GetCatalogByOracleID(ctx context.Context, oracleID string) ([]dto.CatalogCard, error) {
+	c.Log.Info("Get catalog printings", zap.String("method", "HTTPCollectorClient.GetCatalogByOracleID"), zap.String("oracle_id", oracleID))
+
+	var resp []dto.CatalogCard
+	path := fmt.Sprintf("/catalog/oracle/%s", url.PathEscape(oracleID))
+	if err := c.do(ctx, http.MethodGet, path, nil, http.StatusOK, &resp); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		var errorResponse dto.ErrorResponse
-		if err := json.NewDecoder(resp.Body).Decode(&errorResponse); err != nil {
-			c.Log.Error("Failed to decode error response", zap.Error(err))
-			return nil, fmt.Errorf("failed to decode error response, status code: %d", resp.StatusCode)
-		}
-		c.Log.Error("Failed to get user's collection by name", zap.String("message", errorResponse.Message))
-		return nil, fmt.Errorf("failed to get user's collection by name, status code: %d", resp.StatusCode)
+	return resp, nil
+}
+
+// GetCollectionValue returns the value of the collection at the latest prices.
+func (c *HTTPCollectorClient) GetCollectionValue(ctx context.Context, collectionID string, opts *ValuationOptions) (*dto.Valuation, error) {
+	c.Log.Info("Get collection value", zap.String("method", "HTTPCollectorClient.GetCollectionValue"), zap.String("collection_id", collectionID))
+
+	var resp dto.Valuation
+	path := withQuery(fmt.Sprintf("/collections/%s/value", collectionID), opts.values())
+	if err := c.do(ctx, http.MethodGet, path, nil, http.StatusOK, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// GetUserValue returns the value of all collections of the user at the latest prices.
+func (c *HTTPCollectorClient) GetUserValue(ctx context.Context, opts *ValuationOptions) (*dto.Valuation, error) {
+	c.Log.Info("Get user's collections value", zap.String("method", "HTTPCollectorClient.GetUserValue"))
+
+	var resp dto.Valuation
+	if err := c.do(ctx, http.MethodGet, withQuery("/collections/value", opts.values()), nil, http.StatusOK, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// GetCollectionValueHistory returns daily values of the collection. Zero from and to are the server defaults.
+func (c *HTTPCollectorClient) GetCollectionValueHistory(ctx context.Context, collectionID string, from, to time.Time) ([]dto.ValuePoint, error) {
+	c.Log.Info("Get collection value history", zap.String("method", "HTTPCollectorClient.GetCollectionValueHistory"),
+		zap.String("collection_id", collectionID))
+
+	var resp []dto.ValuePoint
+	path := withQuery(fmt.Sprintf("/collections/%s/value/history", collectionID), dateRangeValues(from, to))
+	if err := c.do(ctx, http.MethodGet, path, nil, http.StatusOK, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetUserValueHistory returns daily totals of the user's collections. Zero from and to are the server defaults.
+func (c *HTTPCollectorClient) GetUserValueHistory(ctx context.Context, from, to time.Time) ([]dto.ValuePoint, error) {
+	c.Log.Info("Get user's value history", zap.String("method", "HTTPCollectorClient.GetUserValueHistory"))
+
+	var resp []dto.ValuePoint
+	path := withQuery("/collections/value/history", dateRangeValues(from, to))
+	if err := c.do(ctx, http.MethodGet, path, nil, http.StatusOK, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *HTTPCollectorClient) GetCollectionStats(ctx context.Context, collectionID string) (*dto.CollectionStats, error) {
+	c.Log.Info("Get collection stats", zap.String("method", "HTTPCollectorClient.GetCollectionStats"), zap.String("collection_id", collectionID))
+
+	var resp dto.CollectionStats
+	if err := c.do(ctx, http.MethodGet, "/collections/"+collectionID+"/stats", nil, http.StatusOK, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *HTTPCollectorClient) GetUserStats(ctx context.Context) (*dto.CollectionStats, error) {
+	c.Log.Info("Get user's stats", zap.String("method", "HTTPCollectorClient.GetUserStats"))
+
+	var resp dto.CollectionStats
+	if err := c.do(ctx, http.MethodGet, "/collections/stats", nil, http.StatusOK, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *HTTPCollectorClient) ListTrash(ctx context.Context) (*dto.Trash, error) {
+	c.Log.Info("List trash", zap.String("method", "HTTPCollectorClient.ListTrash"))
+
+	var resp dto.Trash
+	if err := c.do(ctx, http.MethodGet, "/trash", nil, http.StatusOK, &resp); err != nil {
+		return nil, err
 	}
 
+	return &resp, nil
+}
+
+func (c *HTTPCollectorClient) RestoreCollection(ctx context.Context, collectionID string) (*dto.Collection, error) {
+	c.Log.Info("Restore collection", zap.String("method", "HTTPCollectorClient.RestoreCollection"), zap.String("collection_id", collectionID))
+
 	var collection dto.Collection
-	err = json.NewDecoder(resp.Body).Decode(&collection)
-	if err != nil {
-		c.Log.Error("Failed to decode a body request", zap.Error(err))
+	if err := c.do(ctx, http.MethodPost, "/trash/collections/"+collectionID+"/restore", nil, http.StatusOK, &collection); err != nil {
 		return nil, err
 	}
 
 	return &collection, nil
 }
 
-func (c *HTTPCollectorClient) ListCardsInCollection(ctx context.Context, collectionID string) ([]dto.Card, error) {
-	token, ok := authctx.GetJWT(ctx)
-	if !ok || token == "" {
-		c.Log.Error("Authorization token is missing")
-		return nil, fmt.Errorf("authorization token is missing")
+func (c *HTTPCollectorClient) RestoreCard(ctx context.Context, collectionID string, entryID string) (*dto.Card, error) {
+	c.Log.Info("Restore card", zap.String("method", "HTTPCollectorClient.RestoreCard"),
+		zap.String("collection_id", collectionID), zap.String("entry_id", entryID))
+
+	var card dto.Card
+	path := fmt.Sprintf("/trash/collections/%s/cards/%s/restore", collectionID, entryID)
+	if err := c.do(ctx, http.MethodPost, path, nil, http.StatusOK, &card); err != nil {
+		return nil, err
 	}
 
-	c.Log.Info("Get cards from collection", zap.String("method", "HTTPCollectorClient.ListCardsInCollection"), zap.String("token_auth", token), zap.String("collection_id", collectionID))
+	return &card, nil
+}
 
-	request, err := http.NewRequest(http.MethodGet, c.URL+fmt.Sprintf("/collections/%s/cards", collectionID), nil)
-	if err != nil {
-		c.Log.Error("Failed to create request", zap.Error(err))
+// GetCollectionHistory returns a page of the collection's card changes, most recent first.
+// Zero offset and limit are the server defaults.
+func (c *HTTPCollectorClient) GetCollectionHistory(ctx context.Context, collectionID string, offset, limit int) (*dto.ChangesPage, error) {
+	c.Log.Info("Get collection history", zap.String("method", "HTTPCollectorClient.GetCollectionHistory"), zap.String("collection_id", collectionID))
+
+	query := url.Values{}
+	if offset > 0 {
+		query.Set("offset", strconv.Itoa(offset))
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var page dto.ChangesPage
+	if err := c.do(ctx, http.MethodGet, withQuery("/collections/"+collectionID+"/history", query), nil, http.StatusOK, &page); err != nil {
 		return nil, err
 	}
 
-	request.Header.Set("Authorization", "Bearer "+token)
+	return &page, nil
+}
 
-	resp, err := c.ClientHTTP.Do(request)
-	if err != nil {
-		c.Log.Error("Failed to do a request", zap.Error(err))
+func (c *HTTPCollectorClient) UndoChange(ctx context.Context, collectionID, changeID string) (*dto.CollectionChange, error) {
+	c.Log.Info("Undo change", zap.String("method", "HTTPCollectorClient.UndoChange"),
+		zap.String("collection_id", collectionID), zap.String("change_id", changeID))
+
+	var undo dto.CollectionChange
+	path := fmt.Sprintf("/collections/%s/history/%s/undo", collectionID, changeID)
+	if err := c.do(ctx, http.MethodPost, path, nil, http.StatusOK, &undo); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		var errorResponse dto.ErrorResponse
-		if err := json.NewDecoder(resp.Body).Decode(&errorResponse); err != nil {
-			c.Log.Error("Failed to decode error response", zap.Error(err))
-			return nil, fmt.Errorf("failed to decode error response, status code: %d", resp.StatusCode)
-		}
-		c.Log.Error("Failed to get cards from collection", zap.String("message", errorResponse.Message))
-		return nil, fmt.Errorf("failed to get cards from collection, status code: %d", resp.StatusCode)
+	return &undo, nil
+}
+
+func (c *HTTPCollectorClient) UndoLastChanges(ctx context.Context, collectionID string, req *dto.UndoChangesRequest) (*dto.CollectionChange, error) {
+	c.Log.Info("Undo last changes", zap.String("method", "HTTPCollectorClient.UndoLastChanges"),
+		zap.String("collection_id", collectionID), zap.Int("last", req.Last))
+
+	var undo dto.CollectionChange
+	if err := c.do(ctx, http.MethodPost, "/collections/"+collectionID+"/history/undo", req, http.StatusOK, &undo); err != nil {
+		return nil, err
 	}
 
-	var cardsList []dto.Card
-	err = json.NewDecoder(resp.Body).Decode(&cardsList)
-	if err != nil {
-		c.Log.Error("Failed to decode a body request", zap.Error(err))
+	return &undo, nil
+}
+
+func (c *HTTPCollectorClient) CreateSnapshot(ctx context.Context, collectionID string, req *dto.CreateSnapshotRequest) (*dto.Snapshot, error) {
+	c.Log.Info("Create snapshot", zap.String("method", "HTTPCollectorClient.CreateSnapshot"),
+		zap.String("collection_id", collectionID), zap.String("label", req.Label))
+
+	var snapshot dto.Snapshot
+	if err := c.do(ctx, http.MethodPost, "/collections/"+collectionID+"/snapshots", req, http.StatusCreated, &snapshot); err != nil {
 		return nil, err
 	}
 
-	return cardsList, nil
+	return &snapshot, nil
 }
 
-func (c *HTTPCollectorClient) AddCardToCollection(ctx context.Context, collectionID string, card *dto.Card) error {
-	token, ok := authctx.GetJWT(ctx)
-	if !ok || token == "" {
-		c.Log.Error("Authorization token is missing")
-		return fmt.Errorf("authorization token is missing")
+// ListSnapshots returns the collection's snapshots without their cards, most recent first.
+func (c *HTTPCollectorClient) ListSnapshots(ctx context.Context, collectionID string) ([]dto.Snapshot, error) {
+	c.Log.Info("List snapshots", zap.String("method", "HTTPCollectorClient.ListSnapshots"), zap.String("collection_id", collectionID))
+
+	var snapshots []dto.Snapshot
+	if err := c.do(ctx, http.MethodGet, "/collections/"+collectionID+"/snapshots", nil, http.StatusOK, &snapshots); err != nil {
+		return nil, err
 	}
 
-	c.Log.Info("Add card to collection", zap.String("method", "HTTPCollectorClient.AddCardToCollection"), zap.String("token_auth", token), zap.String("collection_id", collectionID))
+	return snapshots, nil
+}
 
-	body, err := json.Marshal(card)
-	if err != nil {
-		c.Log.Error("Failed to marshal card data", zap.Error(err))
-		return err
+func (c *HTTPCollectorClient) GetSnapshot(ctx context.Context, collectionID, snapshotID string) (*dto.Snapshot, error) {
+	c.Log.Info("Get snapshot", zap.String("method", "HTTPCollectorClient.GetSnapshot"),
+		zap.String("collection_id", collectionID), zap.String("snapshot_id", snapshotID))
+
+	var snapshot dto.Snapshot
+	path := fmt.Sprintf("/collections/%s/snapshots/%s", collectionID, snapshotID)
+	if err := c.do(ctx, http.MethodGet, path, nil, http.StatusOK, &snapshot); err != nil {
+		return nil, err
 	}
 
-	request, err := http.NewRequest(http.MethodPost, c.URL+fmt.Sprintf("/collections/%s/cards", collectionID), bytes.NewBuffer(body))
-	if err != nil {
-		c.Log.Error("Failed to create request", zap.Error(err))
-		return err
+	return &snapshot, nil
+}
+
+func (c *HTTPCollectorClient) DeleteSnapshot(ctx context.Context, collectionID, snapshotID string) error {
+	c.Log.Info("Delete snapshot", zap.String("method", "HTTPCollectorClient.DeleteSnapshot"),
+		zap.String("collection_id", collectionID), zap.String("snapshot_id", snapshotID))
+
+	path := fmt.Sprintf("/collections/%s/snapshots/%s", collectionID, snapshotID)
+	return c.do(ctx, http.MethodDelete, path, nil, http.StatusNoContent, nil)
+}
+
+// DiffSnapshot compares the snapshot to another snapshot of the collection, or to
+// the live collection when toSnapshotID is empty.
+func (c *HTTPCollectorClient) DiffSnapshot(ctx context.Context, collectionID, snapshotID, toSnapshotID string) (*dto.SnapshotDiff, error) {
+	c.Log.Info("Diff snapshot", zap.String("method", "HTTPCollectorClient.DiffSnapshot"),
+		zap.String("collection_id", collectionID), zap.String("snapshot_id", snapshotID), zap.String("to", toSnapshotID))
+
+	query := url.Values{}
+	if toSnapshotID != "" {
+		query.Set("to", toSnapshotID)
 	}
 
-	request.Header.Set("Authorization", "Bearer "+token)
+	var diff dto.SnapshotDiff
+	path := fmt.Sprintf("/collections/%s/snapshots/%s/diff", collectionID, snapshotID)
+	if err := c.do(ctx, http.MethodGet, withQuery(path, query), nil, http.StatusOK, &diff); err != nil {
+		return nil, err
+	}
 
-	resp, err := c.ClientHTTP.Do(request)
-	if err != nil {
-		c.Log.Error("Failed to do a request", zap.Error(err))
-		return err
+	return &diff, nil
+}
+
+func (c *HTTPCollectorClient) RestoreSnapshot(ctx context.Context, collectionID, snapshotID string) error {
+	c.Log.Info("Restore snapshot", zap.String("method", "HTTPCollectorClient.RestoreSnapshot"),
+		zap.String("collection_id", collectionID), zap.String("snapshot_id", snapshotID))
+
+	path := fmt.Sprintf("/collections/%s/snapshots/%s/restore", collectionID, snapshotID)
+	return c.do(ctx, http.MethodPost, path, nil, http.StatusNoContent, nil)
+}
+
+func (c *HTTPCollectorClient) ListGroups(ctx context.Context) ([]dto.Group, error) {
+	c.Log.Info("List groups", zap.String("method", "HTTPCollectorClient.ListGroups"))
+
+	var groups []dto.Group
+	if err := c.do(ctx, http.MethodGet, "/groups", nil, http.StatusOK, &groups); err != nil {
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusCreated {
-		var errorResponse dto.ErrorResponse
-		if err := json.NewDecoder(resp.Body).Decode(&errorResponse); err != nil {
-			c.Log.Error("Failed to decode error response", zap.Error(err))
-			return fmt.Errorf("failed to decode error response, status code: %d", resp.StatusCode)
-		}
-		c.Log.Error("Failed to add card to collection", zap.String("message", errorResponse.Message))
-		return fmt.Errorf("failed to add card to collection, status code: %d", resp.StatusCode)
+	return groups, nil
+}
+
+func (c *HTTPCollectorClient) CreateGroup(ctx context.Context, req *dto.CreateGroupRequest) (*dto.Group, error) {
+	c.Log.Info("Create group", zap.String("method", "HTTPCollectorClient.CreateGroup"), zap.String("name", req.Name))
+
+	var group dto.Group
+	if err := c.do(ctx, http.MethodPost, "/groups", req, http.StatusCreated, &group); err != nil {
+		return nil, err
 	}
 
-	return nil
+	return &group, nil
 }
 
-func (c *HTTPCollectorClient) SetCardCountInCollection(ctx context.Context, collectionID string, card *dto.Card) error {
-	token, ok := authctx.GetJWT(ctx)
-	if !ok || token == "" {
-		c.Log.Error("Authorization token is missing")
-		return fmt.Errorf("authorization token is missing")
+func (c *HTTPCollectorClient) JoinGroup(ctx context.Context, req *dto.JoinGroupRequest) (*dto.Group, error) {
+	c.Log.Info("Join group", zap.String("method", "HTTPCollectorClient.JoinGroup"))
+
+	var group dto.Group
+	if err := c.do(ctx, http.MethodPost, "/groups/join", req, http.StatusOK, &group); err != nil {
+		return nil, err
 	}
 
-	c.Log.Info("Set card's count in collection", zap.String("method", "HTTPCollectorClient.SetCardCountInCollection"), zap.String("token_auth", token), zap.String("collection_id", collectionID))
+	return &group, nil
+}
 
-	request, err := http.NewRequest(http.MethodPatch, c.URL+fmt.Sprintf("/collections/%s/cards/%s", collectionID, card.ScryfallID), nil)
-	if err != nil {
-		c.Log.Error("Failed to create request", zap.Error(err))
-		return err
+func (c *HTTPCollectorClient) LeaveGroup(ctx context.Context, groupID string) error {
+	c.Log.Info("Leave group", zap.String("method", "HTTPCollectorClient.LeaveGroup"), zap.String("group_id", groupID))
+
+	return c.do(ctx, http.MethodPost, "/groups/"+groupID+"/leave", nil, http.StatusNoContent, nil)
+}
+
+// ListTradeMatches returns a page of trade matches with members of the user's groups.
+// Zero offset and limit are the server defaults.
+func (c *HTTPCollectorClient) ListTradeMatches(ctx context.Context, offset, limit int) (*dto.TradeMatchesPage, error) {
+	c.Log.Info("List trade matches", zap.String("method", "HTTPCollectorClient.ListTradeMatches"))
+
+	query := url.Values{}
+	if offset > 0 {
+		query.Set("offset", strconv.Itoa(offset))
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
 	}
 
-	request.Header.Set("Authorization", "Bearer "+token)
+	var page dto.TradeMatchesPage
+	if err := c.do(ctx, http.MethodGet, withQuery("/trades/matches", query), nil, http.StatusOK, &page); err != nil {
+		return nil, err
+	}
 
-	resp, err := c.ClientHTTP.Do(request)
+	return &page, nil
+}
+
+func (o *ValuationOptions) values() url.Values {
+	query := url.Values{}
+	if o == nil {
+		return query
+	}
+	if o.Currency != "" {
+		query.Set("currency", o.Currency)
+	}
+	if o.Top > 0 {
+		query.Set("top", strconv.Itoa(o.Top))
+	}
+	if !o.Since.IsZero() {
+		query.Set("since", o.Since.Format(time.DateOnly))
+	}
+	return query
+}
+
+func dateRangeValues(from, to time.Time) url.Values {
+	query := url.Values{}
+	if !from.IsZero() {
+		query.Set("from", from.Format(time.DateOnly))
+	}
+	if !to.IsZero() {
+		query.Set("to", to.Format(time.DateOnly))
+	}
+	return query
+}
+
+func withQuery(path string, query url.Values) string {
+	if len(query) == 0 {
+		return path
+	}
+	return path + "?" + query.Encode()
+}
+
+// do sends an authorized request with reqBody encoded as JSON and decodes
+// the response into out when the service answers with wantStatus.
+// Need JWT token for this opperation
+func (c *HTTPCollectorClient) do(ctx context.Context, method, path string, reqBody any, wantStatus int, out any) error {
+	var body io.Reader
+	contentType := ""
+	if reqBody != nil {
+		data, err := json.Marshal(reqBody)
+		if err != nil {
+			c.Log.Error("Failed to marshal request data", zap.Error(err))
+			return err
+		}
+		body = bytes.NewBuffer(data)
+		contentType = "application/json"
+	}
+
+	resp, err := c.send(ctx, method, path, body, contentType, wantStatus)
 	if err != nil {
-		c.Log.Error("Failed to do a request", zap.Error(err))
 		return err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusNoContent {
-		var errorResponse dto.ErrorResponse
-		if err := json.NewDecoder(resp.Body).Decode(&errorResponse); err != nil {
-			c.Log.Error("Failed to decode error response", zap.Error(err))
-			return fmt.Errorf("failed to decode error response, status code: %d", resp.StatusCode)
-		}
-		c.Log.Error("Failed to set card's count in collection", zap.String("message", errorResponse.Message))
-		return fmt.Errorf("failed to set card's count in collection, status code: %d", resp.StatusCode)
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		c.Log.Error("Failed to decode a response body", zap.Error(err))
+		return err
 	}
 
 	return nil
 }
 
-func (c *HTTPCollectorClient) DeleteCardFromCollection(ctx context.Context, collectionID string, cardIdScryfall string) error {
+// send does an authorized request and checks the response status. The caller
+// closes the body of the returned response. The preconditions of ctx are sent
+// as If-Match and If-None-Match headers.
+func (c *HTTPCollectorClient) send(ctx context.Context, method, path string, body io.Reader, contentType string, wantStatus int) (*http.Response, error) {
 	token, ok := authctx.GetJWT(ctx)
 	if !ok || token == "" {
 		c.Log.Error("Authorization token is missing")
-		return fmt.Errorf("authorization token is missing")
+		return nil, fmt.Errorf("authorization token is missing")
 	}
 
-	c.Log.Info("Delete card from collection", zap.String("method", "HTTPCollectorClient.DeleteCardFromCollection"),
-		zap.String("token_auth", token), zap.String("collection_id", collectionID), zap.String("card_id_scryfall", cardIdScryfall))
-
-	request, err := http.NewRequest(http.MethodGet, c.URL+fmt.Sprintf("/collections/%s/cards/%s", collectionID, cardIdScryfall), nil)
+	request, err := http.NewRequestWithContext(ctx, method, c.URL+path, body)
 	if err != nil {
 		c.Log.Error("Failed to create request", zap.Error(err))
-		return err
+		return nil, err
 	}
 
 	request.Header.Set("Authorization", "Bearer "+token)
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+	if version, ok := ctx.Value(ifMatchKey).(int64); ok {
+		request.Header.Set("If-Match", versionETag(version))
+	}
+	if version, ok := ctx.Value(ifNoneMatchKey).(int64); ok {
+		request.Header.Set("If-None-Match", versionETag(version))
+	}
 
 	resp, err := c.ClientHTTP.Do(request)
 	if err != nil {
 		c.Log.Error("Failed to do a request", zap.Error(err))
-		return err
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && wantStatus != http.StatusNotModified {
+		resp.Body.Close()
+		return nil, errNotModified
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusNoContent {
+	if resp.StatusCode != wantStatus {
+		defer resp.Body.Close()
 		var errorResponse dto.ErrorResponse
 		if err := json.NewDecoder(resp.Body).Decode(&errorResponse); err != nil {
 			c.Log.Error("Failed to decode error response", zap.Error(err))
-			return fmt.Errorf("failed to decode error response, status code: %d", resp.StatusCode)
+			return nil, fmt.Errorf("failed to decode error response, status code: %d", resp.StatusCode)
+		}
+		c.Log.Error("Request to collector service failed", zap.String("path", path), zap.String("message", errorResponse.Message))
+		if resp.StatusCode == http.StatusPreconditionFailed {
+			return nil, fmt.Errorf("%s %s: %w", method, path, ErrCollectionChanged)
 		}
-		c.Log.Error("Failed to delete card from collection", zap.String("message", errorResponse.Message))
-		return fmt.Errorf("failed to delete card from collection, status code: %d", resp.StatusCode)
+		return nil, fmt.Errorf("%s %s failed, status code: %d", method, path, resp.StatusCode)
 	}
 
-	return nil
+	return resp, nil
+}
+
+func versionETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
 }
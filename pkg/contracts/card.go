@@ -1,13 +1,17 @@
 package dto
 
 // CreateCardRequest — запрос для добавления новой карты в коллекцию
-// @Description Запрос для добавления карты с Scryfall ID, именем и URL изображения
-// @example { "scryfall_id": "12345678-1234-1234-1234-123456789012", "name": "Black Lotus", "card_url": "https://example.com/black-lotus.jpg", "count": 1 }
+// @Description Запрос для добавления карты по Scryfall ID. Имя, изображение, сет, редкость и тип карты заполняются из Scryfall
+// @example { "scryfall_id": "12345678-1234-1234-1234-123456789012", "count": 1, "zone": "main", "finish": "foil", "condition": "NM", "language": "en" }
 type AddCardRequest struct {
 	ScryfallID string `json:"scryfall_id" binding:"required" example:"12345678-1234-1234-1234-123456789012"`
-	Name       string `json:"name" binding:"required" example:"Black Lotus"`
-	CardUrl    string `json:"card_url" binding:"required" example:"https://example.com/black-lotus.jpg"`
+	Name       string `json:"name,omitempty" example:"Black Lotus"`                             // берётся из Scryfall, используется только если Scryfall недоступен
+	CardUrl    string `json:"card_url,omitempty" example:"https://example.com/black-lotus.jpg"` // берётся из Scryfall, используется только если Scryfall недоступен
 	Count      int    `json:"count" binding:"required" example:"1"`
+	Zone       string `json:"zone,omitempty" example:"main"`    // main, side, maybe или commander; по умолчанию main
+	Finish     string `json:"finish,omitempty" example:"foil"`  // nonfoil, foil или etched; по умолчанию nonfoil
+	Condition  string `json:"condition,omitempty" example:"NM"` // NM, LP, MP, HP или DMG; по умолчанию NM
+	Language   string `json:"language,omitempty" example:"en"`  // код языка Scryfall; по умолчанию en
 }
 
 // SetCardCountRequest — запрос для изменения количества карт в коллекции
@@ -19,11 +23,110 @@ type SetCardsCountRequest struct {
 }
 
 // Card - модель карты в ответах
-// @Description Модель карты с Scryfall ID, именем, URL изображения и количеством
-// @example { "scryfall_id": "12345678-1234-1234-1234-123456789012", "name": "Black Lotus", "card_url": "https://example.com/black-lotus.jpg", "count": 1 }
+// @Description Модель записи карты: ID записи, Scryfall ID, имя, URL изображения, количество, вариант, сет, редкость и тип
+// @example { "id": "64a9b66b2db8b91234a6e8e4", "scryfall_id": "12345678-1234-1234-1234-123456789012", "name": "Black Lotus", "card_url": "https://example.com/black-lotus.jpg", "count": 1, "zone": "main", "finish": "foil", "condition": "NM", "language": "en", "set_code": "lea", "rarity": "rare", "type_line": "Artifact" }
 type Card struct {
+	ID         string `json:"id,omitempty" example:"64a9b66b2db8b91234a6e8e4"`
 	ScryfallID string `json:"scryfall_id" example:"12345678-1234-1234-1234-123456789012"`
 	Name       string `json:"name" example:"Black Lotus"`
 	CardUrl    string `json:"card_url" example:"https://example.com/black-lotus.jpg"`
 	Count      int    `json:"count" example:"1"`
+	Zone       string `json:"zone,omitempty" example:"main"`
+	Finish     string `json:"finish,omitempty" example:"foil"`
+	Condition  string `json:"condition,omitempty" example:"NM"`
+	Language   string `json:"language,omitempty" example:"en"`
+	SetCode    string `json:"set_code,omitempty" example:"lea"`
+	Rarity     string `json:"rarity,omitempty" example:"rare"`
+	TypeLine   string `json:"type_line,omitempty" example:"Artifact"`
+}
+
+// CardsPage — страница карт коллекции
+// @Description Страница записей карт коллекции с метаданными пагинации
+type CardsPage struct {
+	Cards      []Card     `json:"cards"`
+	Pagination Pagination `json:"pagination"`
+}
+
+// Pagination — метаданные пагинации
+// @Description Общее число записей, подходящих под фильтры, смещение и размер страницы
+// @example { "total": 120, "offset": 50, "limit": 50 }
+type Pagination struct {
+	Total  int `json:"total" example:"120"`
+	Offset int `json:"offset" example:"50"`
+	Limit  int `json:"limit" example:"50"`
+}
+
+// AdjustCardCountRequest — запрос для изменения количества копий записи карты
+// @Description Запрос для добавления (delta > 0) или удаления (delta < 0) копий записи карты
+// @example { "delta": -1 }
+type AdjustCardCountRequest struct {
+	Delta int `json:"delta" binding:"required" example:"-1"`
+}
+
+// MoveCardRequest — запрос для перемещения копий записи карты в другую зону колоды
+// @Description Запрос для перемещения карт между main, side, maybe и commander
+// @example { "to_zone": "side", "count": 2 }
+type MoveCardRequest struct {
+	ToZone string `json:"to_zone" binding:"required" example:"side"`
+	Count  int    `json:"count" binding:"required" example:"2"`
+}
+
+// TransferCardRequest — запрос для переноса копий карты в другую коллекцию
+// @Description Запрос для переноса копий записи карты в другую коллекцию пользователя
+// @example { "to_collection_id": "64a9b66b2db8b91234a6e8e5", "count": 3 }
+type TransferCardRequest struct {
+	ToCollectionID string `json:"to_collection_id" binding:"required" example:"64a9b66b2db8b91234a6e8e5"`
+	Count          int    `json:"count" binding:"required" example:"3"`
+}
+
+// TransferCardsRequest — запрос для переноса нескольких карт в другую коллекцию
+// @Description Запрос для переноса нескольких записей карт в другую коллекцию пользователя одной транзакцией
+// @example { "to_collection_id": "64a9b66b2db8b91234a6e8e5", "items": [{ "entry_id": "64a9b66b2db8b91234a6e8e4", "count": 3 }] }
+type TransferCardsRequest struct {
+	ToCollectionID string             `json:"to_collection_id" binding:"required" example:"64a9b66b2db8b91234a6e8e5"`
+	Items          []TransferCardItem `json:"items" binding:"required,min=1,dive"`
+}
+
+// TransferCardItem — запись карты и количество копий для переноса
+type TransferCardItem struct {
+	EntryID string `json:"entry_id" binding:"required" example:"64a9b66b2db8b91234a6e8e4"`
+	Count   int    `json:"count" binding:"required" example:"3"`
+}
+
+// CardBatchRequest — пакет операций над картами коллекции
+// @Description Операции add, set_count и delete, применяемые одной транзакцией. В режиме atomic (по умолчанию) пакет применяется целиком или не применяется, в режиме best_effort ошибочные операции пропускаются
+// @example { "mode": "atomic", "operations": [{ "op": "add", "scryfall_id": "12345678-1234-1234-1234-123456789012", "name": "Black Lotus", "card_url": "https://example.com/black-lotus.jpg", "count": 1 }, { "op": "delete", "entry_id": "64a9b66b2db8b91234a6e8e4" }] }
+type CardBatchRequest struct {
+	Mode       string                 `json:"mode,omitempty" example:"atomic"` // atomic или best_effort
+	Operations []CardOperationRequest `json:"operations" binding:"required,dive"`
+}
+
+// CardOperationRequest — операция пакета
+// @Description Для add задаются карта и её вариант, для set_count и delete — ID записи, для set_count ещё и новое количество
+type CardOperationRequest struct {
+	Op         string `json:"op" binding:"required" example:"add"` // add, set_count или delete
+	EntryID    string `json:"entry_id,omitempty" example:"64a9b66b2db8b91234a6e8e4"`
+	ScryfallID string `json:"scryfall_id,omitempty" example:"12345678-1234-1234-1234-123456789012"`
+	Name       string `json:"name,omitempty" example:"Black Lotus"`
+	CardUrl    string `json:"card_url,omitempty" example:"https://example.com/black-lotus.jpg"`
+	Count      int    `json:"count,omitempty" example:"1"`
+	Zone       string `json:"zone,omitempty" example:"main"`
+	Finish     string `json:"finish,omitempty" example:"nonfoil"`
+	Condition  string `json:"condition,omitempty" example:"NM"`
+	Language   string `json:"language,omitempty" example:"en"`
+}
+
+// CardBatchResponse — результаты пакета операций
+// @Description Результат для каждой операции в порядке запроса. applied = false, если atomic пакет отклонён
+type CardBatchResponse struct {
+	Applied bool                  `json:"applied" example:"true"`
+	Results []CardOperationResult `json:"results"`
+}
+
+// CardOperationResult — результат операции пакета
+// @Description HTTP статус операции, запись карты после операции (count = 0 для удалённой) или ошибка
+type CardOperationResult struct {
+	Status int    `json:"status" example:"200"`
+	Card   *Card  `json:"card,omitempty"`
+	Error  string `json:"error,omitempty"`
 }
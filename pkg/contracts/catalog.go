@@ -0,0 +1,22 @@
+package dto
+
+// CatalogCard — печать карты из каталога
+// @Description Печать карты из офлайн-каталога, собранного из bulk-данных Scryfall
+// @example { "scryfall_id": "e3285e6b-3e79-4d7c-bf96-d920f973b80d", "oracle_id": "4457ed35-7c10-48c8-9776-456485fdf070", "name": "Lightning Bolt", "lang": "en", "set_code": "m10", "set_name": "Magic 2010", "collector_number": "146", "rarity": "common", "type_line": "Instant", "mana_cost": "{R}", "cmc": 1, "colors": ["R"], "color_identity": ["R"], "image_uri": "https://cards.scryfall.io/normal/front/e/3/bolt.jpg", "legalities": { "modern": "legal", "standard": "not_legal" } }
+type CatalogCard struct {
+	ScryfallID      string            `json:"scryfall_id" example:"e3285e6b-3e79-4d7c-bf96-d920f973b80d"`
+	OracleID        string            `json:"oracle_id,omitempty" example:"4457ed35-7c10-48c8-9776-456485fdf070"`
+	Name            string            `json:"name" example:"Lightning Bolt"`
+	Lang            string            `json:"lang,omitempty" example:"en"`
+	SetCode         string            `json:"set_code" example:"m10"`
+	SetName         string            `json:"set_name,omitempty" example:"Magic 2010"`
+	CollectorNumber string            `json:"collector_number" example:"146"`
+	Rarity          string            `json:"rarity,omitempty" example:"common"`
+	TypeLine        string            `json:"type_line,omitempty" example:"Instant"`
+	ManaCost        string            `json:"mana_cost,omitempty" example:"{R}"`
+	CMC             float64           `json:"cmc" example:"1"`
+	Colors          []string          `json:"colors,omitempty"`
+	ColorIdentity   []string          `json:"color_identity,omitempty"`
+	ImageURI        string            `json:"image_uri,omitempty" example:"https://cards.scryfall.io/normal/front/e/3/bolt.jpg"`
+	Legalities      map[string]string `json:"legalities,omitempty"` // формат → legal, not_legal, restricted или banned
+}
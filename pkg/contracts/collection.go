@@ -1,10 +1,18 @@
 package dto
 
 // CreateCollectionRequest — запрос для создания новой коллекции
-// @Description Запрос для создания коллекции с указанным именем
-// @example { "name": "My cool collection" }
+// @Description Запрос для создания коллекции с указанным именем и видом
+// @example { "name": "My cool collection", "kind": "deck" }
 type CreateCollectionRequest struct {
 	Name string `json:"name" binding:"required" example:"My cool collection"`
+	Kind string `json:"kind,omitempty" example:"deck"` // binder, deck, wishlist или trade; по умолчанию binder
+}
+
+// SetCollectionKindRequest — запрос для изменения вида коллекции
+// @Description Вид коллекции: binder (карты пользователя), deck (колода), wishlist (список желаемого) или trade (карты на обмен)
+// @example { "kind": "wishlist" }
+type SetCollectionKindRequest struct {
+	Kind string `json:"kind" binding:"required" example:"wishlist"`
 }
 
 // RenameCollectionRequest — запрос для переименования коллекции
@@ -15,9 +23,27 @@ type RenameCollectionRequest struct {
 }
 
 // Collection — модель коллекции в ответах
-// @Description Модель коллекции с ID и именем
-// @example { "id": "64a9b66b2db8b91234a6e8e3", "name": "My cool collection" }
+// @Description Модель коллекции с ID, именем и видом
+// @example { "id": "64a9b66b2db8b91234a6e8e3", "name": "My cool collection", "kind": "binder", "version": 7 }
 type Collection struct {
-	ID   string `json:"id" example:"64a9b66b2db8b91234a6e8e3"`
-	Name string `json:"name" example:"My cool collection"`
+	ID      string `json:"id" example:"64a9b66b2db8b91234a6e8e3"`
+	Name    string `json:"name" example:"My cool collection"`
+	Kind    string `json:"kind,omitempty" example:"binder"` // в списке коллекций пользователя не заполняется
+	Version int64  `json:"version,omitempty" example:"7"`   // версия для If-Match, ETag коллекции; в списке коллекций пользователя не заполняется
+}
+
+// CloneCollectionRequest — запрос для копирования коллекции
+// @Description Запрос для копирования коллекции вместе с картами под новым именем
+// @example { "name": "Burn before changes" }
+type CloneCollectionRequest struct {
+	Name string `json:"name" binding:"required" example:"Burn before changes"`
+}
+
+// MergeCollectionsRequest — запрос для слияния коллекций
+// @Description Слить карты коллекции-источника в текущую. strategy: sum, max или keep_target
+// @example { "source_collection_id": "64a9b66b2db8b91234a6e8e5", "strategy": "sum", "delete_source": false }
+type MergeCollectionsRequest struct {
+	SourceCollectionID string `json:"source_collection_id" binding:"required" example:"64a9b66b2db8b91234a6e8e5"`
+	Strategy           string `json:"strategy,omitempty" example:"sum"` // по умолчанию sum
+	DeleteSource       bool   `json:"delete_source,omitempty" example:"false"`
 }
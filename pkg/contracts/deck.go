@@ -0,0 +1,57 @@
+package dto
+
+// DeckValidation — результат проверки коллекции как колоды формата
+// @Description Легальность колоды в формате: размер колоды и сайдборда и список нарушений. Карты из maybe не проверяются
+// @example { "format": "modern", "legal": false, "deck_size": 58, "sideboard": 15, "violations": [{ "kind": "deck_too_small", "count": 58, "limit": 60, "message": "Deck has 58 cards, modern needs at least 60" }, { "kind": "banned", "card": "Mental Misstep", "count": 1, "message": "Mental Misstep is banned in modern" }] }
+type DeckValidation struct {
+	Format     string          `json:"format" example:"modern"`
+	Legal      bool            `json:"legal" example:"false"`
+	DeckSize   int             `json:"deck_size" example:"58"` // основная колода вместе с командирами
+	Sideboard  int             `json:"sideboard" example:"15"`
+	Violations []DeckViolation `json:"violations"`
+}
+
+// DeckViolation — нарушение правил формата
+// @Description Вид нарушения, карта (пусто для правил всей колоды), количество и допустимый предел
+// @example { "kind": "too_many_copies", "card": "Lightning Bolt", "count": 5, "limit": 4, "message": "Deck has 5 copies of Lightning Bolt, modern allows 4" }
+type DeckViolation struct {
+	// banned, not_legal, restricted, too_many_copies, deck_too_small, deck_too_large,
+	// sideboard_too_large, missing_commander, too_many_commanders, color_identity или unknown_card
+	Kind    string   `json:"kind" example:"too_many_copies"`
+	Card    string   `json:"card,omitempty" example:"Lightning Bolt"`
+	Count   int      `json:"count,omitempty" example:"5"`
+	Limit   int      `json:"limit,omitempty" example:"4"`
+	Colors  []string `json:"colors,omitempty"` // цвета вне цветовой идентичности командира
+	Message string   `json:"message" example:"Deck has 5 copies of Lightning Bolt, modern allows 4"`
+}
+
+// MissingReport — недостающие карты коллекции
+// @Description Сколько копий карт коллекции (колоды или списка желаемого) есть в коллекциях-источниках и сколько не хватает. Подходит любой выпуск карты, карты из maybe не учитываются
+// @example { "collection_id": "64a9b66b2db8b91234a6e8e3", "sources": [{ "id": "64a9b66b2db8b91234a6e8e4", "name": "Binder" }], "needed": 60, "owned": 52, "missing": 8, "entries": [] }
+type MissingReport struct {
+	CollectionID string              `json:"collection_id" example:"64a9b66b2db8b91234a6e8e3"`
+	Sources      []Collection        `json:"sources"` // по умолчанию все binder-коллекции пользователя
+	Needed       int                 `json:"needed" example:"60"`
+	Owned        int                 `json:"owned" example:"52"`
+	Missing      int                 `json:"missing" example:"8"`
+	Entries      []MissingCardsEntry `json:"entries"`
+}
+
+// MissingCardsEntry — недостающие копии записи карты
+// @Description Запись карты коллекции, число нужных копий, коллекции-источники с найденными копиями и число недостающих
+// @example { "card": {}, "needed": 4, "owned": [{ "collection_id": "64a9b66b2db8b91234a6e8e4", "collection_name": "Binder", "count": 3 }], "missing": 1 }
+type MissingCardsEntry struct {
+	Card    Card          `json:"card"`
+	Needed  int           `json:"needed" example:"4"`
+	Owned   []OwnedCopies `json:"owned"`
+	Missing int           `json:"missing" example:"1"`
+}
+
+// OwnedCopies — копии карты в коллекции-источнике
+// @Description Сколько копий карты из коллекции-источника засчитано записи
+// @example { "collection_id": "64a9b66b2db8b91234a6e8e4", "collection_name": "Binder", "count": 3 }
+type OwnedCopies struct {
+	CollectionID   string `json:"collection_id" example:"64a9b66b2db8b91234a6e8e4"`
+	CollectionName string `json:"collection_name" example:"Binder"`
+	Count          int    `json:"count" example:"3"`
+}
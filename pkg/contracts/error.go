@@ -6,4 +6,6 @@ package dto
 type ErrorResponse struct {
 	Message string `json:"message" example:"unauthorized"`
 	Status  int    `json:"status,omitempty"` // Optional, can be used to indicate HTTP status code
+	// Позиция ошибки в поисковом запросе, с 1, в символах. Только для синтаксических ошибок запроса
+	Position int `json:"position,omitempty" example:"5"`
 }
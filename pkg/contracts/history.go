@@ -0,0 +1,39 @@
+package dto
+
+import "time"
+
+// ChangesPage — страница истории изменений коллекции
+// @Description Изменения карт коллекции, последние первыми
+type ChangesPage struct {
+	Changes    []CollectionChange `json:"changes"`
+	Pagination Pagination         `json:"pagination"`
+}
+
+// CollectionChange — изменение карт коллекции
+// @Description Одно изменение карт: kind — add, import, set_count, delete, adjust, move, transfer, batch, merge, restore, snapshot_restore или undo. Перенос между коллекциями есть в истории обеих. undone_by — ID отменившего изменения, reverts — изменения, которые отменяет undo
+// @example { "id": "6710a0b2c3d4e5f601234567", "kind": "adjust", "user_id": "64a9b66b2db8b91234a6e8e0", "request_id": "2f1c7a9e-4b8d-4f3a-9c1e-7d2b5a6f8e90", "at": "2026-10-19T12:00:00Z", "entries": [{ "collection_id": "64a9b66b2db8b91234a6e8e3", "card": { "id": "64a9b66b2db8b91234a6e8e4", "name": "Lightning Bolt", "count": 3 }, "before": 4, "after": 3 }] }
+type CollectionChange struct {
+	ID        string        `json:"id" example:"6710a0b2c3d4e5f601234567"`
+	Kind      string        `json:"kind" example:"adjust"`
+	UserID    string        `json:"user_id" example:"64a9b66b2db8b91234a6e8e0"`
+	RequestID string        `json:"request_id,omitempty" example:"2f1c7a9e-4b8d-4f3a-9c1e-7d2b5a6f8e90"`
+	At        time.Time     `json:"at" example:"2026-10-19T12:00:00Z"`
+	Entries   []ChangeEntry `json:"entries"`
+	Reverts   []string      `json:"reverts,omitempty"`
+	UndoneBy  string        `json:"undone_by,omitempty"`
+}
+
+// ChangeEntry — изменение количества копий записи карты
+// @Description before равно 0 для добавленной записи, after равно 0 для удалённой. count карты равен after
+type ChangeEntry struct {
+	CollectionID string `json:"collection_id" example:"64a9b66b2db8b91234a6e8e3"`
+	Card         Card   `json:"card"`
+	Before       int    `json:"before" example:"4"`
+	After        int    `json:"after" example:"3"`
+}
+
+// UndoChangesRequest — отмена последних изменений
+// @Description Сколько последних ещё не отменённых изменений отменить
+type UndoChangesRequest struct {
+	Last int `json:"last" binding:"required" example:"3"`
+}
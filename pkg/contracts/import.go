@@ -0,0 +1,33 @@
+package dto
+
+// ImportDecklistRequest — запрос для импорта текстового списка карт
+// @Description Список карт в формате MTGO, Arena (с заголовками Deck/Sideboard) или простой список "4x Lightning Bolt"
+// @example { "text": "Deck\n4 Lightning Bolt (M10) 146\n\nSideboard\n2 Duress (M19) 94", "dry_run": true }
+type ImportDecklistRequest struct {
+	Text   string `json:"text" binding:"required,max=100000" example:"4 Lightning Bolt (M10) 146"` // не длиннее 100000 символов
+	DryRun bool   `json:"dry_run" example:"true"`                                                  // только показать результат, ничего не добавляя
+}
+
+// ImportResponse — результат импорта списка карт или CSV-файла
+// @Description Распознанные карты (с ID записей, если импорт не dry_run) и строки, которые не удалось импортировать
+type ImportResponse struct {
+	DryRun     bool             `json:"dry_run" example:"true"`
+	Cards      []ImportedCard   `json:"cards"`
+	Unresolved []UnresolvedLine `json:"unresolved"`
+}
+
+// ImportedCard — распознанная строка списка или CSV-файла
+// @Description Номер строки и карта, в которую она превратилась
+type ImportedCard struct {
+	Line int  `json:"line" example:"2"`
+	Card Card `json:"card"`
+}
+
+// UnresolvedLine — строка списка или CSV-файла, которую не удалось импортировать
+// @Description Номер строки, её текст и причина
+// @example { "line": 3, "text": "4 Lightning Blot", "reason": "Card not found in catalog" }
+type UnresolvedLine struct {
+	Line   int    `json:"line" example:"3"`
+	Text   string `json:"text" example:"4 Lightning Blot"`
+	Reason string `json:"reason" example:"Card not found in catalog"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+// CardSearchResult — результат поиска карт по всем коллекциям пользователя
+// @Description Найденные записи карт с коллекциями, в которых они лежат. match: scryfall_id (поиск по ID выпуска), text (по словам названия), fuzzy (по похожим названиям, с опечатками и началами слов) или query (по запросу с ключевыми словами)
+// @example { "query": "sheoldred", "match": "text", "hits": [{ "collection_id": "64a9b66b2db8b91234a6e8e3", "collection_name": "Binder", "card": {} }] }
+type CardSearchResult struct {
+	Query string          `json:"query" example:"sheoldred"`
+	Match string          `json:"match" example:"text"`
+	Hits  []CardSearchHit `json:"hits"`
+}
+
+// CardSearchHit — найденная запись карты
+// @Description Запись карты (количество копий и вариант: зона, отделка, состояние, язык) и коллекция, в которой она лежит
+// @example { "collection_id": "64a9b66b2db8b91234a6e8e3", "collection_name": "Binder", "card": {} }
+type CardSearchHit struct {
+	CollectionID   string `json:"collection_id" example:"64a9b66b2db8b91234a6e8e3"`
+	CollectionName string `json:"collection_name" example:"Binder"`
+	Card           Card   `json:"card"`
+}
@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+// Snapshot — именованный снимок карт коллекции
+// @Description Неизменяемая копия записей карт коллекции на момент created_at. entries — число записей, copies — число копий. cards возвращается только для одного снимка
+// @example { "id": "6710a0b2c3d4e5f601234570", "collection_id": "64a9b66b2db8b91234a6e8e3", "label": "Before FNM", "created_at": "2026-10-19T12:00:00Z", "entries": 2, "copies": 7 }
+type Snapshot struct {
+	ID           string    `json:"id" example:"6710a0b2c3d4e5f601234570"`
+	CollectionID string    `json:"collection_id" example:"64a9b66b2db8b91234a6e8e3"`
+	Label        string    `json:"label" example:"Before FNM"`
+	CreatedAt    time.Time `json:"created_at" example:"2026-10-19T12:00:00Z"`
+	Entries      int       `json:"entries" example:"2"`
+	Copies       int       `json:"copies" example:"7"`
+	Cards        []Card    `json:"cards,omitempty"`
+}
+
+// CreateSnapshotRequest — создание снимка коллекции
+// @Description Метка снимка, уникальная в пределах коллекции, до 100 символов
+type CreateSnapshotRequest struct {
+	Label string `json:"label" binding:"required" example:"Before FNM"`
+}
+
+// SnapshotDiff — разница между двумя версиями коллекции
+// @Description Карты, добавленные, удалённые и с изменённым количеством копий по сравнению со снимком from_snapshot_id. Если to_snapshot_id пуст, сравнение с текущим состоянием коллекции. Варианты карт сравниваются по Scryfall ID, зоне, отделке, состоянию и языку
+type SnapshotDiff struct {
+	FromSnapshotID string            `json:"from_snapshot_id" example:"6710a0b2c3d4e5f601234570"`
+	ToSnapshotID   string            `json:"to_snapshot_id,omitempty" example:"6710a0b2c3d4e5f601234571"`
+	Added          []Card            `json:"added"`
+	Removed        []Card            `json:"removed"`
+	Changed        []CardCountChange `json:"changed"`
+}
+
+// CardCountChange — изменение количества копий варианта карты
+// @Description card — вариант карты в более новой версии
+type CardCountChange struct {
+	Card   Card `json:"card"`
+	Before int  `json:"before" example:"4"`
+	After  int  `json:"after" example:"2"`
+}
@@ -0,0 +1,49 @@
+package dto
+
+import "time"
+
+// CollectionStats — статистика коллекции или всех коллекций пользователя
+// @Description Количество копий и уникальных карт, доля фольги и разбивки по цвету, редкости, сету, типу и мана-стоимости. Цвет, тип и мана-стоимость считаются только для карт из каталога; mana_curve есть только у колод
+// @example { "cards": 250, "unique": 180, "printings": 195, "foil": 25, "foil_ratio": 0.1, "multicolor": 12, "uncataloged": 3, "by_color": [{ "key": "R", "cards": 60, "unique": 40 }], "by_rarity": [], "by_set": [], "by_type": [], "by_mana_value": [], "mana_curve": [{ "mana_value": 1, "cards": 12 }], "added_per_month": [{ "month": "2026-10", "cards": 40 }], "updated_at": "2026-10-19T12:00:00Z" }
+type CollectionStats struct {
+	Cards         int           `json:"cards" example:"250"`
+	Unique        int           `json:"unique" example:"180"`    // разные карты, все выпуски карты считаются одной
+	Printings     int           `json:"printings" example:"195"` // разные выпуски
+	Foil          int           `json:"foil" example:"25"`       // копии foil и etched
+	FoilRatio     float64       `json:"foil_ratio" example:"0.1"`
+	Multicolor    int           `json:"multicolor" example:"12"`
+	Uncataloged   int           `json:"uncataloged" example:"3"` // копии выпусков, которых нет в каталоге
+	ByColor       []StatsBucket `json:"by_color"`                // W, U, B, R, G и C для бесцветных
+	ByRarity      []StatsBucket `json:"by_rarity"`
+	BySet         []StatsBucket `json:"by_set"`
+	ByType        []StatsBucket `json:"by_type"`
+	ByManaValue   []StatsBucket `json:"by_mana_value"`
+	ManaCurve     []CurveBar    `json:"mana_curve,omitempty"` // только для колод: карты кроме земель в main и commander
+	AddedPerMonth []MonthCount  `json:"added_per_month"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+}
+
+// StatsBucket — группа разбивки статистики
+// @Description Копии и уникальные карты с одним значением: цветом, редкостью, кодом сета, типом или мана-стоимостью
+// @example { "key": "R", "cards": 60, "unique": 40 }
+type StatsBucket struct {
+	Key    string `json:"key" example:"R"`
+	Cards  int    `json:"cards" example:"60"`
+	Unique int    `json:"unique" example:"40"`
+}
+
+// CurveBar — столбец кривой маны
+// @Description Копии с мана-стоимостью mana_value; последний столбец, 7, включает всё дороже
+// @example { "mana_value": 1, "cards": 12 }
+type CurveBar struct {
+	ManaValue int `json:"mana_value" example:"1"`
+	Cards     int `json:"cards" example:"12"`
+}
+
+// MonthCount — копии, добавленные за месяц
+// @Description Месяц в формате YYYY-MM и число добавленных за него копий
+// @example { "month": "2026-10", "cards": 40 }
+type MonthCount struct {
+	Month string `json:"month" example:"2026-10"`
+	Cards int    `json:"cards" example:"40"`
+}
@@ -0,0 +1,62 @@
+package dto
+
+import "time"
+
+// CreateGroupRequest — запрос для создания группы
+// @Description Название новой группы игроков. Создатель становится её первым участником
+// @example { "name": "Friday Night Magic" }
+type CreateGroupRequest struct {
+	Name string `json:"name" binding:"required" example:"Friday Night Magic"`
+}
+
+// JoinGroupRequest — запрос для вступления в группу
+// @Description Код приглашения группы
+// @example { "invite_code": "K3ZQ7M2A" }
+type JoinGroupRequest struct {
+	InviteCode string `json:"invite_code" binding:"required" example:"K3ZQ7M2A"`
+}
+
+// Group — группа игроков
+// @Description Группа игроков, которые обмениваются картами. Участники группы видят предложения обмена друг с другом
+// @example { "id": "64a9b66b2db8b91234a6e8f0", "name": "Friday Night Magic", "owner_id": "64a9b66b2db8b91234a6e8e0", "invite_code": "K3ZQ7M2A", "members": ["64a9b66b2db8b91234a6e8e0"] }
+type Group struct {
+	ID         string   `json:"id" example:"64a9b66b2db8b91234a6e8f0"`
+	Name       string   `json:"name" example:"Friday Night Magic"`
+	OwnerID    string   `json:"owner_id" example:"64a9b66b2db8b91234a6e8e0"`
+	InviteCode string   `json:"invite_code" example:"K3ZQ7M2A"`
+	Members    []string `json:"members"`
+}
+
+// TradeMatchesPage — страница предложений обмена
+// @Description Предложения обмена с участниками общих групп, самые равноценные первыми
+type TradeMatchesPage struct {
+	Matches    []TradeMatch `json:"matches"`
+	Pagination Pagination   `json:"pagination"`
+}
+
+// TradeMatch — предложение обмена
+// @Description Карты из списка обмена партнёра, которые есть в списке желаемого пользователя (gets), и наоборот (gives), со стоимостью в USD. balance = gets_value - gives_value, score от 0 (обмен в одну сторону) до 1 (равноценный обмен)
+// @example { "partner_id": "64a9b66b2db8b91234a6e8e1", "partner_name": "bob", "gets": [], "gives": [], "gets_value": 10, "gives_value": 7, "balance": 3, "score": 0.7, "updated_at": "2026-10-19T12:00:00Z" }
+type TradeMatch struct {
+	PartnerID   string      `json:"partner_id" example:"64a9b66b2db8b91234a6e8e1"`
+	PartnerName string      `json:"partner_name" example:"bob"`
+	Gets        []TradeItem `json:"gets"`
+	Gives       []TradeItem `json:"gives"`
+	GetsValue   float64     `json:"gets_value" example:"10"`
+	GivesValue  float64     `json:"gives_value" example:"7"`
+	Balance     float64     `json:"balance" example:"3"`
+	Score       float64     `json:"score" example:"0.7"`
+	UpdatedAt   time.Time   `json:"updated_at"` // время последнего пересчёта
+}
+
+// TradeItem — карта в обмене
+// @Description Выпуск карты, число копий и их стоимость в USD
+// @example { "name": "Lightning Bolt", "scryfall_id": "e3285e6b-3e79-4d7c-bf96-d920f973b122", "finish": "foil", "count": 2, "unit_price": 5, "value": 10 }
+type TradeItem struct {
+	Name       string  `json:"name" example:"Lightning Bolt"`
+	ScryfallID string  `json:"scryfall_id" example:"e3285e6b-3e79-4d7c-bf96-d920f973b122"`
+	Finish     string  `json:"finish" example:"foil"`
+	Count      int     `json:"count" example:"2"`
+	UnitPrice  float64 `json:"unit_price" example:"5"`
+	Value      float64 `json:"value" example:"10"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+import "time"
+
+// Trash — корзина пользователя
+// @Description Удалённые коллекции и карты, удалённые из коллекций пользователя. Их можно восстановить, пока корзина не очищена по сроку хранения. Карты удалённой коллекции восстанавливаются вместе с ней и отдельно не показываются
+// @example { "collections": [{ "id": "64a9b66b2db8b91234a6e8e3", "name": "Old binder", "kind": "binder", "deleted_at": "2026-10-19T12:00:00Z" }], "cards": [{ "collection_id": "64a9b66b2db8b91234a6e8e5", "collection_name": "Burn", "card": { "id": "64a9b66b2db8b91234a6e8e4", "scryfall_id": "e3285e6b-3e79-4d7c-bf96-d920f973b80d", "name": "Lightning Bolt", "count": 4 }, "deleted_at": "2026-10-19T12:00:00Z" }] }
+type Trash struct {
+	Collections []TrashedCollection `json:"collections"` // сначала удалённые последними
+	Cards       []TrashedCard       `json:"cards"`       // сначала удалённые последними
+}
+
+// TrashedCollection — удалённая коллекция
+// @Description Коллекция в корзине и время её удаления
+// @example { "id": "64a9b66b2db8b91234a6e8e3", "name": "Old binder", "kind": "binder", "deleted_at": "2026-10-19T12:00:00Z" }
+type TrashedCollection struct {
+	ID        string    `json:"id" example:"64a9b66b2db8b91234a6e8e3"`
+	Name      string    `json:"name" example:"Old binder"`
+	Kind      string    `json:"kind" example:"binder"`
+	DeletedAt time.Time `json:"deleted_at" example:"2026-10-19T12:00:00Z"`
+}
+
+// TrashedCard — удалённая запись карты
+// @Description Запись карты в корзине, коллекция, из которой она удалена, и время удаления
+// @example { "collection_id": "64a9b66b2db8b91234a6e8e5", "collection_name": "Burn", "card": { "id": "64a9b66b2db8b91234a6e8e4", "name": "Lightning Bolt", "count": 4 }, "deleted_at": "2026-10-19T12:00:00Z" }
+type TrashedCard struct {
+	CollectionID   string    `json:"collection_id" example:"64a9b66b2db8b91234a6e8e5"`
+	CollectionName string    `json:"collection_name" example:"Burn"`
+	Card           Card      `json:"card"`
+	DeletedAt      time.Time `json:"deleted_at" example:"2026-10-19T12:00:00Z"`
+}
@@ -0,0 +1,38 @@
+package dto
+
+import "time"
+
+// Valuation — стоимость коллекции или всех коллекций пользователя
+// @Description Стоимость карт по последним ценам с учётом отделки (foil, etched), самые дорогие карты и изменение цены с даты since
+// @example { "currency": "usd", "price_date": "2026-10-19T00:00:00Z", "total": 1234.56, "cards": 250, "unpriced": 3, "top": [], "since": "2026-09-01T00:00:00Z", "since_total": 1180.1, "change": 54.46 }
+type Valuation struct {
+	Currency   string      `json:"currency" example:"usd"`
+	PriceDate  *time.Time  `json:"price_date,omitempty"` // дата самых свежих использованных цен
+	Total      float64     `json:"total" example:"1234.56"`
+	Cards      int         `json:"cards" example:"250"`  // число копий, включая копии без цены
+	Unpriced   int         `json:"unpriced" example:"3"` // копии без цены, в total не входят
+	Top        []CardValue `json:"top"`
+	Since      *time.Time  `json:"since,omitempty"`
+	SinceTotal *float64    `json:"since_total,omitempty" example:"1180.1"` // стоимость тех же карт по ценам на дату since
+	Change     *float64    `json:"change,omitempty" example:"54.46"`
+}
+
+// CardValue — стоимость записи карты
+// @Description Цена одной копии и стоимость всех копий записи карты
+// @example { "collection_id": "64a9b66b2db8b91234a6e8e4", "card": {}, "unit_price": 2.15, "value": 8.6 }
+type CardValue struct {
+	CollectionID string  `json:"collection_id" example:"64a9b66b2db8b91234a6e8e4"`
+	Card         Card    `json:"card"`
+	UnitPrice    float64 `json:"unit_price" example:"2.15"`
+	Value        float64 `json:"value" example:"8.6"`
+}
+
+// ValuePoint — стоимость за день
+// @Description Стоимость коллекции или всех коллекций пользователя за день во всех валютах
+// @example { "date": "2026-10-19T00:00:00Z", "usd": 1234.56, "eur": 1100.2, "tix": 310.5 }
+type ValuePoint struct {
+	Date time.Time `json:"date"`
+	USD  float64   `json:"usd" example:"1234.56"`
+	EUR  float64   `json:"eur" example:"1100.2"`
+	Tix  float64   `json:"tix" example:"310.5"`
+}
@@ -0,0 +1,196 @@
+// Package decklist parses text decklists: MTGO exports, Arena exports with
+// section headers and set codes, and plain "4x Card name" lists.
+package decklist
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Zones of parsed entries, they match zones of collection card entries.
+const (
+	ZoneMain      = "main"
+	ZoneSide      = "side"
+	ZoneMaybe     = "maybe"
+	ZoneCommander = "commander"
+)
+
+// Finishes marked in decklists, nonfoil cards have an empty finish.
+const (
+	FinishFoil   = "foil"
+	FinishEtched = "etched"
+)
+
+// Entry is a card line of a decklist.
+type Entry struct {
+	Line            int // 1-based line number
+	Count           int
+	Name            string
+	SetCode         string // lower case, empty when the line has no set
+	CollectorNumber string
+	Zone            string
+	Finish          string
+}
+
+// LineError is a line which doesn't look like a card line.
+type LineError struct {
+	Line int
+	Text string
+	Err  error
+}
+
+func (e LineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+// Decklist is a parsed decklist. Lines with errors are collected instead of
+// stopping the parsing, so a single typo doesn't discard the whole list.
+type Decklist struct {
+	Entries []Entry
+	Errors  []LineError
+}
+
+var headers = map[string]string{
+	"deck":        ZoneMain,
+	"main":        ZoneMain,
+	"maindeck":    ZoneMain,
+	"mainboard":   ZoneMain,
+	"sideboard":   ZoneSide,
+	"side":        ZoneSide,
+	"companion":   ZoneSide, // companions are kept in the sideboard
+	"commander":   ZoneCommander,
+	"commanders":  ZoneCommander,
+	"maybeboard":  ZoneMaybe,
+	"maybe":       ZoneMaybe,
+	"considering": ZoneMaybe,
+	"about":       "", // Arena export metadata, lines of the section are skipped
+}
+
+// entryRegexp matches "4 Lightning Bolt", "4x Lightning Bolt (M10) 146 *F*" and "Lightning Bolt".
+var entryRegexp = regexp.MustCompile(`^(?:(\d+)\s*[xX]?\s+)?(.+?)(?:\s+\(([A-Za-z0-9]{2,6})\)(?:\s+([A-Za-z0-9★†-]+))?)?(?:\s+\*([FfEe])\*)?$`)
+
+// Parse reads a decklist. The returned error is only about reading r.
+//
+// Without section headers a blank line after the main deck starts the
+// sideboard, as in MTGO exports. "SB:" prefixes put single lines into the
+// sideboard. Lines starting with "//" or "#" are comments.
+func Parse(r io.Reader) (*Decklist, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("line %d: %w", len(lines)+1, err)
+	}
+
+	hasHeaders := false
+	for _, line := range lines {
+		if _, ok := header(line); ok {
+			hasHeaders = true
+			break
+		}
+	}
+
+	list := &Decklist{}
+	zone := ZoneMain
+	skipSection := false
+	for i, raw := range lines {
+		number := i + 1
+		line := strings.TrimSpace(raw)
+
+		if line == "" {
+			if !hasHeaders && zone == ZoneMain && len(list.Entries) > 0 {
+				zone = ZoneSide
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "//") || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if z, ok := header(line); ok {
+			skipSection = z == ""
+			if !skipSection {
+				zone = z
+			}
+			continue
+		}
+		if skipSection {
+			continue
+		}
+
+		entryZone := zone
+		if rest, ok := cutPrefixFold(line, "SB:"); ok {
+			line = strings.TrimSpace(rest)
+			entryZone = ZoneSide
+		}
+
+		entry, err := parseEntry(line)
+		if err != nil {
+			list.Errors = append(list.Errors, LineError{Line: number, Text: raw, Err: err})
+			continue
+		}
+		entry.Line = number
+		entry.Zone = entryZone
+		list.Entries = append(list.Entries, entry)
+	}
+
+	return list, nil
+}
+
+// ParseString parses a decklist from a string. It fails only on lines longer
+// than bufio.MaxScanTokenSize.
+func ParseString(s string) (*Decklist, error) {
+	return Parse(strings.NewReader(s))
+}
+
+func parseEntry(line string) (Entry, error) {
+	m := entryRegexp.FindStringSubmatch(line)
+	if m == nil {
+		return Entry{}, fmt.Errorf("not a card line")
+	}
+
+	entry := Entry{
+		Count:           1,
+		Name:            strings.TrimSpace(m[2]),
+		SetCode:         strings.ToLower(m[3]),
+		CollectorNumber: m[4],
+	}
+	if m[1] != "" {
+		count, err := strconv.Atoi(m[1])
+		if err != nil || count <= 0 {
+			return Entry{}, fmt.Errorf("invalid count %q", m[1])
+		}
+		entry.Count = count
+	}
+
+	switch strings.ToUpper(m[5]) {
+	case "F":
+		entry.Finish = FinishFoil
+	case "E":
+		entry.Finish = FinishEtched
+	}
+
+	if entry.Name == "" {
+		return Entry{}, fmt.Errorf("card name is missing")
+	}
+	return entry, nil
+}
+
+// header returns the zone of a section header line like "Sideboard" or "Deck:"
+func header(line string) (string, bool) {
+	key := strings.ToLower(strings.TrimSuffix(strings.TrimSpace(line), ":"))
+	zone, ok := headers[key]
+	return zone, ok
+}
+
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix) {
+		return s[len(prefix):], true
+	}
+	return s, false
+}
@@ -0,0 +1,122 @@
+package decklist
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		entries []Entry
+		errors  []int // lines with errors
+	}{
+		{
+			name: "mtgo with blank line before sideboard",
+			text: "4 Lightning Bolt\n20 Mountain\n\n2 Smash to Smithereens\n",
+			entries: []Entry{
+				{Line: 1, Count: 4, Name: "Lightning Bolt", Zone: ZoneMain},
+				{Line: 2, Count: 20, Name: "Mountain", Zone: ZoneMain},
+				{Line: 4, Count: 2, Name: "Smash to Smithereens", Zone: ZoneSide},
+			},
+		},
+		{
+			name: "arena with headers and sets",
+			text: "About\nName Burn\n\nDeck\n4 Lightning Bolt (M10) 146\n4 Monastery Swiftspear (KTK) 118\n\nSideboard\n2 Roiling Vortex (ZNR) 156\n",
+			entries: []Entry{
+				{Line: 5, Count: 4, Name: "Lightning Bolt", SetCode: "m10", CollectorNumber: "146", Zone: ZoneMain},
+				{Line: 6, Count: 4, Name: "Monastery Swiftspear", SetCode: "ktk", CollectorNumber: "118", Zone: ZoneMain},
+				{Line: 9, Count: 2, Name: "Roiling Vortex", SetCode: "znr", CollectorNumber: "156", Zone: ZoneSide},
+			},
+		},
+		{
+			name: "arena commander and companion",
+			text: "Commander\n1 Krenko, Mob Boss (DDT) 52\n\nCompanion\n1 Lurrus of the Dream-Den (IKO) 226\n\nDeck\n1 Goblin Guide (ZEN) 126\n",
+			entries: []Entry{
+				{Line: 2, Count: 1, Name: "Krenko, Mob Boss", SetCode: "ddt", CollectorNumber: "52", Zone: ZoneCommander},
+				{Line: 5, Count: 1, Name: "Lurrus of the Dream-Den", SetCode: "iko", CollectorNumber: "226", Zone: ZoneSide},
+				{Line: 8, Count: 1, Name: "Goblin Guide", SetCode: "zen", CollectorNumber: "126", Zone: ZoneMain},
+			},
+		},
+		{
+			name: "headers ignore blank lines",
+			text: "Deck\n4 Lightning Bolt\n\n4 Mountain\nSideboard:\n1 Duress\n",
+			entries: []Entry{
+				{Line: 2, Count: 4, Name: "Lightning Bolt", Zone: ZoneMain},
+				{Line: 4, Count: 4, Name: "Mountain", Zone: ZoneMain},
+				{Line: 6, Count: 1, Name: "Duress", Zone: ZoneSide},
+			},
+		},
+		{
+			name: "plain list with x counts, no counts and comments",
+			text: "// Burn\n# budget\n4x Lightning Bolt\nSol Ring\n2X Fire // Ice\n",
+			entries: []Entry{
+				{Line: 3, Count: 4, Name: "Lightning Bolt", Zone: ZoneMain},
+				{Line: 4, Count: 1, Name: "Sol Ring", Zone: ZoneMain},
+				{Line: 5, Count: 2, Name: "Fire // Ice", Zone: ZoneMain},
+			},
+		},
+		{
+			name: "sb prefix and finish markers",
+			text: "1 Sol Ring (C21) 263 *F*\n1 Arcane Signet (CMR) 297 *E*\nSB: 2 Duress\n",
+			entries: []Entry{
+				{Line: 1, Count: 1, Name: "Sol Ring", SetCode: "c21", CollectorNumber: "263", Zone: ZoneMain, Finish: FinishFoil},
+				{Line: 2, Count: 1, Name: "Arcane Signet", SetCode: "cmr", CollectorNumber: "297", Zone: ZoneMain, Finish: FinishEtched},
+				{Line: 3, Count: 2, Name: "Duress", Zone: ZoneSide},
+			},
+		},
+		{
+			name: "set without collector number and promo numbers",
+			text: "1 Thoughtseize (THS)\n1 Ragavan, Nimble Pilferer (PMH2) 138p\n",
+			entries: []Entry{
+				{Line: 1, Count: 1, Name: "Thoughtseize", SetCode: "ths", Zone: ZoneMain},
+				{Line: 2, Count: 1, Name: "Ragavan, Nimble Pilferer", SetCode: "pmh2", CollectorNumber: "138p", Zone: ZoneMain},
+			},
+		},
+		{
+			name: "zero count is an error",
+			text: "0 Lightning Bolt\n4 Mountain\n",
+			entries: []Entry{
+				{Line: 2, Count: 4, Name: "Mountain", Zone: ZoneMain},
+			},
+			errors: []int{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := ParseString(tt.text)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.entries, list.Entries)
+
+			var errLines []int
+			for _, e := range list.Errors {
+				errLines = append(errLines, e.Line)
+			}
+			assert.Equal(t, tt.errors, errLines)
+		})
+	}
+}
+
+func TestParseErrorKeepsLineText(t *testing.T) {
+	list, err := ParseString("4 Mountain\n  0 Bolt  \n")
+	require.NoError(t, err)
+
+	require.Len(t, list.Errors, 1)
+	assert.Equal(t, 2, list.Errors[0].Line)
+	assert.Equal(t, "  0 Bolt  ", list.Errors[0].Text)
+	assert.EqualError(t, list.Errors[0], `line 2: invalid count "0"`)
+}
+
+func TestParseLineTooLong(t *testing.T) {
+	_, err := ParseString("4 Mountain\n4 " + strings.Repeat("a", bufio.MaxScanTokenSize) + "\n")
+
+	require.ErrorIs(t, err, bufio.ErrTooLong)
+	assert.EqualError(t, err, "line 2: bufio.Scanner: token too long")
+}
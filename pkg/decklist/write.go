@@ -0,0 +1,223 @@
+package decklist
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Format is a text format decklists are written in.
+type Format string
+
+const (
+	FormatArena    Format = "arena"    // MTG Arena import: sections, set codes and collector numbers
+	FormatMTGO     Format = "mtgo"     // MTGO import: names only, a blank line before the sideboard
+	FormatText     Format = "text"     // plain text with all zones and finishes, readable by Parse
+	FormatMarkdown Format = "markdown" // markdown for chats and posts
+)
+
+func (f Format) IsValid() bool {
+	switch f {
+	case FormatArena, FormatMTGO, FormatText, FormatMarkdown:
+		return true
+	}
+	return false
+}
+
+// ContentType is the MIME type of decklists in the format.
+func (f Format) ContentType() string {
+	if f == FormatMarkdown {
+		return "text/markdown; charset=utf-8"
+	}
+	return "text/plain; charset=utf-8"
+}
+
+// Extension is the file extension of decklists in the format.
+func (f Format) Extension() string {
+	if f == FormatMarkdown {
+		return ".md"
+	}
+	return ".txt"
+}
+
+// section is a zone as it's written in decklists
+type section struct {
+	zone  string
+	title string
+}
+
+// sections lists zones in the order they are written. MTG Arena expects the
+// commander first and has no maybeboard.
+var sections = map[Format][]section{
+	FormatArena: {
+		{ZoneCommander, "Commander"},
+		{ZoneMain, "Deck"},
+		{ZoneSide, "Sideboard"},
+	},
+	FormatText: {
+		{ZoneCommander, "Commander"},
+		{ZoneMain, "Deck"},
+		{ZoneSide, "Sideboard"},
+		{ZoneMaybe, "Maybeboard"},
+	},
+	FormatMarkdown: {
+		{ZoneCommander, "Commander"},
+		{ZoneMain, "Deck"},
+		{ZoneSide, "Sideboard"},
+		{ZoneMaybe, "Maybeboard"},
+	},
+}
+
+// Write writes entries grouped by zone. Entries of the same card are merged
+// when the format can't tell them apart, e.g. foil and nonfoil copies in MTGO.
+// The title is only used by the markdown format.
+func Write(w io.Writer, format Format, title string, entries []Entry) error {
+	if !format.IsValid() {
+		return fmt.Errorf("unknown decklist format %q", format)
+	}
+
+	bw := bufio.NewWriter(w)
+	switch format {
+	case FormatMTGO:
+		writeMTGO(bw, entries)
+	case FormatMarkdown:
+		writeMarkdown(bw, title, entries)
+	default:
+		writeSections(bw, format, entries)
+	}
+	return bw.Flush()
+}
+
+func writeSections(w *bufio.Writer, format Format, entries []Entry) {
+	zones := groupByZone(format, entries)
+	first := true
+	for _, s := range sections[format] {
+		if len(zones[s.zone]) == 0 {
+			continue
+		}
+		if !first {
+			w.WriteString("\n")
+		}
+		first = false
+
+		w.WriteString(s.title + "\n")
+		for _, e := range zones[s.zone] {
+			w.WriteString(entryLine(format, e) + "\n")
+		}
+	}
+}
+
+// writeMTGO writes the main deck, a blank line and the sideboard. MTGO keeps
+// commanders in the sideboard.
+func writeMTGO(w *bufio.Writer, entries []Entry) {
+	zones := groupByZone(FormatMTGO, entries)
+	for _, e := range zones[ZoneMain] {
+		w.WriteString(entryLine(FormatMTGO, e) + "\n")
+	}
+
+	side := append(zones[ZoneCommander], zones[ZoneSide]...)
+	if len(side) == 0 {
+		return
+	}
+	w.WriteString("\n")
+	for _, e := range side {
+		w.WriteString(entryLine(FormatMTGO, e) + "\n")
+	}
+}
+
+func writeMarkdown(w *bufio.Writer, title string, entries []Entry) {
+	if title != "" {
+		w.WriteString("# " + escapeMarkdown(title) + "\n")
+	}
+
+	zones := groupByZone(FormatMarkdown, entries)
+	for _, s := range sections[FormatMarkdown] {
+		if len(zones[s.zone]) == 0 {
+			continue
+		}
+		total := 0
+		for _, e := range zones[s.zone] {
+			total += e.Count
+		}
+
+		fmt.Fprintf(w, "\n## %s (%d)\n\n", s.title, total)
+		for _, e := range zones[s.zone] {
+			w.WriteString("- " + escapeMarkdown(entryLine(FormatMarkdown, e)) + "\n")
+		}
+	}
+}
+
+// groupByZone merges entries the format can't tell apart and sorts them by name
+func groupByZone(format Format, entries []Entry) map[string][]Entry {
+	type key struct {
+		zone, name, set, number, finish string
+	}
+
+	merged := make(map[key]int)
+	order := make([]key, 0, len(entries))
+	for _, e := range entries {
+		k := key{zone: e.Zone, name: e.Name}
+		if format != FormatMTGO {
+			k.set, k.number = e.SetCode, e.CollectorNumber
+		}
+		if format == FormatText || format == FormatMarkdown {
+			k.finish = e.Finish
+		}
+		if _, ok := merged[k]; !ok {
+			order = append(order, k)
+		}
+		merged[k] += e.Count
+	}
+
+	zones := make(map[string][]Entry)
+	for _, k := range order {
+		zones[k.zone] = append(zones[k.zone], Entry{
+			Count:           merged[k],
+			Name:            k.name,
+			SetCode:         k.set,
+			CollectorNumber: k.number,
+			Zone:            k.zone,
+			Finish:          k.finish,
+		})
+	}
+	for _, list := range zones {
+		sort.SliceStable(list, func(i, j int) bool {
+			if list[i].Name != list[j].Name {
+				return list[i].Name < list[j].Name
+			}
+			return list[i].SetCode < list[j].SetCode
+		})
+	}
+	return zones
+}
+
+// entryLine formats an entry as "4 Lightning Bolt (M10) 146 *F*". The set is
+// left out when it's unknown, MTG Arena then picks a printing by itself.
+func entryLine(format Format, e Entry) string {
+	line := fmt.Sprintf("%d %s", e.Count, e.Name)
+	if format == FormatMTGO {
+		return line
+	}
+
+	if e.SetCode != "" {
+		line += " (" + strings.ToUpper(e.SetCode) + ")"
+		if e.CollectorNumber != "" {
+			line += " " + e.CollectorNumber
+		}
+	}
+	switch e.Finish {
+	case FinishFoil:
+		line += " *F*"
+	case FinishEtched:
+		line += " *E*"
+	}
+	return line
+}
+
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `_`, `\_`, "`", "\\`", `[`, `\[`, `]`, `\]`, `#`, `\#`)
+
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
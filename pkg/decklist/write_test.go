@@ -0,0 +1,81 @@
+package decklist
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var writeEntries = []Entry{
+	{Count: 4, Name: "Lightning Bolt", SetCode: "m10", CollectorNumber: "146", Zone: ZoneMain},
+	{Count: 1, Name: "Lightning Bolt", SetCode: "m10", CollectorNumber: "146", Zone: ZoneMain, Finish: FinishFoil},
+	{Count: 20, Name: "Mountain", Zone: ZoneMain},
+	{Count: 2, Name: "Smash to Smithereens", SetCode: "m19", CollectorNumber: "163", Zone: ZoneSide},
+	{Count: 1, Name: "Krenko, Mob Boss", SetCode: "ddt", CollectorNumber: "52", Zone: ZoneCommander},
+	{Count: 1, Name: "Goblin Guide", SetCode: "zen", CollectorNumber: "126", Zone: ZoneMaybe},
+}
+
+func TestWrite(t *testing.T) {
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{
+			format: FormatArena,
+			want: "Commander\n1 Krenko, Mob Boss (DDT) 52\n\n" +
+				"Deck\n5 Lightning Bolt (M10) 146\n20 Mountain\n\n" +
+				"Sideboard\n2 Smash to Smithereens (M19) 163\n",
+		},
+		{
+			format: FormatMTGO,
+			want:   "5 Lightning Bolt\n20 Mountain\n\n1 Krenko, Mob Boss\n2 Smash to Smithereens\n",
+		},
+		{
+			format: FormatText,
+			want: "Commander\n1 Krenko, Mob Boss (DDT) 52\n\n" +
+				"Deck\n4 Lightning Bolt (M10) 146\n1 Lightning Bolt (M10) 146 *F*\n20 Mountain\n\n" +
+				"Sideboard\n2 Smash to Smithereens (M19) 163\n\n" +
+				"Maybeboard\n1 Goblin Guide (ZEN) 126\n",
+		},
+		{
+			format: FormatMarkdown,
+			want: "# Krenko \\[tokens\\]\n" +
+				"\n## Commander (1)\n\n- 1 Krenko, Mob Boss (DDT) 52\n" +
+				"\n## Deck (25)\n\n- 4 Lightning Bolt (M10) 146\n- 1 Lightning Bolt (M10) 146 \\*F\\*\n- 20 Mountain\n" +
+				"\n## Sideboard (2)\n\n- 2 Smash to Smithereens (M19) 163\n" +
+				"\n## Maybeboard (1)\n\n- 1 Goblin Guide (ZEN) 126\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, Write(&buf, tt.format, "Krenko [tokens]", writeEntries))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestWriteTextParsesBack(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, FormatText, "", writeEntries))
+
+	list, err := ParseString(buf.String())
+	require.NoError(t, err)
+	require.Empty(t, list.Errors)
+
+	got := make([]Entry, len(list.Entries))
+	for i, e := range list.Entries {
+		e.Line = 0
+		got[i] = e
+	}
+	assert.ElementsMatch(t, writeEntries, got)
+}
+
+func TestWriteUnknownFormat(t *testing.T) {
+	var buf bytes.Buffer
+	assert.EqualError(t, Write(&buf, "dek", "", writeEntries), `unknown decklist format "dek"`)
+	assert.Empty(t, buf.String())
+}
@@ -0,0 +1,58 @@
+package scryfall
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// BulkReader reads cards of a Scryfall bulk data file (default_cards,
+// oracle_cards and the like) one by one, without loading the whole array.
+type BulkReader struct {
+	dec    *json.Decoder
+	opened bool
+	closed bool
+}
+
+func NewBulkReader(r io.Reader) *BulkReader {
+	return &BulkReader{dec: json.NewDecoder(r)}
+}
+
+// Next returns the next card or io.EOF after the last one.
+func (b *BulkReader) Next() (*Card, error) {
+	if b.closed {
+		return nil, io.EOF
+	}
+	if !b.opened {
+		tok, err := b.dec.Token()
+		if err != nil {
+			if err == io.EOF {
+				return nil, fmt.Errorf("scryfall: bulk data is empty")
+			}
+			return nil, err
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+			return nil, fmt.Errorf("scryfall: bulk data is not an array")
+		}
+		b.opened = true
+	}
+
+	if !b.dec.More() {
+		if _, err := b.dec.Token(); err != nil {
+			return nil, err
+		}
+		b.closed = true
+		return nil, io.EOF
+	}
+
+	var card Card
+	if err := b.dec.Decode(&card); err != nil {
+		return nil, fmt.Errorf("scryfall: decode card at byte %d: %w", b.dec.InputOffset(), err)
+	}
+	return &card, nil
+}
+
+// Offset is the number of bytes read so far, for progress reports.
+func (b *BulkReader) Offset() int64 {
+	return b.dec.InputOffset()
+}
@@ -0,0 +1,49 @@
+package scryfall
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkReader(t *testing.T) {
+	data := `[
+		{"object": "card", "id": "e3285e6b-3e79-4d7c-bf96-d920f973b80d", "name": "Lightning Bolt", "set": "m10", "collector_number": "146", "legalities": {"modern": "legal"}},
+		{"object": "card", "id": "11bf83bb-c95b-4b4f-9a56-ce7a1816307a", "name": "Delver of Secrets // Insectile Aberration", "set": "isd",
+		 "card_faces": [{"name": "Delver of Secrets", "image_uris": {"normal": "front.jpg"}}]}
+	]`
+	r := NewBulkReader(strings.NewReader(data))
+
+	card, err := r.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "Lightning Bolt", card.Name)
+	assert.Equal(t, "146", card.CollectorNumber)
+
+	card, err = r.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "isd", card.Set)
+	assert.Equal(t, "front.jpg", card.ImageURI())
+
+	_, err = r.Next()
+	assert.Equal(t, io.EOF, err)
+	_, err = r.Next()
+	assert.Equal(t, io.EOF, err)
+	assert.EqualValues(t, len(data), r.Offset())
+}
+
+func TestBulkReaderErrors(t *testing.T) {
+	_, err := NewBulkReader(strings.NewReader("")).Next()
+	assert.EqualError(t, err, "scryfall: bulk data is empty")
+
+	_, err = NewBulkReader(strings.NewReader(`{"object": "list"}`)).Next()
+	assert.EqualError(t, err, "scryfall: bulk data is not an array")
+
+	r := NewBulkReader(strings.NewReader(`[{"name": "Lightning Bolt"}, {"name": 5}]`))
+	_, err = r.Next()
+	require.NoError(t, err)
+	_, err = r.Next()
+	assert.ErrorContains(t, err, "scryfall: decode card at byte")
+}
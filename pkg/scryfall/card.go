@@ -0,0 +1,66 @@
+package scryfall
+
+// Card is a card printing as the Scryfall API returns it. Only fields the
+// service uses are decoded.
+type Card struct {
+	ID              string     `json:"id"`
+	OracleID        string     `json:"oracle_id"`
+	Name            string     `json:"name"`
+	Lang            string     `json:"lang"`
+	Set             string     `json:"set"`
+	SetName         string     `json:"set_name"`
+	CollectorNumber string     `json:"collector_number"`
+	Rarity          string     `json:"rarity"`
+	TypeLine        string     `json:"type_line"`
+	ManaCost        string     `json:"mana_cost"`
+	CMC             float64    `json:"cmc"`
+	Colors          []string   `json:"colors"`
+	ColorIdentity   []string   `json:"color_identity"`
+	ImageURIs       *ImageURIs `json:"image_uris,omitempty"`
+	CardFaces       []CardFace `json:"card_faces,omitempty"`
+	Prices          Prices     `json:"prices"`
+	// Legalities map format names (standard, modern, commander...) to
+	// legal, not_legal, restricted or banned.
+	Legalities map[string]string `json:"legalities,omitempty"`
+}
+
+// Prices are the current market prices of a printing as decimal strings.
+// Scryfall has no price for many printings and finishes, those are empty.
+type Prices struct {
+	USD       string `json:"usd"`
+	USDFoil   string `json:"usd_foil"`
+	USDEtched string `json:"usd_etched"`
+	EUR       string `json:"eur"`
+	EURFoil   string `json:"eur_foil"`
+	Tix       string `json:"tix"`
+}
+
+// CardFace is a face of a multi-faced card.
+type CardFace struct {
+	Name      string     `json:"name"`
+	TypeLine  string     `json:"type_line"`
+	ManaCost  string     `json:"mana_cost"`
+	ImageURIs *ImageURIs `json:"image_uris,omitempty"`
+}
+
+// ImageURIs are links to card images of different sizes.
+type ImageURIs struct {
+	Small  string `json:"small"`
+	Normal string `json:"normal"`
+	Large  string `json:"large"`
+	PNG    string `json:"png"`
+}
+
+// ImageURI is the normal size image of the card. Double-faced cards have
+// images per face, the front one is returned for them.
+func (c *Card) ImageURI() string {
+	if c.ImageURIs != nil {
+		return c.ImageURIs.Normal
+	}
+	for _, face := range c.CardFaces {
+		if face.ImageURIs != nil {
+			return face.ImageURIs.Normal
+		}
+	}
+	return ""
+}
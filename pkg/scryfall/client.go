@@ -0,0 +1,357 @@
+// Package scryfall is a client of the Scryfall card API. It keeps to the
+// rate Scryfall asks for, caches responses by their ETags and stops calling
+// the API while it's failing.
+package scryfall
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultBaseURL           = "https://api.scryfall.com"
+	DefaultRequestsPerSecond = 10 // https://scryfall.com/docs/api/rate-limits
+	DefaultCacheTTL          = 24 * time.Hour
+	DefaultFailureThreshold  = 5
+	DefaultOpenTimeout       = 30 * time.Second
+	DefaultUserAgent         = "CollectorOuphe/1.0"
+
+	// MaxCollectionIdentifiers is how many cards one collection request may ask for
+	MaxCollectionIdentifiers = 75
+	collectionPath           = "/cards/collection"
+)
+
+var (
+	// ErrNotFound is returned for IDs Scryfall doesn't know.
+	ErrNotFound = errors.New("scryfall: not found")
+	// ErrUnavailable is returned when Scryfall fails or the circuit breaker is open
+	// and there is no cached response to fall back to.
+	ErrUnavailable = errors.New("scryfall: unavailable")
+)
+
+// CachedResponse is a response body with its ETag.
+type CachedResponse struct {
+	ETag     string
+	Body     []byte
+	StoredAt time.Time
+}
+
+// Cache stores responses between requests and restarts. Load returns nil
+// without an error when the key is not cached.
+type Cache interface {
+	Load(ctx context.Context, key string) (*CachedResponse, error)
+	Store(ctx context.Context, key string, resp *CachedResponse) error
+}
+
+// Config configures a Client. Zero fields take the defaults.
+type Config struct {
+	BaseURL           string
+	RequestsPerSecond float64
+	// CacheTTL is how long a cached response is used without asking Scryfall.
+	// Older responses are revalidated with If-None-Match.
+	CacheTTL time.Duration
+	// FailureThreshold failures in a row open the circuit breaker for OpenTimeout.
+	FailureThreshold int
+	OpenTimeout      time.Duration
+	UserAgent        string
+	HTTPClient       *http.Client
+	Cache            Cache // responses aren't cached when nil
+}
+
+type Client struct {
+	baseURL    string
+	cacheTTL   time.Duration
+	userAgent  string
+	httpClient *http.Client
+	cache      Cache
+	limiter    *rateLimiter
+	breaker    *circuitBreaker
+}
+
+func NewClient(cfg Config) *Client {
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = DefaultBaseURL
+	}
+	if cfg.RequestsPerSecond <= 0 {
+		cfg.RequestsPerSecond = DefaultRequestsPerSecond
+	}
+	if cfg.CacheTTL <= 0 {
+		cfg.CacheTTL = DefaultCacheTTL
+	}
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = DefaultFailureThreshold
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = DefaultOpenTimeout
+	}
+	if cfg.UserAgent == "" {
+		cfg.UserAgent = DefaultUserAgent
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &Client{
+		baseURL:    strings.TrimSuffix(cfg.BaseURL, "/"),
+		cacheTTL:   cfg.CacheTTL,
+		userAgent:  cfg.UserAgent,
+		httpClient: cfg.HTTPClient,
+		cache:      cfg.Cache,
+		limiter:    newRateLimiter(cfg.RequestsPerSecond),
+		breaker:    newCircuitBreaker(cfg.FailureThreshold, cfg.OpenTimeout),
+	}
+}
+
+// Card returns the printing by its Scryfall ID.
+func (c *Client) Card(ctx context.Context, id string) (*Card, error) {
+	var card Card
+	if err := c.get(ctx, cardPath(id), &card); err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+// Cards returns printings by their Scryfall IDs, IDs Scryfall doesn't know are
+// missing from the map. Cached printings aren't asked for, the rest are fetched
+// by collection requests of up to MaxCollectionIdentifiers cards, which share
+// the cache with Card.
+func (c *Client) Cards(ctx context.Context, ids []string) (map[string]*Card, error) {
+	cards := make(map[string]*Card, len(ids))
+	stale := make(map[string]*CachedResponse)
+	seen := make(map[string]bool, len(ids))
+	var missing []string
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		cached := c.load(ctx, cardPath(id))
+		if cached != nil && time.Since(cached.StoredAt) < c.cacheTTL {
+			var card Card
+			if err := json.Unmarshal(cached.Body, &card); err == nil {
+				cards[id] = &card
+				continue
+			}
+		}
+		if cached != nil {
+			stale[id] = cached
+		}
+		missing = append(missing, id)
+	}
+
+	for chunk := range slices.Chunk(missing, MaxCollectionIdentifiers) {
+		found, err := c.collection(ctx, chunk)
+		if err != nil {
+			if !errors.Is(err, ErrUnavailable) {
+				return nil, err
+			}
+			// As with Card, stale printings are used while Scryfall is unavailable
+			for _, id := range chunk {
+				cached, ok := stale[id]
+				if !ok {
+					return nil, err
+				}
+				var card Card
+				if err := json.Unmarshal(cached.Body, &card); err != nil {
+					return nil, err
+				}
+				cards[id] = &card
+			}
+			continue
+		}
+		for _, card := range found {
+			cards[card.ID] = card
+		}
+	}
+
+	return cards, nil
+}
+
+// collection fetches printings by the collection endpoint and caches each of them
+func (c *Client) collection(ctx context.Context, ids []string) ([]*Card, error) {
+	type identifier struct {
+		ID string `json:"id"`
+	}
+	request := struct {
+		Identifiers []identifier `json:"identifiers"`
+	}{Identifiers: make([]identifier, len(ids))}
+	for i, id := range ids {
+		request.Identifiers[i].ID = id
+	}
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	status, _, body, err := c.send(ctx, http.MethodPost, collectionPath, payload, "")
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, statusError(http.MethodPost, collectionPath, status, body)
+	}
+
+	var list struct {
+		Data []json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, err
+	}
+
+	cards := make([]*Card, 0, len(list.Data))
+	for _, raw := range list.Data {
+		var card Card
+		if err := json.Unmarshal(raw, &card); err != nil {
+			return nil, err
+		}
+		c.store(ctx, cardPath(card.ID), &CachedResponse{Body: raw, StoredAt: time.Now()})
+		cards = append(cards, &card)
+	}
+	return cards, nil
+}
+
+func cardPath(id string) string {
+	return "/cards/" + url.PathEscape(id)
+}
+
+// apiError is the error object of the Scryfall API
+type apiError struct {
+	Status  int    `json:"status"`
+	Code    string `json:"code"`
+	Details string `json:"details"`
+}
+
+// get decodes the response of the path into out. Fresh cached responses are
+// used as is, stale ones are revalidated and used when Scryfall is unavailable.
+func (c *Client) get(ctx context.Context, path string, out any) error {
+	cached := c.load(ctx, path)
+	if cached != nil && time.Since(cached.StoredAt) < c.cacheTTL {
+		return json.Unmarshal(cached.Body, out)
+	}
+
+	body, err := c.fetch(ctx, path, cached)
+	if err != nil {
+		if errors.Is(err, ErrUnavailable) && cached != nil {
+			return json.Unmarshal(cached.Body, out)
+		}
+		return err
+	}
+	return json.Unmarshal(body, out)
+}
+
+func (c *Client) fetch(ctx context.Context, path string, cached *CachedResponse) ([]byte, error) {
+	etag := ""
+	if cached != nil {
+		etag = cached.ETag
+	}
+	status, header, body, err := c.send(ctx, http.MethodGet, path, nil, etag)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case status == http.StatusNotModified && cached != nil:
+		c.store(ctx, path, &CachedResponse{ETag: cached.ETag, Body: cached.Body, StoredAt: time.Now()})
+		return cached.Body, nil
+	case status == http.StatusNotFound:
+		return nil, ErrNotFound
+	case status != http.StatusOK:
+		return nil, statusError(http.MethodGet, path, status, body)
+	}
+
+	c.store(ctx, path, &CachedResponse{ETag: header.Get("ETag"), Body: body, StoredAt: time.Now()})
+	return body, nil
+}
+
+// send does a rate limited request through the circuit breaker and reads the
+// response. Server errors and throttling are returned as ErrUnavailable.
+func (c *Client) send(ctx context.Context, method, path string, payload []byte, etag string) (int, http.Header, []byte, error) {
+	if !c.breaker.allow() {
+		return 0, nil, nil, fmt.Errorf("%w: circuit breaker is open", ErrUnavailable)
+	}
+	if err := c.limiter.wait(ctx); err != nil {
+		c.breaker.cancel()
+		return 0, nil, nil, err
+	}
+
+	var reqBody io.Reader
+	if payload != nil {
+		reqBody = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
+	if err != nil {
+		c.breaker.cancel()
+		return 0, nil, nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		// A canceled request says nothing about Scryfall
+		if ctx.Err() != nil {
+			c.breaker.cancel()
+			return 0, nil, nil, ctx.Err()
+		}
+		c.breaker.failure()
+		return 0, nil, nil, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests {
+		c.breaker.failure()
+		return 0, nil, nil, fmt.Errorf("%w: status %d", ErrUnavailable, resp.StatusCode)
+	}
+	c.breaker.success()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	return resp.StatusCode, resp.Header, body, nil
+}
+
+// statusError describes an unexpected response with the details of the Scryfall error object
+func statusError(method, path string, status int, body []byte) error {
+	var apiErr apiError
+	if err := json.Unmarshal(body, &apiErr); err != nil || apiErr.Details == "" {
+		return fmt.Errorf("scryfall: %s %s: status %d", method, path, status)
+	}
+	return fmt.Errorf("scryfall: %s %s: %s", method, path, apiErr.Details)
+}
+
+// load returns the cached response of the key. A failing cache only makes
+// requests slower, so its errors are treated as misses.
+func (c *Client) load(ctx context.Context, key string) *CachedResponse {
+	if c.cache == nil {
+		return nil
+	}
+	cached, err := c.cache.Load(ctx, key)
+	if err != nil {
+		return nil
+	}
+	return cached
+}
+
+func (c *Client) store(ctx context.Context, key string, resp *CachedResponse) {
+	if c.cache == nil {
+		return
+	}
+	_ = c.cache.Store(ctx, key, resp)
+}
@@ -0,0 +1,296 @@
+package scryfall
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const boltID = "e3285e6b-3e79-4d7c-bf96-d920f973b80d"
+
+const boltJSON = `{
+	"object": "card",
+	"id": "e3285e6b-3e79-4d7c-bf96-d920f973b80d",
+	"oracle_id": "4457ed35-7c10-48c8-9776-456485fdf070",
+	"name": "Lightning Bolt",
+	"lang": "en",
+	"set": "m10",
+	"set_name": "Magic 2010",
+	"collector_number": "146",
+	"rarity": "common",
+	"type_line": "Instant",
+	"mana_cost": "{R}",
+	"cmc": 1.0,
+	"colors": ["R"],
+	"color_identity": ["R"],
+	"image_uris": {"normal": "https://cards.scryfall.io/normal/front/e/3/bolt.jpg"},
+	"prices": {"usd": "2.15", "usd_foil": null, "usd_etched": null, "eur": "1.80", "eur_foil": null, "tix": "0.03"}
+}`
+
+// fakeScryfall serves Lightning Bolt with an ETag and answers conditional requests
+type fakeScryfall struct {
+	*httptest.Server
+	requests    atomic.Int32
+	notModified atomic.Int32
+	status      atomic.Int32 // forced response status, 0 serves the card
+}
+
+func newFakeScryfall(t *testing.T) *fakeScryfall {
+	f := &fakeScryfall{}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.requests.Add(1)
+		if status := int(f.status.Load()); status != 0 {
+			w.WriteHeader(status)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/cards/collection":
+			var req struct {
+				Identifiers []struct {
+					ID string `json:"id"`
+				} `json:"identifiers"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			var data []string
+			for _, identifier := range req.Identifiers {
+				if identifier.ID == boltID {
+					data = append(data, boltJSON)
+				}
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"object":"list","data":[` + strings.Join(data, ",") + `]}`))
+		case "/cards/" + boltID:
+			if r.Header.Get("If-None-Match") == `"bolt-v1"` {
+				f.notModified.Add(1)
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", `"bolt-v1"`)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(boltJSON))
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"object":"error","code":"not_found","status":404,"details":"No card found with the given ID or set code and collector number."}`))
+		}
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+type memoryCache struct {
+	mu        sync.Mutex
+	responses map[string]CachedResponse
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{responses: make(map[string]CachedResponse)}
+}
+
+func (m *memoryCache) Load(_ context.Context, key string) (*CachedResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	resp, ok := m.responses[key]
+	if !ok {
+		return nil, nil
+	}
+	return &resp, nil
+}
+
+func (m *memoryCache) Store(_ context.Context, key string, resp *CachedResponse) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.responses[key] = *resp
+	return nil
+}
+
+func TestCard(t *testing.T) {
+	var userAgent, accept string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent, accept = r.Header.Get("User-Agent"), r.Header.Get("Accept")
+		_, _ = w.Write([]byte(boltJSON))
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{BaseURL: server.URL + "/"})
+	card, err := client.Card(context.Background(), boltID)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Lightning Bolt", card.Name)
+	assert.Equal(t, "m10", card.Set)
+	assert.Equal(t, "146", card.CollectorNumber)
+	assert.Equal(t, "common", card.Rarity)
+	assert.Equal(t, "Instant", card.TypeLine)
+	assert.Equal(t, "https://cards.scryfall.io/normal/front/e/3/bolt.jpg", card.ImageURI())
+	assert.Equal(t, Prices{USD: "2.15", EUR: "1.80", Tix: "0.03"}, card.Prices)
+	assert.Equal(t, DefaultUserAgent, userAgent)
+	assert.Equal(t, "application/json", accept)
+}
+
+func TestCardsAsksOnlyForUncachedCards(t *testing.T) {
+	fake := newFakeScryfall(t)
+	client := NewClient(Config{BaseURL: fake.URL, Cache: newMemoryCache()})
+
+	cards, err := client.Cards(context.Background(), []string{boltID, "unknown", boltID})
+
+	require.NoError(t, err)
+	require.Len(t, cards, 1)
+	assert.Equal(t, "Lightning Bolt", cards[boltID].Name)
+	assert.Equal(t, int32(1), fake.requests.Load())
+
+	// Printings of the collection request are cached for Card too
+	card, err := client.Card(context.Background(), boltID)
+	require.NoError(t, err)
+	assert.Equal(t, "Lightning Bolt", card.Name)
+
+	cards, err = client.Cards(context.Background(), []string{boltID})
+	require.NoError(t, err)
+	assert.Len(t, cards, 1)
+	assert.Equal(t, int32(1), fake.requests.Load())
+}
+
+func TestCardNotFound(t *testing.T) {
+	fake := newFakeScryfall(t)
+	client := NewClient(Config{BaseURL: fake.URL})
+
+	_, err := client.Card(context.Background(), "00000000-0000-0000-0000-000000000000")
+
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestCardFaceImage(t *testing.T) {
+	card := Card{CardFaces: []CardFace{
+		{Name: "Delver of Secrets", ImageURIs: &ImageURIs{Normal: "front.jpg"}},
+		{Name: "Insectile Aberration", ImageURIs: &ImageURIs{Normal: "back.jpg"}},
+	}}
+	assert.Equal(t, "front.jpg", card.ImageURI())
+}
+
+func TestCacheServesFreshResponses(t *testing.T) {
+	fake := newFakeScryfall(t)
+	client := NewClient(Config{BaseURL: fake.URL, Cache: newMemoryCache()})
+
+	for i := 0; i < 3; i++ {
+		card, err := client.Card(context.Background(), boltID)
+		require.NoError(t, err)
+		assert.Equal(t, "Lightning Bolt", card.Name)
+	}
+
+	assert.EqualValues(t, 1, fake.requests.Load())
+}
+
+func TestCacheRevalidatesStaleResponsesWithETag(t *testing.T) {
+	fake := newFakeScryfall(t)
+	cache := newMemoryCache()
+	client := NewClient(Config{BaseURL: fake.URL, Cache: cache, CacheTTL: time.Nanosecond})
+
+	_, err := client.Card(context.Background(), boltID)
+	require.NoError(t, err)
+	card, err := client.Card(context.Background(), boltID)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Lightning Bolt", card.Name)
+	assert.EqualValues(t, 2, fake.requests.Load())
+	assert.EqualValues(t, 1, fake.notModified.Load())
+	cached, _ := cache.Load(context.Background(), "/cards/"+boltID)
+	require.NotNil(t, cached)
+	assert.Equal(t, `"bolt-v1"`, cached.ETag)
+}
+
+func TestCircuitBreakerOpensAfterFailures(t *testing.T) {
+	fake := newFakeScryfall(t)
+	fake.status.Store(http.StatusInternalServerError)
+	client := NewClient(Config{BaseURL: fake.URL, FailureThreshold: 2, OpenTimeout: time.Minute})
+
+	for i := 0; i < 2; i++ {
+		_, err := client.Card(context.Background(), boltID)
+		require.ErrorIs(t, err, ErrUnavailable)
+	}
+	_, err := client.Card(context.Background(), boltID)
+
+	assert.ErrorIs(t, err, ErrUnavailable)
+	assert.EqualValues(t, 2, fake.requests.Load(), "open breaker must not call Scryfall")
+}
+
+func TestCircuitBreakerProbesAfterTimeout(t *testing.T) {
+	fake := newFakeScryfall(t)
+	fake.status.Store(http.StatusServiceUnavailable)
+	client := NewClient(Config{BaseURL: fake.URL, FailureThreshold: 1, OpenTimeout: time.Minute})
+	now := time.Now()
+	client.breaker.now = func() time.Time { return now }
+
+	_, err := client.Card(context.Background(), boltID)
+	require.ErrorIs(t, err, ErrUnavailable)
+
+	// Scryfall is back, but the breaker is still open
+	fake.status.Store(0)
+	_, err = client.Card(context.Background(), boltID)
+	require.ErrorIs(t, err, ErrUnavailable)
+	assert.EqualValues(t, 1, fake.requests.Load())
+
+	now = now.Add(time.Minute)
+	card, err := client.Card(context.Background(), boltID)
+	require.NoError(t, err)
+	assert.Equal(t, "Lightning Bolt", card.Name)
+	assert.EqualValues(t, 2, fake.requests.Load())
+}
+
+func TestStaleCacheIsUsedWhileScryfallFails(t *testing.T) {
+	fake := newFakeScryfall(t)
+	client := NewClient(Config{BaseURL: fake.URL, Cache: newMemoryCache(), CacheTTL: time.Nanosecond})
+
+	_, err := client.Card(context.Background(), boltID)
+	require.NoError(t, err)
+
+	fake.status.Store(http.StatusBadGateway)
+	card, err := client.Card(context.Background(), boltID)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Lightning Bolt", card.Name)
+}
+
+func TestRateLimit(t *testing.T) {
+	fake := newFakeScryfall(t)
+	client := NewClient(Config{BaseURL: fake.URL, RequestsPerSecond: 20})
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = client.Card(context.Background(), boltID)
+		}()
+	}
+	wg.Wait()
+
+	// 5 requests at 20 req/s are 4 intervals of 50ms apart
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+	assert.EqualValues(t, 5, fake.requests.Load())
+}
+
+func TestRateLimitHonoursContext(t *testing.T) {
+	fake := newFakeScryfall(t)
+	client := NewClient(Config{BaseURL: fake.URL, RequestsPerSecond: 1})
+
+	_, err := client.Card(context.Background(), boltID)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = client.Card(ctx, boltID)
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.EqualValues(t, 1, fake.requests.Load())
+}
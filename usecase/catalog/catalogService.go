@@ -0,0 +1,81 @@
+package catalog
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"go.uber.org/zap"
+)
+
+type CatalogService struct {
+	catalogRepository CatalogRepositorer
+	log               *zap.Logger
+}
+
+type CatalogRepositorer interface {
+	GetCatalogCard(scryfallId string) (*domain.CatalogCard, *domain.ResponseErr)
+	FindCatalogPrinting(setCode, collectorNumber string) (*domain.CatalogCard, *domain.ResponseErr)
+	SearchCatalogByName(prefix string, limit int) ([]domain.CatalogCard, *domain.ResponseErr)
+	FindCatalogByOracleID(oracleId string) ([]domain.CatalogCard, *domain.ResponseErr)
+}
+
+func NewCatalogService(log *zap.Logger, catalogRepository CatalogRepositorer) *CatalogService {
+	return &CatalogService{
+		catalogRepository: catalogRepository,
+		log:               log.With(zap.String("service", "catalog")),
+	}
+}
+
+// SearchByName returns printings whose names start with the prefix. A zero limit is the default one.
+func (cs CatalogService) SearchByName(prefix string, limit int) ([]domain.CatalogCard, *domain.ResponseErr) {
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Name prefix is empty",
+		}
+	}
+
+	if limit == 0 {
+		limit = domain.DefaultCatalogLimit
+	}
+	if limit < 0 || limit > domain.MaxCatalogLimit {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid limit",
+		}
+	}
+
+	cards, respErr := cs.catalogRepository.SearchCatalogByName(prefix, limit)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	// For json serialization, ensure cards is not nil
+	if cards == nil {
+		cards = []domain.CatalogCard{}
+	}
+	return cards, nil
+}
+
+// GetPrinting returns the printing with the collector number in the set.
+func (cs CatalogService) GetPrinting(setCode, collectorNumber string) (*domain.CatalogCard, *domain.ResponseErr) {
+	return cs.catalogRepository.FindCatalogPrinting(setCode, collectorNumber)
+}
+
+// GetByOracleID returns all printings of the card, 404 when there are none.
+func (cs CatalogService) GetByOracleID(oracleId string) ([]domain.CatalogCard, *domain.ResponseErr) {
+	cards, respErr := cs.catalogRepository.FindCatalogByOracleID(oracleId)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	if len(cards) == 0 {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusNotFound,
+			Message: "Card not found",
+		}
+	}
+	return cards, nil
+}
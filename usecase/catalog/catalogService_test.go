@@ -0,0 +1,113 @@
+package catalog
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/ShenokZlob/collector-service/pkg/scryfall"
+	"github.com/ShenokZlob/collector-service/usecase/catalog/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var boltCatalogCard = domain.CatalogCard{
+	ScryfallID:      "e3285e6b-3e79-4d7c-bf96-d920f973b80d",
+	OracleID:        "4457ed35-7c10-48c8-9776-456485fdf070",
+	Name:            "Lightning Bolt",
+	SetCode:         "m10",
+	CollectorNumber: "146",
+	Rarity:          "common",
+	TypeLine:        "Instant",
+	ImageURI:        "https://cards.scryfall.io/normal/bolt.jpg",
+}
+
+func TestSearchByName(t *testing.T) {
+	repository := mocks.NewMockCatalogRepositorer(t)
+	service := NewCatalogService(zap.NewNop(), repository)
+
+	repository.On("SearchCatalogByName", "Lightning", domain.DefaultCatalogLimit).Return(nil, nil)
+
+	cards, respErr := service.SearchByName(" Lightning ", 0)
+
+	require.Nil(t, respErr)
+	assert.NotNil(t, cards)
+	assert.Empty(t, cards)
+}
+
+func TestSearchByNameValidation(t *testing.T) {
+	service := NewCatalogService(zap.NewNop(), mocks.NewMockCatalogRepositorer(t))
+
+	_, respErr := service.SearchByName("  ", 0)
+	require.NotNil(t, respErr)
+	assert.Equal(t, "Name prefix is empty", respErr.Message)
+
+	_, respErr = service.SearchByName("Bolt", domain.MaxCatalogLimit+1)
+	require.NotNil(t, respErr)
+	assert.Equal(t, http.StatusBadRequest, respErr.Status)
+}
+
+func TestGetByOracleIDNotFound(t *testing.T) {
+	repository := mocks.NewMockCatalogRepositorer(t)
+	service := NewCatalogService(zap.NewNop(), repository)
+
+	repository.On("FindCatalogByOracleID", "missing").Return([]domain.CatalogCard{}, nil)
+
+	_, respErr := service.GetByOracleID("missing")
+
+	require.NotNil(t, respErr)
+	assert.Equal(t, http.StatusNotFound, respErr.Status)
+}
+
+func TestLookupPrefersCatalog(t *testing.T) {
+	catalog := mocks.NewMockCardGetter(t)
+	client := mocks.NewMockScryfallClient(t)
+	lookup := NewLookup(zap.NewNop(), catalog, client)
+
+	catalog.On("GetCatalogCard", boltCatalogCard.ScryfallID).Return(&boltCatalogCard, nil)
+
+	card, err := lookup.Card(context.Background(), boltCatalogCard.ScryfallID)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Lightning Bolt", card.Name)
+	assert.Equal(t, "m10", card.Set)
+	assert.Equal(t, "https://cards.scryfall.io/normal/bolt.jpg", card.ImageURI())
+	client.AssertNotCalled(t, "Card", mock.Anything, mock.Anything)
+}
+
+func TestLookupFallsBackToScryfall(t *testing.T) {
+	catalog := mocks.NewMockCardGetter(t)
+	client := mocks.NewMockScryfallClient(t)
+	lookup := NewLookup(zap.NewNop(), catalog, client)
+
+	catalog.On("GetCatalogCard", "unknown").
+		Return(nil, &domain.ResponseErr{Status: http.StatusNotFound, Message: "Card not found"})
+	client.On("Card", mock.Anything, "unknown").Return(nil, scryfall.ErrNotFound)
+
+	_, err := lookup.Card(context.Background(), "unknown")
+
+	assert.ErrorIs(t, err, scryfall.ErrNotFound)
+}
+
+func TestLookupCardsAsksScryfallOnlyForMissingPrintings(t *testing.T) {
+	catalog := mocks.NewMockCardGetter(t)
+	client := mocks.NewMockScryfallClient(t)
+	lookup := NewLookup(zap.NewNop(), catalog, client)
+
+	const duressID = "9b4b9e4c-6c62-4d02-a5a5-8bbd4c5c7c2d"
+	ids := []string{boltCatalogCard.ScryfallID, duressID, "unknown"}
+	catalog.On("FindPrintings", ids).
+		Return(map[string]domain.CatalogCard{boltCatalogCard.ScryfallID: boltCatalogCard}, nil)
+	client.On("Cards", mock.Anything, []string{duressID, "unknown"}).
+		Return(map[string]*scryfall.Card{duressID: {ID: duressID, Name: "Duress"}}, nil)
+
+	cards, err := lookup.Cards(context.Background(), ids)
+
+	require.NoError(t, err)
+	require.Len(t, cards, 2)
+	assert.Equal(t, "Lightning Bolt", cards[boltCatalogCard.ScryfallID].Name)
+	assert.Equal(t, "Duress", cards[duressID].Name)
+}
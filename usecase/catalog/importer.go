@@ -0,0 +1,164 @@
+package catalog
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/ShenokZlob/collector-service/pkg/scryfall"
+	"go.uber.org/zap"
+)
+
+// DefaultImportBatchSize is the number of cards stored and checkpointed at once.
+const DefaultImportBatchSize = 1000
+
+type Importer struct {
+	importRepository ImportRepositorer
+	batchSize        int
+	log              *zap.Logger
+}
+
+type ImportRepositorer interface {
+	UpsertCatalogCards(cards []domain.CatalogCard) *domain.ResponseErr
+	GetCatalogImport(source string) (*domain.CatalogImport, *domain.ResponseErr)
+	SaveCatalogImport(state *domain.CatalogImport) *domain.ResponseErr
+}
+
+func NewImporter(log *zap.Logger, importRepository ImportRepositorer, batchSize int) *Importer {
+	if batchSize <= 0 {
+		batchSize = DefaultImportBatchSize
+	}
+	return &Importer{
+		importRepository: importRepository,
+		batchSize:        batchSize,
+		log:              log.With(zap.String("service", "catalog_import")),
+	}
+}
+
+// Import streams a Scryfall bulk data file (default_cards, oracle_cards) into the catalog.
+//
+// Cards are upserted by Scryfall ID, so importing a file again leaves the catalog as it was.
+// Progress is saved after every batch: an import which was interrupted, e.g. by canceling
+// ctx, continues after the last stored batch when it's started for the same unchanged file.
+// A finished import of the file is not repeated unless force is set. progress, when not nil,
+// is called after every batch.
+func (im Importer) Import(ctx context.Context, path string, force bool, progress func(domain.CatalogImport)) error {
+	source, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	state, respErr := im.importRepository.GetCatalogImport(source)
+	if respErr != nil && respErr.Status != http.StatusNotFound {
+		return respErr
+	}
+	switch {
+	case state == nil || force || !state.SameFile(info.Size(), info.ModTime()):
+		state = &domain.CatalogImport{
+			Source:    source,
+			Size:      info.Size(),
+			ModTime:   info.ModTime(),
+			StartedAt: time.Now(),
+		}
+	case state.Done:
+		im.log.Info("Bulk data file is already imported", zap.String("source", source), zap.Int("cards", state.Processed))
+		report(progress, state)
+		return nil
+	default:
+		im.log.Info("Resuming catalog import", zap.String("source", source), zap.Int("processed", state.Processed))
+	}
+
+	reader := scryfall.NewBulkReader(bufio.NewReader(file))
+	batch := make([]domain.CatalogCard, 0, im.batchSize)
+	flush := func() error {
+		if respErr := im.importRepository.UpsertCatalogCards(batch); respErr != nil {
+			return respErr
+		}
+		state.Processed += len(batch)
+		state.BytesRead = reader.Offset()
+		state.UpdatedAt = time.Now()
+		if respErr := im.importRepository.SaveCatalogImport(state); respErr != nil {
+			return respErr
+		}
+		batch = batch[:0]
+		report(progress, state)
+		return nil
+	}
+
+	skip := state.Processed
+	for read := 0; ; read++ {
+		card, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("read %s: %w", source, err)
+		}
+		if read < skip {
+			continue
+		}
+
+		batch = append(batch, catalogCardFromScryfall(card))
+		if len(batch) < im.batchSize {
+			continue
+		}
+		if err := flush(); err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			im.log.Info("Catalog import is interrupted", zap.String("source", source), zap.Int("processed", state.Processed))
+			return err
+		}
+	}
+
+	state.Done = true
+	if err := flush(); err != nil {
+		return err
+	}
+
+	im.log.Info("Catalog import is done", zap.String("source", source), zap.Int("cards", state.Processed))
+	return nil
+}
+
+func report(progress func(domain.CatalogImport), state *domain.CatalogImport) {
+	if progress != nil {
+		progress(*state)
+	}
+}
+
+func catalogCardFromScryfall(card *scryfall.Card) domain.CatalogCard {
+	return domain.CatalogCard{
+		ScryfallID:      card.ID,
+		OracleID:        card.OracleID,
+		Name:            card.Name,
+		Lang:            card.Lang,
+		SetCode:         card.Set,
+		SetName:         card.SetName,
+		CollectorNumber: card.CollectorNumber,
+		Rarity:          card.Rarity,
+		TypeLine:        card.TypeLine,
+		ManaCost:        card.ManaCost,
+		CMC:             card.CMC,
+		Colors:          card.Colors,
+		ColorIdentity:   card.ColorIdentity,
+		ImageURI:        card.ImageURI(),
+		Legalities:      card.Legalities,
+	}
+}
@@ -0,0 +1,156 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryImportRepository keeps the catalog and import progress in memory
+type memoryImportRepository struct {
+	cards   map[string]domain.CatalogCard
+	upserts [][]string
+	imports map[string]domain.CatalogImport
+}
+
+func newMemoryImportRepository() *memoryImportRepository {
+	return &memoryImportRepository{
+		cards:   make(map[string]domain.CatalogCard),
+		imports: make(map[string]domain.CatalogImport),
+	}
+}
+
+func (m *memoryImportRepository) UpsertCatalogCards(cards []domain.CatalogCard) *domain.ResponseErr {
+	var ids []string
+	for _, card := range cards {
+		m.cards[card.ScryfallID] = card
+		ids = append(ids, card.ScryfallID)
+	}
+	if len(ids) > 0 {
+		m.upserts = append(m.upserts, ids)
+	}
+	return nil
+}
+
+func (m *memoryImportRepository) GetCatalogImport(source string) (*domain.CatalogImport, *domain.ResponseErr) {
+	state, ok := m.imports[source]
+	if !ok {
+		return nil, &domain.ResponseErr{Status: http.StatusNotFound, Message: "Catalog import not found"}
+	}
+	return &state, nil
+}
+
+func (m *memoryImportRepository) SaveCatalogImport(state *domain.CatalogImport) *domain.ResponseErr {
+	m.imports[state.Source] = *state
+	return nil
+}
+
+// writeBulkFile writes a bulk data file with n cards named "Card 1".."Card n"
+func writeBulkFile(t *testing.T, n int) string {
+	t.Helper()
+
+	cards := make([]string, n)
+	for i := range cards {
+		cards[i] = fmt.Sprintf(`{"object":"card","id":"id-%d","oracle_id":"oracle-%d","name":"Card %d","set":"tst","collector_number":"%d","rarity":"common","type_line":"Instant","image_uris":{"normal":"%d.jpg"}}`, i+1, i+1, i+1, i+1, i+1)
+	}
+	path := filepath.Join(t.TempDir(), "default-cards.json")
+	require.NoError(t, os.WriteFile(path, []byte("[\n"+strings.Join(cards, ",\n")+"\n]"), 0o644))
+	return path
+}
+
+func TestImport(t *testing.T) {
+	repository := newMemoryImportRepository()
+	importer := NewImporter(zap.NewNop(), repository, 2)
+	path := writeBulkFile(t, 5)
+
+	var reports []domain.CatalogImport
+	err := importer.Import(context.Background(), path, false, func(state domain.CatalogImport) {
+		reports = append(reports, state)
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"id-1", "id-2"}, {"id-3", "id-4"}, {"id-5"}}, repository.upserts)
+	assert.Equal(t, domain.CatalogCard{
+		ScryfallID:      "id-3",
+		OracleID:        "oracle-3",
+		Name:            "Card 3",
+		SetCode:         "tst",
+		CollectorNumber: "3",
+		Rarity:          "common",
+		TypeLine:        "Instant",
+		ImageURI:        "3.jpg",
+	}, repository.cards["id-3"])
+
+	require.Len(t, reports, 3)
+	assert.Equal(t, []int{2, 4, 5}, []int{reports[0].Processed, reports[1].Processed, reports[2].Processed})
+	assert.Less(t, reports[0].BytesRead, reports[1].BytesRead)
+	assert.True(t, reports[2].Done)
+}
+
+func TestImportSkipsImportedFileUnlessForced(t *testing.T) {
+	repository := newMemoryImportRepository()
+	importer := NewImporter(zap.NewNop(), repository, 10)
+	path := writeBulkFile(t, 3)
+
+	require.NoError(t, importer.Import(context.Background(), path, false, nil))
+	require.NoError(t, importer.Import(context.Background(), path, false, nil))
+	assert.Len(t, repository.upserts, 1)
+
+	require.NoError(t, importer.Import(context.Background(), path, true, nil))
+	assert.Len(t, repository.upserts, 2)
+	assert.Len(t, repository.cards, 3)
+}
+
+func TestImportResumesAfterInterruption(t *testing.T) {
+	repository := newMemoryImportRepository()
+	importer := NewImporter(zap.NewNop(), repository, 2)
+	path := writeBulkFile(t, 5)
+
+	// The import is canceled after the first batch
+	ctx, cancel := context.WithCancel(context.Background())
+	err := importer.Import(ctx, path, false, func(domain.CatalogImport) { cancel() })
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, [][]string{{"id-1", "id-2"}}, repository.upserts)
+
+	require.NoError(t, importer.Import(context.Background(), path, false, nil))
+
+	assert.Equal(t, [][]string{{"id-1", "id-2"}, {"id-3", "id-4"}, {"id-5"}}, repository.upserts)
+	source, _ := filepath.Abs(path)
+	assert.Equal(t, 5, repository.imports[source].Processed)
+	assert.True(t, repository.imports[source].Done)
+}
+
+func TestImportStartsOverForChangedFile(t *testing.T) {
+	repository := newMemoryImportRepository()
+	importer := NewImporter(zap.NewNop(), repository, 2)
+	path := writeBulkFile(t, 4)
+	source, _ := filepath.Abs(path)
+
+	// Progress of another version of the file
+	repository.imports[source] = domain.CatalogImport{Source: source, Size: 1, Processed: 2}
+
+	require.NoError(t, importer.Import(context.Background(), path, false, nil))
+
+	assert.Equal(t, [][]string{{"id-1", "id-2"}, {"id-3", "id-4"}}, repository.upserts)
+}
+
+func TestImportInvalidFile(t *testing.T) {
+	importer := NewImporter(zap.NewNop(), newMemoryImportRepository(), 2)
+
+	err := importer.Import(context.Background(), filepath.Join(t.TempDir(), "missing.json"), false, nil)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	path := filepath.Join(t.TempDir(), "broken.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[{"id": 1}]`), 0o644))
+	err = importer.Import(context.Background(), path, false, nil)
+	assert.ErrorContains(t, err, "decode card")
+}
@@ -0,0 +1,103 @@
+package catalog
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/ShenokZlob/collector-service/pkg/scryfall"
+	"go.uber.org/zap"
+)
+
+// Lookup finds printings in the offline catalog and asks Scryfall only for
+// printings the catalog doesn't have yet.
+type Lookup struct {
+	catalog  CardGetter
+	scryfall ScryfallClient
+	log      *zap.Logger
+}
+
+type CardGetter interface {
+	GetCatalogCard(scryfallId string) (*domain.CatalogCard, *domain.ResponseErr)
+	FindPrintings(scryfallIds []string) (map[string]domain.CatalogCard, *domain.ResponseErr)
+}
+
+type ScryfallClient interface {
+	Card(ctx context.Context, id string) (*scryfall.Card, error)
+	Cards(ctx context.Context, ids []string) (map[string]*scryfall.Card, error)
+}
+
+func NewLookup(log *zap.Logger, catalog CardGetter, scryfallClient ScryfallClient) *Lookup {
+	return &Lookup{
+		catalog:  catalog,
+		scryfall: scryfallClient,
+		log:      log.With(zap.String("service", "catalog_lookup")),
+	}
+}
+
+// Card returns the printing by its Scryfall ID. A failing catalog only makes
+// the lookup slower, Scryfall is asked then.
+func (l Lookup) Card(ctx context.Context, id string) (*scryfall.Card, error) {
+	card, respErr := l.catalog.GetCatalogCard(id)
+	if respErr == nil {
+		return scryfallCardFromCatalog(card), nil
+	}
+	if respErr.Status != http.StatusNotFound {
+		l.log.Warn("Failed to look up catalog card", zap.String("scryfallID", id), zap.Error(respErr))
+	}
+
+	return l.scryfall.Card(ctx, id)
+}
+
+// Cards returns printings by their Scryfall IDs, unknown IDs are missing from the map.
+// Printings the catalog doesn't have are asked from Scryfall all at once.
+func (l Lookup) Cards(ctx context.Context, ids []string) (map[string]*scryfall.Card, error) {
+	printings, respErr := l.catalog.FindPrintings(ids)
+	if respErr != nil {
+		l.log.Warn("Failed to look up catalog cards", zap.Int("cards", len(ids)), zap.Error(respErr))
+	}
+
+	cards := make(map[string]*scryfall.Card, len(ids))
+	var missing []string
+	for _, id := range ids {
+		if card, ok := printings[id]; ok {
+			cards[id] = scryfallCardFromCatalog(&card)
+			continue
+		}
+		missing = append(missing, id)
+	}
+	if len(missing) == 0 {
+		return cards, nil
+	}
+
+	found, err := l.scryfall.Cards(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	for id, card := range found {
+		cards[id] = card
+	}
+	return cards, nil
+}
+
+func scryfallCardFromCatalog(card *domain.CatalogCard) *scryfall.Card {
+	printing := &scryfall.Card{
+		ID:              card.ScryfallID,
+		OracleID:        card.OracleID,
+		Name:            card.Name,
+		Lang:            card.Lang,
+		Set:             card.SetCode,
+		SetName:         card.SetName,
+		CollectorNumber: card.CollectorNumber,
+		Rarity:          card.Rarity,
+		TypeLine:        card.TypeLine,
+		ManaCost:        card.ManaCost,
+		CMC:             card.CMC,
+		Colors:          card.Colors,
+		ColorIdentity:   card.ColorIdentity,
+	}
+	if card.ImageURI != "" {
+		printing.ImageURIs = &scryfall.ImageURIs{Normal: card.ImageURI}
+	}
+	return printing
+}
@@ -1,64 +1,394 @@
 package collection
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"regexp"
+	"strings"
 
 	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/ShenokZlob/collector-service/pkg/scryfall"
 	"go.uber.org/zap"
 )
 
 type CardsService struct {
 	cardsRepository CardsRepositorer
+	cardLookup      CardLookup
 	log             *zap.Logger
 }
 
 type CardsRepositorer interface {
 	GetCollection(collectionId string) (*domain.Collection, *domain.ResponseErr)
-	AddCardToCollection(collectionId string, card *domain.Card) *domain.ResponseErr
-	SetCardCountInCollection(collectionId string, card *domain.Card) *domain.ResponseErr
-	DeleteCardFromCollection(collectionId string, card *domain.Card) *domain.ResponseErr
+	ListCards(collectionId string, query *domain.CardsQuery) (*domain.CardsPage, *domain.ResponseErr)
+	AddCardToCollection(actor domain.Actor, collectionId string, card *domain.Card) (*domain.Card, *domain.ResponseErr)
+	SetCardCountInCollection(actor domain.Actor, collectionId string, card *domain.Card) *domain.ResponseErr
+	DeleteCardFromCollection(actor domain.Actor, collectionId string, card *domain.Card) *domain.ResponseErr
+	AdjustCardCount(actor domain.Actor, collectionId string, adjust *domain.CardAdjustment) (*domain.Card, *domain.ResponseErr)
+	MoveCardBetweenZones(actor domain.Actor, collectionId string, move *domain.CardMove) *domain.ResponseErr
+	TransferCards(actor domain.Actor, transfer *domain.CardTransfer) *domain.ResponseErr
+	ApplyCardOperations(actor domain.Actor, collectionId string, batch *domain.CardBatch) (*domain.CardBatchResult, *domain.ResponseErr)
 }
 
-func NewCardsService(log *zap.Logger, cardsRepository CardsRepositorer) *CardsService {
+// CardLookup finds printings in the Scryfall catalog.
+type CardLookup interface {
+	Card(ctx context.Context, id string) (*scryfall.Card, error)
+	Cards(ctx context.Context, ids []string) (map[string]*scryfall.Card, error)
+}
+
+func NewCardsService(log *zap.Logger, cardsRepository CardsRepositorer, cardLookup CardLookup) *CardsService {
 	return &CardsService{
 		cardsRepository: cardsRepository,
+		cardLookup:      cardLookup,
 		log:             log.With(zap.String("service", "cards")),
 	}
 }
 
-// ListCardsInCollection retrieves all cards in a collection by its ID.
-func (cs CardsService) ListCardsInCollection(collectionId string) ([]domain.Card, *domain.ResponseErr) {
-	collection, err := cs.cardsRepository.GetCollection(collectionId)
+// ListCardsInCollection retrieves a page of cards in a collection by its ID.
+// Unset sorting and pagination fields of the query are filled with defaults.
+func (cs CardsService) ListCardsInCollection(collectionId string, query *domain.CardsQuery) (*domain.CardsPage, *domain.ResponseErr) {
+	if query.SortBy == "" {
+		query.SortBy = domain.SortByName
+	}
+	if !query.SortBy.IsValid() {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid sort field",
+		}
+	}
+	if query.Limit == 0 {
+		query.Limit = domain.DefaultCardsLimit
+	}
+	if query.Limit < 0 || query.Limit > domain.MaxCardsLimit || query.Offset < 0 || query.MinCount < 0 {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid pagination or filter parameters",
+		}
+	}
+
+	page, err := cs.cardsRepository.ListCards(collectionId, query)
 	if err != nil {
 		return nil, err
 	}
 
-	if collection == nil {
+	// For json serialization, ensure Cards is not nil
+	if page.Cards == nil {
+		page.Cards = []domain.Card{}
+	}
+
+	return page, nil
+}
+
+// AddCardToCollection adds a card to a collection by its ID and returns the card entry.
+func (cs CardsService) AddCardToCollection(actor domain.Actor, collectionId string, card *domain.Card) (*domain.Card, *domain.ResponseErr) {
+	if respErr := normalizeCardVariant(card); respErr != nil {
+		cs.log.Warn("Invalid card variant", zap.String("scryfallID", card.ScryfallID), zap.Error(respErr))
+		return nil, respErr
+	}
+
+	if respErr := cs.fillCardMetadata(card); respErr != nil {
+		return nil, respErr
+	}
+
+	return cs.cardsRepository.AddCardToCollection(actor, collectionId, card)
+}
+
+// fillCardMetadata checks that Scryfall knows the card and takes its name, image, set, rarity
+// and type line from there. While Scryfall is unavailable cards sent with a name are stored
+// with the data of the request, so adding cards doesn't stop together with Scryfall.
+func (cs CardsService) fillCardMetadata(card *domain.Card) *domain.ResponseErr {
+	if !scryfallIDRegexp.MatchString(card.ScryfallID) {
+		cs.log.Warn("Invalid Scryfall ID", zap.String("scryfallID", card.ScryfallID))
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid Scryfall ID",
+		}
+	}
+
+	printing, err := cs.cardLookup.Card(context.TODO(), card.ScryfallID)
+	return cs.fillFromPrinting(card, printing, err)
+}
+
+// fillFromPrinting takes the card data from the printing found for it, err is the error of the lookup.
+func (cs CardsService) fillFromPrinting(card *domain.Card, printing *scryfall.Card, err error) *domain.ResponseErr {
+	if err != nil {
+		if errors.Is(err, scryfall.ErrNotFound) {
+			return &domain.ResponseErr{
+				Status:  http.StatusBadRequest,
+				Message: "Card not found in Scryfall",
+			}
+		}
+		if card.Name != "" {
+			cs.log.Warn("Card is added without Scryfall data", zap.String("scryfallID", card.ScryfallID), zap.Error(err))
+			return nil
+		}
+		cs.log.Error("Failed to look up card", zap.String("scryfallID", card.ScryfallID), zap.Error(err))
+		return &domain.ResponseErr{
+			Status:  http.StatusServiceUnavailable,
+			Message: "Card catalog is unavailable",
+		}
+	}
+
+	card.Name = printing.Name
+	card.CardUrl = printing.ImageURI()
+	card.SetCode = printing.Set
+	card.Rarity = printing.Rarity
+	card.TypeLine = printing.TypeLine
+	return nil
+}
+
+// SetCardCountInCollection updates the count of a card entry in a collection by its ID.
+// The count must be positive, entries are removed with DeleteCardFromCollection.
+func (cs CardsService) SetCardCountInCollection(actor domain.Actor, collectionId string, card *domain.Card) *domain.ResponseErr {
+	if card.Count <= 0 {
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Count must be positive",
+		}
+	}
+
+	return cs.cardsRepository.SetCardCountInCollection(actor, collectionId, card)
+}
+
+// DeleteCardFromCollection moves a card entry of a collection to the trash by its ID.
+func (cs CardsService) DeleteCardFromCollection(actor domain.Actor, collectionId string, card *domain.Card) *domain.ResponseErr {
+	return cs.cardsRepository.DeleteCardFromCollection(actor, collectionId, card)
+}
+
+// AdjustCardCount adds or removes copies of a card entry, the entry goes to the trash at zero copies.
+func (cs CardsService) AdjustCardCount(actor domain.Actor, collectionId string, adjust *domain.CardAdjustment) (*domain.Card, *domain.ResponseErr) {
+	if adjust.Delta == 0 {
 		return nil, &domain.ResponseErr{
-			Status:  http.StatusNotFound,
-			Message: "Collection not found",
+			Status:  http.StatusBadRequest,
+			Message: "Delta must not be zero",
 		}
 	}
 
-	// For json serialization, ensure Cards is not nil
-	if collection.Cards == nil {
-		collection.Cards = []domain.Card{}
+	return cs.cardsRepository.AdjustCardCount(actor, collectionId, adjust)
+}
+
+// MoveCardBetweenZones moves copies of a card entry to another zone of the collection.
+func (cs CardsService) MoveCardBetweenZones(actor domain.Actor, collectionId string, move *domain.CardMove) *domain.ResponseErr {
+	if !move.To.IsValid() {
+		cs.log.Warn("Invalid zone", zap.String("zone", string(move.To)))
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid zone",
+		}
 	}
 
-	return collection.Cards, nil
+	if move.Count <= 0 {
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Count must be positive",
+		}
+	}
+
+	return cs.cardsRepository.MoveCardBetweenZones(actor, collectionId, move)
 }
 
-// AddCardToCollection adds a card to a collection by its ID.
-func (cs CardsService) AddCardToCollection(collectionId string, card *domain.Card) *domain.ResponseErr {
-	return cs.cardsRepository.AddCardToCollection(collectionId, card)
+// TransferCards moves copies of card entries from one user's collection to another.
+func (cs CardsService) TransferCards(actor domain.Actor, transfer *domain.CardTransfer) *domain.ResponseErr {
+	if !isValidCollectionID(transfer.FromCollectionID) || !isValidCollectionID(transfer.ToCollectionID) {
+		cs.log.Warn("Invalid collection ID", zap.String("from", transfer.FromCollectionID), zap.String("to", transfer.ToCollectionID))
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID",
+		}
+	}
+
+	if transfer.FromCollectionID == transfer.ToCollectionID {
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Source and destination collections are the same",
+		}
+	}
+
+	if len(transfer.Items) == 0 {
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Nothing to transfer",
+		}
+	}
+
+	for _, item := range transfer.Items {
+		if item.Count <= 0 {
+			return &domain.ResponseErr{
+				Status:  http.StatusBadRequest,
+				Message: "Count must be positive",
+			}
+		}
+	}
+
+	return cs.cardsRepository.TransferCards(actor, transfer)
 }
 
-// SetCardCountInCollection updates the count of a card in a collection by its ID.
-func (cs CardsService) SetCardCountInCollection(collectionId string, card *domain.Card) *domain.ResponseErr {
-	return cs.cardsRepository.SetCardCountInCollection(collectionId, card)
+// ApplyCardOperations applies a batch of add, set count and delete operations to a collection.
+// Invalid operations fail an atomic batch and are skipped in a best effort one.
+func (cs CardsService) ApplyCardOperations(actor domain.Actor, collectionId string, batch *domain.CardBatch) (*domain.CardBatchResult, *domain.ResponseErr) {
+	if batch.Mode == "" {
+		batch.Mode = domain.BatchAtomic
+	}
+	if !batch.Mode.IsValid() {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid batch mode",
+		}
+	}
+
+	if len(batch.Operations) == 0 || len(batch.Operations) > domain.MaxCardOperations {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: fmt.Sprintf("Batch must have from 1 to %d operations", domain.MaxCardOperations),
+		}
+	}
+
+	result := &domain.CardBatchResult{
+		Applied: true,
+		Results: make([]domain.CardOperationResult, len(batch.Operations)),
+	}
+
+	// Only valid operations reach the repository, indexes map their results back
+	valid := &domain.CardBatch{Mode: batch.Mode}
+	var indexes []int
+	for i := range batch.Operations {
+		result.Results[i].Err = validateCardOperation(&batch.Operations[i])
+	}
+	cs.fillBatchMetadata(batch, result)
+	for i, op := range batch.Operations {
+		if result.Results[i].Err != nil {
+			continue
+		}
+		valid.Operations = append(valid.Operations, op)
+		indexes = append(indexes, i)
+	}
+
+	if len(indexes) < len(batch.Operations) && batch.Mode == domain.BatchAtomic {
+		cs.log.Warn("Atomic card batch has invalid operations", zap.String("collectionID", collectionId))
+		result.Applied = false
+		return result, nil
+	}
+	if len(indexes) == 0 {
+		return result, nil
+	}
+
+	applied, respErr := cs.cardsRepository.ApplyCardOperations(actor, collectionId, valid)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	result.Applied = applied.Applied
+	for j, res := range applied.Results {
+		result.Results[indexes[j]] = res
+	}
+
+	return result, nil
 }
 
-// DeleteCardFromCollection removes a card from a collection by its ID.
-func (cs CardsService) DeleteCardFromCollection(collectionId string, card *domain.Card) *domain.ResponseErr {
-	return cs.cardsRepository.DeleteCardFromCollection(collectionId, card)
+// fillBatchMetadata checks the cards of valid add operations in Scryfall as fillCardMetadata does,
+// the printings are looked up together. Failed checks are set as errors of the operations.
+func (cs CardsService) fillBatchMetadata(batch *domain.CardBatch, result *domain.CardBatchResult) {
+	var adds []int
+	var ids []string
+	for i, op := range batch.Operations {
+		if op.Type != domain.CardOperationAdd || result.Results[i].Err != nil {
+			continue
+		}
+		adds = append(adds, i)
+		ids = append(ids, op.Card.ScryfallID)
+	}
+	if len(adds) == 0 {
+		return
+	}
+
+	printings, err := cs.cardLookup.Cards(context.TODO(), ids)
+	for _, i := range adds {
+		card := &batch.Operations[i].Card
+		printing, ok := printings[card.ScryfallID]
+		lookupErr := err
+		if err == nil && !ok {
+			lookupErr = scryfall.ErrNotFound
+		}
+		result.Results[i].Err = cs.fillFromPrinting(card, printing, lookupErr)
+	}
+}
+
+// validateCardOperation checks an operation of a card batch and fills default variant fields of added cards
+func validateCardOperation(op *domain.CardOperation) *domain.ResponseErr {
+	switch op.Type {
+	case domain.CardOperationAdd:
+		if !scryfallIDRegexp.MatchString(op.Card.ScryfallID) {
+			return &domain.ResponseErr{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid Scryfall ID",
+			}
+		}
+		if op.Card.Count <= 0 {
+			return &domain.ResponseErr{
+				Status:  http.StatusBadRequest,
+				Message: "Count must be positive",
+			}
+		}
+		return normalizeCardVariant(&op.Card)
+
+	case domain.CardOperationSetCount, domain.CardOperationDelete:
+		if !isValidCollectionID(op.Card.ID) {
+			return &domain.ResponseErr{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid card entry ID",
+			}
+		}
+		if op.Type == domain.CardOperationSetCount && op.Card.Count <= 0 {
+			return &domain.ResponseErr{
+				Status:  http.StatusBadRequest,
+				Message: "Count must be positive",
+			}
+		}
+		return nil
+	}
+
+	return &domain.ResponseErr{
+		Status:  http.StatusBadRequest,
+		Message: fmt.Sprintf("Unknown operation %q", op.Type),
+	}
+}
+
+// normalizeCardVariant fills default variant fields and validates them. Scryfall IDs are
+// lower cased as Scryfall and the catalog return them, so both cases are one variant.
+func normalizeCardVariant(card *domain.Card) *domain.ResponseErr {
+	card.ScryfallID = strings.ToLower(card.ScryfallID)
+	card.SetVariantDefaults()
+
+	if !card.Zone.IsValid() {
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid zone",
+		}
+	}
+
+	if !card.Finish.IsValid() {
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid finish",
+		}
+	}
+
+	if !card.Condition.IsValid() {
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid condition",
+		}
+	}
+
+	if !domain.IsValidLanguage(card.Language) {
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid language",
+		}
+	}
+
+	return nil
 }
+
+var scryfallIDRegexp = regexp.MustCompile("^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$")
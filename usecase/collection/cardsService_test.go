@@ -0,0 +1,197 @@
+package collection
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/ShenokZlob/collector-service/pkg/scryfall"
+	"github.com/ShenokZlob/collector-service/usecase/collection/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const boltScryfallID = "e3285e6b-3e79-4d7c-bf96-d920f973b80d"
+
+var boltPrinting = &scryfall.Card{
+	ID:        boltScryfallID,
+	Name:      "Lightning Bolt",
+	Set:       "m10",
+	Rarity:    "common",
+	TypeLine:  "Instant",
+	ImageURIs: &scryfall.ImageURIs{Normal: "https://cards.scryfall.io/normal/bolt.jpg"},
+}
+
+func TestAddCardToCollectionFillsScryfallData(t *testing.T) {
+	repository := mocks.NewMockCardsRepositorer(t)
+	lookup := mocks.NewMockCardLookup(t)
+	service := NewCardsService(zap.NewNop(), repository, lookup)
+
+	lookup.On("Card", mock.Anything, boltScryfallID).Return(boltPrinting, nil)
+	repository.On("AddCardToCollection", testActor, testCollectionID, mock.MatchedBy(func(c *domain.Card) bool {
+		return c.Name == "Lightning Bolt" && c.CardUrl == "https://cards.scryfall.io/normal/bolt.jpg" &&
+			c.SetCode == "m10" && c.Rarity == "common" && c.TypeLine == "Instant"
+	})).Return(&domain.Card{ID: "64a9b66b2db8b91234a6e8e4"}, nil)
+
+	// The name of the request is replaced with the Scryfall one
+	entry, respErr := service.AddCardToCollection(testActor, testCollectionID, &domain.Card{ScryfallID: boltScryfallID, Name: "bolt", Count: 1})
+
+	require.Nil(t, respErr)
+	assert.Equal(t, "64a9b66b2db8b91234a6e8e4", entry.ID)
+}
+
+func TestAddCardToCollectionLowerCasesScryfallID(t *testing.T) {
+	repository := mocks.NewMockCardsRepositorer(t)
+	lookup := mocks.NewMockCardLookup(t)
+	service := NewCardsService(zap.NewNop(), repository, lookup)
+
+	lookup.On("Card", mock.Anything, boltScryfallID).Return(boltPrinting, nil)
+	entries := map[string]int{}
+	repository.On("AddCardToCollection", testActor, testCollectionID, mock.Anything).
+		Run(func(args mock.Arguments) {
+			card := args.Get(2).(*domain.Card)
+			entries[card.VariantKey()] += card.Count
+		}).
+		Return(&domain.Card{ID: "64a9b66b2db8b91234a6e8e4"}, nil)
+
+	for _, id := range []string{strings.ToUpper(boltScryfallID), boltScryfallID} {
+		_, respErr := service.AddCardToCollection(testActor, testCollectionID, &domain.Card{ScryfallID: id, Count: 1})
+		require.Nil(t, respErr)
+	}
+
+	assert.Len(t, entries, 1)
+	for key, count := range entries {
+		assert.True(t, strings.HasPrefix(key, boltScryfallID))
+		assert.Equal(t, 2, count)
+	}
+}
+
+func TestAddCardToCollectionRejectsUnknownCards(t *testing.T) {
+	tests := []struct {
+		name       string
+		scryfallID string
+		lookupErr  error
+		message    string
+	}{
+		{name: "malformed ID", scryfallID: "bolt", message: "Invalid Scryfall ID"},
+		{name: "unknown ID", scryfallID: boltScryfallID, lookupErr: scryfall.ErrNotFound, message: "Card not found in Scryfall"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := mocks.NewMockCardsRepositorer(t)
+			lookup := mocks.NewMockCardLookup(t)
+			service := NewCardsService(zap.NewNop(), repository, lookup)
+			if tt.lookupErr != nil {
+				lookup.On("Card", mock.Anything, tt.scryfallID).Return(nil, tt.lookupErr)
+			}
+
+			_, respErr := service.AddCardToCollection(testActor, testCollectionID, &domain.Card{ScryfallID: tt.scryfallID, Count: 1})
+
+			require.NotNil(t, respErr)
+			assert.Equal(t, http.StatusBadRequest, respErr.Status)
+			assert.Equal(t, tt.message, respErr.Message)
+			repository.AssertNotCalled(t, "AddCardToCollection", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAddCardToCollectionWhileScryfallIsUnavailable(t *testing.T) {
+	unavailable := fmt.Errorf("%w: circuit breaker is open", scryfall.ErrUnavailable)
+
+	t.Run("request with a name is stored as is", func(t *testing.T) {
+		repository := mocks.NewMockCardsRepositorer(t)
+		lookup := mocks.NewMockCardLookup(t)
+		service := NewCardsService(zap.NewNop(), repository, lookup)
+
+		lookup.On("Card", mock.Anything, boltScryfallID).Return(nil, unavailable)
+		repository.On("AddCardToCollection", testActor, testCollectionID, mock.MatchedBy(func(c *domain.Card) bool {
+			return c.Name == "Lightning Bolt" && c.SetCode == ""
+		})).Return(&domain.Card{ID: "64a9b66b2db8b91234a6e8e4"}, nil)
+
+		_, respErr := service.AddCardToCollection(testActor, testCollectionID, &domain.Card{ScryfallID: boltScryfallID, Name: "Lightning Bolt", Count: 1})
+
+		require.Nil(t, respErr)
+	})
+
+	t.Run("request without a name fails", func(t *testing.T) {
+		repository := mocks.NewMockCardsRepositorer(t)
+		lookup := mocks.NewMockCardLookup(t)
+		service := NewCardsService(zap.NewNop(), repository, lookup)
+
+		lookup.On("Card", mock.Anything, boltScryfallID).Return(nil, unavailable)
+
+		_, respErr := service.AddCardToCollection(testActor, testCollectionID, &domain.Card{ScryfallID: boltScryfallID, Count: 1})
+
+		require.NotNil(t, respErr)
+		assert.Equal(t, http.StatusServiceUnavailable, respErr.Status)
+	})
+}
+
+func TestSetCardCountInCollectionRejectsNonPositiveCount(t *testing.T) {
+	repository := mocks.NewMockCardsRepositorer(t)
+	service := NewCardsService(zap.NewNop(), repository, mocks.NewMockCardLookup(t))
+
+	for _, count := range []int{0, -3} {
+		respErr := service.SetCardCountInCollection(testActor, testCollectionID, &domain.Card{ID: "64a9b66b2db8b91234a6e8e4", Count: count})
+		require.NotNil(t, respErr)
+		assert.Equal(t, http.StatusBadRequest, respErr.Status)
+	}
+	repository.AssertNotCalled(t, "SetCardCountInCollection", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestApplyCardOperationsChecksAddedCardsTogether(t *testing.T) {
+	repository := mocks.NewMockCardsRepositorer(t)
+	lookup := mocks.NewMockCardLookup(t)
+	service := NewCardsService(zap.NewNop(), repository, lookup)
+
+	const unknownID = "00000000-0000-0000-0000-000000000000"
+	lookup.On("Cards", mock.Anything, []string{boltScryfallID, unknownID}).
+		Return(map[string]*scryfall.Card{boltScryfallID: boltPrinting}, nil).Once()
+	repository.On("ApplyCardOperations", testActor, testCollectionID, mock.MatchedBy(func(b *domain.CardBatch) bool {
+		return len(b.Operations) == 1 && b.Operations[0].Card.Name == "Lightning Bolt" && b.Operations[0].Card.SetCode == "m10"
+	})).Return(&domain.CardBatchResult{Applied: true, Results: []domain.CardOperationResult{{Entry: &domain.Card{ID: "64a9b66b2db8b91234a6e8e4"}}}}, nil)
+
+	batch := &domain.CardBatch{
+		Mode: domain.BatchBestEffort,
+		Operations: []domain.CardOperation{
+			{Type: domain.CardOperationAdd, Card: domain.Card{ScryfallID: boltScryfallID, Name: "bolt", Count: 4}},
+			{Type: domain.CardOperationAdd, Card: domain.Card{ScryfallID: unknownID, Name: "Fake card", Count: 1}},
+			{Type: domain.CardOperationAdd, Card: domain.Card{ScryfallID: "not-an-id", Count: 1}},
+		},
+	}
+	result, respErr := service.ApplyCardOperations(testActor, testCollectionID, batch)
+
+	require.Nil(t, respErr)
+	assert.True(t, result.Applied)
+	assert.Equal(t, "64a9b66b2db8b91234a6e8e4", result.Results[0].Entry.ID)
+	require.NotNil(t, result.Results[1].Err)
+	assert.Equal(t, "Card not found in Scryfall", result.Results[1].Err.Message)
+	require.NotNil(t, result.Results[2].Err)
+	assert.Equal(t, http.StatusBadRequest, result.Results[2].Err.Status)
+}
+
+func TestApplyCardOperationsWhileScryfallIsUnavailable(t *testing.T) {
+	repository := mocks.NewMockCardsRepositorer(t)
+	lookup := mocks.NewMockCardLookup(t)
+	service := NewCardsService(zap.NewNop(), repository, lookup)
+
+	lookup.On("Cards", mock.Anything, []string{boltScryfallID}).Return(nil, scryfall.ErrUnavailable)
+
+	// Cards without a name can't be stored without Scryfall, so the atomic batch is rejected
+	batch := &domain.CardBatch{
+		Operations: []domain.CardOperation{
+			{Type: domain.CardOperationAdd, Card: domain.Card{ScryfallID: boltScryfallID, Count: 4}},
+		},
+	}
+	result, respErr := service.ApplyCardOperations(testActor, testCollectionID, batch)
+
+	require.Nil(t, respErr)
+	assert.False(t, result.Applied)
+	assert.Equal(t, http.StatusServiceUnavailable, result.Results[0].Err.Status)
+	repository.AssertNotCalled(t, "ApplyCardOperations", mock.Anything, mock.Anything, mock.Anything)
+}
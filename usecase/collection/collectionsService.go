@@ -17,9 +17,13 @@ type CollectionsRepositorer interface {
 	// GetAllUsersCollections(userId string) ([]*domain.UserCollectionRef, *domain.ResponseErr)
 	GetUser(userId string) (*domain.User, *domain.ResponseErr)
 	GetCollection(collectionID string) (*domain.Collection, *domain.ResponseErr)
+	FindCollectionByName(userID, name string) (*domain.Collection, *domain.ResponseErr)
 	CreateCollection(collection *domain.Collection) (*domain.Collection, *domain.ResponseErr)
-	RenameCollection(collection *domain.Collection) (*domain.Collection, *domain.ResponseErr)
-	DeleteCollection(userID, collectionID string) *domain.ResponseErr
+	RenameCollection(actor domain.Actor, collection *domain.Collection) (*domain.Collection, *domain.ResponseErr)
+	DeleteCollection(actor domain.Actor, collectionID string) *domain.ResponseErr
+	CloneCollection(userID, collectionID, name string) (*domain.Collection, *domain.ResponseErr)
+	MergeCollections(actor domain.Actor, merge *domain.CollectionMerge) (*domain.Collection, *domain.ResponseErr)
+	SetCollectionKind(actor domain.Actor, collectionID string, kind domain.CollectionKind) (*domain.Collection, *domain.ResponseErr)
 }
 
 func NewCollectionsService(log *zap.Logger, collectionRepository CollectionsRepositorer) *CollectionsService {
@@ -50,15 +54,20 @@ func (cs CollectionsService) Get(collectionID string) (*domain.Collection, *doma
 	return cs.collectionRepository.GetCollection(collectionID)
 }
 
-func (cs CollectionsService) Create(collection *domain.Collection) (*domain.Collection, *domain.ResponseErr) {
-	if !isValidCollectionID(collection.ID) {
-		cs.log.Warn("Invalid collection ID", zap.String("collectionID", collection.ID))
+// GetByName finds the user's collection by name ignoring case
+func (cs CollectionsService) GetByName(userID, name string) (*domain.Collection, *domain.ResponseErr) {
+	if !isValidCollectionName(name) {
+		cs.log.Warn("Invalid collection name", zap.String("collectionName", name))
 		return nil, &domain.ResponseErr{
 			Status:  http.StatusBadRequest,
-			Message: "Invalid collection ID",
+			Message: "Invalid collection name",
 		}
 	}
 
+	return cs.collectionRepository.FindCollectionByName(userID, name)
+}
+
+func (cs CollectionsService) Create(collection *domain.Collection) (*domain.Collection, *domain.ResponseErr) {
 	if !isValidCollectionName(collection.Name) {
 		cs.log.Warn("Invalid collection name", zap.String("collectionName", collection.Name))
 		return nil, &domain.ResponseErr{
@@ -67,10 +76,42 @@ func (cs CollectionsService) Create(collection *domain.Collection) (*domain.Coll
 		}
 	}
 
+	if collection.Kind == "" {
+		collection.Kind = domain.KindBinder
+	}
+	if !collection.Kind.IsValid() {
+		cs.log.Warn("Invalid collection kind", zap.String("kind", string(collection.Kind)))
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection kind",
+		}
+	}
+
 	return cs.collectionRepository.CreateCollection(collection)
 }
 
-func (cs CollectionsService) Rename(collection *domain.Collection) (*domain.Collection, *domain.ResponseErr) {
+// SetKind changes what the user's collection is used for: binder, deck, wishlist or trade list
+func (cs CollectionsService) SetKind(actor domain.Actor, collectionID string, kind domain.CollectionKind) (*domain.Collection, *domain.ResponseErr) {
+	if !isValidCollectionID(collectionID) {
+		cs.log.Warn("Invalid collection ID", zap.String("collectionID", collectionID))
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID",
+		}
+	}
+
+	if !kind.IsValid() {
+		cs.log.Warn("Invalid collection kind", zap.String("kind", string(kind)))
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection kind",
+		}
+	}
+
+	return cs.collectionRepository.SetCollectionKind(actor, collectionID, kind)
+}
+
+func (cs CollectionsService) Rename(actor domain.Actor, collection *domain.Collection) (*domain.Collection, *domain.ResponseErr) {
 	if !isValidCollectionID(collection.ID) {
 		cs.log.Warn("Invalid collection ID", zap.String("collectionID", collection.ID))
 		return nil, &domain.ResponseErr{
@@ -87,10 +128,10 @@ func (cs CollectionsService) Rename(collection *domain.Collection) (*domain.Coll
 		}
 	}
 
-	return cs.collectionRepository.RenameCollection(collection)
+	return cs.collectionRepository.RenameCollection(actor, collection)
 }
 
-func (cs CollectionsService) Delete(userID, collectionID string) *domain.ResponseErr {
+func (cs CollectionsService) Delete(actor domain.Actor, collectionID string) *domain.ResponseErr {
 	if !isValidCollectionID(collectionID) {
 		cs.log.Warn("Invalid collection ID", zap.String("collectionID", collectionID))
 		return &domain.ResponseErr{
@@ -99,7 +140,59 @@ func (cs CollectionsService) Delete(userID, collectionID string) *domain.Respons
 		}
 	}
 
-	return cs.collectionRepository.DeleteCollection(userID, collectionID)
+	return cs.collectionRepository.DeleteCollection(actor, collectionID)
+}
+
+// Clone copies the user's collection with all its cards under a new name
+func (cs CollectionsService) Clone(userID, collectionID, name string) (*domain.Collection, *domain.ResponseErr) {
+	if !isValidCollectionID(collectionID) {
+		cs.log.Warn("Invalid collection ID", zap.String("collectionID", collectionID))
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID",
+		}
+	}
+
+	if !isValidCollectionName(name) {
+		cs.log.Warn("Invalid collection name", zap.String("collectionName", name))
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection name",
+		}
+	}
+
+	return cs.collectionRepository.CloneCollection(userID, collectionID, name)
+}
+
+// Merge merges one user's collection into another
+func (cs CollectionsService) Merge(actor domain.Actor, merge *domain.CollectionMerge) (*domain.Collection, *domain.ResponseErr) {
+	if !isValidCollectionID(merge.SourceID) || !isValidCollectionID(merge.TargetID) {
+		cs.log.Warn("Invalid collection ID", zap.String("sourceID", merge.SourceID), zap.String("targetID", merge.TargetID))
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID",
+		}
+	}
+
+	if merge.SourceID == merge.TargetID {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Can't merge collection into itself",
+		}
+	}
+
+	if merge.Strategy == "" {
+		merge.Strategy = domain.MergeSum
+	}
+	if !merge.Strategy.IsValid() {
+		cs.log.Warn("Invalid merge strategy", zap.String("strategy", string(merge.Strategy)))
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid merge strategy",
+		}
+	}
+
+	return cs.collectionRepository.MergeCollections(actor, merge)
 }
 
 var oidRegexp = regexp.MustCompile("^[0-9a-fA-F]{24}$")
@@ -0,0 +1,162 @@
+package collection
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"go.uber.org/zap"
+)
+
+type DeckService struct {
+	deckRepository DeckRepositorer
+	catalog        PrintingCatalog
+	log            *zap.Logger
+}
+
+type DeckRepositorer interface {
+	StreamCards(collectionId string, fn func(domain.Card) error) *domain.ResponseErr
+	GetUser(userId string) (*domain.User, *domain.ResponseErr)
+	FindCollectionsByKind(userID string, kind domain.CollectionKind) ([]domain.Collection, *domain.ResponseErr)
+	FindHoldings(targetId string, sourceIds []string) ([]domain.CardHoldings, *domain.ResponseErr)
+}
+
+func NewDeckService(log *zap.Logger, deckRepository DeckRepositorer, catalog PrintingCatalog) *DeckService {
+	return &DeckService{
+		deckRepository: deckRepository,
+		catalog:        catalog,
+		log:            log.With(zap.String("service", "deck")),
+	}
+}
+
+// ValidateDeck checks the collection as a deck of the format. Legalities and color
+// identities come from the card catalog.
+func (ds DeckService) ValidateDeck(collectionId string, format domain.DeckFormat) (*domain.DeckValidation, *domain.ResponseErr) {
+	if !isValidCollectionID(collectionId) {
+		ds.log.Warn("Invalid collection ID", zap.String("collectionID", collectionId))
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID",
+		}
+	}
+
+	if !format.IsValid() {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid format",
+		}
+	}
+
+	var cards []domain.Card
+	respErr := ds.deckRepository.StreamCards(collectionId, func(card domain.Card) error {
+		cards = append(cards, card)
+		return nil
+	})
+	if respErr != nil {
+		ds.log.Error("Failed to read cards", zap.String("collectionID", collectionId), zap.Error(respErr))
+		return nil, respErr
+	}
+
+	printings, respErr := ds.catalog.FindPrintings(scryfallIDs(cards))
+	if respErr != nil {
+		ds.log.Error("Failed to find printings", zap.String("collectionID", collectionId), zap.Error(respErr))
+		return nil, respErr
+	}
+
+	validation := format.Validate(cards, printings)
+	return &validation, nil
+}
+
+// FindMissing compares the user's target collection, usually a deck or a wishlist, to
+// the source collections and reports the copies the user owns and still needs. Any
+// printing of a card counts. Sources default to all of the user's binders.
+func (ds DeckService) FindMissing(userID, targetID string, sourceIDs []string) (*domain.MissingReport, *domain.ResponseErr) {
+	if !isValidCollectionID(targetID) {
+		ds.log.Warn("Invalid collection ID", zap.String("collectionID", targetID))
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID",
+		}
+	}
+
+	user, respErr := ds.deckRepository.GetUser(userID)
+	if respErr != nil {
+		ds.log.Error("Failed to find user", zap.String("userID", userID), zap.Error(respErr))
+		return nil, respErr
+	}
+	owned := make(map[string]domain.UserCollectionRef, len(user.Collections))
+	for _, ref := range user.Collections {
+		owned[ref.ID] = ref
+	}
+	if _, ok := owned[targetID]; !ok {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusNotFound,
+			Message: "Collection not found",
+		}
+	}
+
+	var sources []domain.UserCollectionRef
+	if len(sourceIDs) == 0 {
+		binders, respErr := ds.deckRepository.FindCollectionsByKind(userID, domain.KindBinder)
+		if respErr != nil {
+			ds.log.Error("Failed to find binders", zap.String("userID", userID), zap.Error(respErr))
+			return nil, respErr
+		}
+		for _, binder := range binders {
+			if binder.ID != targetID {
+				sources = append(sources, domain.UserCollectionRef{ID: binder.ID, Name: binder.Name})
+			}
+		}
+	} else {
+		seen := make(map[string]bool, len(sourceIDs))
+		for _, id := range sourceIDs {
+			ref, ok := owned[id]
+			if !ok {
+				return nil, &domain.ResponseErr{
+					Status:  http.StatusNotFound,
+					Message: fmt.Sprintf("Source collection %s not found", id),
+				}
+			}
+			if id == targetID {
+				return nil, &domain.ResponseErr{
+					Status:  http.StatusBadRequest,
+					Message: "Target collection can't be a source",
+				}
+			}
+			if !seen[id] {
+				seen[id] = true
+				sources = append(sources, ref)
+			}
+		}
+	}
+
+	ids := make([]string, len(sources))
+	for i, source := range sources {
+		ids[i] = source.ID
+	}
+	holdings, respErr := ds.deckRepository.FindHoldings(targetID, ids)
+	if respErr != nil {
+		ds.log.Error("Failed to find holdings", zap.String("collectionID", targetID), zap.Error(respErr))
+		return nil, respErr
+	}
+
+	report := domain.FindMissing(holdings, sources)
+	report.CollectionID = targetID
+	if report.Sources == nil {
+		report.Sources = []domain.UserCollectionRef{}
+	}
+	return &report, nil
+}
+
+// scryfallIDs returns the distinct Scryfall IDs of the cards in order
+func scryfallIDs(cards []domain.Card) []string {
+	ids := make([]string, 0, len(cards))
+	seen := make(map[string]bool, len(cards))
+	for _, card := range cards {
+		if !seen[card.ScryfallID] {
+			seen[card.ScryfallID] = true
+			ids = append(ids, card.ScryfallID)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,100 @@
+package collection
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/ShenokZlob/collector-service/usecase/collection/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestValidateDeck(t *testing.T) {
+	repo := mocks.NewMockDeckRepositorer(t)
+	catalog := mocks.NewMockPrintingCatalog(t)
+	service := NewDeckService(zap.NewNop(), repo, catalog)
+
+	repo.On("StreamCards", testCollectionID, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(domain.Card) error)
+			_ = fn(domain.Card{ScryfallID: "bolt", Name: "Lightning Bolt", Count: 4, Zone: domain.ZoneMain})
+			_ = fn(domain.Card{ScryfallID: "bolt", Name: "Lightning Bolt", Count: 1, Zone: domain.ZoneSide})
+			_ = fn(domain.Card{ScryfallID: "mountain", Name: "Mountain", Count: 56, Zone: domain.ZoneMain})
+		}).
+		Return(nil)
+	catalog.On("FindPrintings", []string{"bolt", "mountain"}).
+		Return(map[string]domain.CatalogCard{
+			"bolt":     {ScryfallID: "bolt", Name: "Lightning Bolt", TypeLine: "Instant", Legalities: map[string]string{"modern": "legal"}},
+			"mountain": {ScryfallID: "mountain", Name: "Mountain", TypeLine: "Basic Land — Mountain", Legalities: map[string]string{"modern": "legal"}},
+		}, nil)
+
+	validation, respErr := service.ValidateDeck(testCollectionID, domain.FormatModern)
+
+	require.Nil(t, respErr)
+	assert.False(t, validation.Legal)
+	assert.Equal(t, 60, validation.DeckSize)
+	require.Len(t, validation.Violations, 1)
+	assert.Equal(t, domain.ViolationTooManyCopies, validation.Violations[0].Kind)
+}
+
+func TestValidateDeckInvalidFormat(t *testing.T) {
+	service := NewDeckService(zap.NewNop(), mocks.NewMockDeckRepositorer(t), mocks.NewMockPrintingCatalog(t))
+
+	_, respErr := service.ValidateDeck(testCollectionID, "brawl")
+
+	require.NotNil(t, respErr)
+	assert.Equal(t, http.StatusBadRequest, respErr.Status)
+}
+
+func TestFindMissingDefaultsToBinders(t *testing.T) {
+	repo := mocks.NewMockDeckRepositorer(t)
+	service := NewDeckService(zap.NewNop(), repo, mocks.NewMockPrintingCatalog(t))
+	binderID, wishlistID := "64a9b66b2db8b91234a6e8e4", "64a9b66b2db8b91234a6e8e5"
+
+	repo.On("GetUser", "user").Return(&domain.User{Collections: []domain.UserCollectionRef{
+		{ID: testCollectionID, Name: "Burn"},
+		{ID: binderID, Name: "Binder"},
+		{ID: wishlistID, Name: "Wishlist"},
+	}}, nil)
+	repo.On("FindCollectionsByKind", "user", domain.KindBinder).
+		Return([]domain.Collection{{ID: binderID, Name: "Binder"}}, nil)
+	repo.On("FindHoldings", testCollectionID, []string{binderID}).
+		Return([]domain.CardHoldings{{
+			OracleKey: "bolt",
+			Entries:   []domain.Card{{Name: "Lightning Bolt", Count: 4, Zone: domain.ZoneMain}},
+			Owned:     map[string]int{binderID: 3},
+		}}, nil)
+
+	report, respErr := service.FindMissing("user", testCollectionID, nil)
+
+	require.Nil(t, respErr)
+	assert.Equal(t, testCollectionID, report.CollectionID)
+	assert.Equal(t, []domain.UserCollectionRef{{ID: binderID, Name: "Binder"}}, report.Sources)
+	assert.Equal(t, 3, report.Owned)
+	assert.Equal(t, 1, report.Missing)
+	require.Len(t, report.Entries, 1)
+	assert.Equal(t, []domain.OwnedCopies{{CollectionID: binderID, CollectionName: "Binder", Count: 3}}, report.Entries[0].Owned)
+}
+
+func TestFindMissingRejectsOtherUsersCollections(t *testing.T) {
+	repo := mocks.NewMockDeckRepositorer(t)
+	service := NewDeckService(zap.NewNop(), repo, mocks.NewMockPrintingCatalog(t))
+	repo.On("GetUser", "user").Return(&domain.User{Collections: []domain.UserCollectionRef{
+		{ID: testCollectionID, Name: "Burn"},
+	}}, nil)
+
+	_, respErr := service.FindMissing("user", "64a9b66b2db8b91234a6e8e9", nil)
+	require.NotNil(t, respErr)
+	assert.Equal(t, http.StatusNotFound, respErr.Status)
+
+	_, respErr = service.FindMissing("user", testCollectionID, []string{"64a9b66b2db8b91234a6e8e9"})
+	require.NotNil(t, respErr)
+	assert.Equal(t, http.StatusNotFound, respErr.Status)
+
+	_, respErr = service.FindMissing("user", testCollectionID, []string{testCollectionID})
+	require.NotNil(t, respErr)
+	assert.Equal(t, http.StatusBadRequest, respErr.Status)
+}
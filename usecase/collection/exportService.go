@@ -0,0 +1,162 @@
+package collection
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/ShenokZlob/collector-service/pkg/cardcsv"
+	"github.com/ShenokZlob/collector-service/pkg/decklist"
+	"go.uber.org/zap"
+)
+
+type ExportService struct {
+	exportRepository ExportRepositorer
+	catalog          PrintingCatalog
+	log              *zap.Logger
+}
+
+type ExportRepositorer interface {
+	GetCollection(collectionId string) (*domain.Collection, *domain.ResponseErr)
+	// StreamCards reads card entries of a collection one by one, so exports
+	// don't keep huge collections in memory.
+	StreamCards(collectionId string, fn func(domain.Card) error) *domain.ResponseErr
+}
+
+// PrintingCatalog knows sets and collector numbers of printings, card entries only keep Scryfall IDs.
+type PrintingCatalog interface {
+	FindPrintings(scryfallIds []string) (map[string]domain.CatalogCard, *domain.ResponseErr)
+}
+
+func NewExportService(log *zap.Logger, exportRepository ExportRepositorer, catalog PrintingCatalog) *ExportService {
+	return &ExportService{
+		exportRepository: exportRepository,
+		catalog:          catalog,
+		log:              log.With(zap.String("service", "export")),
+	}
+}
+
+// ExportCSV writes the cards of the collection to w as CSV of the given format,
+// the native format is used when it's empty. Cards are streamed, so a part of
+// the file may already be written to w when reading them fails.
+func (es ExportService) ExportCSV(collectionId, format string, w io.Writer) *domain.ResponseErr {
+	if !isValidCollectionID(collectionId) {
+		es.log.Warn("Invalid collection ID", zap.String("collectionID", collectionId))
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID",
+		}
+	}
+
+	if format == "" {
+		format = string(cardcsv.FormatNative)
+	}
+	writer, err := cardcsv.NewWriter(w, cardcsv.Format(format))
+	if err != nil {
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid CSV format",
+		}
+	}
+
+	respErr := es.exportRepository.StreamCards(collectionId, func(card domain.Card) error {
+		return writer.Write(cardcsv.Record{
+			ID:         card.ID,
+			ScryfallID: card.ScryfallID,
+			Name:       card.Name,
+			CardURL:    card.CardUrl,
+			Count:      card.Count,
+			Zone:       string(card.Zone),
+			Finish:     string(card.Finish),
+			Condition:  string(card.Condition),
+			Language:   card.Language,
+			SetCode:    card.SetCode,
+			AddedAt:    card.AddedAt,
+		})
+	})
+	if respErr != nil {
+		es.log.Error("Failed to export cards", zap.String("collectionID", collectionId), zap.Error(respErr))
+		return respErr
+	}
+
+	if err := writer.Flush(); err != nil {
+		es.log.Error("Failed to flush CSV", zap.String("collectionID", collectionId), zap.Error(err))
+		return &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: "Failed to write CSV",
+		}
+	}
+
+	return nil
+}
+
+// ExportDecklist writes the cards of the collection to w as a text decklist grouped by zone.
+// Set codes and collector numbers are taken from the catalog, printings missing from it are
+// written with the set of the entry, if it's known. Nothing is written to w when the cards
+// can't be read, a failed write to w may leave a part of the list in it.
+func (es ExportService) ExportDecklist(collectionId, format string, w io.Writer) *domain.ResponseErr {
+	if !isValidCollectionID(collectionId) {
+		es.log.Warn("Invalid collection ID", zap.String("collectionID", collectionId))
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID",
+		}
+	}
+
+	if !decklist.Format(format).IsValid() {
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid decklist format",
+		}
+	}
+
+	collection, respErr := es.exportRepository.GetCollection(collectionId)
+	if respErr != nil {
+		return respErr
+	}
+
+	// Decklists are grouped by zone, so all entries are needed before writing
+	var cards []domain.Card
+	respErr = es.exportRepository.StreamCards(collectionId, func(card domain.Card) error {
+		cards = append(cards, card)
+		return nil
+	})
+	if respErr != nil {
+		es.log.Error("Failed to read cards", zap.String("collectionID", collectionId), zap.Error(respErr))
+		return respErr
+	}
+
+	printings, respErr := es.catalog.FindPrintings(scryfallIDs(cards))
+	if respErr != nil {
+		es.log.Error("Failed to find printings", zap.String("collectionID", collectionId), zap.Error(respErr))
+		return respErr
+	}
+
+	entries := make([]decklist.Entry, len(cards))
+	for i, card := range cards {
+		entry := decklist.Entry{
+			Count:   card.Count,
+			Name:    card.Name,
+			SetCode: card.SetCode,
+			Zone:    string(card.Zone),
+		}
+		if card.Finish != domain.FinishNonfoil {
+			entry.Finish = string(card.Finish)
+		}
+		if printing, ok := printings[card.ScryfallID]; ok {
+			entry.SetCode = printing.SetCode
+			entry.CollectorNumber = printing.CollectorNumber
+		}
+		entries[i] = entry
+	}
+
+	if err := decklist.Write(w, decklist.Format(format), collection.Name, entries); err != nil {
+		es.log.Error("Failed to write decklist", zap.String("collectionID", collectionId), zap.Error(err))
+		return &domain.ResponseErr{
+			Status:  http.StatusInternalServerError,
+			Message: "Failed to write decklist",
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package collection
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/ShenokZlob/collector-service/usecase/collection/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestExportCSV(t *testing.T) {
+	streamer := mocks.NewMockExportRepositorer(t)
+	service := NewExportService(zap.NewNop(), streamer, mocks.NewMockPrintingCatalog(t))
+
+	streamer.On("StreamCards", testCollectionID, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(domain.Card) error)
+			_ = fn(domain.Card{
+				ID:         "64a9b66b2db8b91234a6e8e4",
+				ScryfallID: "0afa0e33-4804-4b00-b625-c2d6b61090fc",
+				Name:       "Sol Ring",
+				Count:      2,
+				Zone:       domain.ZoneMain,
+				Finish:     domain.FinishFoil,
+				Condition:  domain.ConditionNearMint,
+				Language:   "en",
+				AddedAt:    time.Date(2024, 5, 1, 10, 20, 30, 0, time.UTC),
+			})
+		}).
+		Return(nil)
+
+	var buf bytes.Buffer
+	respErr := service.ExportCSV(testCollectionID, "manabox", &buf)
+
+	require.Nil(t, respErr)
+	assert.Equal(t, "Name,Set code,Set name,Collector number,Foil,Rarity,Quantity,ManaBox ID,Scryfall ID,Purchase price,Misprint,Altered,Condition,Language,Purchase price currency\n"+
+		"Sol Ring,,,,foil,,2,,0afa0e33-4804-4b00-b625-c2d6b61090fc,,,,near_mint,en,\n", buf.String())
+}
+
+func TestExportCSVMissingCollectionWritesNothing(t *testing.T) {
+	streamer := mocks.NewMockExportRepositorer(t)
+	service := NewExportService(zap.NewNop(), streamer, mocks.NewMockPrintingCatalog(t))
+
+	streamer.On("StreamCards", testCollectionID, mock.Anything).
+		Return(&domain.ResponseErr{Status: http.StatusNotFound, Message: "Collection not found"})
+
+	var buf bytes.Buffer
+	respErr := service.ExportCSV(testCollectionID, "", &buf)
+
+	require.NotNil(t, respErr)
+	assert.Equal(t, http.StatusNotFound, respErr.Status)
+	assert.Empty(t, buf.String())
+}
+
+func TestExportDecklistUsesCatalogPrintings(t *testing.T) {
+	repository := mocks.NewMockExportRepositorer(t)
+	catalog := mocks.NewMockPrintingCatalog(t)
+	service := NewExportService(zap.NewNop(), repository, catalog)
+
+	repository.On("GetCollection", testCollectionID).
+		Return(&domain.Collection{ID: testCollectionID, Name: "Burn"}, nil)
+	repository.On("StreamCards", testCollectionID, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(domain.Card) error)
+			_ = fn(domain.Card{ScryfallID: "bolt", Name: "Lightning Bolt", Count: 3, Zone: domain.ZoneMain, Finish: domain.FinishNonfoil})
+			_ = fn(domain.Card{ScryfallID: "bolt", Name: "Lightning Bolt", Count: 1, Zone: domain.ZoneMain, Finish: domain.FinishFoil})
+			_ = fn(domain.Card{ScryfallID: "duress", Name: "Duress", Count: 2, Zone: domain.ZoneSide, Finish: domain.FinishNonfoil})
+		}).
+		Return(nil)
+	catalog.On("FindPrintings", []string{"bolt", "duress"}).
+		Return(map[string]domain.CatalogCard{
+			"bolt": {ScryfallID: "bolt", Name: "Lightning Bolt", SetCode: "m10", CollectorNumber: "146"},
+		}, nil)
+
+	var buf bytes.Buffer
+	respErr := service.ExportDecklist(testCollectionID, "arena", &buf)
+
+	require.Nil(t, respErr)
+	assert.Equal(t, "Deck\n4 Lightning Bolt (M10) 146\n\nSideboard\n2 Duress\n", buf.String())
+}
+
+func TestExportDecklistInvalidFormat(t *testing.T) {
+	service := NewExportService(zap.NewNop(), mocks.NewMockExportRepositorer(t), mocks.NewMockPrintingCatalog(t))
+
+	var buf bytes.Buffer
+	respErr := service.ExportDecklist(testCollectionID, "dek", &buf)
+
+	require.NotNil(t, respErr)
+	assert.Equal(t, http.StatusBadRequest, respErr.Status)
+	assert.Equal(t, "Invalid decklist format", respErr.Message)
+}
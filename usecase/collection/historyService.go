@@ -0,0 +1,102 @@
+package collection
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"go.uber.org/zap"
+)
+
+type HistoryService struct {
+	historyRepository HistoryRepositorer
+	log               *zap.Logger
+}
+
+type HistoryRepositorer interface {
+	FindCollectionChanges(userId, collectionId string, offset, limit int) (*domain.ChangesPage, *domain.ResponseErr)
+	UndoChange(actor domain.Actor, collectionId, changeId string) (*domain.CollectionChange, *domain.ResponseErr)
+	UndoLastChanges(actor domain.Actor, collectionId string, count int) (*domain.CollectionChange, *domain.ResponseErr)
+}
+
+func NewHistoryService(log *zap.Logger, historyRepository HistoryRepositorer) *HistoryService {
+	return &HistoryService{
+		historyRepository: historyRepository,
+		log:               log.With(zap.String("service", "history")),
+	}
+}
+
+// History returns a page of the user's collection card changes, most recent first.
+func (hs HistoryService) History(userId, collectionId string, offset, limit int) (*domain.ChangesPage, *domain.ResponseErr) {
+	if !isValidCollectionID(collectionId) {
+		hs.log.Warn("Invalid collection ID", zap.String("collectionID", collectionId))
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID",
+		}
+	}
+
+	if limit == 0 {
+		limit = domain.DefaultChangesLimit
+	}
+	if limit < 0 || limit > domain.MaxChangesLimit || offset < 0 {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid pagination parameters",
+		}
+	}
+
+	page, respErr := hs.historyRepository.FindCollectionChanges(userId, collectionId, offset, limit)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	// For json serialization, ensure Changes is not nil
+	if page.Changes == nil {
+		page.Changes = []domain.CollectionChange{}
+	}
+	return page, nil
+}
+
+// Undo reverts a change of the collection history unless its cards were changed later.
+func (hs HistoryService) Undo(actor domain.Actor, collectionId, changeId string) (*domain.CollectionChange, *domain.ResponseErr) {
+	if !isValidCollectionID(collectionId) {
+		hs.log.Warn("Invalid collection ID", zap.String("collectionID", collectionId))
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID",
+		}
+	}
+
+	undo, respErr := hs.historyRepository.UndoChange(actor, collectionId, changeId)
+	if respErr != nil {
+		hs.log.Warn("Failed to undo change", zap.String("collectionID", collectionId), zap.String("changeID", changeId), zap.Error(respErr))
+		return nil, respErr
+	}
+	return undo, nil
+}
+
+// UndoLast reverts the last count changes of the collection which aren't undone yet.
+func (hs HistoryService) UndoLast(actor domain.Actor, collectionId string, count int) (*domain.CollectionChange, *domain.ResponseErr) {
+	if !isValidCollectionID(collectionId) {
+		hs.log.Warn("Invalid collection ID", zap.String("collectionID", collectionId))
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID",
+		}
+	}
+
+	if count <= 0 || count > domain.MaxUndoChanges {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: fmt.Sprintf("Count must be from 1 to %d", domain.MaxUndoChanges),
+		}
+	}
+
+	undo, respErr := hs.historyRepository.UndoLastChanges(actor, collectionId, count)
+	if respErr != nil {
+		hs.log.Warn("Failed to undo changes", zap.String("collectionID", collectionId), zap.Int("count", count), zap.Error(respErr))
+		return nil, respErr
+	}
+	return undo, nil
+}
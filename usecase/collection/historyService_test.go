@@ -0,0 +1,51 @@
+package collection
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/ShenokZlob/collector-service/usecase/collection/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestHistoryDefaultLimit(t *testing.T) {
+	repo := mocks.NewMockHistoryRepositorer(t)
+	service := NewHistoryService(zap.NewNop(), repo)
+
+	repo.On("FindCollectionChanges", testActor.UserID, testCollectionID, 0, domain.DefaultChangesLimit).
+		Return(&domain.ChangesPage{Limit: domain.DefaultChangesLimit}, nil)
+
+	page, respErr := service.History(testActor.UserID, testCollectionID, 0, 0)
+
+	require.Nil(t, respErr)
+	assert.NotNil(t, page.Changes)
+}
+
+func TestHistoryInvalidPagination(t *testing.T) {
+	service := NewHistoryService(zap.NewNop(), mocks.NewMockHistoryRepositorer(t))
+
+	_, respErr := service.History(testActor.UserID, testCollectionID, 0, domain.MaxChangesLimit+1)
+
+	require.NotNil(t, respErr)
+	assert.Equal(t, http.StatusBadRequest, respErr.Status)
+}
+
+func TestUndoLastChecksCount(t *testing.T) {
+	repo := mocks.NewMockHistoryRepositorer(t)
+	service := NewHistoryService(zap.NewNop(), repo)
+
+	for _, count := range []int{0, domain.MaxUndoChanges + 1} {
+		_, respErr := service.UndoLast(testActor, testCollectionID, count)
+		require.NotNil(t, respErr)
+		assert.Equal(t, http.StatusBadRequest, respErr.Status)
+	}
+
+	repo.On("UndoLastChanges", testActor, testCollectionID, 3).
+		Return(&domain.CollectionChange{Kind: domain.ChangeUndo}, nil)
+	undo, respErr := service.UndoLast(testActor, testCollectionID, 3)
+	require.Nil(t, respErr)
+	assert.Equal(t, domain.ChangeUndo, undo.Kind)
+}
@@ -0,0 +1,268 @@
+package collection
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/ShenokZlob/collector-service/pkg/cardcsv"
+	"github.com/ShenokZlob/collector-service/pkg/decklist"
+	"go.uber.org/zap"
+)
+
+type ImportService struct {
+	cards   CardAdder
+	catalog CardCatalog
+	log     *zap.Logger
+}
+
+// CardAdder stores imported cards. Imports go through CardsService to get
+// the same validation as cards added one by one.
+type CardAdder interface {
+	AddCardToCollection(actor domain.Actor, collectionId string, card *domain.Card) (*domain.Card, *domain.ResponseErr)
+}
+
+// CardCatalog resolves card names to Scryfall printings.
+type CardCatalog interface {
+	// FindCard finds a printing of the card by its name. Set code and collector
+	// number narrow the search to a printing when they are not empty.
+	FindCard(name, setCode, collectorNumber string) (*domain.CatalogCard, *domain.ResponseErr)
+}
+
+func NewImportService(log *zap.Logger, cards CardAdder, catalog CardCatalog) *ImportService {
+	return &ImportService{
+		cards:   cards,
+		catalog: catalog,
+		log:     log.With(zap.String("service", "import")),
+	}
+}
+
+// ImportDecklist parses a text decklist, resolves its cards through the catalog and adds
+// them to the collection. A dry run only returns the resolved cards. Lines which can't be
+// parsed, resolved or added are reported as unresolved and don't stop the import.
+func (is ImportService) ImportDecklist(actor domain.Actor, collectionId, text string, dryRun bool) (*domain.CardImport, *domain.ResponseErr) {
+	if !isValidCollectionID(collectionId) {
+		is.log.Warn("Invalid collection ID", zap.String("collectionID", collectionId))
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID",
+		}
+	}
+
+	list, err := decklist.ParseString(text)
+	if err != nil {
+		is.log.Warn("Failed to read decklist", zap.String("collectionID", collectionId), zap.Error(err))
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: fmt.Sprintf("Invalid decklist: %v", err),
+		}
+	}
+	if len(list.Entries) == 0 && len(list.Errors) == 0 {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Decklist is empty",
+		}
+	}
+
+	result := &domain.CardImport{
+		DryRun:     dryRun,
+		Cards:      []domain.ImportedCard{},
+		Unresolved: []domain.UnresolvedLine{},
+	}
+	for _, e := range list.Errors {
+		result.Unresolved = append(result.Unresolved, domain.UnresolvedLine{
+			Line:   e.Line,
+			Text:   e.Text,
+			Reason: e.Err.Error(),
+		})
+	}
+
+	lines := strings.Split(text, "\n")
+	for _, entry := range list.Entries {
+		lineText := strings.TrimSpace(lines[entry.Line-1])
+
+		catalogCard, respErr := is.catalog.FindCard(entry.Name, entry.SetCode, entry.CollectorNumber)
+		if respErr != nil {
+			if respErr.Status != http.StatusNotFound {
+				is.log.Error("Failed to resolve card", zap.String("name", entry.Name), zap.Error(respErr))
+				return nil, respErr
+			}
+			result.Unresolved = append(result.Unresolved, domain.UnresolvedLine{
+				Line:   entry.Line,
+				Text:   lineText,
+				Reason: "Card not found in catalog",
+			})
+			continue
+		}
+
+		card := domain.Card{
+			ScryfallID: catalogCard.ScryfallID,
+			Name:       catalogCard.Name,
+			CardUrl:    catalogCard.ImageURI,
+			Count:      entry.Count,
+			Zone:       domain.Zone(entry.Zone),
+			Finish:     domain.Finish(entry.Finish),
+		}
+		card.SetVariantDefaults()
+
+		if respErr := is.addImportedCard(&actor, result, collectionId, entry.Line, lineText, card); respErr != nil {
+			return nil, respErr
+		}
+	}
+
+	sort.Slice(result.Unresolved, func(i, j int) bool {
+		return result.Unresolved[i].Line < result.Unresolved[j].Line
+	})
+
+	return result, nil
+}
+
+// ImportCSV reads a CSV export of a collection manager row by row and adds its cards to the
+// collection. An empty format is detected by the header. Rows without a Scryfall ID are
+// resolved through the catalog by name, set code and collector number. Rows which can't be
+// read, resolved or added are reported as unresolved and don't stop the import.
+func (is ImportService) ImportCSV(actor domain.Actor, collectionId string, r io.Reader, format string, dryRun bool) (*domain.CardImport, *domain.ResponseErr) {
+	if !isValidCollectionID(collectionId) {
+		is.log.Warn("Invalid collection ID", zap.String("collectionID", collectionId))
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID",
+		}
+	}
+
+	if format != "" && !cardcsv.Format(format).IsValid() {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid CSV format",
+		}
+	}
+
+	reader, err := cardcsv.NewReader(r, cardcsv.Format(format))
+	if err != nil {
+		is.log.Warn("Failed to read CSV header", zap.Error(err))
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid CSV: " + err.Error(),
+		}
+	}
+
+	result := &domain.CardImport{
+		DryRun:     dryRun,
+		Cards:      []domain.ImportedCard{},
+		Unresolved: []domain.UnresolvedLine{},
+	}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var rowErr *cardcsv.RowError
+		if errors.As(err, &rowErr) {
+			result.Unresolved = append(result.Unresolved, domain.UnresolvedLine{
+				Line:   rowErr.Line,
+				Text:   rowErr.Text,
+				Reason: rowErr.Err.Error(),
+			})
+			continue
+		}
+		if err != nil {
+			is.log.Warn("Failed to read CSV", zap.Error(err))
+			return nil, &domain.ResponseErr{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid CSV: " + err.Error(),
+			}
+		}
+
+		card := domain.Card{
+			ScryfallID: record.ScryfallID,
+			Name:       record.Name,
+			CardUrl:    record.CardURL,
+			Count:      record.Count,
+			Zone:       domain.Zone(record.Zone),
+			Finish:     domain.Finish(record.Finish),
+			Condition:  domain.Condition(record.Condition),
+			Language:   record.Language,
+			AddedAt:    record.AddedAt,
+		}
+		lineText := csvRecordText(record)
+
+		if card.ScryfallID == "" {
+			catalogCard, respErr := is.catalog.FindCard(record.Name, record.SetCode, record.CollectorNumber)
+			if respErr != nil {
+				if respErr.Status != http.StatusNotFound {
+					is.log.Error("Failed to resolve card", zap.String("name", record.Name), zap.Error(respErr))
+					return nil, respErr
+				}
+				result.Unresolved = append(result.Unresolved, domain.UnresolvedLine{
+					Line:   record.Line,
+					Text:   lineText,
+					Reason: "Card not found in catalog",
+				})
+				continue
+			}
+			card.ScryfallID = catalogCard.ScryfallID
+			card.Name = catalogCard.Name
+			card.CardUrl = catalogCard.ImageURI
+		}
+
+		if respErr := is.addImportedCard(&actor, result, collectionId, record.Line, lineText, card); respErr != nil {
+			return nil, respErr
+		}
+	}
+
+	return result, nil
+}
+
+// addImportedCard adds a resolved card to the collection unless the import is a dry run.
+// Only an error which stops the whole import is returned, others make the line unresolved.
+// The If-Match of the actor is checked by the first added card, the next ones follow its change.
+func (is ImportService) addImportedCard(actor *domain.Actor, result *domain.CardImport, collectionId string, line int, text string, card domain.Card) *domain.ResponseErr {
+	if result.DryRun {
+		if respErr := normalizeCardVariant(&card); respErr != nil {
+			result.Unresolved = append(result.Unresolved, domain.UnresolvedLine{
+				Line:   line,
+				Text:   text,
+				Reason: respErr.Message,
+			})
+			return nil
+		}
+		result.Cards = append(result.Cards, domain.ImportedCard{Line: line, Card: card})
+		return nil
+	}
+
+	actor.Import = true
+	stored, respErr := is.cards.AddCardToCollection(*actor, collectionId, &card)
+	if respErr != nil {
+		// Nothing can be added to a missing collection or to one changed by another request
+		if respErr.Status == http.StatusNotFound || respErr.Status == http.StatusPreconditionFailed {
+			return respErr
+		}
+		is.log.Warn("Failed to add imported card", zap.String("scryfallID", card.ScryfallID), zap.Error(respErr))
+		result.Unresolved = append(result.Unresolved, domain.UnresolvedLine{
+			Line:   line,
+			Text:   text,
+			Reason: respErr.Message,
+		})
+		return nil
+	}
+
+	actor.IfMatch = nil
+	result.Cards = append(result.Cards, domain.ImportedCard{Line: line, Card: *stored})
+	return nil
+}
+
+// csvRecordText describes a CSV row in unresolved lines.
+func csvRecordText(record cardcsv.Record) string {
+	text := fmt.Sprintf("%d %s", record.Count, record.Name)
+	if record.SetCode != "" {
+		text += fmt.Sprintf(" (%s)", strings.ToUpper(record.SetCode))
+	}
+	if record.CollectorNumber != "" {
+		text += " " + record.CollectorNumber
+	}
+	return text
+}
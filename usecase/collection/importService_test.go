@@ -0,0 +1,208 @@
+package collection
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/ShenokZlob/collector-service/usecase/collection/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testCollectionID = "64a9b66b2db8b91234a6e8e3"
+
+var (
+	testActor   = domain.Actor{UserID: "64a9b66b2db8b91234a6e8e0", RequestID: "test-request"}
+	importActor = domain.Actor{UserID: testActor.UserID, RequestID: testActor.RequestID, Import: true}
+)
+
+var boltCatalogCard = &domain.CatalogCard{
+	ScryfallID: "e3285e6b-3e79-4d7c-bf96-d920f973b80d",
+	Name:       "Lightning Bolt",
+	SetCode:    "m10",
+	ImageURI:   "https://example.com/bolt.jpg",
+}
+
+func TestImportDecklistDryRun(t *testing.T) {
+	cards := mocks.NewMockCardAdder(t)
+	catalog := mocks.NewMockCardCatalog(t)
+	service := NewImportService(zap.NewNop(), cards, catalog)
+
+	catalog.On("FindCard", "Lightning Bolt", "m10", "146").Return(boltCatalogCard, nil)
+	catalog.On("FindCard", "Lightning Blot", "", "").
+		Return(nil, &domain.ResponseErr{Status: http.StatusNotFound, Message: "Card not found"})
+
+	text := "Deck\n4 Lightning Bolt (M10) 146\n0 Mountain\n\nSideboard\n2 Lightning Blot\n"
+	result, respErr := service.ImportDecklist(testActor, testCollectionID, text, true)
+
+	require.Nil(t, respErr)
+	assert.True(t, result.DryRun)
+	require.Len(t, result.Cards, 1)
+	assert.Equal(t, 2, result.Cards[0].Line)
+	assert.Equal(t, domain.Card{
+		ScryfallID: boltCatalogCard.ScryfallID,
+		Name:       "Lightning Bolt",
+		CardUrl:    "https://example.com/bolt.jpg",
+		Count:      4,
+		Zone:       domain.ZoneMain,
+		Finish:     domain.FinishNonfoil,
+		Condition:  domain.ConditionNearMint,
+		Language:   domain.DefaultLanguage,
+	}, result.Cards[0].Card)
+
+	assert.Equal(t, []domain.UnresolvedLine{
+		{Line: 3, Text: "0 Mountain", Reason: `invalid count "0"`},
+		{Line: 6, Text: "2 Lightning Blot", Reason: "Card not found in catalog"},
+	}, result.Unresolved)
+	cards.AssertNotCalled(t, "AddCardToCollection", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestImportDecklistCommitsThroughAddCard(t *testing.T) {
+	cards := mocks.NewMockCardAdder(t)
+	catalog := mocks.NewMockCardCatalog(t)
+	service := NewImportService(zap.NewNop(), cards, catalog)
+
+	catalog.On("FindCard", "Lightning Bolt", "", "").Return(boltCatalogCard, nil)
+	cards.On("AddCardToCollection", importActor, testCollectionID, mock.MatchedBy(func(c *domain.Card) bool {
+		return c.ScryfallID == boltCatalogCard.ScryfallID && c.Count == 4 && c.Zone == domain.ZoneSide && c.Finish == domain.FinishFoil
+	})).Return(&domain.Card{ID: "64a9b66b2db8b91234a6e8e4", ScryfallID: boltCatalogCard.ScryfallID, Count: 4}, nil)
+
+	result, respErr := service.ImportDecklist(testActor, testCollectionID, "SB: 4 Lightning Bolt *F*", false)
+
+	require.Nil(t, respErr)
+	require.Len(t, result.Cards, 1)
+	assert.Equal(t, "64a9b66b2db8b91234a6e8e4", result.Cards[0].Card.ID)
+	assert.Empty(t, result.Unresolved)
+}
+
+func TestImportDecklistMissingCollection(t *testing.T) {
+	cards := mocks.NewMockCardAdder(t)
+	catalog := mocks.NewMockCardCatalog(t)
+	service := NewImportService(zap.NewNop(), cards, catalog)
+
+	catalog.On("FindCard", "Lightning Bolt", "", "").Return(boltCatalogCard, nil)
+	cards.On("AddCardToCollection", importActor, testCollectionID, mock.Anything).
+		Return(nil, &domain.ResponseErr{Status: http.StatusNotFound, Message: "Collection not found"})
+
+	_, respErr := service.ImportDecklist(testActor, testCollectionID, "4 Lightning Bolt\n4 Lightning Bolt", false)
+
+	require.NotNil(t, respErr)
+	assert.Equal(t, http.StatusNotFound, respErr.Status)
+	cards.AssertNumberOfCalls(t, "AddCardToCollection", 1)
+}
+
+func TestImportDecklistChecksIfMatchOnce(t *testing.T) {
+	cards := mocks.NewMockCardAdder(t)
+	catalog := mocks.NewMockCardCatalog(t)
+	service := NewImportService(zap.NewNop(), cards, catalog)
+
+	actor := testActor
+	actor.IfMatch = []int64{7}
+	first := importActor
+	first.IfMatch = []int64{7}
+
+	catalog.On("FindCard", "Lightning Bolt", "", "").Return(boltCatalogCard, nil)
+	cards.On("AddCardToCollection", first, testCollectionID, mock.Anything).
+		Return(&domain.Card{ID: "64a9b66b2db8b91234a6e8e4"}, nil).Once()
+	cards.On("AddCardToCollection", importActor, testCollectionID, mock.Anything).
+		Return(&domain.Card{ID: "64a9b66b2db8b91234a6e8e4"}, nil).Once()
+
+	result, respErr := service.ImportDecklist(actor, testCollectionID, "4 Lightning Bolt\n4 Lightning Bolt", false)
+
+	require.Nil(t, respErr)
+	assert.Len(t, result.Cards, 2)
+}
+
+func TestImportDecklistLineTooLong(t *testing.T) {
+	service := NewImportService(zap.NewNop(), mocks.NewMockCardAdder(t), mocks.NewMockCardCatalog(t))
+
+	_, respErr := service.ImportDecklist(testActor, testCollectionID, "4 "+strings.Repeat("a", 70_000), false)
+
+	require.NotNil(t, respErr)
+	assert.Equal(t, http.StatusBadRequest, respErr.Status)
+}
+
+func TestImportDecklistEmpty(t *testing.T) {
+	service := NewImportService(zap.NewNop(), mocks.NewMockCardAdder(t), mocks.NewMockCardCatalog(t))
+
+	_, respErr := service.ImportDecklist(testActor, testCollectionID, "// nothing here\n\n", true)
+
+	require.NotNil(t, respErr)
+	assert.Equal(t, http.StatusBadRequest, respErr.Status)
+}
+
+func TestImportCSVResolvesRowsWithoutScryfallID(t *testing.T) {
+	cards := mocks.NewMockCardAdder(t)
+	catalog := mocks.NewMockCardCatalog(t)
+	service := NewImportService(zap.NewNop(), cards, catalog)
+
+	catalog.On("FindCard", "Lightning Bolt", "m10", "146").Return(boltCatalogCard, nil)
+	catalog.On("FindCard", "Lightning Blot", "", "").
+		Return(nil, &domain.ResponseErr{Status: http.StatusNotFound, Message: "Card not found"})
+	cards.On("AddCardToCollection", importActor, testCollectionID, mock.MatchedBy(func(c *domain.Card) bool {
+		return c.ScryfallID == boltCatalogCard.ScryfallID && c.Count == 2 && c.Finish == domain.FinishFoil &&
+			c.Condition == domain.ConditionLightlyPlayed && c.Language == "ja" &&
+			c.AddedAt.Equal(time.Date(2024, 5, 1, 10, 20, 30, 0, time.UTC))
+	})).Return(&domain.Card{ID: "64a9b66b2db8b91234a6e8e4", ScryfallID: boltCatalogCard.ScryfallID, Count: 2}, nil)
+
+	csv := `Count,Tradelist Count,Name,Edition,Condition,Language,Foil,Tags,Last Modified,Collector Number,Alter,Proxy,Purchase Price
+2,0,Lightning Bolt,m10,Lightly Played,Japanese,foil,,2024-05-01 10:20:30.000000,146,False,False,
+x,0,Forest,,,,,,,,,,
+1,0,Lightning Blot,,,,,,,,,,
+`
+	result, respErr := service.ImportCSV(testActor, testCollectionID, strings.NewReader(csv), "", false)
+
+	require.Nil(t, respErr)
+	require.Len(t, result.Cards, 1)
+	assert.Equal(t, 2, result.Cards[0].Line)
+	assert.Equal(t, "64a9b66b2db8b91234a6e8e4", result.Cards[0].Card.ID)
+	assert.Equal(t, []domain.UnresolvedLine{
+		{Line: 3, Text: "x,0,Forest,,,,,,,,,,", Reason: `invalid count "x"`},
+		{Line: 4, Text: "1 Lightning Blot", Reason: "Card not found in catalog"},
+	}, result.Unresolved)
+}
+
+func TestImportCSVDryRunKeepsScryfallID(t *testing.T) {
+	cards := mocks.NewMockCardAdder(t)
+	catalog := mocks.NewMockCardCatalog(t)
+	service := NewImportService(zap.NewNop(), cards, catalog)
+
+	csv := `Name,Set code,Set name,Collector number,Foil,Rarity,Quantity,ManaBox ID,Scryfall ID,Purchase price,Misprint,Altered,Condition,Language,Purchase price currency
+Sol Ring,C21,Commander 2021,263,normal,uncommon,1,123,0AFA0E33-4804-4B00-B625-C2D6B61090FC,1.5,false,false,near_mint,en,USD
+`
+	result, respErr := service.ImportCSV(testActor, testCollectionID, strings.NewReader(csv), "manabox", true)
+
+	require.Nil(t, respErr)
+	assert.Empty(t, result.Unresolved)
+	require.Len(t, result.Cards, 1)
+	// The ID is lower cased as the one of a card added by hand
+	assert.Equal(t, domain.Card{
+		ScryfallID: "0afa0e33-4804-4b00-b625-c2d6b61090fc",
+		Name:       "Sol Ring",
+		Count:      1,
+		Zone:       domain.ZoneMain,
+		Finish:     domain.FinishNonfoil,
+		Condition:  domain.ConditionNearMint,
+		Language:   "en",
+	}, result.Cards[0].Card)
+	catalog.AssertNotCalled(t, "FindCard", mock.Anything, mock.Anything, mock.Anything)
+	cards.AssertNotCalled(t, "AddCardToCollection", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestImportCSVInvalidFile(t *testing.T) {
+	service := NewImportService(zap.NewNop(), mocks.NewMockCardAdder(t), mocks.NewMockCardCatalog(t))
+
+	_, respErr := service.ImportCSV(testActor, testCollectionID, strings.NewReader("a,b\n1,2\n"), "", false)
+	require.NotNil(t, respErr)
+	assert.Equal(t, http.StatusBadRequest, respErr.Status)
+	assert.Equal(t, "Invalid CSV: unknown csv format", respErr.Message)
+
+	_, respErr = service.ImportCSV(testActor, testCollectionID, strings.NewReader(""), "archidekt", false)
+	require.NotNil(t, respErr)
+	assert.Equal(t, "Invalid CSV format", respErr.Message)
+}
@@ -0,0 +1,133 @@
+package collection
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/ShenokZlob/collector-service/pkg/cardquery"
+	"go.uber.org/zap"
+)
+
+type SearchService struct {
+	searchRepository SearchRepositorer
+	log              *zap.Logger
+}
+
+type SearchRepositorer interface {
+	GetUser(userId string) (*domain.User, *domain.ResponseErr)
+	FindCardsByScryfallID(collectionIds []string, scryfallId string, limit int) ([]domain.CardSearchHit, *domain.ResponseErr)
+	SearchCardsByText(collectionIds []string, text string, limit int) ([]domain.CardSearchHit, *domain.ResponseErr)
+	FindCardNames(collectionIds []string) ([]string, *domain.ResponseErr)
+	FindCardsByNames(collectionIds []string, names []string, limit int) ([]domain.CardSearchHit, *domain.ResponseErr)
+	SearchCardsByQuery(collectionIds []string, query cardquery.Expr, limit int) ([]domain.CardSearchHit, *domain.ResponseErr)
+}
+
+func NewSearchService(log *zap.Logger, searchRepository SearchRepositorer) *SearchService {
+	return &SearchService{
+		searchRepository: searchRepository,
+		log:              log.With(zap.String("service", "search")),
+	}
+}
+
+// SearchCards finds card entries in all of the user's collections. A Scryfall ID
+// finds the entries of the printing, queries of name words only are searched as
+// words of card names and, when no name has them, as names with typos or word
+// prefixes. Queries with keywords find the entries matching them.
+func (ss SearchService) SearchCards(userID string, search *domain.CardSearchQuery) (*domain.CardSearchResult, *domain.ResponseErr) {
+	query := strings.TrimSpace(search.Text)
+	limit := search.Limit
+	if query == "" {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Search query is empty",
+		}
+	}
+	if limit == 0 {
+		limit = domain.DefaultCardSearchLimit
+	}
+	if limit < 0 || limit > domain.MaxCardSearchLimit {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid limit",
+		}
+	}
+
+	user, respErr := ss.searchRepository.GetUser(userID)
+	if respErr != nil {
+		ss.log.Error("Failed to find user", zap.String("userID", userID), zap.Error(respErr))
+		return nil, respErr
+	}
+	result := &domain.CardSearchResult{Query: query, Hits: []domain.CardSearchHit{}}
+	if len(user.Collections) == 0 {
+		result.Match = domain.MatchText
+		return result, nil
+	}
+
+	collectionIds := make([]string, len(user.Collections))
+	collectionNames := make(map[string]string, len(user.Collections))
+	for i, ref := range user.Collections {
+		collectionIds[i] = ref.ID
+		collectionNames[ref.ID] = ref.Name
+	}
+
+	var hits []domain.CardSearchHit
+	if scryfallIDRegexp.MatchString(query) {
+		result.Match = domain.MatchScryfallID
+		hits, respErr = ss.searchRepository.FindCardsByScryfallID(collectionIds, strings.ToLower(query), limit)
+	} else if terms, ok := cardquery.NameTerms(search.Filter); ok || search.Filter == nil {
+		text := strings.Join(terms, " ")
+		if !ok {
+			text = query
+		}
+		result.Match = domain.MatchText
+		hits, respErr = ss.searchRepository.SearchCardsByText(collectionIds, text, limit)
+		if respErr == nil && len(hits) == 0 {
+			result.Match = domain.MatchFuzzy
+			hits, respErr = ss.fuzzySearch(collectionIds, text, limit)
+		}
+	} else {
+		result.Match = domain.MatchQuery
+		hits, respErr = ss.searchRepository.SearchCardsByQuery(collectionIds, search.Filter, limit)
+	}
+	if respErr != nil {
+		ss.log.Error("Failed to search cards", zap.String("userID", userID), zap.String("query", query), zap.Error(respErr))
+		return nil, respErr
+	}
+
+	for i := range hits {
+		hits[i].CollectionName = collectionNames[hits[i].CollectionID]
+	}
+	if hits != nil {
+		result.Hits = hits
+	}
+	return result, nil
+}
+
+// fuzzySearch finds the entries of the names closest to the query, closest names first
+func (ss SearchService) fuzzySearch(collectionIds []string, query string, limit int) ([]domain.CardSearchHit, *domain.ResponseErr) {
+	names, respErr := ss.searchRepository.FindCardNames(collectionIds)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	matched := domain.FuzzyMatchNames(query, names, limit)
+	if len(matched) == 0 {
+		return nil, nil
+	}
+
+	hits, respErr := ss.searchRepository.FindCardsByNames(collectionIds, matched, limit)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	rank := make(map[string]int, len(matched))
+	for i, name := range matched {
+		rank[name] = i
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		return rank[hits[i].Card.Name] < rank[hits[j].Card.Name]
+	})
+	return hits, nil
+}
@@ -0,0 +1,112 @@
+package collection
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/ShenokZlob/collector-service/pkg/cardquery"
+	"github.com/ShenokZlob/collector-service/usecase/collection/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var searchUser = &domain.User{Collections: []domain.UserCollectionRef{
+	{ID: "64a9b66b2db8b91234a6e8e3", Name: "Binder"},
+	{ID: "64a9b66b2db8b91234a6e8e4", Name: "Deck"},
+}}
+
+var searchCollectionIDs = []string{"64a9b66b2db8b91234a6e8e3", "64a9b66b2db8b91234a6e8e4"}
+
+func searchQuery(t *testing.T, text string, limit int) *domain.CardSearchQuery {
+	filter, err := cardquery.Parse(text)
+	require.NoError(t, err)
+	return &domain.CardSearchQuery{Text: text, Filter: filter, Limit: limit}
+}
+
+func TestSearchCardsByScryfallID(t *testing.T) {
+	repo := mocks.NewMockSearchRepositorer(t)
+	service := NewSearchService(zap.NewNop(), repo)
+	id := "5E1EAB22-4D5B-4B3B-8D6B-1A2B3C4D5E6F"
+
+	repo.On("GetUser", "user").Return(searchUser, nil)
+	repo.On("FindCardsByScryfallID", searchCollectionIDs, "5e1eab22-4d5b-4b3b-8d6b-1a2b3c4d5e6f", domain.DefaultCardSearchLimit).
+		Return([]domain.CardSearchHit{{CollectionID: "64a9b66b2db8b91234a6e8e4", Card: domain.Card{Name: "Sheoldred, the Apocalypse"}}}, nil)
+
+	result, respErr := service.SearchCards("user", searchQuery(t, id, 0))
+
+	require.Nil(t, respErr)
+	assert.Equal(t, domain.MatchScryfallID, result.Match)
+	require.Len(t, result.Hits, 1)
+	assert.Equal(t, "Deck", result.Hits[0].CollectionName)
+}
+
+func TestSearchCardsFallsBackToFuzzyNames(t *testing.T) {
+	repo := mocks.NewMockSearchRepositorer(t)
+	service := NewSearchService(zap.NewNop(), repo)
+
+	repo.On("GetUser", "user").Return(searchUser, nil)
+	repo.On("SearchCardsByText", searchCollectionIDs, "sheoldrd", 10).Return([]domain.CardSearchHit{}, nil)
+	repo.On("FindCardNames", searchCollectionIDs).
+		Return([]string{"Lightning Bolt", "Sheoldred, Whispering One", "Sheoldred, the Apocalypse"}, nil)
+	repo.On("FindCardsByNames", searchCollectionIDs, []string{"Sheoldred, Whispering One", "Sheoldred, the Apocalypse"}, 10).
+		Return([]domain.CardSearchHit{
+			{CollectionID: "64a9b66b2db8b91234a6e8e3", Card: domain.Card{Name: "Sheoldred, the Apocalypse", Count: 1}},
+			{CollectionID: "64a9b66b2db8b91234a6e8e4", Card: domain.Card{Name: "Sheoldred, Whispering One", Count: 2}},
+		}, nil)
+
+	result, respErr := service.SearchCards("user", searchQuery(t, " sheoldrd ", 10))
+
+	require.Nil(t, respErr)
+	assert.Equal(t, domain.MatchFuzzy, result.Match)
+	require.Len(t, result.Hits, 2)
+	assert.Equal(t, "Sheoldred, Whispering One", result.Hits[0].Card.Name, "hits follow the order of matched names")
+	assert.Equal(t, "Deck", result.Hits[0].CollectionName)
+	assert.Equal(t, "Binder", result.Hits[1].CollectionName)
+}
+
+func TestSearchCardsByNameWords(t *testing.T) {
+	repo := mocks.NewMockSearchRepositorer(t)
+	service := NewSearchService(zap.NewNop(), repo)
+
+	repo.On("GetUser", "user").Return(searchUser, nil)
+	repo.On("SearchCardsByText", searchCollectionIDs, "lightning bolt", 10).
+		Return([]domain.CardSearchHit{{CollectionID: "64a9b66b2db8b91234a6e8e3", Card: domain.Card{Name: "Lightning Bolt"}}}, nil)
+
+	result, respErr := service.SearchCards("user", searchQuery(t, `name:lightning "bolt"`, 10))
+
+	require.Nil(t, respErr)
+	assert.Equal(t, domain.MatchText, result.Match)
+	assert.Equal(t, `name:lightning "bolt"`, result.Query)
+	require.Len(t, result.Hits, 1)
+}
+
+func TestSearchCardsByQuery(t *testing.T) {
+	repo := mocks.NewMockSearchRepositorer(t)
+	service := NewSearchService(zap.NewNop(), repo)
+	query := searchQuery(t, "c:r t:creature cmc<=3", 10)
+
+	repo.On("GetUser", "user").Return(searchUser, nil)
+	repo.On("SearchCardsByQuery", searchCollectionIDs, query.Filter, 10).
+		Return([]domain.CardSearchHit{{CollectionID: "64a9b66b2db8b91234a6e8e4", Card: domain.Card{Name: "Goblin Guide"}}}, nil)
+
+	result, respErr := service.SearchCards("user", query)
+
+	require.Nil(t, respErr)
+	assert.Equal(t, domain.MatchQuery, result.Match)
+	require.Len(t, result.Hits, 1)
+	assert.Equal(t, "Deck", result.Hits[0].CollectionName)
+}
+
+func TestSearchCardsInvalidQuery(t *testing.T) {
+	service := NewSearchService(zap.NewNop(), mocks.NewMockSearchRepositorer(t))
+
+	_, respErr := service.SearchCards("user", searchQuery(t, "  ", 0))
+	require.NotNil(t, respErr)
+	assert.Equal(t, http.StatusBadRequest, respErr.Status)
+
+	_, respErr = service.SearchCards("user", searchQuery(t, "bolt", domain.MaxCardSearchLimit+1))
+	require.NotNil(t, respErr)
+	assert.Equal(t, http.StatusBadRequest, respErr.Status)
+}
@@ -0,0 +1,173 @@
+package collection
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"go.uber.org/zap"
+)
+
+type SnapshotService struct {
+	snapshotRepository SnapshotRepositorer
+	log                *zap.Logger
+}
+
+type SnapshotRepositorer interface {
+	CreateSnapshot(userId, collectionId, label string) (*domain.Snapshot, *domain.ResponseErr)
+	FindSnapshots(userId, collectionId string) ([]domain.Snapshot, *domain.ResponseErr)
+	GetSnapshot(userId, collectionId, snapshotId string) (*domain.Snapshot, *domain.ResponseErr)
+	DeleteSnapshot(userId, collectionId, snapshotId string) *domain.ResponseErr
+	RestoreSnapshot(actor domain.Actor, collectionId, snapshotId string) *domain.ResponseErr
+	StreamCards(collectionId string, fn func(domain.Card) error) *domain.ResponseErr
+}
+
+func NewSnapshotService(log *zap.Logger, snapshotRepository SnapshotRepositorer) *SnapshotService {
+	return &SnapshotService{
+		snapshotRepository: snapshotRepository,
+		log:                log.With(zap.String("service", "snapshot")),
+	}
+}
+
+// Create takes a snapshot of the user's collection cards with the label, labels are unique within a collection.
+func (ss SnapshotService) Create(userId, collectionId, label string) (*domain.Snapshot, *domain.ResponseErr) {
+	if !isValidCollectionID(collectionId) {
+		ss.log.Warn("Invalid collection ID", zap.String("collectionID", collectionId))
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID",
+		}
+	}
+
+	label = strings.TrimSpace(label)
+	if label == "" || utf8.RuneCountInString(label) > domain.MaxSnapshotLabelLength {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: fmt.Sprintf("Label must be from 1 to %d characters", domain.MaxSnapshotLabelLength),
+		}
+	}
+
+	snapshot, respErr := ss.snapshotRepository.CreateSnapshot(userId, collectionId, label)
+	if respErr != nil {
+		ss.log.Warn("Failed to create snapshot", zap.String("collectionID", collectionId), zap.Error(respErr))
+		return nil, respErr
+	}
+
+	ss.log.Info("Snapshot created", zap.String("collectionID", collectionId), zap.String("snapshotID", snapshot.ID))
+	return snapshot, nil
+}
+
+// List returns the snapshots of the user's collection without their cards, most recent first.
+func (ss SnapshotService) List(userId, collectionId string) ([]domain.Snapshot, *domain.ResponseErr) {
+	if !isValidCollectionID(collectionId) {
+		ss.log.Warn("Invalid collection ID", zap.String("collectionID", collectionId))
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID",
+		}
+	}
+
+	snapshots, respErr := ss.snapshotRepository.FindSnapshots(userId, collectionId)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	// For json serialization, ensure snapshots is not nil
+	if snapshots == nil {
+		snapshots = []domain.Snapshot{}
+	}
+	return snapshots, nil
+}
+
+// Get returns the snapshot with its cards.
+func (ss SnapshotService) Get(userId, collectionId, snapshotId string) (*domain.Snapshot, *domain.ResponseErr) {
+	if !isValidCollectionID(collectionId) {
+		ss.log.Warn("Invalid collection ID", zap.String("collectionID", collectionId))
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID",
+		}
+	}
+
+	return ss.snapshotRepository.GetSnapshot(userId, collectionId, snapshotId)
+}
+
+// Delete removes the snapshot, the collection isn't changed.
+func (ss SnapshotService) Delete(userId, collectionId, snapshotId string) *domain.ResponseErr {
+	if !isValidCollectionID(collectionId) {
+		ss.log.Warn("Invalid collection ID", zap.String("collectionID", collectionId))
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID",
+		}
+	}
+
+	if respErr := ss.snapshotRepository.DeleteSnapshot(userId, collectionId, snapshotId); respErr != nil {
+		ss.log.Warn("Failed to delete snapshot", zap.String("collectionID", collectionId), zap.String("snapshotID", snapshotId), zap.Error(respErr))
+		return respErr
+	}
+	return nil
+}
+
+// Diff compares the snapshot to another snapshot of the collection, or to the
+// live collection when toSnapshotId is empty. Getting the snapshot first checks
+// the collection belongs to the user.
+func (ss SnapshotService) Diff(userId, collectionId, snapshotId, toSnapshotId string) (*domain.SnapshotDiff, *domain.ResponseErr) {
+	if !isValidCollectionID(collectionId) {
+		ss.log.Warn("Invalid collection ID", zap.String("collectionID", collectionId))
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID",
+		}
+	}
+
+	from, respErr := ss.snapshotRepository.GetSnapshot(userId, collectionId, snapshotId)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	var to []domain.Card
+	if toSnapshotId != "" {
+		snapshot, respErr := ss.snapshotRepository.GetSnapshot(userId, collectionId, toSnapshotId)
+		if respErr != nil {
+			return nil, respErr
+		}
+		to = snapshot.Cards
+	} else {
+		respErr = ss.snapshotRepository.StreamCards(collectionId, func(card domain.Card) error {
+			to = append(to, card)
+			return nil
+		})
+		if respErr != nil {
+			ss.log.Error("Failed to read cards", zap.String("collectionID", collectionId), zap.Error(respErr))
+			return nil, respErr
+		}
+	}
+
+	diff := domain.DiffCards(from.Cards, to)
+	diff.FromSnapshotID = from.ID
+	diff.ToSnapshotID = toSnapshotId
+	return &diff, nil
+}
+
+// Restore sets the collection's cards to the ones of the snapshot, the restore
+// is one change of the collection history, so it can be undone.
+func (ss SnapshotService) Restore(actor domain.Actor, collectionId, snapshotId string) *domain.ResponseErr {
+	if !isValidCollectionID(collectionId) {
+		ss.log.Warn("Invalid collection ID", zap.String("collectionID", collectionId))
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID",
+		}
+	}
+
+	if respErr := ss.snapshotRepository.RestoreSnapshot(actor, collectionId, snapshotId); respErr != nil {
+		ss.log.Warn("Failed to restore snapshot", zap.String("collectionID", collectionId), zap.String("snapshotID", snapshotId), zap.Error(respErr))
+		return respErr
+	}
+
+	ss.log.Info("Snapshot restored", zap.String("collectionID", collectionId), zap.String("snapshotID", snapshotId))
+	return nil
+}
@@ -0,0 +1,92 @@
+package collection
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/ShenokZlob/collector-service/usecase/collection/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCreateSnapshotChecksLabel(t *testing.T) {
+	repo := mocks.NewMockSnapshotRepositorer(t)
+	service := NewSnapshotService(zap.NewNop(), repo)
+
+	for _, label := range []string{"  ", strings.Repeat("л", domain.MaxSnapshotLabelLength+1)} {
+		_, respErr := service.Create(testActor.UserID, testCollectionID, label)
+		require.NotNil(t, respErr)
+		assert.Equal(t, http.StatusBadRequest, respErr.Status)
+	}
+
+	repo.On("CreateSnapshot", testActor.UserID, testCollectionID, "Before FNM").
+		Return(&domain.Snapshot{ID: "snapshot", Label: "Before FNM"}, nil)
+	snapshot, respErr := service.Create(testActor.UserID, testCollectionID, " Before FNM ")
+	require.Nil(t, respErr)
+	assert.Equal(t, "Before FNM", snapshot.Label)
+}
+
+func TestDiffSnapshotWithLiveCollection(t *testing.T) {
+	repo := mocks.NewMockSnapshotRepositorer(t)
+	service := NewSnapshotService(zap.NewNop(), repo)
+
+	bolt := domain.Card{ScryfallID: "bolt", Name: "Lightning Bolt", Count: 4}
+	guide := domain.Card{ScryfallID: "guide", Name: "Goblin Guide", Count: 4}
+	repo.On("GetSnapshot", testActor.UserID, testCollectionID, "snapshot").
+		Return(&domain.Snapshot{ID: "snapshot", Cards: []domain.Card{bolt, guide}}, nil)
+	repo.On("StreamCards", testCollectionID, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(domain.Card) error)
+			fewerBolts := bolt
+			fewerBolts.Count = 3
+			_ = fn(fewerBolts)
+		}).
+		Return(nil)
+
+	diff, respErr := service.Diff(testActor.UserID, testCollectionID, "snapshot", "")
+
+	require.Nil(t, respErr)
+	assert.Equal(t, "snapshot", diff.FromSnapshotID)
+	assert.Empty(t, diff.ToSnapshotID)
+	assert.Empty(t, diff.Added)
+	assert.Equal(t, []domain.Card{guide}, diff.Removed)
+	require.Len(t, diff.Changed, 1)
+	assert.Equal(t, 4, diff.Changed[0].Before)
+	assert.Equal(t, 3, diff.Changed[0].After)
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	repo := mocks.NewMockSnapshotRepositorer(t)
+	service := NewSnapshotService(zap.NewNop(), repo)
+
+	bolt := domain.Card{ScryfallID: "bolt", Name: "Lightning Bolt", Count: 4}
+	repo.On("GetSnapshot", testActor.UserID, testCollectionID, "from").
+		Return(&domain.Snapshot{ID: "from", Cards: []domain.Card{}}, nil)
+	repo.On("GetSnapshot", testActor.UserID, testCollectionID, "to").
+		Return(&domain.Snapshot{ID: "to", Cards: []domain.Card{bolt}}, nil)
+
+	diff, respErr := service.Diff(testActor.UserID, testCollectionID, "from", "to")
+
+	require.Nil(t, respErr)
+	assert.Equal(t, "to", diff.ToSnapshotID)
+	assert.Equal(t, []domain.Card{bolt}, diff.Added)
+	repo.AssertNotCalled(t, "StreamCards", mock.Anything, mock.Anything)
+}
+
+func TestDiffSnapshotOfAnotherUser(t *testing.T) {
+	repo := mocks.NewMockSnapshotRepositorer(t)
+	service := NewSnapshotService(zap.NewNop(), repo)
+
+	repo.On("GetSnapshot", testActor.UserID, testCollectionID, "snapshot").
+		Return(nil, &domain.ResponseErr{Status: http.StatusNotFound, Message: "Collection not found"})
+
+	_, respErr := service.Diff(testActor.UserID, testCollectionID, "snapshot", "")
+
+	require.NotNil(t, respErr)
+	assert.Equal(t, http.StatusNotFound, respErr.Status)
+	repo.AssertNotCalled(t, "StreamCards", mock.Anything, mock.Anything)
+}
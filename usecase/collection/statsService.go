@@ -0,0 +1,120 @@
+package collection
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"go.uber.org/zap"
+)
+
+type StatsService struct {
+	statsRepository StatsRepositorer
+	log             *zap.Logger
+}
+
+type StatsRepositorer interface {
+	GetCollection(collectionId string) (*domain.Collection, *domain.ResponseErr)
+	FindUserCollections(userID string) ([]domain.Collection, *domain.ResponseErr)
+	CollectionStats(collectionIds []string) (*domain.CollectionStats, *domain.ResponseErr)
+	// GetCachedStats returns nil without an error when nothing is cached for the version.
+	GetCachedStats(key, version string) (*domain.CollectionStats, *domain.ResponseErr)
+	SaveCachedStats(key, version string, stats *domain.CollectionStats) *domain.ResponseErr
+}
+
+func NewStatsService(log *zap.Logger, statsRepository StatsRepositorer) *StatsService {
+	return &StatsService{
+		statsRepository: statsRepository,
+		log:             log.With(zap.String("service", "stats")),
+	}
+}
+
+// CollectionStats returns the statistics of the collection. They are cached for the
+// collection version, every change of the collection bumps it. Only decks have a mana curve.
+func (ss StatsService) CollectionStats(collectionId string) (*domain.CollectionStats, *domain.ResponseErr) {
+	if !isValidCollectionID(collectionId) {
+		ss.log.Warn("Invalid collection ID", zap.String("collectionID", collectionId))
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID",
+		}
+	}
+
+	collection, respErr := ss.statsRepository.GetCollection(collectionId)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	version := strconv.FormatInt(collection.Version, 10)
+	return ss.cachedStats("collection:"+collectionId, version, func() (*domain.CollectionStats, *domain.ResponseErr) {
+		stats, respErr := ss.statsRepository.CollectionStats([]string{collectionId})
+		if respErr != nil {
+			return nil, respErr
+		}
+		if collection.Kind != domain.KindDeck {
+			stats.ManaCurve = nil
+		}
+		stats.UpdatedAt = collection.UpdatedAt
+		return stats, nil
+	})
+}
+
+// UserStats returns the statistics of all collections of the user together.
+// They are cached until one of the collections is updated, added or removed.
+func (ss StatsService) UserStats(userID string) (*domain.CollectionStats, *domain.ResponseErr) {
+	collections, respErr := ss.statsRepository.FindUserCollections(userID)
+	if respErr != nil {
+		ss.log.Error("Failed to find user's collections", zap.String("userID", userID), zap.Error(respErr))
+		return nil, respErr
+	}
+
+	sort.Slice(collections, func(i, j int) bool { return collections[i].ID < collections[j].ID })
+	hash := sha256.New()
+	ids := make([]string, len(collections))
+	var updatedAt time.Time
+	for i, collection := range collections {
+		ids[i] = collection.ID
+		hash.Write([]byte(collection.ID + "@" + strconv.FormatInt(collection.Version, 10) + ";"))
+		if collection.UpdatedAt.After(updatedAt) {
+			updatedAt = collection.UpdatedAt
+		}
+	}
+
+	version := hex.EncodeToString(hash.Sum(nil))
+	return ss.cachedStats("user:"+userID, version, func() (*domain.CollectionStats, *domain.ResponseErr) {
+		stats, respErr := ss.statsRepository.CollectionStats(ids)
+		if respErr != nil {
+			return nil, respErr
+		}
+		stats.ManaCurve = nil
+		stats.UpdatedAt = updatedAt
+		return stats, nil
+	})
+}
+
+// cachedStats returns the statistics cached under the key for the version or
+// computes and caches them. Failing to use the cache doesn't fail the request.
+func (ss StatsService) cachedStats(key, version string, compute func() (*domain.CollectionStats, *domain.ResponseErr)) (*domain.CollectionStats, *domain.ResponseErr) {
+	cached, respErr := ss.statsRepository.GetCachedStats(key, version)
+	if respErr != nil {
+		ss.log.Warn("Failed to read cached stats", zap.String("key", key), zap.Error(respErr))
+	}
+	if cached != nil {
+		return cached, nil
+	}
+
+	stats, respErr := compute()
+	if respErr != nil {
+		ss.log.Error("Failed to compute stats", zap.String("key", key), zap.Error(respErr))
+		return nil, respErr
+	}
+
+	if respErr := ss.statsRepository.SaveCachedStats(key, version, stats); respErr != nil {
+		ss.log.Warn("Failed to cache stats", zap.String("key", key), zap.Error(respErr))
+	}
+	return stats, nil
+}
@@ -0,0 +1,113 @@
+package collection
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/ShenokZlob/collector-service/usecase/collection/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCollectionStatsComputesAndCaches(t *testing.T) {
+	repo := mocks.NewMockStatsRepositorer(t)
+	service := NewStatsService(zap.NewNop(), repo)
+	updatedAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	key, version := "collection:"+testCollectionID, "7"
+
+	repo.On("GetCollection", testCollectionID).
+		Return(&domain.Collection{ID: testCollectionID, Kind: domain.KindBinder, UpdatedAt: updatedAt, Version: 7}, nil)
+	repo.On("GetCachedStats", key, version).Return(nil, nil)
+	repo.On("CollectionStats", []string{testCollectionID}).
+		Return(&domain.CollectionStats{Cards: 10, ManaCurve: []domain.CurveBar{{ManaValue: 1, Cards: 4}}}, nil)
+	repo.On("SaveCachedStats", key, version, mock.MatchedBy(func(stats *domain.CollectionStats) bool {
+		return stats.Cards == 10 && stats.ManaCurve == nil && stats.UpdatedAt.Equal(updatedAt)
+	})).Return(nil)
+
+	stats, respErr := service.CollectionStats(testCollectionID)
+
+	require.Nil(t, respErr)
+	assert.Equal(t, 10, stats.Cards)
+	assert.Nil(t, stats.ManaCurve, "only decks have a mana curve")
+}
+
+func TestCollectionStatsUsesCache(t *testing.T) {
+	repo := mocks.NewMockStatsRepositorer(t)
+	service := NewStatsService(zap.NewNop(), repo)
+	updatedAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	repo.On("GetCollection", testCollectionID).
+		Return(&domain.Collection{ID: testCollectionID, Kind: domain.KindDeck, UpdatedAt: updatedAt, Version: 7}, nil)
+	repo.On("GetCachedStats", "collection:"+testCollectionID, "7").
+		Return(&domain.CollectionStats{Cards: 60, ManaCurve: []domain.CurveBar{}}, nil)
+
+	stats, respErr := service.CollectionStats(testCollectionID)
+
+	require.Nil(t, respErr)
+	assert.Equal(t, 60, stats.Cards)
+	repo.AssertNotCalled(t, "CollectionStats", mock.Anything)
+}
+
+func TestCollectionStatsCacheFailureDoesNotFail(t *testing.T) {
+	repo := mocks.NewMockStatsRepositorer(t)
+	service := NewStatsService(zap.NewNop(), repo)
+	cacheErr := &domain.ResponseErr{Status: http.StatusInternalServerError, Message: "cache is down"}
+
+	repo.On("GetCollection", testCollectionID).
+		Return(&domain.Collection{ID: testCollectionID, Kind: domain.KindDeck}, nil)
+	repo.On("GetCachedStats", mock.Anything, mock.Anything).Return(nil, cacheErr)
+	repo.On("CollectionStats", []string{testCollectionID}).
+		Return(&domain.CollectionStats{Cards: 60, ManaCurve: []domain.CurveBar{{ManaValue: 2, Cards: 8}}}, nil)
+	repo.On("SaveCachedStats", mock.Anything, mock.Anything, mock.Anything).Return(cacheErr)
+
+	stats, respErr := service.CollectionStats(testCollectionID)
+
+	require.Nil(t, respErr)
+	assert.Len(t, stats.ManaCurve, 1, "decks keep their mana curve")
+}
+
+func TestCollectionStatsInvalidID(t *testing.T) {
+	service := NewStatsService(zap.NewNop(), mocks.NewMockStatsRepositorer(t))
+
+	_, respErr := service.CollectionStats("nope")
+
+	require.NotNil(t, respErr)
+	assert.Equal(t, http.StatusBadRequest, respErr.Status)
+}
+
+func TestUserStatsVersionFollowsCollections(t *testing.T) {
+	older := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	newer := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	collections := []domain.Collection{
+		{ID: "64a9b66b2db8b91234a6e8e4", UpdatedAt: newer, Version: 3},
+		{ID: testCollectionID, UpdatedAt: older, Version: 1},
+	}
+
+	// Changes in the same millisecond have the same update time but not the same version
+	versions := make([]string, 0, 2)
+	for _, version := range []int64{3, 4} {
+		repo := mocks.NewMockStatsRepositorer(t)
+		service := NewStatsService(zap.NewNop(), repo)
+		collections[0].Version = version
+
+		repo.On("FindUserCollections", "user").Return(append([]domain.Collection(nil), collections...), nil)
+		repo.On("GetCachedStats", "user:user", mock.Anything).
+			Run(func(args mock.Arguments) { versions = append(versions, args.String(1)) }).
+			Return(nil, nil)
+		repo.On("CollectionStats", []string{testCollectionID, "64a9b66b2db8b91234a6e8e4"}).
+			Return(&domain.CollectionStats{Cards: 5, ManaCurve: []domain.CurveBar{}}, nil)
+		repo.On("SaveCachedStats", "user:user", mock.Anything, mock.Anything).Return(nil)
+
+		stats, respErr := service.UserStats("user")
+
+		require.Nil(t, respErr)
+		assert.Nil(t, stats.ManaCurve)
+		assert.True(t, stats.UpdatedAt.Equal(newer), "the latest update of the collections")
+	}
+	require.Len(t, versions, 2)
+	assert.NotEqual(t, versions[0], versions[1], "changing a collection invalidates the user's stats")
+}
@@ -0,0 +1,133 @@
+package collection
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"go.uber.org/zap"
+)
+
+// DefaultTrashPurgeInterval is how often the trash is purged in the background.
+const DefaultTrashPurgeInterval = time.Hour
+
+type TrashService struct {
+	trashRepository TrashRepositorer
+	log             *zap.Logger
+	now             func() time.Time
+}
+
+type TrashRepositorer interface {
+	FindUserCollections(userID string) ([]domain.Collection, *domain.ResponseErr)
+	FindTrashedCollections(userID string) ([]domain.Collection, *domain.ResponseErr)
+	FindTrashedCards(collectionIds []string) ([]domain.TrashedCard, *domain.ResponseErr)
+	RestoreCollection(userID, collectionID string) (*domain.Collection, *domain.ResponseErr)
+	RestoreCard(actor domain.Actor, collectionId, entryId string) (*domain.Card, *domain.ResponseErr)
+	PurgeTrash(deletedBefore time.Time) (*domain.TrashPurge, *domain.ResponseErr)
+}
+
+func NewTrashService(log *zap.Logger, trashRepository TrashRepositorer) *TrashService {
+	return &TrashService{
+		trashRepository: trashRepository,
+		log:             log.With(zap.String("service", "trash")),
+		now:             time.Now,
+	}
+}
+
+// Trash lists the user's deleted collections and the cards deleted from the
+// user's collections which can still be restored.
+func (ts TrashService) Trash(userID string) (*domain.Trash, *domain.ResponseErr) {
+	collections, respErr := ts.trashRepository.FindTrashedCollections(userID)
+	if respErr != nil {
+		ts.log.Error("Failed to find trashed collections", zap.String("userID", userID), zap.Error(respErr))
+		return nil, respErr
+	}
+
+	active, respErr := ts.trashRepository.FindUserCollections(userID)
+	if respErr != nil {
+		ts.log.Error("Failed to find user's collections", zap.String("userID", userID), zap.Error(respErr))
+		return nil, respErr
+	}
+
+	trash := &domain.Trash{Collections: collections, Cards: []domain.TrashedCard{}}
+	if len(active) == 0 {
+		return trash, nil
+	}
+
+	ids := make([]string, len(active))
+	names := make(map[string]string, len(active))
+	for i, collection := range active {
+		ids[i] = collection.ID
+		names[collection.ID] = collection.Name
+	}
+
+	cards, respErr := ts.trashRepository.FindTrashedCards(ids)
+	if respErr != nil {
+		ts.log.Error("Failed to find trashed cards", zap.String("userID", userID), zap.Error(respErr))
+		return nil, respErr
+	}
+	for i := range cards {
+		cards[i].CollectionName = names[cards[i].CollectionID]
+	}
+	trash.Cards = cards
+
+	return trash, nil
+}
+
+// RestoreCollection takes the user's collection with its cards out of the trash
+func (ts TrashService) RestoreCollection(userID, collectionID string) (*domain.Collection, *domain.ResponseErr) {
+	if !isValidCollectionID(collectionID) {
+		ts.log.Warn("Invalid collection ID", zap.String("collectionID", collectionID))
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID",
+		}
+	}
+
+	return ts.trashRepository.RestoreCollection(userID, collectionID)
+}
+
+// RestoreCard takes a card entry out of the trash, its copies join the entry
+// of the same variant if the collection has one now.
+func (ts TrashService) RestoreCard(actor domain.Actor, collectionId, entryId string) (*domain.Card, *domain.ResponseErr) {
+	if !isValidCollectionID(collectionId) {
+		ts.log.Warn("Invalid collection ID", zap.String("collectionID", collectionId))
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid collection ID",
+		}
+	}
+
+	return ts.trashRepository.RestoreCard(actor, collectionId, entryId)
+}
+
+// Purge removes everything deleted longer than retention ago for good
+func (ts TrashService) Purge(retention time.Duration) (*domain.TrashPurge, *domain.ResponseErr) {
+	purge, respErr := ts.trashRepository.PurgeTrash(ts.now().Add(-retention))
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	ts.log.Info("Trash is purged", zap.Int("collections", purge.Collections), zap.Int("cards", purge.Cards))
+	return purge, nil
+}
+
+// RunPurge purges the trash right away and then every interval until ctx is done.
+// Failed purges are logged and retried on the next tick.
+func (ts TrashService) RunPurge(ctx context.Context, interval, retention time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, respErr := ts.Purge(retention); respErr != nil {
+			ts.log.Error("Failed to purge trash", zap.Error(respErr))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package collection
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/ShenokZlob/collector-service/usecase/collection/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTrashListsCardsOfActiveCollections(t *testing.T) {
+	repo := mocks.NewMockTrashRepositorer(t)
+	service := NewTrashService(zap.NewNop(), repo)
+	deletedAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	repo.On("FindTrashedCollections", "user").
+		Return([]domain.Collection{{ID: "old", Name: "Old binder", DeletedAt: deletedAt}}, nil)
+	repo.On("FindUserCollections", "user").
+		Return([]domain.Collection{{ID: testCollectionID, Name: "Burn"}}, nil)
+	repo.On("FindTrashedCards", []string{testCollectionID}).
+		Return([]domain.TrashedCard{{CollectionID: testCollectionID, Card: domain.Card{Name: "Lightning Bolt"}, DeletedAt: deletedAt}}, nil)
+
+	trash, respErr := service.Trash("user")
+
+	require.Nil(t, respErr)
+	require.Len(t, trash.Collections, 1)
+	require.Len(t, trash.Cards, 1)
+	assert.Equal(t, "Burn", trash.Cards[0].CollectionName)
+}
+
+func TestTrashWithoutCollections(t *testing.T) {
+	repo := mocks.NewMockTrashRepositorer(t)
+	service := NewTrashService(zap.NewNop(), repo)
+
+	repo.On("FindTrashedCollections", "user").Return([]domain.Collection{}, nil)
+	repo.On("FindUserCollections", "user").Return([]domain.Collection{}, nil)
+
+	trash, respErr := service.Trash("user")
+
+	require.Nil(t, respErr)
+	assert.Empty(t, trash.Cards)
+	repo.AssertNotCalled(t, "FindTrashedCards")
+}
+
+func TestRestoreCollectionInvalidID(t *testing.T) {
+	service := NewTrashService(zap.NewNop(), mocks.NewMockTrashRepositorer(t))
+
+	_, respErr := service.RestoreCollection("user", "nope")
+
+	require.NotNil(t, respErr)
+	assert.Equal(t, http.StatusBadRequest, respErr.Status)
+}
+
+func TestPurgeUsesRetention(t *testing.T) {
+	repo := mocks.NewMockTrashRepositorer(t)
+	service := NewTrashService(zap.NewNop(), repo)
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	repo.On("PurgeTrash", now.Add(-domain.DefaultTrashRetention)).
+		Return(&domain.TrashPurge{Collections: 1, Cards: 3}, nil)
+
+	purge, respErr := service.Purge(domain.DefaultTrashRetention)
+
+	require.Nil(t, respErr)
+	assert.Equal(t, &domain.TrashPurge{Collections: 1, Cards: 3}, purge)
+}
@@ -0,0 +1,93 @@
+package trade
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"go.uber.org/zap"
+)
+
+// inviteCodeLength is the length of group invite codes, 40 random bits
+const inviteCodeLength = 8
+
+type GroupService struct {
+	groupRepository GroupRepositorer
+	log             *zap.Logger
+}
+
+type GroupRepositorer interface {
+	CreateGroup(group *domain.Group) (*domain.Group, *domain.ResponseErr)
+	JoinGroup(inviteCode, userID string) (*domain.Group, *domain.ResponseErr)
+	LeaveGroup(groupID, userID string) *domain.ResponseErr
+	FindUserGroups(userID string) ([]domain.Group, *domain.ResponseErr)
+}
+
+func NewGroupService(log *zap.Logger, groupRepository GroupRepositorer) *GroupService {
+	return &GroupService{
+		groupRepository: groupRepository,
+		log:             log.With(zap.String("service", "group")),
+	}
+}
+
+// CreateGroup creates a group owned by the user with a new invite code
+func (gs GroupService) CreateGroup(userID, name string) (*domain.Group, *domain.ResponseErr) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > 64 {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid group name",
+		}
+	}
+
+	group, respErr := gs.groupRepository.CreateGroup(&domain.Group{
+		Name:       name,
+		OwnerID:    userID,
+		InviteCode: newInviteCode(),
+	})
+	if respErr != nil {
+		gs.log.Error("Failed to create group", zap.String("userID", userID), zap.Error(respErr))
+		return nil, respErr
+	}
+
+	return group, nil
+}
+
+// JoinGroup adds the user to the group with the invite code
+func (gs GroupService) JoinGroup(userID, inviteCode string) (*domain.Group, *domain.ResponseErr) {
+	inviteCode = strings.ToUpper(strings.TrimSpace(inviteCode))
+	if len(inviteCode) != inviteCodeLength {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid invite code",
+		}
+	}
+
+	return gs.groupRepository.JoinGroup(inviteCode, userID)
+}
+
+func (gs GroupService) LeaveGroup(userID, groupID string) *domain.ResponseErr {
+	return gs.groupRepository.LeaveGroup(groupID, userID)
+}
+
+func (gs GroupService) ListGroups(userID string) ([]domain.Group, *domain.ResponseErr) {
+	groups, respErr := gs.groupRepository.FindUserGroups(userID)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	// For json serialization, ensure groups is not nil
+	if groups == nil {
+		groups = []domain.Group{}
+	}
+	return groups, nil
+}
+
+func newInviteCode() string {
+	b := make([]byte, inviteCodeLength*5/8)
+	_, _ = rand.Read(b)
+	return base32.StdEncoding.EncodeToString(b)
+}
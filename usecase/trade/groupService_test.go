@@ -0,0 +1,41 @@
+package trade
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/ShenokZlob/collector-service/usecase/trade/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCreateGroupGeneratesInviteCode(t *testing.T) {
+	repo := mocks.NewMockGroupRepositorer(t)
+	service := NewGroupService(zap.NewNop(), repo)
+
+	repo.On("CreateGroup", mock.MatchedBy(func(group *domain.Group) bool {
+		return group.Name == "FNM" && group.OwnerID == "alice" && len(group.InviteCode) == inviteCodeLength
+	})).Return(&domain.Group{ID: "g1", Name: "FNM"}, nil)
+
+	group, respErr := service.CreateGroup("alice", "  FNM ")
+
+	require.Nil(t, respErr)
+	assert.Equal(t, "g1", group.ID)
+}
+
+func TestJoinGroupNormalizesInviteCode(t *testing.T) {
+	repo := mocks.NewMockGroupRepositorer(t)
+	service := NewGroupService(zap.NewNop(), repo)
+
+	repo.On("JoinGroup", "K3ZQ7M2A", "bob").Return(&domain.Group{ID: "g1"}, nil)
+
+	_, respErr := service.JoinGroup("bob", " k3zq7m2a")
+	require.Nil(t, respErr)
+
+	_, respErr = service.JoinGroup("bob", "short")
+	require.NotNil(t, respErr)
+	assert.Equal(t, http.StatusBadRequest, respErr.Status)
+}
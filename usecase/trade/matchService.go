@@ -0,0 +1,202 @@
+package trade
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"go.uber.org/zap"
+)
+
+// DefaultRefreshInterval is how often trade matches are recomputed in the background.
+const DefaultRefreshInterval = time.Hour
+
+type MatchService struct {
+	matchRepository MatchRepositorer
+	log             *zap.Logger
+	now             func() time.Time
+}
+
+type MatchRepositorer interface {
+	GetUser(userId string) (*domain.User, *domain.ResponseErr)
+	ListGroups() ([]domain.Group, *domain.ResponseErr)
+	FindTradeCards(userIDs []string) ([]domain.TradeCard, *domain.ResponseErr)
+	// FindCardPrices returns the newest price snapshots taken on the day of date or before it.
+	FindCardPrices(scryfallIds []string, date time.Time) (map[string]domain.CardPrice, *domain.ResponseErr)
+	SaveTradeMatches(matches []domain.TradeMatch, refreshedAt time.Time) *domain.ResponseErr
+	ListTradeMatches(userID string, offset, limit int) (*domain.TradeMatchesPage, *domain.ResponseErr)
+}
+
+func NewMatchService(log *zap.Logger, matchRepository MatchRepositorer) *MatchService {
+	return &MatchService{
+		matchRepository: matchRepository,
+		log:             log.With(zap.String("service", "trade_match")),
+		now:             time.Now,
+	}
+}
+
+// ListMatches returns a page of the user's trade matches from the last refresh
+func (ms MatchService) ListMatches(userID string, offset, limit int) (*domain.TradeMatchesPage, *domain.ResponseErr) {
+	if limit == 0 {
+		limit = domain.DefaultTradeMatchesLimit
+	}
+	if limit < 0 || limit > domain.MaxTradeMatchesLimit || offset < 0 {
+		return nil, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid pagination parameters",
+		}
+	}
+
+	page, respErr := ms.matchRepository.ListTradeMatches(userID, offset, limit)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	// For json serialization, ensure Matches is not nil
+	if page.Matches == nil {
+		page.Matches = []domain.TradeMatch{}
+	}
+	return page, nil
+}
+
+// Refresh recomputes the trade matches of every pair of users sharing a group and
+// replaces the stored ones. It returns the number of matches found.
+func (ms MatchService) Refresh(ctx context.Context) (int, error) {
+	refreshedAt := ms.now()
+
+	groups, respErr := ms.matchRepository.ListGroups()
+	if respErr != nil {
+		return 0, respErr
+	}
+
+	partners := make(map[string]map[string]bool)
+	for _, group := range groups {
+		for _, member := range group.Members {
+			for _, other := range group.Members {
+				if member == other {
+					continue
+				}
+				if partners[member] == nil {
+					partners[member] = make(map[string]bool)
+				}
+				partners[member][other] = true
+			}
+		}
+	}
+
+	userIDs := make([]string, 0, len(partners))
+	for userID := range partners {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Strings(userIDs)
+
+	var matches []domain.TradeMatch
+	if len(userIDs) > 0 {
+		cards, respErr := ms.matchRepository.FindTradeCards(userIDs)
+		if respErr != nil {
+			return 0, respErr
+		}
+		byUser := make(map[string][]domain.TradeCard)
+		for _, card := range cards {
+			byUser[card.UserID] = append(byUser[card.UserID], card)
+		}
+
+		for _, userID := range userIDs {
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
+			for _, partnerID := range sortedKeys(partners[userID]) {
+				gets := domain.MatchTrades(byUser[userID], byUser[partnerID])
+				gives := domain.MatchTrades(byUser[partnerID], byUser[userID])
+				if len(gets) == 0 && len(gives) == 0 {
+					continue
+				}
+				matches = append(matches, domain.TradeMatch{UserID: userID, PartnerID: partnerID, Gets: gets, Gives: gives})
+			}
+		}
+
+		if matches, respErr = ms.priceMatches(matches, refreshedAt); respErr != nil {
+			return 0, respErr
+		}
+	}
+
+	if respErr := ms.matchRepository.SaveTradeMatches(matches, refreshedAt); respErr != nil {
+		return 0, respErr
+	}
+
+	ms.log.Info("Trade matches are refreshed", zap.Int("users", len(userIDs)), zap.Int("matches", len(matches)))
+	return len(matches), nil
+}
+
+// RunRefresh refreshes trade matches right away and then every interval until ctx is done.
+// Failed refreshes are logged and retried on the next tick.
+func (ms MatchService) RunRefresh(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := ms.Refresh(ctx); err != nil && ctx.Err() == nil {
+			ms.log.Error("Failed to refresh trade matches", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// priceMatches values and ranks the matches at the prices of the date and fills partner names
+func (ms MatchService) priceMatches(matches []domain.TradeMatch, date time.Time) ([]domain.TradeMatch, *domain.ResponseErr) {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, match := range matches {
+		for _, items := range [][]domain.TradeItem{match.Gets, match.Gives} {
+			for _, item := range items {
+				if !seen[item.ScryfallID] {
+					seen[item.ScryfallID] = true
+					ids = append(ids, item.ScryfallID)
+				}
+			}
+		}
+	}
+
+	prices, respErr := ms.matchRepository.FindCardPrices(ids, date)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	names := make(map[string]string)
+	priced := make([]domain.TradeMatch, len(matches))
+	for i, match := range matches {
+		name, ok := names[match.PartnerID]
+		if !ok {
+			partner, respErr := ms.matchRepository.GetUser(match.PartnerID)
+			if respErr != nil {
+				return nil, respErr
+			}
+			name = partner.Username
+			if name == "" {
+				name = partner.FirstName
+			}
+			names[match.PartnerID] = name
+		}
+
+		priced[i] = domain.NewTradeMatch(match.UserID, match.PartnerID, match.Gets, match.Gives, prices)
+		priced[i].PartnerName = name
+		priced[i].UpdatedAt = date
+	}
+	return priced, nil
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,103 @@
+package trade
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/ShenokZlob/collector-service/usecase/trade/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRefreshMatchesGroupMembers(t *testing.T) {
+	repo := mocks.NewMockMatchRepositorer(t)
+	service := NewMatchService(zap.NewNop(), repo)
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	repo.On("ListGroups").Return([]domain.Group{
+		{ID: "g1", Members: []string{"alice", "bob"}},
+		{ID: "g2", Members: []string{"bob", "carol"}},
+	}, nil)
+	repo.On("FindTradeCards", []string{"alice", "bob", "carol"}).Return([]domain.TradeCard{
+		{UserID: "alice", Kind: domain.KindWishlist, OracleKey: "bolt", Name: "Lightning Bolt", Count: 4},
+		{UserID: "alice", Kind: domain.KindTrade, OracleKey: "guide", Name: "Goblin Guide", ScryfallID: "guide", Count: 1},
+		{UserID: "bob", Kind: domain.KindTrade, OracleKey: "bolt", Name: "Lightning Bolt", ScryfallID: "bolt", Count: 2},
+		{UserID: "bob", Kind: domain.KindWishlist, OracleKey: "guide", Name: "Goblin Guide", Count: 2},
+		// carol shares a group with bob only, her trade list doesn't reach alice
+		{UserID: "carol", Kind: domain.KindTrade, OracleKey: "guide", Name: "Goblin Guide", ScryfallID: "guide", Count: 1},
+	}, nil)
+	repo.On("FindCardPrices", mock.Anything, now).Return(map[string]domain.CardPrice{
+		"bolt":  {USD: 2},
+		"guide": {USD: 3},
+	}, nil)
+	repo.On("GetUser", "alice").Return(&domain.User{FirstName: "Alice"}, nil)
+	repo.On("GetUser", "bob").Return(&domain.User{FirstName: "Bob", Username: "bob"}, nil)
+	repo.On("GetUser", "carol").Return(&domain.User{FirstName: "Carol"}, nil)
+
+	var saved []domain.TradeMatch
+	repo.On("SaveTradeMatches", mock.Anything, now).
+		Run(func(args mock.Arguments) { saved = args.Get(0).([]domain.TradeMatch) }).
+		Return(nil)
+
+	count, err := service.Refresh(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, count)
+	require.Len(t, saved, 4)
+
+	aliceBob := saved[0]
+	assert.Equal(t, "alice", aliceBob.UserID)
+	assert.Equal(t, "bob", aliceBob.PartnerID)
+	assert.Equal(t, "bob", aliceBob.PartnerName)
+	assert.Equal(t, 4.0, aliceBob.GetsValue)
+	assert.Equal(t, 3.0, aliceBob.GivesValue)
+	assert.Equal(t, 1.0, aliceBob.Balance)
+	assert.Equal(t, 0.75, aliceBob.Score)
+	assert.Equal(t, now, aliceBob.UpdatedAt)
+
+	bobAlice := saved[1]
+	assert.Equal(t, "Alice", bobAlice.PartnerName)
+	assert.Equal(t, -1.0, bobAlice.Balance)
+
+	bobCarol, carolBob := saved[2], saved[3]
+	assert.Equal(t, "carol", bobCarol.PartnerID)
+	assert.Empty(t, bobCarol.Gives)
+	assert.Equal(t, 0.0, bobCarol.Score)
+	assert.Equal(t, "bob", carolBob.PartnerID)
+	assert.Len(t, carolBob.Gives, 1)
+}
+
+func TestRefreshWithoutGroupsClearsMatches(t *testing.T) {
+	repo := mocks.NewMockMatchRepositorer(t)
+	service := NewMatchService(zap.NewNop(), repo)
+
+	repo.On("ListGroups").Return([]domain.Group{{ID: "g1", Members: []string{"alice"}}}, nil)
+	repo.On("SaveTradeMatches", []domain.TradeMatch(nil), mock.Anything).Return(nil)
+
+	count, err := service.Refresh(context.Background())
+
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestListMatchesPagination(t *testing.T) {
+	repo := mocks.NewMockMatchRepositorer(t)
+	service := NewMatchService(zap.NewNop(), repo)
+
+	repo.On("ListTradeMatches", "alice", 0, domain.DefaultTradeMatchesLimit).
+		Return(&domain.TradeMatchesPage{Limit: domain.DefaultTradeMatchesLimit}, nil)
+
+	page, respErr := service.ListMatches("alice", 0, 0)
+	require.Nil(t, respErr)
+	assert.NotNil(t, page.Matches)
+
+	_, respErr = service.ListMatches("alice", 0, domain.MaxTradeMatchesLimit+1)
+	require.NotNil(t, respErr)
+	assert.Equal(t, http.StatusBadRequest, respErr.Status)
+}
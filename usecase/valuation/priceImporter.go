@@ -0,0 +1,141 @@
+package valuation
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/ShenokZlob/collector-service/pkg/scryfall"
+	"go.uber.org/zap"
+)
+
+// DefaultImportBatchSize is the number of price snapshots stored at once.
+const DefaultImportBatchSize = 1000
+
+type PriceImporter struct {
+	priceRepository PriceRepositorer
+	batchSize       int
+	log             *zap.Logger
+}
+
+type PriceRepositorer interface {
+	UpsertCardPrices(prices []domain.CardPrice) *domain.ResponseErr
+}
+
+func NewPriceImporter(log *zap.Logger, priceRepository PriceRepositorer, batchSize int) *PriceImporter {
+	if batchSize <= 0 {
+		batchSize = DefaultImportBatchSize
+	}
+	return &PriceImporter{
+		priceRepository: priceRepository,
+		batchSize:       batchSize,
+		log:             log.With(zap.String("service", "price_import")),
+	}
+}
+
+// Import stores the prices of a Scryfall bulk data file (default_cards) as the
+// snapshots of the day of date. Printings without any price are skipped.
+//
+// Snapshots are upserted by Scryfall ID and day, so an interrupted import is
+// finished by running it again for the same date. progress, when not nil, is
+// called with the number of stored snapshots after every batch.
+func (pi PriceImporter) Import(ctx context.Context, path string, date time.Time, progress func(stored int)) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	day := domain.PriceDate(date)
+	reader := scryfall.NewBulkReader(bufio.NewReader(file))
+	batch := make([]domain.CardPrice, 0, pi.batchSize)
+	stored := 0
+	flush := func() error {
+		if respErr := pi.priceRepository.UpsertCardPrices(batch); respErr != nil {
+			return respErr
+		}
+		stored += len(batch)
+		batch = batch[:0]
+		if progress != nil {
+			progress(stored)
+		}
+		return nil
+	}
+
+	for {
+		card, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return stored, fmt.Errorf("read %s: %w", path, err)
+		}
+
+		price, err := cardPriceFromScryfall(card, day)
+		if err != nil {
+			return stored, err
+		}
+		if price == nil {
+			continue
+		}
+
+		batch = append(batch, *price)
+		if len(batch) < pi.batchSize {
+			continue
+		}
+		if err := flush(); err != nil {
+			return stored, err
+		}
+		if err := ctx.Err(); err != nil {
+			pi.log.Info("Price import is interrupted", zap.String("source", path), zap.Int("stored", stored))
+			return stored, err
+		}
+	}
+
+	if err := flush(); err != nil {
+		return stored, err
+	}
+
+	pi.log.Info("Price import is done", zap.String("source", path), zap.Time("date", day), zap.Int("stored", stored))
+	return stored, nil
+}
+
+// cardPriceFromScryfall returns nil for printings without prices
+func cardPriceFromScryfall(card *scryfall.Card, date time.Time) (*domain.CardPrice, error) {
+	price := domain.CardPrice{ScryfallID: card.ID, Date: date}
+	fields := []struct {
+		raw string
+		dst *float64
+	}{
+		{card.Prices.USD, &price.USD},
+		{card.Prices.USDFoil, &price.USDFoil},
+		{card.Prices.USDEtched, &price.USDEtched},
+		{card.Prices.EUR, &price.EUR},
+		{card.Prices.EURFoil, &price.EURFoil},
+		{card.Prices.Tix, &price.Tix},
+	}
+
+	priced := false
+	for _, field := range fields {
+		if field.raw == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(field.raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("card %s: invalid price %q", card.ID, field.raw)
+		}
+		*field.dst = value
+		priced = priced || value > 0
+	}
+
+	if !priced {
+		return nil, nil
+	}
+	return &price, nil
+}
@@ -0,0 +1,97 @@
+package valuation
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryPriceRepository keeps price snapshots by Scryfall ID and day
+type memoryPriceRepository struct {
+	prices  map[string]domain.CardPrice
+	batches int
+}
+
+func (m *memoryPriceRepository) UpsertCardPrices(prices []domain.CardPrice) *domain.ResponseErr {
+	for _, price := range prices {
+		m.prices[price.ScryfallID+price.Date.Format(time.DateOnly)] = price
+	}
+	if len(prices) > 0 {
+		m.batches++
+	}
+	return nil
+}
+
+const bulkPrices = `[
+	{"id": "bolt", "name": "Lightning Bolt", "prices": {"usd": "2.15", "usd_foil": "9.50", "usd_etched": null, "eur": "1.80", "eur_foil": null, "tix": "0.03"}},
+	{"id": "token", "name": "Goblin", "prices": {"usd": null, "usd_foil": null, "usd_etched": null, "eur": null, "eur_foil": null, "tix": null}},
+	{"id": "ring", "name": "Sol Ring", "prices": {"usd": "1.50", "usd_foil": null, "usd_etched": "4.00", "eur": null, "eur_foil": null, "tix": null}},
+	{"id": "lotus", "name": "Black Lotus", "prices": {"usd": "25000.00"}}
+]`
+
+func writePricesFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "default-cards.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestPriceImport(t *testing.T) {
+	repo := &memoryPriceRepository{prices: make(map[string]domain.CardPrice)}
+	importer := NewPriceImporter(zap.NewNop(), repo, 2)
+	path := writePricesFile(t, bulkPrices)
+
+	var progress []int
+	stored, err := importer.Import(context.Background(), path, time.Date(2026, 10, 19, 18, 0, 0, 0, time.UTC), func(stored int) {
+		progress = append(progress, stored)
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, stored, "printings without prices are skipped")
+	assert.Equal(t, []int{2, 3}, progress)
+	assert.Equal(t, domain.CardPrice{
+		ScryfallID: "bolt",
+		Date:       time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+		USD:        2.15,
+		USDFoil:    9.5,
+		EUR:        1.8,
+		Tix:        0.03,
+	}, repo.prices["bolt2026-10-19"])
+	assert.Equal(t, 4.0, repo.prices["ring2026-10-19"].USDEtched)
+
+	// Importing the day again replaces its snapshots
+	_, err = importer.Import(context.Background(), path, time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), nil)
+	require.NoError(t, err)
+	assert.Len(t, repo.prices, 3)
+}
+
+func TestPriceImportInvalidPrice(t *testing.T) {
+	repo := &memoryPriceRepository{prices: make(map[string]domain.CardPrice)}
+	importer := NewPriceImporter(zap.NewNop(), repo, 0)
+	path := writePricesFile(t, `[{"id": "bolt", "prices": {"usd": "two"}}]`)
+
+	_, err := importer.Import(context.Background(), path, time.Now(), nil)
+
+	assert.ErrorContains(t, err, `card bolt: invalid price "two"`)
+	assert.Empty(t, repo.prices)
+}
+
+func TestPriceImportStopsWhenCanceled(t *testing.T) {
+	repo := &memoryPriceRepository{prices: make(map[string]domain.CardPrice)}
+	importer := NewPriceImporter(zap.NewNop(), repo, 1)
+	path := writePricesFile(t, bulkPrices)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	stored, err := importer.Import(ctx, path, time.Now(), nil)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, stored)
+}
@@ -0,0 +1,314 @@
+package valuation
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"go.uber.org/zap"
+)
+
+// DefaultHistoryDays is the period of value history returned when its start isn't given.
+const DefaultHistoryDays = 90
+
+type ValuationService struct {
+	valuationRepository ValuationRepositorer
+	log                 *zap.Logger
+	now                 func() time.Time
+}
+
+type ValuationRepositorer interface {
+	GetUser(userId string) (*domain.User, *domain.ResponseErr)
+	GetCollection(collectionId string) (*domain.Collection, *domain.ResponseErr)
+	StreamCollections(fn func(domain.Collection) error) *domain.ResponseErr
+	StreamCards(collectionId string, fn func(domain.Card) error) *domain.ResponseErr
+	// FindCardPrices returns the newest price snapshots taken on the day of date or before it.
+	FindCardPrices(scryfallIds []string, date time.Time) (map[string]domain.CardPrice, *domain.ResponseErr)
+	SaveCollectionValue(collection *domain.Collection, point domain.ValuePoint) *domain.ResponseErr
+	CollectionValueHistory(collectionId string, from, to time.Time) ([]domain.ValuePoint, *domain.ResponseErr)
+	UserValueHistory(userId string, from, to time.Time) ([]domain.ValuePoint, *domain.ResponseErr)
+}
+
+func NewValuationService(log *zap.Logger, valuationRepository ValuationRepositorer) *ValuationService {
+	return &ValuationService{
+		valuationRepository: valuationRepository,
+		log:                 log.With(zap.String("service", "valuation")),
+		now:                 time.Now,
+	}
+}
+
+// holding is a card entry with the collection it belongs to
+type holding struct {
+	collectionId string
+	card         domain.Card
+}
+
+// ValueCollection values the cards of the collection at the latest prices.
+func (vs ValuationService) ValueCollection(collectionId string, query domain.ValuationQuery) (*domain.Valuation, *domain.ResponseErr) {
+	if respErr := vs.checkQuery(&query); respErr != nil {
+		return nil, respErr
+	}
+
+	holdings, respErr := vs.collectHoldings([]string{collectionId})
+	if respErr != nil {
+		vs.log.Error("Failed to read collection cards", zap.String("collectionID", collectionId), zap.Error(respErr))
+		return nil, respErr
+	}
+
+	return vs.value(holdings, query)
+}
+
+// ValueUser values the cards of all collections of the user at the latest prices.
+func (vs ValuationService) ValueUser(userId string, query domain.ValuationQuery) (*domain.Valuation, *domain.ResponseErr) {
+	if respErr := vs.checkQuery(&query); respErr != nil {
+		return nil, respErr
+	}
+
+	user, respErr := vs.valuationRepository.GetUser(userId)
+	if respErr != nil {
+		vs.log.Error("Failed to find user", zap.String("userID", userId), zap.Error(respErr))
+		return nil, respErr
+	}
+
+	collectionIds := make([]string, len(user.Collections))
+	for i, ref := range user.Collections {
+		collectionIds[i] = ref.ID
+	}
+	holdings, respErr := vs.collectHoldings(collectionIds)
+	if respErr != nil {
+		vs.log.Error("Failed to read user's cards", zap.String("userID", userId), zap.Error(respErr))
+		return nil, respErr
+	}
+
+	return vs.value(holdings, query)
+}
+
+// CollectionHistory returns the daily values of the collection between from and to.
+// A zero to is today and a zero from is DefaultHistoryDays before to.
+func (vs ValuationService) CollectionHistory(collectionId string, from, to time.Time) ([]domain.ValuePoint, *domain.ResponseErr) {
+	from, to, respErr := vs.historyRange(from, to)
+	if respErr != nil {
+		return nil, respErr
+	}
+	if _, respErr := vs.valuationRepository.GetCollection(collectionId); respErr != nil {
+		return nil, respErr
+	}
+
+	points, respErr := vs.valuationRepository.CollectionValueHistory(collectionId, from, to)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	// For json serialization, ensure points is not nil
+	if points == nil {
+		points = []domain.ValuePoint{}
+	}
+	return points, nil
+}
+
+// UserHistory returns the daily totals of the user's collections between from and to.
+// A zero to is today and a zero from is DefaultHistoryDays before to.
+func (vs ValuationService) UserHistory(userId string, from, to time.Time) ([]domain.ValuePoint, *domain.ResponseErr) {
+	from, to, respErr := vs.historyRange(from, to)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	points, respErr := vs.valuationRepository.UserValueHistory(userId, from, to)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	// For json serialization, ensure points is not nil
+	if points == nil {
+		points = []domain.ValuePoint{}
+	}
+	return points, nil
+}
+
+// RecordValues stores the value of every collection at the prices of the date,
+// it's run daily after prices are imported. Running it again for the date
+// replaces the stored values. It returns the number of collections recorded.
+func (vs ValuationService) RecordValues(ctx context.Context, date time.Time) (int, error) {
+	var collections []domain.Collection
+	respErr := vs.valuationRepository.StreamCollections(func(collection domain.Collection) error {
+		collections = append(collections, collection)
+		return nil
+	})
+	if respErr != nil {
+		return 0, respErr
+	}
+
+	for i := range collections {
+		if err := ctx.Err(); err != nil {
+			return i, err
+		}
+
+		holdings, respErr := vs.collectHoldings([]string{collections[i].ID})
+		if respErr != nil {
+			return i, respErr
+		}
+		prices, respErr := vs.valuationRepository.FindCardPrices(scryfallIds(holdings), date)
+		if respErr != nil {
+			return i, respErr
+		}
+
+		point := domain.ValuePoint{
+			Date: domain.PriceDate(date),
+			USD:  total(holdings, prices, domain.CurrencyUSD),
+			EUR:  total(holdings, prices, domain.CurrencyEUR),
+			Tix:  total(holdings, prices, domain.CurrencyTix),
+		}
+		if respErr := vs.valuationRepository.SaveCollectionValue(&collections[i], point); respErr != nil {
+			return i, respErr
+		}
+	}
+
+	vs.log.Info("Collection values are recorded", zap.Time("date", domain.PriceDate(date)), zap.Int("collections", len(collections)))
+	return len(collections), nil
+}
+
+// checkQuery validates the query and fills its defaults
+func (vs ValuationService) checkQuery(query *domain.ValuationQuery) *domain.ResponseErr {
+	if query.Currency == "" {
+		query.Currency = domain.CurrencyUSD
+	}
+	if !query.Currency.IsValid() {
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid currency",
+		}
+	}
+
+	if query.Top == 0 {
+		query.Top = domain.DefaultValuationTop
+	}
+	if query.Top < 0 || query.Top > domain.MaxValuationTop {
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid top",
+		}
+	}
+
+	if query.Since.After(vs.now()) {
+		return &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "Since is in the future",
+		}
+	}
+
+	return nil
+}
+
+func (vs ValuationService) historyRange(from, to time.Time) (time.Time, time.Time, *domain.ResponseErr) {
+	if to.IsZero() {
+		to = vs.now()
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -DefaultHistoryDays)
+	}
+	if from.After(to) {
+		return from, to, &domain.ResponseErr{
+			Status:  http.StatusBadRequest,
+			Message: "From is after to",
+		}
+	}
+	return from, to, nil
+}
+
+func (vs ValuationService) collectHoldings(collectionIds []string) ([]holding, *domain.ResponseErr) {
+	var holdings []holding
+	for _, collectionId := range collectionIds {
+		respErr := vs.valuationRepository.StreamCards(collectionId, func(card domain.Card) error {
+			holdings = append(holdings, holding{collectionId: collectionId, card: card})
+			return nil
+		})
+		if respErr != nil {
+			return nil, respErr
+		}
+	}
+	return holdings, nil
+}
+
+func (vs ValuationService) value(holdings []holding, query domain.ValuationQuery) (*domain.Valuation, *domain.ResponseErr) {
+	ids := scryfallIds(holdings)
+	prices, respErr := vs.valuationRepository.FindCardPrices(ids, vs.now())
+	if respErr != nil {
+		vs.log.Error("Failed to find card prices", zap.Error(respErr))
+		return nil, respErr
+	}
+
+	valuation := &domain.Valuation{
+		Currency: query.Currency,
+		Top:      []domain.CardValue{},
+	}
+	values := make([]domain.CardValue, 0, len(holdings))
+	for _, h := range holdings {
+		valuation.Cards += h.card.Count
+
+		price, ok := prices[h.card.ScryfallID]
+		unitPrice := price.Price(query.Currency, h.card.Finish)
+		if !ok || unitPrice == 0 {
+			valuation.Unpriced += h.card.Count
+			continue
+		}
+		if price.Date.After(valuation.PriceDate) {
+			valuation.PriceDate = price.Date
+		}
+
+		value := unitPrice * float64(h.card.Count)
+		valuation.Total += value
+		values = append(values, domain.CardValue{
+			CollectionID: h.collectionId,
+			Card:         h.card,
+			UnitPrice:    unitPrice,
+			Value:        domain.RoundPrice(value),
+		})
+	}
+	valuation.Total = domain.RoundPrice(valuation.Total)
+
+	sort.SliceStable(values, func(i, j int) bool {
+		return values[i].Value > values[j].Value
+	})
+	if len(values) > query.Top {
+		values = values[:query.Top]
+	}
+	valuation.Top = values
+
+	if !query.Since.IsZero() {
+		sincePrices, respErr := vs.valuationRepository.FindCardPrices(ids, query.Since)
+		if respErr != nil {
+			vs.log.Error("Failed to find card prices", zap.Time("since", query.Since), zap.Error(respErr))
+			return nil, respErr
+		}
+		valuation.Since = domain.PriceDate(query.Since)
+		valuation.SinceTotal = total(holdings, sincePrices, query.Currency)
+		valuation.Change = domain.RoundPrice(valuation.Total - valuation.SinceTotal)
+	}
+
+	return valuation, nil
+}
+
+func scryfallIds(holdings []holding) []string {
+	seen := make(map[string]bool, len(holdings))
+	ids := make([]string, 0, len(holdings))
+	for _, h := range holdings {
+		if !seen[h.card.ScryfallID] {
+			seen[h.card.ScryfallID] = true
+			ids = append(ids, h.card.ScryfallID)
+		}
+	}
+	return ids
+}
+
+func total(holdings []holding, prices map[string]domain.CardPrice, currency domain.Currency) float64 {
+	var sum float64
+	for _, h := range holdings {
+		if price, ok := prices[h.card.ScryfallID]; ok {
+			sum += price.Price(currency, h.card.Finish) * float64(h.card.Count)
+		}
+	}
+	return domain.RoundPrice(sum)
+}
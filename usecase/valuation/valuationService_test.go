@@ -0,0 +1,192 @@
+package valuation
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ShenokZlob/collector-service/domain"
+	"github.com/ShenokZlob/collector-service/usecase/valuation/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const (
+	testCollectionID = "64a9b66b2db8b91234a6e8e4"
+	testUserID       = "64a9b66b2db8b91234a6e8e5"
+	boltID           = "e3285e6b-3e79-4d7c-bf96-d920f973b80d"
+	ringID           = "0afa0e33-4804-4b00-b625-c2d6b61090fc"
+	tokenID          = "11111111-1111-1111-1111-111111111111"
+)
+
+var (
+	today     = time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	lastMonth = time.Date(2026, 9, 19, 0, 0, 0, 0, time.UTC)
+)
+
+func newTestService(repo ValuationRepositorer) *ValuationService {
+	service := NewValuationService(zap.NewNop(), repo)
+	service.now = func() time.Time { return today.Add(15 * time.Hour) }
+	return service
+}
+
+// streamCards makes the StreamCards mock pass the cards to its callback
+func streamCards(repo *mocks.MockValuationRepositorer, collectionId string, cards ...domain.Card) {
+	repo.On("StreamCards", collectionId, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(domain.Card) error)
+			for _, card := range cards {
+				_ = fn(card)
+			}
+		}).
+		Return(nil)
+}
+
+func TestValueCollection(t *testing.T) {
+	repo := mocks.NewMockValuationRepositorer(t)
+	service := newTestService(repo)
+
+	streamCards(repo, testCollectionID,
+		domain.Card{Name: "Lightning Bolt", ScryfallID: boltID, Count: 4, Finish: domain.FinishNonfoil},
+		domain.Card{Name: "Lightning Bolt", ScryfallID: boltID, Count: 1, Finish: domain.FinishFoil},
+		domain.Card{Name: "Sol Ring", ScryfallID: ringID, Count: 1, Finish: domain.FinishNonfoil},
+		domain.Card{Name: "Goblin Token", ScryfallID: tokenID, Count: 3, Finish: domain.FinishNonfoil},
+	)
+	ids := []string{boltID, ringID, tokenID}
+	repo.On("FindCardPrices", ids, today.Add(15*time.Hour)).
+		Return(map[string]domain.CardPrice{
+			boltID: {ScryfallID: boltID, Date: today, USD: 2, USDFoil: 10},
+			ringID: {ScryfallID: ringID, Date: today.AddDate(0, 0, -1), USD: 1.5},
+		}, nil)
+	repo.On("FindCardPrices", ids, lastMonth).
+		Return(map[string]domain.CardPrice{
+			boltID: {ScryfallID: boltID, Date: lastMonth, USD: 1, USDFoil: 8},
+			ringID: {ScryfallID: ringID, Date: lastMonth, USD: 1.5},
+		}, nil)
+
+	valuation, respErr := service.ValueCollection(testCollectionID, domain.ValuationQuery{Top: 2, Since: lastMonth})
+
+	require.Nil(t, respErr)
+	assert.Equal(t, domain.CurrencyUSD, valuation.Currency)
+	assert.Equal(t, today, valuation.PriceDate)
+	assert.Equal(t, 19.5, valuation.Total)
+	assert.Equal(t, 9, valuation.Cards)
+	assert.Equal(t, 3, valuation.Unpriced)
+
+	require.Len(t, valuation.Top, 2)
+	assert.Equal(t, domain.FinishFoil, valuation.Top[0].Card.Finish)
+	assert.Equal(t, 10.0, valuation.Top[0].Value)
+	assert.Equal(t, testCollectionID, valuation.Top[0].CollectionID)
+	assert.Equal(t, 8.0, valuation.Top[1].Value)
+	assert.Equal(t, 2.0, valuation.Top[1].UnitPrice)
+
+	assert.Equal(t, lastMonth, valuation.Since)
+	assert.Equal(t, 13.5, valuation.SinceTotal)
+	assert.Equal(t, 6.0, valuation.Change)
+}
+
+func TestValueCollectionValidatesQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query domain.ValuationQuery
+	}{
+		{"unknown currency", domain.ValuationQuery{Currency: "rub"}},
+		{"negative top", domain.ValuationQuery{Top: -1}},
+		{"too many top cards", domain.ValuationQuery{Top: domain.MaxValuationTop + 1}},
+		{"since in the future", domain.ValuationQuery{Since: today.AddDate(0, 0, 2)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newTestService(mocks.NewMockValuationRepositorer(t))
+
+			_, respErr := service.ValueCollection(testCollectionID, tt.query)
+
+			require.NotNil(t, respErr)
+			assert.Equal(t, http.StatusBadRequest, respErr.Status)
+		})
+	}
+}
+
+func TestValueUser(t *testing.T) {
+	repo := mocks.NewMockValuationRepositorer(t)
+	service := newTestService(repo)
+	otherID := "64a9b66b2db8b91234a6e8e6"
+
+	repo.On("GetUser", testUserID).Return(&domain.User{
+		ID: testUserID,
+		Collections: []domain.UserCollectionRef{
+			{ID: testCollectionID, Name: "Binder"},
+			{ID: otherID, Name: "Deck"},
+		},
+	}, nil)
+	streamCards(repo, testCollectionID, domain.Card{ScryfallID: boltID, Count: 2, Finish: domain.FinishNonfoil})
+	streamCards(repo, otherID, domain.Card{ScryfallID: boltID, Count: 1, Finish: domain.FinishNonfoil})
+	repo.On("FindCardPrices", []string{boltID}, mock.Anything).
+		Return(map[string]domain.CardPrice{boltID: {ScryfallID: boltID, Date: today, EUR: 1.25}}, nil)
+
+	valuation, respErr := service.ValueUser(testUserID, domain.ValuationQuery{Currency: domain.CurrencyEUR})
+
+	require.Nil(t, respErr)
+	assert.Equal(t, 3.75, valuation.Total)
+	assert.Equal(t, 3, valuation.Cards)
+	require.Len(t, valuation.Top, 2)
+	assert.Equal(t, testCollectionID, valuation.Top[0].CollectionID)
+	assert.Equal(t, otherID, valuation.Top[1].CollectionID)
+	assert.True(t, valuation.Since.IsZero())
+}
+
+func TestCollectionHistoryDefaults(t *testing.T) {
+	repo := mocks.NewMockValuationRepositorer(t)
+	service := newTestService(repo)
+	now := service.now()
+
+	repo.On("GetCollection", testCollectionID).Return(&domain.Collection{ID: testCollectionID}, nil)
+	repo.On("CollectionValueHistory", testCollectionID, now.AddDate(0, 0, -DefaultHistoryDays), now).Return(nil, nil)
+
+	points, respErr := service.CollectionHistory(testCollectionID, time.Time{}, time.Time{})
+
+	require.Nil(t, respErr)
+	assert.NotNil(t, points)
+	assert.Empty(t, points)
+}
+
+func TestUserHistoryFromAfterTo(t *testing.T) {
+	service := newTestService(mocks.NewMockValuationRepositorer(t))
+
+	_, respErr := service.UserHistory(testUserID, today, lastMonth)
+
+	require.NotNil(t, respErr)
+	assert.Equal(t, http.StatusBadRequest, respErr.Status)
+}
+
+func TestRecordValues(t *testing.T) {
+	repo := mocks.NewMockValuationRepositorer(t)
+	service := newTestService(repo)
+	collection := domain.Collection{ID: testCollectionID, UserID: testUserID}
+
+	repo.On("StreamCollections", mock.Anything).
+		Run(func(args mock.Arguments) {
+			_ = args.Get(0).(func(domain.Collection) error)(collection)
+		}).
+		Return(nil)
+	streamCards(repo, testCollectionID,
+		domain.Card{ScryfallID: boltID, Count: 2, Finish: domain.FinishFoil},
+		domain.Card{ScryfallID: ringID, Count: 1, Finish: domain.FinishNonfoil},
+	)
+	repo.On("FindCardPrices", []string{boltID, ringID}, lastMonth).
+		Return(map[string]domain.CardPrice{
+			boltID: {ScryfallID: boltID, Date: lastMonth, USD: 1, USDFoil: 3, EUR: 0.9, Tix: 0.05},
+			ringID: {ScryfallID: ringID, Date: lastMonth, USD: 1.5, EUR: 1.2, Tix: 0.5},
+		}, nil)
+	repo.On("SaveCollectionValue", &collection, domain.ValuePoint{Date: lastMonth, USD: 7.5, EUR: 3, Tix: 0.6}).
+		Return(nil)
+
+	recorded, err := service.RecordValues(context.Background(), lastMonth)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, recorded)
+}